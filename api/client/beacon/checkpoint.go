@@ -59,6 +59,16 @@ func (o *OriginData) BlockBytes() []byte {
 	return o.bb
 }
 
+// BlockRoot returns the hash_tree_root of the downloaded block.
+func (o *OriginData) BlockRoot() [32]byte {
+	return o.br
+}
+
+// StateRoot returns the hash_tree_root of the downloaded state.
+func (o *OriginData) StateRoot() [32]byte {
+	return o.sr
+}
+
 func fname(prefix string, vu *detect.VersionedUnmarshaler, slot primitives.Slot, root [32]byte) string {
 	return fmt.Sprintf("%s_%s_%s_%d-%#x.ssz", prefix, vu.Config.ConfigName, version.String(vu.Fork), slot, root)
 }
@@ -66,23 +76,29 @@ func fname(prefix string, vu *detect.VersionedUnmarshaler, slot primitives.Slot,
 // DownloadFinalizedData downloads the most recently finalized state, and the block most recently applied to that state.
 // This pair can be used to initialize a new beacon node via checkpoint sync.
 func DownloadFinalizedData(ctx context.Context, client *Client) (*OriginData, error) {
-	sb, err := client.GetState(ctx, IdFinalized)
+	return DownloadOriginData(ctx, client, IdFinalized)
+}
+
+// DownloadOriginData downloads the state identified by stateId, and the block most recently applied to that state.
+// The block is checked to be the one referenced by the latest block header of the state.
+func DownloadOriginData(ctx context.Context, client *Client, stateId StateOrBlockId) (*OriginData, error) {
+	sb, err := client.GetState(ctx, stateId)
 	if err != nil {
 		return nil, err
 	}
 	vu, err := detect.FromState(sb)
 	if err != nil {
-		return nil, errors.Wrap(err, "error detecting chain config for finalized state")
+		return nil, errors.Wrapf(err, "error detecting chain config for state %s", stateId)
 	}
 
 	log.WithFields(logrus.Fields{
 		"name": vu.Config.ConfigName,
 		"fork": version.String(vu.Fork),
-	}).Info("Detected supported config in remote finalized state")
+	}).Infof("Detected supported config in remote %s state", stateId)
 
 	s, err := vu.UnmarshalBeaconState(sb)
 	if err != nil {
-		return nil, errors.Wrap(err, "error unmarshaling finalized state to correct version")
+		return nil, errors.Wrapf(err, "error unmarshaling %s state to correct version", stateId)
 	}
 
 	slot := s.LatestBlockHeader().Slot
@@ -109,7 +125,20 @@ func DownloadFinalizedData(ctx context.Context, client *Client) (*OriginData, er
 	}
 	sr, err := s.HashTreeRoot(ctx)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to compute htr for finalized state at slot=%d", s.Slot())
+		return nil, errors.Wrapf(err, "failed to compute htr for %s state at slot=%d", stateId, s.Slot())
+	}
+	// The state root of the latest block header is only filled in by the next slot transition. Filling it in
+	// ourselves if needed, the header root must be the root of the block.
+	header := s.LatestBlockHeader()
+	if bytesutil.ToBytes32(header.StateRoot) == [32]byte{} {
+		header.StateRoot = sr[:]
+	}
+	hr, err := header.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "error computing hash_tree_root of latest block header")
+	}
+	if hr != br {
+		return nil, errors.Wrapf(errCheckpointBlockMismatch, "state latest block header root = %#x, block root = %#x", hr, br)
 	}
 
 	log.
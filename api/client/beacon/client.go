@@ -15,6 +15,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/client"
 	"github.com/prysmaticlabs/prysm/v5/api/server"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
@@ -22,6 +23,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/network/forks"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/sirupsen/logrus"
 )
 
@@ -29,12 +31,19 @@ const (
 	getSignedBlockPath       = "/eth/v2/beacon/blocks"
 	getBlockRootPath         = "/eth/v1/beacon/blocks/{{.Id}}/root"
 	getForkForStatePath      = "/eth/v1/beacon/states/{{.Id}}/fork"
+	getFinalityCheckpoints   = "/eth/v1/beacon/states/{{.Id}}/finality_checkpoints"
 	getWeakSubjectivityPath  = "/prysm/v1/beacon/weak_subjectivity"
 	getForkSchedulePath      = "/eth/v1/config/fork_schedule"
 	getConfigSpecPath        = "/eth/v1/config/spec"
+	getGenesisPath           = "/eth/v1/beacon/genesis"
 	getStatePath             = "/eth/v2/debug/beacon/states"
 	getNodeVersionPath       = "/eth/v1/node/version"
 	changeBLStoExecutionPath = "/eth/v1/beacon/pool/bls_to_execution_changes"
+	getBlockHeaderPath       = "/eth/v1/beacon/headers/{{.Id}}"
+	getCommitteesPath        = "/eth/v1/beacon/states/{{.Id}}/committees"
+	getValidatorsPath        = "/eth/v1/beacon/states/{{.Id}}/validators"
+	attesterSlashingsPath    = "/eth/v2/beacon/pool/attester_slashings"
+	proposerSlashingsPath    = "/eth/v1/beacon/pool/proposer_slashings"
 )
 
 // StateOrBlockId represents the block_id / state_id parameters that several of the Eth Beacon API methods accept.
@@ -157,6 +166,27 @@ func (c *Client) GetFork(ctx context.Context, stateId StateOrBlockId) (*ethpb.Fo
 	return fr.ToConsensus()
 }
 
+var getFinalityCheckpointsTpl = idTemplate(getFinalityCheckpoints)
+
+// GetFinalizedCheckpoint queries the Beacon Node API for the finalized checkpoint of the state identified by stateId.
+// State identifier can be one of: "head" (canonical head in node's view), "genesis", "finalized",
+// <slot>, <hex encoded stateRoot with 0x prefix>. Variables of type StateOrBlockId are exported by this package
+// for the named identifiers.
+func (c *Client) GetFinalizedCheckpoint(ctx context.Context, stateId StateOrBlockId) (*ethpb.Checkpoint, error) {
+	body, err := c.Get(ctx, getFinalityCheckpointsTpl(stateId))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting finality checkpoints by state id = %s", stateId)
+	}
+	resp := &structs.GetFinalityCheckpointsResponse{}
+	if err = json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetFinalizedCheckpoint")
+	}
+	if resp.Data == nil || resp.Data.Finalized == nil {
+		return nil, errors.New("finalized checkpoint missing from finality checkpoints response")
+	}
+	return resp.Data.Finalized.ToConsensus()
+}
+
 // GetForkSchedule retrieve all forks, past present and future, of which this node is aware.
 func (c *Client) GetForkSchedule(ctx context.Context) (forks.OrderedSchedule, error) {
 	body, err := c.Get(ctx, getForkSchedulePath)
@@ -189,6 +219,22 @@ func (c *Client) GetConfigSpec(ctx context.Context) (*structs.GetSpecResponse, e
 	return fsr, nil
 }
 
+// GetGenesis retrieves the genesis time, genesis validators root and genesis fork version of the network.
+func (c *Client) GetGenesis(ctx context.Context) (*structs.Genesis, error) {
+	body, err := c.Get(ctx, getGenesisPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting genesis")
+	}
+	resp := &structs.GetGenesisResponse{}
+	if err = json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetGenesis")
+	}
+	if resp.Data == nil {
+		return nil, errors.New("genesis data missing from genesis response")
+	}
+	return resp.Data, nil
+}
+
 type NodeVersion struct {
 	implementation string
 	semver         string
@@ -334,6 +380,132 @@ func (c *Client) GetBLStoExecutionChanges(ctx context.Context) (*structs.BLSToEx
 	return poolResponse, nil
 }
 
+var getBlockHeaderTpl = idTemplate(getBlockHeaderPath)
+
+// GetBlockHeader retrieves the signed header of the block identified by blockId.
+func (c *Client) GetBlockHeader(ctx context.Context, blockId StateOrBlockId) (*ethpb.SignedBeaconBlockHeader, error) {
+	body, err := c.Get(ctx, getBlockHeaderTpl(blockId))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting block header by block id = %s", blockId)
+	}
+	resp := &structs.GetBlockHeaderResponse{}
+	if err = json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetBlockHeader")
+	}
+	if resp.Data == nil {
+		return nil, errors.New("no header in GetBlockHeader response")
+	}
+	return resp.Data.Header.ToConsensus()
+}
+
+// Committee is a beacon committee, as returned by GetCommittees.
+type Committee struct {
+	Slot       primitives.Slot
+	Index      primitives.CommitteeIndex
+	Validators []primitives.ValidatorIndex
+}
+
+var getCommitteesTpl = idTemplate(getCommitteesPath)
+
+// GetCommittees retrieves all the beacon committees of the given epoch, computed from the state identified by stateId.
+func (c *Client) GetCommittees(ctx context.Context, stateId StateOrBlockId, epoch primitives.Epoch) ([]*Committee, error) {
+	query := url.Values{}
+	query.Set("epoch", strconv.FormatUint(uint64(epoch), 10))
+	body, err := c.Get(ctx, getCommitteesTpl(stateId), withQuery(query))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting committees of epoch %d by state id = %s", epoch, stateId)
+	}
+	resp := &structs.GetCommitteesResponse{}
+	if err = json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetCommittees")
+	}
+	committees := make([]*Committee, len(resp.Data))
+	for i, cm := range resp.Data {
+		slot, err := strconv.ParseUint(cm.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid slot of committee %d", i)
+		}
+		index, err := strconv.ParseUint(cm.Index, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid index of committee %d", i)
+		}
+		validators := make([]primitives.ValidatorIndex, len(cm.Validators))
+		for j, v := range cm.Validators {
+			idx, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid validator index in committee %d", i)
+			}
+			validators[j] = primitives.ValidatorIndex(idx)
+		}
+		committees[i] = &Committee{
+			Slot:       primitives.Slot(slot),
+			Index:      primitives.CommitteeIndex(index),
+			Validators: validators,
+		}
+	}
+	return committees, nil
+}
+
+var getValidatorsTpl = idTemplate(getValidatorsPath)
+
+// GetValidators retrieves the validators with the given indices or hex encoded public keys from the state
+// identified by stateId.
+func (c *Client) GetValidators(ctx context.Context, stateId StateOrBlockId, ids []string) ([]*structs.ValidatorContainer, error) {
+	req, err := json.Marshal(&structs.GetValidatorsRequest{Ids: ids})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal JSON")
+	}
+	body, err := c.Post(ctx, getValidatorsTpl(stateId), bytes.NewBuffer(req))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting validators by state id = %s", stateId)
+	}
+	resp := &structs.GetValidatorsResponse{}
+	if err = json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetValidators")
+	}
+	return resp.Data, nil
+}
+
+// SubmitAttesterSlashing submits an attester slashing to the operations pool of the beacon node.
+func (c *Client) SubmitAttesterSlashing(ctx context.Context, slashing ethpb.AttSlashing) error {
+	var req interface{}
+	switch sl := slashing.(type) {
+	case *ethpb.AttesterSlashing:
+		req = structs.AttesterSlashingFromConsensus(sl)
+	case *ethpb.AttesterSlashingElectra:
+		req = structs.AttesterSlashingElectraFromConsensus(sl)
+	default:
+		return errors.Errorf("unsupported attester slashing type %T", slashing)
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal JSON")
+	}
+	if _, err = c.Post(ctx, attesterSlashingsPath, bytes.NewBuffer(body), withVersion(slashing.Version())); err != nil {
+		return errors.Wrap(err, "error submitting attester slashing")
+	}
+	return nil
+}
+
+// SubmitProposerSlashing submits a proposer slashing to the operations pool of the beacon node.
+func (c *Client) SubmitProposerSlashing(ctx context.Context, slashing *ethpb.ProposerSlashing) error {
+	body, err := json.Marshal(structs.ProposerSlashingFromConsensus(slashing))
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal JSON")
+	}
+	if _, err = c.Post(ctx, proposerSlashingsPath, bytes.NewBuffer(body)); err != nil {
+		return errors.Wrap(err, "error submitting proposer slashing")
+	}
+	return nil
+}
+
+// withVersion is a request option setting the consensus version header of the request.
+func withVersion(v int) client.ReqOption {
+	return func(req *http.Request) {
+		req.Header.Set(api.VersionHeader, version.String(v))
+	}
+}
+
 type forkScheduleResponse struct {
 	Data []structs.Fork
 }
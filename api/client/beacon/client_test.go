@@ -1,11 +1,23 @@
 package beacon
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
 	"net/url"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
 )
 
 func TestParseNodeVersion(t *testing.T) {
@@ -137,3 +149,116 @@ func TestValidHostname(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBlockHeader(t *testing.T) {
+	header := util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{})
+	header.Header.Slot = 5
+	header.Header.ProposerIndex = 3
+	root := [32]byte{'r'}
+	trans := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		res := &http.Response{Request: req}
+		switch req.URL.Path {
+		case getBlockHeaderTpl(IdFromRoot(root)):
+			res.StatusCode = http.StatusOK
+			b, err := json.Marshal(&structs.GetBlockHeaderResponse{Data: &structs.SignedBeaconBlockHeaderContainer{
+				Header:    structs.SignedBeaconBlockHeaderFromConsensus(header),
+				Root:      hexutil.Encode(root[:]),
+				Canonical: true,
+			}})
+			require.NoError(t, err)
+			res.Body = io.NopCloser(bytes.NewBuffer(b))
+		default:
+			res.StatusCode = http.StatusNotFound
+			res.Body = io.NopCloser(bytes.NewBuffer(nil))
+		}
+		return res, nil
+	}}
+	c, err := NewClient("http://localhost:3500", client.WithRoundTripper(trans))
+	require.NoError(t, err)
+
+	got, err := c.GetBlockHeader(context.Background(), IdFromRoot(root))
+	require.NoError(t, err)
+	require.DeepEqual(t, header, got)
+	_, err = c.GetBlockHeader(context.Background(), IdHead)
+	require.ErrorIs(t, err, client.ErrNotFound)
+}
+
+func TestGetCommittees(t *testing.T) {
+	trans := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, getCommitteesTpl(IdHead), req.URL.Path)
+		require.Equal(t, "2", req.URL.Query().Get("epoch"))
+		b, err := json.Marshal(&structs.GetCommitteesResponse{Data: []*structs.Committee{
+			{Index: "0", Slot: "64", Validators: []string{"4", "1"}},
+			{Index: "1", Slot: "64", Validators: []string{"2"}},
+		}})
+		require.NoError(t, err)
+		return &http.Response{Request: req, StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBuffer(b))}, nil
+	}}
+	c, err := NewClient("http://localhost:3500", client.WithRoundTripper(trans))
+	require.NoError(t, err)
+
+	committees, err := c.GetCommittees(context.Background(), IdHead, 2)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*Committee{
+		{Slot: 64, Index: 0, Validators: []primitives.ValidatorIndex{4, 1}},
+		{Slot: 64, Index: 1, Validators: []primitives.ValidatorIndex{2}},
+	}, committees)
+}
+
+func TestGetValidators(t *testing.T) {
+	trans := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, http.MethodPost, req.Method)
+		require.Equal(t, getValidatorsTpl(IdHead), req.URL.Path)
+		vr := &structs.GetValidatorsRequest{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(vr))
+		require.DeepEqual(t, []string{"1", "7"}, vr.Ids)
+		b, err := json.Marshal(&structs.GetValidatorsResponse{Data: []*structs.ValidatorContainer{
+			{Index: "1", Validator: &structs.Validator{Pubkey: "0x01"}},
+			{Index: "7", Validator: &structs.Validator{Pubkey: "0x07"}},
+		}})
+		require.NoError(t, err)
+		return &http.Response{Request: req, StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBuffer(b))}, nil
+	}}
+	c, err := NewClient("http://localhost:3500", client.WithRoundTripper(trans))
+	require.NoError(t, err)
+
+	vals, err := c.GetValidators(context.Background(), IdHead, []string{"1", "7"})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(vals))
+	require.Equal(t, "0x07", vals[1].Validator.Pubkey)
+}
+
+func TestSubmitSlashings(t *testing.T) {
+	var versions []string
+	var paths []string
+	trans := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, http.MethodPost, req.Method)
+		versions = append(versions, req.Header.Get(api.VersionHeader))
+		paths = append(paths, req.URL.Path)
+		return &http.Response{Request: req, StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBuffer(nil))}, nil
+	}}
+	c, err := NewClient("http://localhost:3500", client.WithRoundTripper(trans))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, c.SubmitAttesterSlashing(ctx, &ethpb.AttesterSlashing{
+		Attestation_1: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{}),
+		Attestation_2: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{}),
+	}))
+	require.NoError(t, c.SubmitAttesterSlashing(ctx, &ethpb.AttesterSlashingElectra{
+		Attestation_1: &ethpb.IndexedAttestationElectra{
+			Data:      util.HydrateAttestationData(&ethpb.AttestationData{}),
+			Signature: make([]byte, fieldparams.BLSSignatureLength),
+		},
+		Attestation_2: &ethpb.IndexedAttestationElectra{
+			Data:      util.HydrateAttestationData(&ethpb.AttestationData{}),
+			Signature: make([]byte, fieldparams.BLSSignatureLength),
+		},
+	}))
+	require.NoError(t, c.SubmitProposerSlashing(ctx, &ethpb.ProposerSlashing{
+		Header_1: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+		Header_2: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+	}))
+	require.DeepEqual(t, []string{attesterSlashingsPath, attesterSlashingsPath, proposerSlashingsPath}, paths)
+	require.DeepEqual(t, []string{"phase0", "electra", ""}, versions)
+}
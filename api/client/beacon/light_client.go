@@ -0,0 +1,95 @@
+package beacon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+)
+
+const (
+	getLightClientBootstrapPath        = "/eth/v1/beacon/light_client/bootstrap/{{.Id}}"
+	getLightClientUpdatesByRangePath   = "/eth/v1/beacon/light_client/updates"
+	getLightClientFinalityUpdatePath   = "/eth/v1/beacon/light_client/finality_update"
+	getLightClientOptimisticUpdatePath = "/eth/v1/beacon/light_client/optimistic_update"
+)
+
+var getLightClientBootstrapTpl = idTemplate(getLightClientBootstrapPath)
+
+// GetLightClientBootstrap retrieves the light client bootstrap for the block with the given root, which is the
+// starting point of a light client following the chain from that trusted block.
+func (c *Client) GetLightClientBootstrap(ctx context.Context, blockRoot [32]byte) (interfaces.LightClientBootstrap, error) {
+	body, err := c.Get(ctx, getLightClientBootstrapTpl(IdFromRoot(blockRoot)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting light client bootstrap for block root %#x", blockRoot)
+	}
+	resp := &structs.LightClientBootstrapResponse{}
+	if err = json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetLightClientBootstrap")
+	}
+	return resp.ToConsensus()
+}
+
+// GetLightClientUpdatesByRange retrieves the best light client update of each of the count sync committee periods
+// starting at startPeriod. The beacon node may return fewer updates than requested.
+func (c *Client) GetLightClientUpdatesByRange(ctx context.Context, startPeriod, count uint64) ([]interfaces.LightClientUpdate, error) {
+	query := url.Values{}
+	query.Set("start_period", strconv.FormatUint(startPeriod, 10))
+	query.Set("count", strconv.FormatUint(count, 10))
+	body, err := c.Get(ctx, getLightClientUpdatesByRangePath, withQuery(query))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error requesting %d light client updates from period %d", count, startPeriod)
+	}
+	var resp []*structs.LightClientUpdateResponse
+	if err = json.Unmarshal(body, &resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetLightClientUpdatesByRange")
+	}
+	updates := make([]interfaces.LightClientUpdate, len(resp))
+	for i, r := range resp {
+		updates[i], err = r.ToConsensus()
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("could not convert light client update %d", i))
+		}
+	}
+	return updates, nil
+}
+
+// GetLightClientFinalityUpdate retrieves the latest light client finality update known to the beacon node.
+func (c *Client) GetLightClientFinalityUpdate(ctx context.Context) (interfaces.LightClientFinalityUpdate, error) {
+	body, err := c.Get(ctx, getLightClientFinalityUpdatePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting light client finality update")
+	}
+	resp := &structs.LightClientFinalityUpdateResponse{}
+	if err = json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetLightClientFinalityUpdate")
+	}
+	return resp.ToConsensus()
+}
+
+// GetLightClientOptimisticUpdate retrieves the latest light client optimistic update known to the beacon node.
+func (c *Client) GetLightClientOptimisticUpdate(ctx context.Context) (interfaces.LightClientOptimisticUpdate, error) {
+	body, err := c.Get(ctx, getLightClientOptimisticUpdatePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "error requesting light client optimistic update")
+	}
+	resp := &structs.LightClientOptimisticUpdateResponse{}
+	if err = json.Unmarshal(body, resp); err != nil {
+		return nil, errors.Wrap(err, "error decoding json response in GetLightClientOptimisticUpdate")
+	}
+	return resp.ToConsensus()
+}
+
+// withQuery is a request option setting the query parameters of the request.
+func withQuery(query url.Values) client.ReqOption {
+	return func(req *http.Request) {
+		req.URL.RawQuery = query.Encode()
+	}
+}
@@ -0,0 +1,123 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestGetLightClientBootstrap(t *testing.T) {
+	for _, setup := range []func(*util.TestLightClient) *util.TestLightClient{
+		(*util.TestLightClient).SetupTestAltair,
+		func(l *util.TestLightClient) *util.TestLightClient { return l.SetupTestDeneb(false) },
+	} {
+		l := setup(util.NewTestLightClient(t))
+		bootstrap, err := lightClient.NewLightClientBootstrapFromBeaconState(l.Ctx, l.State.Slot(), l.State, l.Block)
+		require.NoError(t, err)
+		data, err := structs.LightClientBootstrapFromConsensus(bootstrap)
+		require.NoError(t, err)
+		blockRoot, err := l.Block.Block().HashTreeRoot()
+		require.NoError(t, err)
+
+		trans := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+			res := &http.Response{Request: req}
+			switch req.URL.Path {
+			case getLightClientBootstrapTpl(IdFromRoot(blockRoot)):
+				res.StatusCode = http.StatusOK
+				b, err := json.Marshal(&structs.LightClientBootstrapResponse{Version: version.String(bootstrap.Version()), Data: data})
+				require.NoError(t, err)
+				res.Body = io.NopCloser(bytes.NewBuffer(b))
+			default:
+				res.StatusCode = http.StatusNotFound
+				res.Body = io.NopCloser(bytes.NewBuffer(nil))
+			}
+			return res, nil
+		}}
+		c, err := NewClient("http://localhost:3500", client.WithRoundTripper(trans))
+		require.NoError(t, err)
+
+		got, err := c.GetLightClientBootstrap(context.Background(), blockRoot)
+		require.NoError(t, err)
+		require.Equal(t, bootstrap.Version(), got.Version())
+		want, err := bootstrap.MarshalSSZ()
+		require.NoError(t, err)
+		gotSSZ, err := got.MarshalSSZ()
+		require.NoError(t, err)
+		require.DeepEqual(t, want, gotSSZ)
+
+		_, err = c.GetLightClientBootstrap(context.Background(), [32]byte{'a'})
+		require.ErrorIs(t, err, client.ErrNotFound)
+	}
+}
+
+func TestGetLightClientUpdatesByRange(t *testing.T) {
+	l := util.NewTestLightClient(t).SetupTestDeneb(false)
+	update, err := lightClient.NewLightClientUpdateFromBeaconState(l.Ctx, l.State.Slot(), l.State, l.Block, l.AttestedState, l.AttestedBlock, l.FinalizedBlock)
+	require.NoError(t, err)
+	data, err := structs.LightClientUpdateFromConsensus(update)
+	require.NoError(t, err)
+
+	trans := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		res := &http.Response{Request: req, StatusCode: http.StatusOK}
+		require.Equal(t, getLightClientUpdatesByRangePath, req.URL.Path)
+		require.Equal(t, "7", req.URL.Query().Get("start_period"))
+		require.Equal(t, "2", req.URL.Query().Get("count"))
+		b, err := json.Marshal([]*structs.LightClientUpdateResponse{{Version: version.String(update.Version()), Data: data}})
+		require.NoError(t, err)
+		res.Body = io.NopCloser(bytes.NewBuffer(b))
+		return res, nil
+	}}
+	c, err := NewClient("http://localhost:3500", client.WithRoundTripper(trans))
+	require.NoError(t, err)
+
+	got, err := c.GetLightClientUpdatesByRange(context.Background(), 7, 2)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(got))
+	require.DeepSSZEqual(t, update.Proto(), got[0].Proto())
+}
+
+func TestGetLightClientFinalityAndOptimisticUpdates(t *testing.T) {
+	l := util.NewTestLightClient(t).SetupTestCapella(false)
+	finalityUpdate, err := lightClient.NewLightClientFinalityUpdateFromBeaconState(l.Ctx, l.State.Slot(), l.State, l.Block, l.AttestedState, l.AttestedBlock, l.FinalizedBlock)
+	require.NoError(t, err)
+	finalityData, err := structs.LightClientFinalityUpdateFromConsensus(finalityUpdate)
+	require.NoError(t, err)
+	optimisticUpdate, err := lightClient.NewLightClientOptimisticUpdateFromBeaconState(l.Ctx, l.State.Slot(), l.State, l.Block, l.AttestedState, l.AttestedBlock)
+	require.NoError(t, err)
+	optimisticData, err := structs.LightClientOptimisticUpdateFromConsensus(optimisticUpdate)
+	require.NoError(t, err)
+
+	trans := &testRT{rt: func(req *http.Request) (*http.Response, error) {
+		res := &http.Response{Request: req, StatusCode: http.StatusOK}
+		var resp interface{}
+		switch req.URL.Path {
+		case getLightClientFinalityUpdatePath:
+			resp = &structs.LightClientFinalityUpdateResponse{Version: version.String(finalityUpdate.Version()), Data: finalityData}
+		case getLightClientOptimisticUpdatePath:
+			resp = &structs.LightClientOptimisticUpdateResponse{Version: version.String(optimisticUpdate.Version()), Data: optimisticData}
+		}
+		b, err := json.Marshal(resp)
+		require.NoError(t, err)
+		res.Body = io.NopCloser(bytes.NewBuffer(b))
+		return res, nil
+	}}
+	c, err := NewClient("http://localhost:3500", client.WithRoundTripper(trans))
+	require.NoError(t, err)
+
+	gotFinality, err := c.GetLightClientFinalityUpdate(context.Background())
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, finalityUpdate.Proto(), gotFinality.Proto())
+	gotOptimistic, err := c.GetLightClientOptimisticUpdate(context.Background())
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, optimisticUpdate.Proto(), gotOptimistic.Proto())
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"text/template"
 
 	"github.com/pkg/errors"
@@ -25,6 +26,7 @@ import (
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
@@ -37,6 +39,10 @@ const (
 var errMalformedHostname = errors.New("hostname must include port, separated by one colon, like example.com:3500")
 var errMalformedRequest = errors.New("required request data are missing")
 var errNotBlinded = errors.New("submitted block is not blinded")
+var errMissingVersion = errors.New("builder API SSZ response is missing the " + api.VersionHeader + " header")
+
+// sszAccept prefers SSZ encoded responses, while letting the relay answer with JSON if it does not support SSZ.
+var sszAccept = fmt.Sprintf("%s;q=1.0,%s;q=0.9", api.OctetStreamMediaType, api.JsonMediaType)
 
 // ClientOpt is a functional option for the Client type (http.Client wrapper)
 type ClientOpt func(*Client)
@@ -97,6 +103,8 @@ type Client struct {
 	hc      *http.Client
 	baseURL *url.URL
 	obvs    []observer
+	// sszUnsupported holds the endpoints for which the builder rejected SSZ, so that they are only called with JSON.
+	sszUnsupported sync.Map
 }
 
 // NewClient constructs a new client with the provided options (ex WithTimeout).
@@ -137,8 +145,56 @@ func (c *Client) NodeURL() string {
 
 type reqOption func(*http.Request)
 
+// sszEnabled returns whether the endpoint is called with SSZ, which is the case until the builder rejects it.
+func (c *Client) sszEnabled(endpoint string) bool {
+	_, unsupported := c.sszUnsupported.Load(endpoint)
+	return !unsupported
+}
+
+// disableSSZ switches the endpoint to JSON for the lifetime of the client.
+func (c *Client) disableSSZ(endpoint string) {
+	if _, loaded := c.sszUnsupported.LoadOrStore(endpoint, true); !loaded {
+		log.WithFields(log.Fields{
+			"url":      c.NodeURL(),
+			"endpoint": endpoint,
+		}).Info("Builder does not support SSZ, falling back to JSON")
+	}
+}
+
+// sszRejected returns whether the error is the response of a builder which does not support SSZ.
+func sszRejected(err error) bool {
+	return errors.Is(err, ErrNotAcceptable) || errors.Is(err, ErrUnsupportedMediaType)
+}
+
+// isSSZ returns whether the response body is SSZ encoded.
+func isSSZ(h http.Header) bool {
+	return strings.HasPrefix(h.Get("Content-Type"), api.OctetStreamMediaType)
+}
+
+// doNegotiated calls the endpoint with a request encoded by the given function, in SSZ if the builder supports it for
+// this endpoint, and in JSON otherwise. A builder rejecting SSZ is called again with JSON, which is then used for all
+// the following calls to the endpoint.
+func (c *Client) doNegotiated(ctx context.Context, method, endpoint, path string, encode func(sszEnabled bool) (io.Reader, reqOption, error)) ([]byte, http.Header, error) {
+	if c.sszEnabled(endpoint) {
+		body, opt, err := encode(true)
+		if err != nil {
+			return nil, nil, err
+		}
+		res, h, err := c.do(ctx, method, path, body, opt)
+		if !sszRejected(err) {
+			return res, h, err
+		}
+		c.disableSSZ(endpoint)
+	}
+	body, opt, err := encode(false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.do(ctx, method, path, body, opt)
+}
+
 // do is a generic, opinionated request function to reduce boilerplate amongst the methods in this package api/client/builder.
-func (c *Client) do(ctx context.Context, method string, path string, body io.Reader, opts ...reqOption) (res []byte, err error) {
+func (c *Client) do(ctx context.Context, method string, path string, body io.Reader, opts ...reqOption) (res []byte, header http.Header, err error) {
 	ctx, span := trace.StartSpan(ctx, "builder.client.do")
 	defer func() {
 		tracing.AnnotateError(span, err)
@@ -177,6 +233,7 @@ func (c *Client) do(ctx context.Context, method string, path string, body io.Rea
 		err = non200Err(r)
 		return
 	}
+	header = r.Header
 	res, err = io.ReadAll(io.LimitReader(r.Body, client.MaxBodySize))
 	if err != nil {
 		err = errors.Wrap(err, "error reading http response body from builder server")
@@ -211,10 +268,25 @@ func (c *Client) GetHeader(ctx context.Context, slot primitives.Slot, parentHash
 	if err != nil {
 		return nil, err
 	}
-	hb, err := c.do(ctx, http.MethodGet, path, nil)
+	hb, h, err := c.doNegotiated(ctx, http.MethodGet, getExecHeaderPath, path, func(sszEnabled bool) (io.Reader, reqOption, error) {
+		return nil, func(r *http.Request) {
+			if sszEnabled {
+				r.Header.Set("Accept", sszAccept)
+			} else {
+				r.Header.Set("Accept", api.JsonMediaType)
+			}
+		}, nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	if isSSZ(h) {
+		bid, err := unmarshalBidSSZ(hb, h.Get(api.VersionHeader))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error unmarshaling the builder GetHeader SSZ response, using slot=%d, parentHash=%#x, pubkey=%#x", slot, parentHash, pubkey)
+		}
+		return bid, nil
+	}
 	v := &VersionResponse{}
 	if err := json.Unmarshal(hb, v); err != nil {
 		return nil, errors.Wrapf(err, "error unmarshaling the builder GetHeader response, using slot=%d, parentHash=%#x, pubkey=%#x", slot, parentHash, pubkey)
@@ -255,8 +327,36 @@ func (c *Client) GetHeader(ctx context.Context, slot primitives.Slot, parentHash
 	}
 }
 
-// RegisterValidator encodes the SignedValidatorRegistrationV1 message to json (including hex-encoding the byte
-// fields with 0x prefixes) and posts to the builder validator registration endpoint.
+// unmarshalBidSSZ decodes a SSZ encoded signed builder bid of the given consensus version.
+func unmarshalBidSSZ(b []byte, ver string) (SignedBid, error) {
+	switch strings.ToLower(ver) {
+	case version.String(version.Deneb):
+		p := &ethpb.SignedBuilderBidDeneb{}
+		if err := p.UnmarshalSSZ(b); err != nil {
+			return nil, err
+		}
+		return WrappedSignedBuilderBidDeneb(p)
+	case version.String(version.Capella):
+		p := &ethpb.SignedBuilderBidCapella{}
+		if err := p.UnmarshalSSZ(b); err != nil {
+			return nil, err
+		}
+		return WrappedSignedBuilderBidCapella(p)
+	case version.String(version.Bellatrix):
+		p := &ethpb.SignedBuilderBid{}
+		if err := p.UnmarshalSSZ(b); err != nil {
+			return nil, err
+		}
+		return WrappedSignedBuilderBid(p)
+	case "":
+		return nil, errMissingVersion
+	default:
+		return nil, fmt.Errorf("unsupported header version %s", strings.ToLower(ver))
+	}
+}
+
+// RegisterValidator encodes the SignedValidatorRegistrationV1 messages to SSZ, or to json (including hex-encoding the
+// byte fields with 0x prefixes) if the builder does not support SSZ, and posts to the builder validator registration endpoint.
 func (c *Client) RegisterValidator(ctx context.Context, svr []*ethpb.SignedValidatorRegistrationV1) error {
 	ctx, span := trace.StartSpan(ctx, "builder.client.RegisterValidator")
 	defer span.End()
@@ -267,21 +367,32 @@ func (c *Client) RegisterValidator(ctx context.Context, svr []*ethpb.SignedValid
 		tracing.AnnotateError(span, err)
 		return err
 	}
-	vs := make([]*structs.SignedValidatorRegistration, len(svr))
-	for i := 0; i < len(svr); i++ {
-		vs[i] = structs.SignedValidatorRegistrationFromConsensus(svr[i])
-	}
-	body, err := json.Marshal(vs)
+	_, _, err := c.doNegotiated(ctx, http.MethodPost, postRegisterValidatorPath, postRegisterValidatorPath, func(sszEnabled bool) (io.Reader, reqOption, error) {
+		if sszEnabled {
+			body, err := marshalRegistrations(svr)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "error encoding the SignedValidatorRegistration value body in RegisterValidator")
+			}
+			return bytes.NewBuffer(body), func(r *http.Request) {
+				r.Header.Set("Content-Type", api.OctetStreamMediaType)
+			}, nil
+		}
+		vs := make([]*structs.SignedValidatorRegistration, len(svr))
+		for i := 0; i < len(svr); i++ {
+			vs[i] = structs.SignedValidatorRegistrationFromConsensus(svr[i])
+		}
+		body, err := json.Marshal(vs)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error encoding the SignedValidatorRegistration value body in RegisterValidator")
+		}
+		return bytes.NewBuffer(body), func(r *http.Request) {
+			r.Header.Set("Content-Type", api.JsonMediaType)
+		}, nil
+	})
 	if err != nil {
-		err := errors.Wrap(err, "error encoding the SignedValidatorRegistration value body in RegisterValidator")
 		tracing.AnnotateError(span, err)
 		return err
 	}
-
-	_, err = c.do(ctx, http.MethodPost, postRegisterValidatorPath, bytes.NewBuffer(body))
-	if err != nil {
-		return err
-	}
 	log.WithField("registrationCount", len(svr)).Debug("Successfully registered validator(s) on builder")
 	return nil
 }
@@ -295,27 +406,41 @@ func (c *Client) SubmitBlindedBlock(ctx context.Context, sb interfaces.ReadOnlyS
 		return nil, nil, errNotBlinded
 	}
 
-	// massage the proto struct type data into the api response type.
-	mj, err := structs.SignedBeaconBlockMessageJsoner(sb)
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "error generating blinded beacon block post request")
-	}
-
-	body, err := json.Marshal(mj)
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "error marshaling blinded block post request to json")
-	}
-	postOpts := func(r *http.Request) {
-		r.Header.Add("Eth-Consensus-Version", version.String(sb.Version()))
-		r.Header.Set("Content-Type", api.JsonMediaType)
-		r.Header.Set("Accept", api.JsonMediaType)
-	}
 	// post the blinded block - the execution payload response should contain the unblinded payload, along with the
 	// blobs bundle if it is post deneb.
-	rb, err := c.do(ctx, http.MethodPost, postBlindedBeaconBlockPath, bytes.NewBuffer(body), postOpts)
+	rb, h, err := c.doNegotiated(ctx, http.MethodPost, postBlindedBeaconBlockPath, postBlindedBeaconBlockPath, func(sszEnabled bool) (io.Reader, reqOption, error) {
+		if sszEnabled {
+			body, err := sb.MarshalSSZ()
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "error marshaling blinded block post request to ssz")
+			}
+			return bytes.NewBuffer(body), func(r *http.Request) {
+				r.Header.Add(api.VersionHeader, version.String(sb.Version()))
+				r.Header.Set("Content-Type", api.OctetStreamMediaType)
+				r.Header.Set("Accept", sszAccept)
+			}, nil
+		}
+		// massage the proto struct type data into the api response type.
+		mj, err := structs.SignedBeaconBlockMessageJsoner(sb)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error generating blinded beacon block post request")
+		}
+		body, err := json.Marshal(mj)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error marshaling blinded block post request to json")
+		}
+		return bytes.NewBuffer(body), func(r *http.Request) {
+			r.Header.Add(api.VersionHeader, version.String(sb.Version()))
+			r.Header.Set("Content-Type", api.JsonMediaType)
+			r.Header.Set("Accept", api.JsonMediaType)
+		}, nil
+	})
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "error posting the blinded block to the builder api")
 	}
+	if isSSZ(h) {
+		return unmarshalPayloadSSZ(rb, h.Get(api.VersionHeader), sb.Version())
+	}
 	// ExecutionPayloadResponse parses just the outer container and the Value key, enabling it to use the .Value
 	// key to determine which underlying data type to use to finish the unmarshaling.
 	ep := &ExecutionPayloadResponse{}
@@ -350,11 +475,48 @@ func (c *Client) SubmitBlindedBlock(ctx context.Context, sb interfaces.ReadOnlyS
 	return ed, nil, nil
 }
 
+// unmarshalPayloadSSZ decodes the SSZ encoded response of a blinded block submission, made of the execution payload
+// and, since Deneb, of the blobs bundle.
+func unmarshalPayloadSSZ(b []byte, ver string, blockVersion int) (interfaces.ExecutionData, *v1.BlobsBundle, error) {
+	if ver != "" && strings.ToLower(ver) != version.String(blockVersion) {
+		return nil, nil, errors.Wrapf(errResponseVersionMismatch, "req=%s, recv=%s", version.String(blockVersion), strings.ToLower(ver))
+	}
+	var payload proto.Message
+	var bundle *v1.BlobsBundle
+	switch {
+	case blockVersion >= version.Deneb:
+		p := &ExecutionPayloadAndBlobsBundle{}
+		if err := p.UnmarshalSSZ(b); err != nil {
+			return nil, nil, errors.Wrap(err, "error unmarshaling the builder SSZ execution payload and blobs bundle")
+		}
+		payload, bundle = p.ExecutionPayload, p.BlobsBundle
+	case blockVersion == version.Capella:
+		p := &v1.ExecutionPayloadCapella{}
+		if err := p.UnmarshalSSZ(b); err != nil {
+			return nil, nil, errors.Wrap(err, "error unmarshaling the builder SSZ execution payload")
+		}
+		payload = p
+	case blockVersion == version.Bellatrix:
+		p := &v1.ExecutionPayload{}
+		if err := p.UnmarshalSSZ(b); err != nil {
+			return nil, nil, errors.Wrap(err, "error unmarshaling the builder SSZ execution payload")
+		}
+		payload = p
+	default:
+		return nil, nil, errors.Wrapf(blocks.ErrUnsupportedVersion, "version=%s", version.String(blockVersion))
+	}
+	ed, err := blocks.NewWrappedExecutionData(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ed, bundle, nil
+}
+
 // Status asks the remote builder server for a health check. A response of 200 with an empty body is the success/healthy
 // response, and an error response may have an error message. This method will return a nil value for error in the
 // happy path, and an error with information about the server response body for a non-200 response.
 func (c *Client) Status(ctx context.Context) error {
-	_, err := c.do(ctx, http.MethodGet, getStatus, nil)
+	_, _, err := c.do(ctx, http.MethodGet, getStatus, nil)
 	return err
 }
 
@@ -384,6 +546,12 @@ func non200Err(response *http.Response) error {
 			return errors.Wrap(jsonErr, "unable to read response body")
 		}
 		return errors.Wrap(ErrNotFound, errMessage.Message)
+	case http.StatusNotAcceptable:
+		log.WithError(ErrNotAcceptable).Debug(msg)
+		return ErrNotAcceptable
+	case http.StatusUnsupportedMediaType:
+		log.WithError(ErrUnsupportedMediaType).Debug(msg)
+		return ErrUnsupportedMediaType
 	case http.StatusInternalServerError:
 		log.WithError(ErrNotOK).Debug(msg)
 		if jsonErr := json.Unmarshal(bodyBytes, &errMessage); jsonErr != nil {
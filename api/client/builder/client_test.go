@@ -108,6 +108,7 @@ func TestClient_RegisterValidator(t *testing.T) {
 		hc:      hc,
 		baseURL: &url.URL{Host: "localhost:3500", Scheme: "http"},
 	}
+	c.disableSSZ(postRegisterValidatorPath)
 	reg := &eth.SignedValidatorRegistrationV1{
 		Message: &eth.ValidatorRegistrationV1{
 			FeeRecipient: ezDecode(t, params.BeaconConfig().EthBurnAddressHex),
@@ -333,6 +334,7 @@ func TestSubmitBlindedBlock(t *testing.T) {
 			hc:      hc,
 			baseURL: &url.URL{Host: "localhost:3500", Scheme: "http"},
 		}
+		c.disableSSZ(postBlindedBeaconBlockPath)
 		sbbb, err := blocks.NewSignedBeaconBlock(testSignedBlindedBeaconBlockBellatrix(t))
 		require.NoError(t, err)
 		ep, _, err := c.SubmitBlindedBlock(ctx, sbbb)
@@ -361,6 +363,7 @@ func TestSubmitBlindedBlock(t *testing.T) {
 			hc:      hc,
 			baseURL: &url.URL{Host: "localhost:3500", Scheme: "http"},
 		}
+		c.disableSSZ(postBlindedBeaconBlockPath)
 		sbb, err := blocks.NewSignedBeaconBlock(testSignedBlindedBeaconBlockCapella(t))
 		require.NoError(t, err)
 		ep, _, err := c.SubmitBlindedBlock(ctx, sbb)
@@ -398,6 +401,7 @@ func TestSubmitBlindedBlock(t *testing.T) {
 			hc:      hc,
 			baseURL: &url.URL{Host: "localhost:3500", Scheme: "http"},
 		}
+		c.disableSSZ(postBlindedBeaconBlockPath)
 
 		sbb, err := blocks.NewSignedBeaconBlock(test)
 		require.NoError(t, err)
@@ -428,6 +432,7 @@ func TestSubmitBlindedBlock(t *testing.T) {
 			hc:      hc,
 			baseURL: &url.URL{Host: "localhost:3500", Scheme: "http"},
 		}
+		c.disableSSZ(postBlindedBeaconBlockPath)
 		sbbb, err := blocks.NewSignedBeaconBlock(testSignedBlindedBeaconBlockBellatrix(t))
 		require.NoError(t, err)
 		_, _, err = c.SubmitBlindedBlock(ctx, sbbb)
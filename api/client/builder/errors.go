@@ -15,3 +15,9 @@ var ErrBadRequest = errors.Wrap(ErrNotOK, "recv 400 BadRequest response from API
 // ErrNoContent specifically means that a '204 - No Content' response was received from the API.
 // Typically, a 204 is a success but in this case for the Header API means No header is available
 var ErrNoContent = errors.New("recv 204 no content response from API, No header is available")
+
+// ErrNotAcceptable specifically means that a '406 - NOT ACCEPTABLE' response was received from the API.
+var ErrNotAcceptable = errors.Wrap(ErrNotOK, "recv 406 NotAcceptable response from API")
+
+// ErrUnsupportedMediaType specifically means that a '415 - UNSUPPORTED MEDIA TYPE' response was received from the API.
+var ErrUnsupportedMediaType = errors.Wrap(ErrNotOK, "recv 415 UnsupportedMediaType response from API")
@@ -0,0 +1,83 @@
+package builder
+
+import (
+	"github.com/pkg/errors"
+	ssz "github.com/prysmaticlabs/fastssz"
+	v1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+// executionPayloadAndBlobsBundleOffset is the size of the fixed part of ExecutionPayloadAndBlobsBundle,
+// made of the offsets of its two variable size fields.
+const executionPayloadAndBlobsBundleOffset = 8
+
+// ExecutionPayloadAndBlobsBundle is the SSZ response of the builder API blinded block submission since Deneb.
+type ExecutionPayloadAndBlobsBundle struct {
+	ExecutionPayload *v1.ExecutionPayloadDeneb
+	BlobsBundle      *v1.BlobsBundle
+}
+
+// MarshalSSZ ssz marshals the ExecutionPayloadAndBlobsBundle object.
+func (e *ExecutionPayloadAndBlobsBundle) MarshalSSZ() ([]byte, error) {
+	if e.ExecutionPayload == nil || e.BlobsBundle == nil {
+		return nil, errMalformedRequest
+	}
+	dst := make([]byte, 0, executionPayloadAndBlobsBundleOffset+e.ExecutionPayload.SizeSSZ()+e.BlobsBundle.SizeSSZ())
+	dst = ssz.WriteOffset(dst, executionPayloadAndBlobsBundleOffset)
+	dst = ssz.WriteOffset(dst, executionPayloadAndBlobsBundleOffset+e.ExecutionPayload.SizeSSZ())
+	dst, err := e.ExecutionPayload.MarshalSSZTo(dst)
+	if err != nil {
+		return nil, err
+	}
+	return e.BlobsBundle.MarshalSSZTo(dst)
+}
+
+// UnmarshalSSZ ssz unmarshals the ExecutionPayloadAndBlobsBundle object.
+func (e *ExecutionPayloadAndBlobsBundle) UnmarshalSSZ(buf []byte) error {
+	size := uint64(len(buf))
+	if size < executionPayloadAndBlobsBundleOffset {
+		return ssz.ErrSize
+	}
+	o0, o1 := ssz.ReadOffset(buf[0:4]), ssz.ReadOffset(buf[4:8])
+	if o0 != executionPayloadAndBlobsBundleOffset || o1 < o0 || o1 > size {
+		return ssz.ErrOffset
+	}
+	e.ExecutionPayload = &v1.ExecutionPayloadDeneb{}
+	if err := e.ExecutionPayload.UnmarshalSSZ(buf[o0:o1]); err != nil {
+		return errors.Wrap(err, "could not unmarshal execution payload")
+	}
+	e.BlobsBundle = &v1.BlobsBundle{}
+	if err := e.BlobsBundle.UnmarshalSSZ(buf[o1:]); err != nil {
+		return errors.Wrap(err, "could not unmarshal blobs bundle")
+	}
+	return nil
+}
+
+// marshalRegistrations ssz marshals a list of validator registrations. As the registrations have a fixed size,
+// the list is the concatenation of its elements.
+func marshalRegistrations(svr []*ethpb.SignedValidatorRegistrationV1) ([]byte, error) {
+	var dst []byte
+	for _, r := range svr {
+		var err error
+		if dst, err = r.MarshalSSZTo(dst); err != nil {
+			return nil, err
+		}
+	}
+	return dst, nil
+}
+
+// UnmarshalRegistrations ssz unmarshals a list of validator registrations, as sent to the builder API.
+func UnmarshalRegistrations(buf []byte) ([]*ethpb.SignedValidatorRegistrationV1, error) {
+	size := (&ethpb.SignedValidatorRegistrationV1{}).SizeSSZ()
+	if len(buf)%size != 0 {
+		return nil, ssz.ErrSize
+	}
+	regs := make([]*ethpb.SignedValidatorRegistrationV1, len(buf)/size)
+	for i := range regs {
+		regs[i] = &ethpb.SignedValidatorRegistrationV1{}
+		if err := regs[i].UnmarshalSSZ(buf[i*size : (i+1)*size]); err != nil {
+			return nil, err
+		}
+	}
+	return regs, nil
+}
@@ -0,0 +1,316 @@
+package testing
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/client/builder"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	v1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// Relay is a local builder relay serving fixed responses over HTTP, to test the builder client against.
+// Requests are answered in SSZ when the client accepts it, and in JSON otherwise.
+type Relay struct {
+	// Bid is returned by header requests. It is a *ethpb.SignedBuilderBid, *ethpb.SignedBuilderBidCapella
+	// or *ethpb.SignedBuilderBidDeneb, and no bid is available when it is nil.
+	Bid proto.Message
+	// Payload and BlobsBundle are returned by blinded block submissions.
+	Payload     interfaces.ExecutionData
+	BlobsBundle *v1.BlobsBundle
+	// JSONOnly makes the relay reject SSZ request bodies with a 415 and requests accepting SSZ with a 406,
+	// like a relay which does not support SSZ.
+	JSONOnly bool
+
+	server        *httptest.Server
+	lock          sync.Mutex
+	requests      []*Request
+	registrations []*ethpb.SignedValidatorRegistrationV1
+}
+
+// Request is a request received by the relay.
+type Request struct {
+	Method      string
+	Path        string
+	ContentType string
+	Accept      string
+	Body        []byte
+}
+
+// SSZ returns whether the request body was SSZ encoded.
+func (r *Request) SSZ() bool {
+	return strings.HasPrefix(r.ContentType, api.OctetStreamMediaType)
+}
+
+// Start starts serving the relay on a local port.
+func (r *Relay) Start() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /eth/v1/builder/status", func(http.ResponseWriter, *http.Request) {})
+	mux.HandleFunc("GET /eth/v1/builder/header/{slot}/{parent_hash}/{pubkey}", r.handleHeader)
+	mux.HandleFunc("POST /eth/v1/builder/validators", r.handleRegistrations)
+	mux.HandleFunc("POST /eth/v1/builder/blinded_blocks", r.handleBlindedBlock)
+	r.server = httptest.NewServer(r.record(mux))
+}
+
+// Close stops serving the relay.
+func (r *Relay) Close() {
+	r.server.Close()
+}
+
+// URL returns the base URL of the relay.
+func (r *Relay) URL() string {
+	return r.server.URL
+}
+
+// Requests returns the requests received by the relay, in order.
+func (r *Relay) Requests() []*Request {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]*Request{}, r.requests...)
+}
+
+// Registrations returns the validator registrations accepted by the relay.
+func (r *Relay) Registrations() []*ethpb.SignedValidatorRegistrationV1 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]*ethpb.SignedValidatorRegistrationV1{}, r.registrations...)
+}
+
+func (r *Relay) record(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		r.lock.Lock()
+		r.requests = append(r.requests, &Request{
+			Method:      req.Method,
+			Path:        req.URL.Path,
+			ContentType: req.Header.Get("Content-Type"),
+			Accept:      req.Header.Get("Accept"),
+			Body:        body,
+		})
+		r.lock.Unlock()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, req)
+	})
+}
+
+// negotiate checks the encodings of the request against the ones supported by the relay, and returns whether
+// the response is SSZ encoded. It writes the error response when the request is rejected.
+func (r *Relay) negotiate(w http.ResponseWriter, req *http.Request) (sszResponse bool, ok bool) {
+	sszRequest := strings.HasPrefix(req.Header.Get("Content-Type"), api.OctetStreamMediaType)
+	sszAccepted := strings.Contains(req.Header.Get("Accept"), api.OctetStreamMediaType)
+	if r.JSONOnly && sszRequest {
+		writeError(w, http.StatusUnsupportedMediaType, "SSZ request bodies are not supported")
+		return false, false
+	}
+	if r.JSONOnly && sszAccepted {
+		writeError(w, http.StatusNotAcceptable, "SSZ responses are not supported")
+		return false, false
+	}
+	return sszAccepted, true
+}
+
+func (r *Relay) handleHeader(w http.ResponseWriter, req *http.Request) {
+	sszResponse, ok := r.negotiate(w, req)
+	if !ok {
+		return
+	}
+	if r.Bid == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	ver, data, err := bidJSON(r.Bid)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if sszResponse {
+		m, ok := r.Bid.(interface{ MarshalSSZ() ([]byte, error) })
+		if !ok {
+			writeError(w, http.StatusInternalServerError, "bid cannot be SSZ encoded")
+			return
+		}
+		b, err := m.MarshalSSZ()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeSSZ(w, ver, b)
+		return
+	}
+	writeJSON(w, map[string]interface{}{"version": ver, "data": data})
+}
+
+func (r *Relay) handleRegistrations(w http.ResponseWriter, req *http.Request) {
+	if _, ok := r.negotiate(w, req); !ok {
+		return
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var regs []*ethpb.SignedValidatorRegistrationV1
+	if strings.HasPrefix(req.Header.Get("Content-Type"), api.OctetStreamMediaType) {
+		regs, err = builder.UnmarshalRegistrations(body)
+	} else {
+		regs, err = registrationsFromJSON(body)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	r.lock.Lock()
+	r.registrations = append(r.registrations, regs...)
+	r.lock.Unlock()
+}
+
+func (r *Relay) handleBlindedBlock(w http.ResponseWriter, req *http.Request) {
+	sszResponse, ok := r.negotiate(w, req)
+	if !ok {
+		return
+	}
+	if r.Payload == nil {
+		writeError(w, http.StatusInternalServerError, "payload not found")
+		return
+	}
+	if !sszResponse {
+		resp, err := builder.ExecutionPayloadResponseFromData(r.Payload, r.BlobsBundle)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, resp)
+		return
+	}
+	var b []byte
+	var err error
+	var ver string
+	switch p := r.Payload.Proto().(type) {
+	case *v1.ExecutionPayload:
+		ver = version.String(version.Bellatrix)
+		b, err = p.MarshalSSZ()
+	case *v1.ExecutionPayloadCapella:
+		ver = version.String(version.Capella)
+		b, err = p.MarshalSSZ()
+	case *v1.ExecutionPayloadDeneb:
+		ver = req.Header.Get(api.VersionHeader)
+		b, err = (&builder.ExecutionPayloadAndBlobsBundle{ExecutionPayload: p, BlobsBundle: r.BlobsBundle}).MarshalSSZ()
+	default:
+		err = errors.Errorf("unsupported payload type %T", p)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeSSZ(w, ver, b)
+}
+
+// bidJSON returns the version and the JSON representation of the signed bid.
+func bidJSON(bid proto.Message) (string, interface{}, error) {
+	type message struct {
+		Header             interface{} `json:"header"`
+		BlobKzgCommitments []string    `json:"blob_kzg_commitments,omitempty"`
+		Value              string      `json:"value"`
+		Pubkey             string      `json:"pubkey"`
+	}
+	type signedMessage struct {
+		Message   *message `json:"message"`
+		Signature string   `json:"signature"`
+	}
+	value := func(v []byte) string {
+		return new(big.Int).SetBytes(bytesutil.ReverseByteOrder(v)).String()
+	}
+	switch b := bid.(type) {
+	case *ethpb.SignedBuilderBid:
+		h, err := structs.ExecutionPayloadHeaderFromConsensus(b.Message.Header)
+		if err != nil {
+			return "", nil, err
+		}
+		return version.String(version.Bellatrix), &signedMessage{
+			Message:   &message{Header: h, Value: value(b.Message.Value), Pubkey: hexutil.Encode(b.Message.Pubkey)},
+			Signature: hexutil.Encode(b.Signature),
+		}, nil
+	case *ethpb.SignedBuilderBidCapella:
+		h, err := structs.ExecutionPayloadHeaderCapellaFromConsensus(b.Message.Header)
+		if err != nil {
+			return "", nil, err
+		}
+		return version.String(version.Capella), &signedMessage{
+			Message:   &message{Header: h, Value: value(b.Message.Value), Pubkey: hexutil.Encode(b.Message.Pubkey)},
+			Signature: hexutil.Encode(b.Signature),
+		}, nil
+	case *ethpb.SignedBuilderBidDeneb:
+		h, err := structs.ExecutionPayloadHeaderDenebFromConsensus(b.Message.Header)
+		if err != nil {
+			return "", nil, err
+		}
+		commitments := make([]string, len(b.Message.BlobKzgCommitments))
+		for i, c := range b.Message.BlobKzgCommitments {
+			commitments[i] = hexutil.Encode(c)
+		}
+		return version.String(version.Deneb), &signedMessage{
+			Message:   &message{Header: h, BlobKzgCommitments: commitments, Value: value(b.Message.Value), Pubkey: hexutil.Encode(b.Message.Pubkey)},
+			Signature: hexutil.Encode(b.Signature),
+		}, nil
+	default:
+		return "", nil, errors.Errorf("unsupported bid type %T", bid)
+	}
+}
+
+func registrationsFromJSON(body []byte) ([]*ethpb.SignedValidatorRegistrationV1, error) {
+	var vs []*structs.SignedValidatorRegistration
+	if err := json.Unmarshal(body, &vs); err != nil {
+		return nil, err
+	}
+	regs := make([]*ethpb.SignedValidatorRegistrationV1, len(vs))
+	for i, v := range vs {
+		reg, err := v.ToConsensus()
+		if err != nil {
+			return nil, err
+		}
+		regs[i] = reg
+	}
+	return regs, nil
+}
+
+func writeSSZ(w http.ResponseWriter, ver string, b []byte) {
+	w.Header().Set("Content-Type", api.OctetStreamMediaType)
+	w.Header().Set(api.VersionHeader, ver)
+	if _, err := w.Write(b); err != nil {
+		log.WithError(err).Error("Could not write response")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", api.JsonMediaType)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.WithError(err).Error("Could not write response")
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", api.JsonMediaType)
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(&builder.ErrorMessage{Code: code, Message: msg}); err != nil {
+		log.WithError(err).Error("Could not write response")
+	}
+}
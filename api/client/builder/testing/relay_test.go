@@ -0,0 +1,150 @@
+package testing
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/client/builder"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	v1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+// testRelay returns a relay serving a Deneb bid, and the payload and blobs of the bid.
+func testRelay(t *testing.T, jsonOnly bool) *Relay {
+	blk, sidecars := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 2)
+	payload, err := blk.Block().Body().Execution()
+	require.NoError(t, err)
+	header, err := blocks.PayloadToHeaderDeneb(payload)
+	require.NoError(t, err)
+	commitments, err := blk.Block().Body().BlobKzgCommitments()
+	require.NoError(t, err)
+	bundle := &v1.BlobsBundle{}
+	for _, sc := range sidecars {
+		bundle.KzgCommitments = append(bundle.KzgCommitments, sc.KzgCommitment)
+		bundle.Proofs = append(bundle.Proofs, sc.KzgProof)
+		bundle.Blobs = append(bundle.Blobs, sc.Blob)
+	}
+	r := &Relay{
+		Bid: &ethpb.SignedBuilderBidDeneb{
+			Message: &ethpb.BuilderBidDeneb{
+				Header:             header,
+				BlobKzgCommitments: commitments,
+				Value:              bytesutil.PadTo([]byte{1}, 32),
+				Pubkey:             make([]byte, fieldparams.BLSPubkeyLength),
+			},
+			Signature: make([]byte, fieldparams.BLSSignatureLength),
+		},
+		Payload:     payload,
+		BlobsBundle: bundle,
+		JSONOnly:    jsonOnly,
+	}
+	r.Start()
+	t.Cleanup(r.Close)
+	return r
+}
+
+func testRegistrations() []*ethpb.SignedValidatorRegistrationV1 {
+	regs := make([]*ethpb.SignedValidatorRegistrationV1, 2)
+	for i := range regs {
+		regs[i] = &ethpb.SignedValidatorRegistrationV1{
+			Message: &ethpb.ValidatorRegistrationV1{
+				FeeRecipient: bytesutil.PadTo([]byte{byte(i)}, fieldparams.FeeRecipientLength),
+				GasLimit:     30_000_000,
+				Timestamp:    uint64(i),
+				Pubkey:       bytesutil.PadTo([]byte{byte(i)}, fieldparams.BLSPubkeyLength),
+			},
+			Signature: make([]byte, fieldparams.BLSSignatureLength),
+		}
+	}
+	return regs
+}
+
+func testBlindedBlock(t *testing.T, r *Relay) interfaces.ReadOnlySignedBeaconBlock {
+	b := util.NewBlindedBeaconBlockDeneb()
+	b.Message.Body.ExecutionPayloadHeader = r.Bid.(*ethpb.SignedBuilderBidDeneb).Message.Header
+	sb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	return sb
+}
+
+func TestRelay_SSZ(t *testing.T) {
+	ctx := context.Background()
+	r := testRelay(t, false)
+	c, err := builder.NewClient(r.URL())
+	require.NoError(t, err)
+
+	bid, err := c.GetHeader(ctx, 1, [32]byte{}, [48]byte{})
+	require.NoError(t, err)
+	msg, err := bid.Message()
+	require.NoError(t, err)
+	header, err := msg.Header()
+	require.NoError(t, err)
+	assert.DeepEqual(t, r.Payload.BlockHash(), header.BlockHash())
+	commitments, err := msg.BlobKzgCommitments()
+	require.NoError(t, err)
+	assert.DeepEqual(t, r.BlobsBundle.KzgCommitments, commitments)
+
+	regs := testRegistrations()
+	require.NoError(t, c.RegisterValidator(ctx, regs))
+	assert.DeepEqual(t, regs, r.Registrations())
+
+	payload, bundle, err := c.SubmitBlindedBlock(ctx, testBlindedBlock(t, r))
+	require.NoError(t, err)
+	assert.DeepEqual(t, r.Payload.Proto(), payload.Proto())
+	assert.DeepEqual(t, r.BlobsBundle, bundle)
+
+	requests := r.Requests()
+	require.Equal(t, 3, len(requests))
+	assert.Equal(t, false, requests[0].SSZ())
+	assert.Equal(t, true, requests[1].SSZ())
+	assert.Equal(t, true, requests[2].SSZ())
+	for _, req := range requests {
+		assert.StringContains(t, api.OctetStreamMediaType, req.Accept+req.ContentType)
+	}
+}
+
+func TestRelay_JSONFallback(t *testing.T) {
+	ctx := context.Background()
+	r := testRelay(t, true)
+	c, err := builder.NewClient(r.URL())
+	require.NoError(t, err)
+
+	// Each endpoint is first called with SSZ, and called again with JSON once the relay rejected SSZ.
+	for i := 0; i < 2; i++ {
+		bid, err := c.GetHeader(ctx, 1, [32]byte{}, [48]byte{})
+		require.NoError(t, err)
+		msg, err := bid.Message()
+		require.NoError(t, err)
+		header, err := msg.Header()
+		require.NoError(t, err)
+		assert.DeepEqual(t, r.Payload.BlockHash(), header.BlockHash())
+
+		require.NoError(t, c.RegisterValidator(ctx, testRegistrations()))
+
+		payload, bundle, err := c.SubmitBlindedBlock(ctx, testBlindedBlock(t, r))
+		require.NoError(t, err)
+		assert.DeepEqual(t, r.Payload.Proto(), payload.Proto())
+		assert.DeepEqual(t, r.BlobsBundle, bundle)
+	}
+	assert.DeepEqual(t, append(testRegistrations(), testRegistrations()...), r.Registrations())
+
+	// The choice of JSON is remembered, so that SSZ is only tried once per endpoint.
+	requests := r.Requests()
+	require.Equal(t, 9, len(requests))
+	var ssz int
+	for _, req := range requests {
+		if req.SSZ() || strings.Contains(req.Accept, api.OctetStreamMediaType) {
+			ssz++
+		}
+	}
+	assert.Equal(t, 3, ssz)
+}
@@ -87,6 +87,24 @@ func (c *Client) Get(ctx context.Context, path string, opts ...ReqOption) ([]byt
 	for _, o := range opts {
 		o(req)
 	}
+	return c.do(req)
+}
+
+// Post is a generic, opinionated POST function sending a JSON body, the counterpart of Get.
+func (c *Client) Post(ctx context.Context, path string, body io.Reader, opts ...ReqOption) ([]byte, error) {
+	u := c.baseURL.ResolveReference(&url.URL{Path: path})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for _, o := range opts {
+		o(req)
+	}
+	return c.do(req)
+}
+
+func (c *Client) do(req *http.Request) ([]byte, error) {
 	r, err := c.hc.Do(req)
 	if err != nil {
 		return nil, err
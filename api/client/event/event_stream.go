@@ -28,10 +28,15 @@ const (
 	EventLightClientOptimisticUpdate = "light_client_optimistic_update"
 	EventPayloadAttributes           = "payload_attributes"
 	EventBlobSidecar                 = "blob_sidecar"
+	EventBlockGossip                 = "block_gossip"
+	EventSingleAttestation           = "single_attestation"
 	EventError                       = "error"
 	EventConnectionError             = "connection_error"
 )
 
+// lastEventIDHeader is the header holding the id of the last event received by a reconnecting client.
+const lastEventIDHeader = "Last-Event-ID"
+
 var (
 	_ = EventStreamClient(&EventStream{})
 )
@@ -54,6 +59,9 @@ type EventStream struct {
 	httpClient *http.Client
 	host       string
 	topics     []string
+	// lastEventID is the id of the last event received, sent back to the beacon node when subscribing again
+	// so that the events missed while disconnected are replayed.
+	lastEventID string
 }
 
 func NewEventStream(ctx context.Context, httpClient *http.Client, host string, topics []string) (*EventStream, error) {
@@ -87,6 +95,9 @@ func (h *EventStream) Subscribe(eventsChannel chan<- *Event) {
 	}
 	req.Header.Set("Accept", api.EventStreamMediaType)
 	req.Header.Set("Connection", api.KeepAlive)
+	if h.lastEventID != "" {
+		req.Header.Set(lastEventIDHeader, h.lastEventID)
+	}
 	resp, err := h.httpClient.Do(req)
 	if err != nil {
 		eventsChannel <- &Event{
@@ -106,7 +117,7 @@ func (h *EventStream) Subscribe(eventsChannel chan<- *Event) {
 	// Set the split function for the scanning operation
 	scanner.Split(scanLinesWithCarriage)
 
-	var eventType, data string // Variables to store event type and data
+	var eventType, data, id string // Variables to store event type, data and id
 
 	// Iterate over lines of the event stream
 	for scanner.Scan() {
@@ -123,12 +134,19 @@ func (h *EventStream) Subscribe(eventsChannel chan<- *Event) {
 				if eventType != "" && data != "" {
 					// Process the event when both eventType and data are set
 					eventsChannel <- &Event{EventType: eventType, Data: []byte(data)}
+					if id != "" {
+						h.lastEventID = id
+					}
 				}
 
-				// Reset eventType and data for the next event
-				eventType, data = "", ""
+				// Reset eventType, data and id for the next event
+				eventType, data, id = "", "", ""
 				continue
 			}
+			if i, ok := strings.CutPrefix(line, "id: "); ok {
+				// Extract event id from the "id" field
+				id = i
+			}
 			et, ok := strings.CutPrefix(line, "event: ")
 			if ok {
 				// Extract event type from the "event" field
@@ -99,3 +99,25 @@ func TestEventStreamRequestError(t *testing.T) {
 	}
 
 }
+
+func TestEventStream_LastEventID(t *testing.T) {
+	var lastIDs []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/events", func(w http.ResponseWriter, r *http.Request) {
+		lastIDs = append(lastIDs, r.Header.Get(lastEventIDHeader))
+		_, err := fmt.Fprintf(w, "id: %d\nevent: head\ndata: data%d\n\n", len(lastIDs)+6, len(lastIDs))
+		require.NoError(t, err)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	eventsChannel := make(chan *Event, 1)
+	stream, err := NewEventStream(context.Background(), http.DefaultClient, server.URL, []string{"head"})
+	require.NoError(t, err)
+	// Each subscription ends when the server closes the stream, as on a disconnection.
+	stream.Subscribe(eventsChannel)
+	require.Equal(t, "data1", string((<-eventsChannel).Data))
+	stream.Subscribe(eventsChannel)
+	require.Equal(t, "data2", string((<-eventsChannel).Data))
+	require.DeepEqual(t, []string{"", "7"}, lastIDs)
+}
@@ -10,6 +10,7 @@ const (
 	JsonMediaType                 = "application/json"
 	OctetStreamMediaType          = "application/octet-stream"
 	EventStreamMediaType          = "text/event-stream"
+	NDJsonMediaType               = "application/x-ndjson"
 	KeepAlive                     = "keep-alive"
 )
 
@@ -441,6 +441,41 @@ func AttElectraFromConsensus(a *eth.AttestationElectra) *AttestationElectra {
 	}
 }
 
+func (a *SingleAttestation) ToConsensus() (*eth.SingleAttestation, error) {
+	ci, err := strconv.ParseUint(a.CommitteeIndex, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "CommitteeIndex")
+	}
+	ai, err := strconv.ParseUint(a.AttesterIndex, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "AttesterIndex")
+	}
+	data, err := a.Data.ToConsensus()
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Data")
+	}
+	sig, err := bytesutil.DecodeHexWithLength(a.Signature, fieldparams.BLSSignatureLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Signature")
+	}
+
+	return &eth.SingleAttestation{
+		CommitteeId:   primitives.CommitteeIndex(ci),
+		AttesterIndex: primitives.ValidatorIndex(ai),
+		Data:          data,
+		Signature:     sig,
+	}, nil
+}
+
+func SingleAttFromConsensus(a *eth.SingleAttestation) *SingleAttestation {
+	return &SingleAttestation{
+		CommitteeIndex: fmt.Sprintf("%d", a.CommitteeId),
+		AttesterIndex:  fmt.Sprintf("%d", a.AttesterIndex),
+		Data:           AttDataFromConsensus(a.Data),
+		Signature:      hexutil.Encode(a.Signature),
+	}
+}
+
 func (a *AttestationData) ToConsensus() (*eth.AttestationData, error) {
 	slot, err := strconv.ParseUint(a.Slot, 10, 64)
 	if err != nil {
@@ -732,6 +767,18 @@ func (a *IndexedAttestation) ToConsensus() (*eth.IndexedAttestation, error) {
 	}, nil
 }
 
+func IndexedAttFromConsensus(a eth.IndexedAtt) *IndexedAttestation {
+	indices := make([]string, len(a.GetAttestingIndices()))
+	for i, ix := range a.GetAttestingIndices() {
+		indices[i] = fmt.Sprintf("%d", ix)
+	}
+	return &IndexedAttestation{
+		AttestingIndices: indices,
+		Data:             AttDataFromConsensus(a.GetData()),
+		Signature:        hexutil.Encode(a.GetSignature()),
+	}
+}
+
 func (a *IndexedAttestationElectra) ToConsensus() (*eth.IndexedAttestationElectra, error) {
 	indices := make([]uint64, len(a.AttestingIndices))
 	var err error
@@ -1553,3 +1600,21 @@ func SyncAggregateFromConsensus(sa *eth.SyncAggregate) *SyncAggregate {
 		SyncCommitteeSignature: hexutil.Encode(sa.SyncCommitteeSignature),
 	}
 }
+
+func (sa *SyncAggregate) ToConsensus() (*eth.SyncAggregate, error) {
+	if sa == nil {
+		return nil, errNilValue
+	}
+	bits, err := bytesutil.DecodeHexWithLength(sa.SyncCommitteeBits, fieldparams.SyncAggregateSyncCommitteeBytesLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "SyncCommitteeBits")
+	}
+	sig, err := bytesutil.DecodeHexWithLength(sa.SyncCommitteeSignature, fieldparams.BLSSignatureLength)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "SyncCommitteeSignature")
+	}
+	return &eth.SyncAggregate{
+		SyncCommitteeBits:      bits,
+		SyncCommitteeSignature: sig,
+	}, nil
+}
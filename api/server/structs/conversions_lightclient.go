@@ -3,12 +3,20 @@ package structs
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	lightclient "github.com/prysmaticlabs/prysm/v5/consensus-types/light-client"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"google.golang.org/protobuf/proto"
 )
 
 func LightClientUpdateFromConsensus(update interfaces.LightClientUpdate) (*LightClientUpdate, error) {
@@ -227,3 +235,339 @@ func LightClientBootstrapFromConsensus(bootstrap interfaces.LightClientBootstrap
 		CurrentSyncCommitteeBranch: branchToJSON(scBranch),
 	}, nil
 }
+
+func (h *LightClientHeader) ToConsensus() (*eth.LightClientHeaderAltair, error) {
+	if h == nil {
+		return nil, errNilValue
+	}
+	beacon, err := h.Beacon.ToConsensus()
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Beacon")
+	}
+	return &eth.LightClientHeaderAltair{Beacon: beacon}, nil
+}
+
+func (h *LightClientHeaderCapella) ToConsensus() (*eth.LightClientHeaderCapella, error) {
+	if h == nil {
+		return nil, errNilValue
+	}
+	beacon, err := h.Beacon.ToConsensus()
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Beacon")
+	}
+	execution, err := h.Execution.ToConsensus()
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Execution")
+	}
+	branch, err := branchFromJSON(h.ExecutionBranch)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "ExecutionBranch")
+	}
+	return &eth.LightClientHeaderCapella{
+		Beacon:          beacon,
+		Execution:       execution,
+		ExecutionBranch: branch,
+	}, nil
+}
+
+func (h *LightClientHeaderDeneb) ToConsensus() (*eth.LightClientHeaderDeneb, error) {
+	if h == nil {
+		return nil, errNilValue
+	}
+	beacon, err := h.Beacon.ToConsensus()
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Beacon")
+	}
+	execution, err := h.Execution.ToConsensus()
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Execution")
+	}
+	branch, err := branchFromJSON(h.ExecutionBranch)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "ExecutionBranch")
+	}
+	return &eth.LightClientHeaderDeneb{
+		Beacon:          beacon,
+		Execution:       execution,
+		ExecutionBranch: branch,
+	}, nil
+}
+
+// ToConsensus converts the bootstrap to its consensus type, using the response version to decode the header.
+func (r *LightClientBootstrapResponse) ToConsensus() (interfaces.LightClientBootstrap, error) {
+	if r == nil || r.Data == nil {
+		return nil, errNilValue
+	}
+	v, err := version.FromString(r.Version)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Version")
+	}
+	header, err := lightClientHeaderFromJSON(r.Data.Header, v)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Header")
+	}
+	if r.Data.CurrentSyncCommittee == nil {
+		return nil, server.NewDecodeError(errNilValue, "CurrentSyncCommittee")
+	}
+	sc, err := r.Data.CurrentSyncCommittee.ToConsensus()
+	if err != nil {
+		return nil, server.NewDecodeError(err, "CurrentSyncCommittee")
+	}
+	branch, err := branchFromJSON(r.Data.CurrentSyncCommitteeBranch)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "CurrentSyncCommitteeBranch")
+	}
+
+	var m proto.Message
+	switch v {
+	case version.Altair:
+		m = &eth.LightClientBootstrapAltair{Header: header.(*eth.LightClientHeaderAltair), CurrentSyncCommittee: sc, CurrentSyncCommitteeBranch: branch}
+	case version.Capella:
+		m = &eth.LightClientBootstrapCapella{Header: header.(*eth.LightClientHeaderCapella), CurrentSyncCommittee: sc, CurrentSyncCommitteeBranch: branch}
+	case version.Deneb:
+		m = &eth.LightClientBootstrapDeneb{Header: header.(*eth.LightClientHeaderDeneb), CurrentSyncCommittee: sc, CurrentSyncCommitteeBranch: branch}
+	default:
+		m = &eth.LightClientBootstrapElectra{Header: header.(*eth.LightClientHeaderDeneb), CurrentSyncCommittee: sc, CurrentSyncCommitteeBranch: branch}
+	}
+	return lightclient.NewWrappedBootstrap(m)
+}
+
+// ToConsensus converts the update to its consensus type, using the response version to decode the headers.
+func (r *LightClientUpdateResponse) ToConsensus() (interfaces.LightClientUpdate, error) {
+	if r == nil || r.Data == nil {
+		return nil, errNilValue
+	}
+	v, err := version.FromString(r.Version)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Version")
+	}
+	attestedHeader, err := lightClientHeaderFromJSON(r.Data.AttestedHeader, v)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "AttestedHeader")
+	}
+	finalizedHeader, err := lightClientHeaderFromJSON(r.Data.FinalizedHeader, v)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "FinalizedHeader")
+	}
+	if r.Data.NextSyncCommittee == nil {
+		return nil, server.NewDecodeError(errNilValue, "NextSyncCommittee")
+	}
+	nextSyncCommittee, err := r.Data.NextSyncCommittee.ToConsensus()
+	if err != nil {
+		return nil, server.NewDecodeError(err, "NextSyncCommittee")
+	}
+	nextSyncCommitteeBranch, err := branchFromJSON(r.Data.NextSyncCommitteeBranch)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "NextSyncCommitteeBranch")
+	}
+	finalityBranch, err := branchFromJSON(r.Data.FinalityBranch)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "FinalityBranch")
+	}
+	syncAggregate, err := r.Data.SyncAggregate.ToConsensus()
+	if err != nil {
+		return nil, server.NewDecodeError(err, "SyncAggregate")
+	}
+	signatureSlot, err := strconv.ParseUint(r.Data.SignatureSlot, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "SignatureSlot")
+	}
+
+	var m proto.Message
+	switch v {
+	case version.Altair:
+		m = &eth.LightClientUpdateAltair{
+			AttestedHeader:          attestedHeader.(*eth.LightClientHeaderAltair),
+			NextSyncCommittee:       nextSyncCommittee,
+			NextSyncCommitteeBranch: nextSyncCommitteeBranch,
+			FinalizedHeader:         finalizedHeader.(*eth.LightClientHeaderAltair),
+			FinalityBranch:          finalityBranch,
+			SyncAggregate:           syncAggregate,
+			SignatureSlot:           primitives.Slot(signatureSlot),
+		}
+	case version.Capella:
+		m = &eth.LightClientUpdateCapella{
+			AttestedHeader:          attestedHeader.(*eth.LightClientHeaderCapella),
+			NextSyncCommittee:       nextSyncCommittee,
+			NextSyncCommitteeBranch: nextSyncCommitteeBranch,
+			FinalizedHeader:         finalizedHeader.(*eth.LightClientHeaderCapella),
+			FinalityBranch:          finalityBranch,
+			SyncAggregate:           syncAggregate,
+			SignatureSlot:           primitives.Slot(signatureSlot),
+		}
+	case version.Deneb:
+		m = &eth.LightClientUpdateDeneb{
+			AttestedHeader:          attestedHeader.(*eth.LightClientHeaderDeneb),
+			NextSyncCommittee:       nextSyncCommittee,
+			NextSyncCommitteeBranch: nextSyncCommitteeBranch,
+			FinalizedHeader:         finalizedHeader.(*eth.LightClientHeaderDeneb),
+			FinalityBranch:          finalityBranch,
+			SyncAggregate:           syncAggregate,
+			SignatureSlot:           primitives.Slot(signatureSlot),
+		}
+	default:
+		m = &eth.LightClientUpdateElectra{
+			AttestedHeader:          attestedHeader.(*eth.LightClientHeaderDeneb),
+			NextSyncCommittee:       nextSyncCommittee,
+			NextSyncCommitteeBranch: nextSyncCommitteeBranch,
+			FinalizedHeader:         finalizedHeader.(*eth.LightClientHeaderDeneb),
+			FinalityBranch:          finalityBranch,
+			SyncAggregate:           syncAggregate,
+			SignatureSlot:           primitives.Slot(signatureSlot),
+		}
+	}
+	return lightclient.NewWrappedUpdate(m)
+}
+
+// ToConsensus converts the finality update to its consensus type, using the response version to decode the headers.
+func (r *LightClientFinalityUpdateResponse) ToConsensus() (interfaces.LightClientFinalityUpdate, error) {
+	if r == nil || r.Data == nil {
+		return nil, errNilValue
+	}
+	v, err := version.FromString(r.Version)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Version")
+	}
+	attestedHeader, err := lightClientHeaderFromJSON(r.Data.AttestedHeader, v)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "AttestedHeader")
+	}
+	finalizedHeader, err := lightClientHeaderFromJSON(r.Data.FinalizedHeader, v)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "FinalizedHeader")
+	}
+	finalityBranch, err := branchFromJSON(r.Data.FinalityBranch)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "FinalityBranch")
+	}
+	syncAggregate, err := r.Data.SyncAggregate.ToConsensus()
+	if err != nil {
+		return nil, server.NewDecodeError(err, "SyncAggregate")
+	}
+	signatureSlot, err := strconv.ParseUint(r.Data.SignatureSlot, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "SignatureSlot")
+	}
+
+	var m proto.Message
+	switch v {
+	case version.Altair:
+		m = &eth.LightClientFinalityUpdateAltair{
+			AttestedHeader:  attestedHeader.(*eth.LightClientHeaderAltair),
+			FinalizedHeader: finalizedHeader.(*eth.LightClientHeaderAltair),
+			FinalityBranch:  finalityBranch,
+			SyncAggregate:   syncAggregate,
+			SignatureSlot:   primitives.Slot(signatureSlot),
+		}
+	case version.Capella:
+		m = &eth.LightClientFinalityUpdateCapella{
+			AttestedHeader:  attestedHeader.(*eth.LightClientHeaderCapella),
+			FinalizedHeader: finalizedHeader.(*eth.LightClientHeaderCapella),
+			FinalityBranch:  finalityBranch,
+			SyncAggregate:   syncAggregate,
+			SignatureSlot:   primitives.Slot(signatureSlot),
+		}
+	case version.Deneb:
+		m = &eth.LightClientFinalityUpdateDeneb{
+			AttestedHeader:  attestedHeader.(*eth.LightClientHeaderDeneb),
+			FinalizedHeader: finalizedHeader.(*eth.LightClientHeaderDeneb),
+			FinalityBranch:  finalityBranch,
+			SyncAggregate:   syncAggregate,
+			SignatureSlot:   primitives.Slot(signatureSlot),
+		}
+	default:
+		m = &eth.LightClientFinalityUpdateElectra{
+			AttestedHeader:  attestedHeader.(*eth.LightClientHeaderDeneb),
+			FinalizedHeader: finalizedHeader.(*eth.LightClientHeaderDeneb),
+			FinalityBranch:  finalityBranch,
+			SyncAggregate:   syncAggregate,
+			SignatureSlot:   primitives.Slot(signatureSlot),
+		}
+	}
+	return lightclient.NewWrappedFinalityUpdate(m)
+}
+
+// ToConsensus converts the optimistic update to its consensus type, using the response version to decode the header.
+func (r *LightClientOptimisticUpdateResponse) ToConsensus() (interfaces.LightClientOptimisticUpdate, error) {
+	if r == nil || r.Data == nil {
+		return nil, errNilValue
+	}
+	v, err := version.FromString(r.Version)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "Version")
+	}
+	attestedHeader, err := lightClientHeaderFromJSON(r.Data.AttestedHeader, v)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "AttestedHeader")
+	}
+	syncAggregate, err := r.Data.SyncAggregate.ToConsensus()
+	if err != nil {
+		return nil, server.NewDecodeError(err, "SyncAggregate")
+	}
+	signatureSlot, err := strconv.ParseUint(r.Data.SignatureSlot, 10, 64)
+	if err != nil {
+		return nil, server.NewDecodeError(err, "SignatureSlot")
+	}
+
+	var m proto.Message
+	switch v {
+	case version.Altair:
+		m = &eth.LightClientOptimisticUpdateAltair{
+			AttestedHeader: attestedHeader.(*eth.LightClientHeaderAltair),
+			SyncAggregate:  syncAggregate,
+			SignatureSlot:  primitives.Slot(signatureSlot),
+		}
+	case version.Capella:
+		m = &eth.LightClientOptimisticUpdateCapella{
+			AttestedHeader: attestedHeader.(*eth.LightClientHeaderCapella),
+			SyncAggregate:  syncAggregate,
+			SignatureSlot:  primitives.Slot(signatureSlot),
+		}
+	default:
+		m = &eth.LightClientOptimisticUpdateDeneb{
+			AttestedHeader: attestedHeader.(*eth.LightClientHeaderDeneb),
+			SyncAggregate:  syncAggregate,
+			SignatureSlot:  primitives.Slot(signatureSlot),
+		}
+	}
+	return lightclient.NewWrappedOptimisticUpdate(m)
+}
+
+// lightClientHeaderFromJSON decodes a header of the given version into its proto type:
+// LightClientHeaderAltair, LightClientHeaderCapella, or LightClientHeaderDeneb from Deneb onwards.
+func lightClientHeaderFromJSON(raw json.RawMessage, v int) (proto.Message, error) {
+	switch v {
+	case version.Altair:
+		h := &LightClientHeader{}
+		if err := json.Unmarshal(raw, h); err != nil {
+			return nil, err
+		}
+		return h.ToConsensus()
+	case version.Capella:
+		h := &LightClientHeaderCapella{}
+		if err := json.Unmarshal(raw, h); err != nil {
+			return nil, err
+		}
+		return h.ToConsensus()
+	case version.Deneb, version.Electra:
+		h := &LightClientHeaderDeneb{}
+		if err := json.Unmarshal(raw, h); err != nil {
+			return nil, err
+		}
+		return h.ToConsensus()
+	default:
+		return nil, fmt.Errorf("unsupported header version %s", version.String(v))
+	}
+}
+
+func branchFromJSON(branch []string) ([][]byte, error) {
+	result := make([][]byte, len(branch))
+	for i, root := range branch {
+		b, err := bytesutil.DecodeHexWithLength(root, fieldparams.RootLength)
+		if err != nil {
+			return nil, server.NewDecodeError(err, fmt.Sprintf("[%d]", i))
+		}
+		result[i] = b
+	}
+	return result, nil
+}
@@ -7,6 +7,12 @@ type SidecarsResponse struct {
 	Finalized           bool       `json:"finalized"`
 }
 
+type GetBlobsResponse struct {
+	Data                []string `json:"data"`
+	ExecutionOptimistic bool     `json:"execution_optimistic"`
+	Finalized           bool     `json:"finalized"`
+}
+
 type Sidecar struct {
 	Index                    string                   `json:"index"`
 	Blob                     string                   `json:"blob"`
@@ -83,6 +83,11 @@ type PayloadAttributesV3 struct {
 	ParentBeaconBlockRoot string        `json:"parent_beacon_block_root"`
 }
 
+type BlockGossipEvent struct {
+	Slot  string `json:"slot"`
+	Block string `json:"block"`
+}
+
 type BlobSidecarEvent struct {
 	BlockRoot     string `json:"block_root"`
 	Index         string `json:"index"`
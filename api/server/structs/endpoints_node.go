@@ -10,6 +10,14 @@ type SyncStatusResponseData struct {
 	IsSyncing    bool   `json:"is_syncing"`
 	IsOptimistic bool   `json:"is_optimistic"`
 	ElOffline    bool   `json:"el_offline"`
+	// BlobBackfill is a Prysm-specific extension describing the progress of blob backfill, when it has run.
+	BlobBackfill *BlobBackfillStatus `json:"blob_backfill,omitempty"`
+}
+
+type BlobBackfillStatus struct {
+	LowSlot   string `json:"low_slot"`
+	HighSlot  string `json:"high_slot"`
+	IsSyncing bool   `json:"is_syncing"`
 }
 
 type GetIdentityResponse struct {
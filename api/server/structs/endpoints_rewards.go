@@ -52,3 +52,21 @@ type SyncCommitteeReward struct {
 	ValidatorIndex string `json:"validator_index"`
 	Reward         string `json:"reward"`
 }
+
+type RewardHistoryEpoch struct {
+	Epoch               string                  `json:"epoch"`
+	ExecutionOptimistic bool                    `json:"execution_optimistic"`
+	Finalized           bool                    `json:"finalized"`
+	Data                []ValidatorEpochRewards `json:"data"`
+}
+
+type ValidatorEpochRewards struct {
+	ValidatorIndex string `json:"validator_index"`
+	Head           string `json:"head"`
+	Target         string `json:"target"`
+	Source         string `json:"source"`
+	Inactivity     string `json:"inactivity"`
+	SyncCommittee  string `json:"sync_committee"`
+	Proposals      string `json:"proposals"`
+	Total          string `json:"total"`
+}
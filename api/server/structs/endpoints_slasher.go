@@ -0,0 +1,36 @@
+package structs
+
+import "encoding/json"
+
+type GetSlasherSlashingsResponse struct {
+	AttesterSlashings []*SlasherAttesterSlashing `json:"attester_slashings"`
+	ProposerSlashings []*ProposerSlashing        `json:"proposer_slashings"`
+}
+
+type SlasherAttesterSlashing struct {
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"` // Accepts both `*AttesterSlashing` and `*AttesterSlashingElectra` types
+}
+
+type GetSlasherAttestationsResponse struct {
+	Data []*SlasherAttestation `json:"data"`
+}
+
+type SlasherAttestation struct {
+	DataRoot    string              `json:"data_root"`
+	Attestation *IndexedAttestation `json:"attestation"`
+}
+
+type GetSlasherProposalsResponse struct {
+	Data []*SlasherProposal `json:"data"`
+}
+
+type SlasherProposal struct {
+	HeaderRoot string                   `json:"header_root"`
+	Header     *SignedBeaconBlockHeader `json:"header"`
+}
+
+type CheckSlashableAttestationResponse struct {
+	Slashable         bool                       `json:"slashable"`
+	AttesterSlashings []*SlasherAttesterSlashing `json:"attester_slashings"`
+}
@@ -30,6 +30,10 @@ var (
 	ErrNotCheckpoint = errors.New("not a checkpoint in forkchoice")
 	// ErrNilHead is returned when no head is present in the blockchain service.
 	ErrNilHead = errors.New("nil head")
+	// errStaleForkchoiceSnapshot is returned when a saved forkchoice snapshot does not match the db.
+	errStaleForkchoiceSnapshot = errors.New("forkchoice snapshot is stale")
+	// errMissingDataColumnStorage is returned when data columns are handled without a configured data column storage.
+	errMissingDataColumnStorage = errors.New("data column storage is not configured")
 )
 
 var errMaxBlobsExceeded = errors.New("Expected commitments in block exceeds MAX_BLOBS_PER_BLOCK")
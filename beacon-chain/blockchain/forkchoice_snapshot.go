@@ -0,0 +1,129 @@
+package blockchain
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// saveForkchoiceSnapshot serializes the forkchoice store and saves it to the db, so that
+// a restart does not need to rebuild forkchoice from the finalized checkpoint.
+func (s *Service) saveForkchoiceSnapshot(ctx context.Context) error {
+	start := time.Now()
+	s.cfg.ForkChoiceStore.RLock()
+	nodes := s.cfg.ForkChoiceStore.NodeCount()
+	if nodes == 0 {
+		// Nothing to save before forkchoice has been initialized.
+		s.cfg.ForkChoiceStore.RUnlock()
+		return nil
+	}
+	enc, err := s.cfg.ForkChoiceStore.Snapshot()
+	s.cfg.ForkChoiceStore.RUnlock()
+	if err != nil {
+		return errors.Wrap(err, "could not serialize forkchoice")
+	}
+	if err := s.cfg.BeaconDB.SaveForkchoiceSnapshot(ctx, enc); err != nil {
+		return errors.Wrap(err, "could not save forkchoice snapshot")
+	}
+	log.WithFields(logrus.Fields{
+		"nodes":    nodes,
+		"size":     len(enc),
+		"duration": time.Since(start),
+	}).Debug("Saved forkchoice snapshot")
+	return nil
+}
+
+// runForkchoiceSnapshots saves a forkchoice snapshot at the start of every epoch.
+func (s *Service) runForkchoiceSnapshots() {
+	ticker := slots.NewSlotTicker(s.genesisTime, params.BeaconConfig().SecondsPerSlot)
+	defer ticker.Done()
+	for {
+		select {
+		case slot := <-ticker.C():
+			if !slots.IsEpochStart(slot) {
+				continue
+			}
+			if err := s.saveForkchoiceSnapshot(s.ctx); err != nil {
+				log.WithError(err).Error("Could not save forkchoice snapshot")
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// restoreForkchoiceSnapshot attempts to initialize forkchoice from the snapshot saved in the db.
+// The snapshot is only used if it is consistent with the db: it must have the same finalized checkpoint,
+// contain the db head block, and every node in it must have its block in the db. It returns false, leaving
+// forkchoice untouched, when there is no usable snapshot so the caller can fall back to rebuilding
+// forkchoice from the finalized checkpoint. The caller must hold the forkchoice lock.
+func (s *Service) restoreForkchoiceSnapshot(ctx context.Context, finalized *ethpb.Checkpoint) bool {
+	enc, err := s.cfg.BeaconDB.ForkchoiceSnapshot(ctx)
+	if err != nil {
+		if !errors.Is(err, db.ErrNotFound) {
+			log.WithError(err).Warn("Could not read forkchoice snapshot")
+		}
+		return false
+	}
+	if err := s.validateForkchoiceSnapshot(ctx, enc, finalized); err != nil {
+		log.WithError(err).Info("Not using forkchoice snapshot, rebuilding forkchoice from the finalized checkpoint")
+		return false
+	}
+	if err := s.cfg.ForkChoiceStore.RestoreSnapshot(ctx, enc); err != nil {
+		log.WithError(err).Warn("Could not restore forkchoice snapshot")
+		return false
+	}
+	log.WithFields(logrus.Fields{
+		"nodes":          s.cfg.ForkChoiceStore.NodeCount(),
+		"finalizedEpoch": finalized.Epoch,
+	}).Info("Restored forkchoice from snapshot")
+	return true
+}
+
+// validateForkchoiceSnapshot decodes the snapshot into a scratch forkchoice store and checks it against the db.
+func (s *Service) validateForkchoiceSnapshot(ctx context.Context, enc []byte, finalized *ethpb.Checkpoint) error {
+	fc := doublylinkedtree.New()
+	if err := fc.RestoreSnapshot(ctx, enc); err != nil {
+		return err
+	}
+	fcp := fc.FinalizedCheckpoint()
+	if fcp.Epoch != finalized.Epoch || fcp.Root != bytesutil.ToBytes32(finalized.Root) {
+		return errors.Wrapf(errStaleForkchoiceSnapshot, "snapshot finalized epoch %d, db finalized epoch %d", fcp.Epoch, finalized.Epoch)
+	}
+	if !fc.HasNode(s.ensureRootNotZeros(fcp.Root)) {
+		return errors.Wrap(errStaleForkchoiceSnapshot, "finalized block is missing from snapshot")
+	}
+	headBlock, err := s.cfg.BeaconDB.HeadBlock(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head block")
+	}
+	if headBlock == nil || headBlock.IsNil() {
+		return errors.Wrap(errStaleForkchoiceSnapshot, "no head block in the db")
+	}
+	headRoot, err := headBlock.Block().HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute head block root")
+	}
+	if !fc.HasNode(headRoot) {
+		return errors.Wrapf(errStaleForkchoiceSnapshot, "db head %#x is missing from snapshot", headRoot)
+	}
+	dump, err := fc.ForkChoiceDump(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range dump.ForkChoiceNodes {
+		root := bytesutil.ToBytes32(n.BlockRoot)
+		if !s.cfg.BeaconDB.HasBlock(ctx, root) {
+			return errors.Wrapf(errStaleForkchoiceSnapshot, "block %#x is missing from the db", root)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package blockchain
+
+import (
+	"testing"
+
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestService_ForkchoiceSnapshotRestart(t *testing.T) {
+	genesis := util.NewBeaconBlock()
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	finalizedSlot := params.BeaconConfig().SlotsPerEpoch*2 + 1
+	finalizedBlock := util.NewBeaconBlock()
+	finalizedBlock.Block.Slot = finalizedSlot
+	finalizedBlock.Block.ParentRoot = bytesutil.PadTo(genesisRoot[:], 32)
+	finalizedRoot, err := finalizedBlock.Block.HashTreeRoot()
+	require.NoError(t, err)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(finalizedSlot))
+	require.NoError(t, st.SetGenesisValidatorsRoot(params.BeaconConfig().ZeroHash[:]))
+
+	c, tr := minimalTestService(t, WithFinalizedStateAtStartUp(st))
+	ctx, beaconDB := tr.ctx, tr.db
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	util.SaveBlock(t, ctx, beaconDB, finalizedBlock)
+	require.NoError(t, beaconDB.SaveState(ctx, st, genesisRoot))
+	require.NoError(t, beaconDB.SaveState(ctx, st, finalizedRoot))
+	finalized := &ethpb.Checkpoint{Epoch: slots.ToEpoch(finalizedSlot), Root: finalizedRoot[:]}
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, finalized))
+	require.NoError(t, c.StartFromSavedState(st))
+
+	// Extend forkchoice past the finalized block and make the child the db head.
+	child := util.NewBeaconBlock()
+	child.Block.Slot = finalizedSlot + 1
+	child.Block.ParentRoot = finalizedRoot[:]
+	childRoot, err := child.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, beaconDB, child)
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: child.Block.Slot, Root: childRoot[:]}))
+	require.NoError(t, beaconDB.SaveHeadBlockRoot(ctx, childRoot))
+	wsb, err := blocks.NewSignedBeaconBlock(child)
+	require.NoError(t, err)
+	roblock, err := blocks.NewROBlockWithRoot(wsb, childRoot)
+	require.NoError(t, err)
+	childState := st.Copy()
+	require.NoError(t, childState.SetSlot(child.Block.Slot))
+	c.cfg.ForkChoiceStore.Lock()
+	require.NoError(t, c.cfg.ForkChoiceStore.InsertNode(ctx, childState, roblock))
+	c.cfg.ForkChoiceStore.Unlock()
+	require.NoError(t, c.saveForkchoiceSnapshot(ctx))
+
+	t.Run("restored", func(t *testing.T) {
+		fcs := doublylinkedtree.New()
+		restarted := &Service{cfg: &config{BeaconDB: beaconDB, ForkChoiceStore: fcs}}
+		require.Equal(t, true, restarted.restoreForkchoiceSnapshot(ctx, finalized))
+		require.Equal(t, 2, fcs.NodeCount())
+		require.Equal(t, true, fcs.HasNode(childRoot))
+	})
+	t.Run("finalized checkpoint mismatch", func(t *testing.T) {
+		fcs := doublylinkedtree.New()
+		restarted := &Service{cfg: &config{BeaconDB: beaconDB, ForkChoiceStore: fcs}}
+		require.Equal(t, false, restarted.restoreForkchoiceSnapshot(ctx, &ethpb.Checkpoint{Epoch: finalized.Epoch + 1, Root: finalized.Root}))
+		require.Equal(t, 0, fcs.NodeCount())
+	})
+	t.Run("block missing from db", func(t *testing.T) {
+		// A node whose block is missing from the db makes the snapshot inconsistent.
+		orphan := util.NewBeaconBlock()
+		orphan.Block.Slot = finalizedSlot + 2
+		orphan.Block.ParentRoot = childRoot[:]
+		orphanRoot, err := orphan.Block.HashTreeRoot()
+		require.NoError(t, err)
+		wsb, err := blocks.NewSignedBeaconBlock(orphan)
+		require.NoError(t, err)
+		roblock, err := blocks.NewROBlockWithRoot(wsb, orphanRoot)
+		require.NoError(t, err)
+		orphanState := st.Copy()
+		require.NoError(t, orphanState.SetSlot(orphan.Block.Slot))
+		c.cfg.ForkChoiceStore.Lock()
+		require.NoError(t, c.cfg.ForkChoiceStore.InsertNode(ctx, orphanState, roblock))
+		c.cfg.ForkChoiceStore.Unlock()
+		require.NoError(t, c.saveForkchoiceSnapshot(ctx))
+
+		fcs := doublylinkedtree.New()
+		restarted := &Service{cfg: &config{BeaconDB: beaconDB, ForkChoiceStore: fcs}}
+		require.Equal(t, false, restarted.restoreForkchoiceSnapshot(ctx, finalized))
+		require.Equal(t, 0, fcs.NodeCount())
+	})
+}
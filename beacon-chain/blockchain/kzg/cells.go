@@ -0,0 +1,354 @@
+package kzg
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"math/bits"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	// FieldElementsPerCell is the number of field elements in a cell, FIELD_ELEMENTS_PER_CELL.
+	FieldElementsPerCell = 64
+	// BytesPerCell is the size of a serialized cell, BYTES_PER_CELL.
+	BytesPerCell = FieldElementsPerCell * bytesPerFieldElement
+	// CellsPerExtBlob is the number of cells in an extended blob, CELLS_PER_EXT_BLOB.
+	CellsPerExtBlob = fieldElementsPerExtBlob / FieldElementsPerCell
+
+	bytesPerFieldElement    = 32
+	bytesPerG1Point         = 48
+	fieldElementsPerBlob    = 4096
+	fieldElementsPerExtBlob = 2 * fieldElementsPerBlob
+	primitiveRootOfUnity    = 7
+
+	// cellBatchChallengeDomain is RANDOM_CHALLENGE_KZG_CELL_BATCH_DOMAIN.
+	cellBatchChallengeDomain = "RCKZGCBATCH__V1_"
+)
+
+var (
+	// ErrInvalidCellProof is returned when the batch of cell KZG proofs does not verify.
+	ErrInvalidCellProof = errors.New("invalid cell KZG proof")
+	// ErrInvalidCellBatch is returned when the inputs of a cell KZG proof batch are malformed.
+	ErrInvalidCellBatch = errors.New("invalid cell KZG proof batch")
+
+	cellSetupOnce sync.Once
+	cellSetupErr  error
+	cellSetup     *cellVerificationSetup
+)
+
+// cellVerificationSetup holds the precomputed values needed to verify cell KZG proofs.
+type cellVerificationSetup struct {
+	// g1Monomial holds [tau^i]G1 for i < FIELD_ELEMENTS_PER_CELL.
+	g1Monomial []bls12381.G1Affine
+	// negG2Tau holds -[tau^FIELD_ELEMENTS_PER_CELL]G2.
+	negG2Tau bls12381.G2Affine
+	g2Gen    bls12381.G2Affine
+	// rootsBrp holds the FIELD_ELEMENTS_PER_EXT_BLOB roots of unity in bit-reversed order.
+	rootsBrp []fr.Element
+	// cellRoots holds the FIELD_ELEMENTS_PER_CELL roots of unity in natural order.
+	cellRoots []fr.Element
+}
+
+// VerifyCellKZGProofBatch verifies a batch of cells against their commitments and cell KZG proofs,
+// the i-th cell being at index cellIndices[i] of the extended blob committed to by commitments[i].
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/fulu/polynomial-commitments-sampling.md#verify_cell_kzg_proof_batch
+func VerifyCellKZGProofBatch(commitments [][]byte, cellIndices []uint64, cells [][]byte, proofs [][]byte) error {
+	if len(commitments) != len(cells) || len(cellIndices) != len(cells) || len(proofs) != len(cells) {
+		return errors.Wrap(ErrInvalidCellBatch, "mismatch in the number of commitments, cell indices, cells and proofs")
+	}
+	if len(cells) == 0 {
+		return nil
+	}
+	setup, err := loadCellSetup()
+	if err != nil {
+		return err
+	}
+
+	// Deduplicate the commitments, in order of first appearance.
+	uniqueCommitments := make([][]byte, 0, len(commitments))
+	commitmentPoints := make([]bls12381.G1Affine, 0, len(commitments))
+	commitmentIndices := make([]uint64, len(commitments))
+	seen := make(map[[bytesPerG1Point]byte]uint64, len(commitments))
+	for i, c := range commitments {
+		if len(c) != bytesPerG1Point {
+			return errors.Wrapf(ErrInvalidCellBatch, "commitment %d has length %d", i, len(c))
+		}
+		key := [bytesPerG1Point]byte(c)
+		idx, ok := seen[key]
+		if !ok {
+			var point bls12381.G1Affine
+			if _, err := point.SetBytes(c); err != nil {
+				return errors.Wrapf(ErrInvalidCellBatch, "could not decode commitment %d: %v", i, err)
+			}
+			idx = uint64(len(uniqueCommitments))
+			seen[key] = idx
+			uniqueCommitments = append(uniqueCommitments, c)
+			commitmentPoints = append(commitmentPoints, point)
+		}
+		commitmentIndices[i] = idx
+	}
+
+	proofPoints := make([]bls12381.G1Affine, len(proofs))
+	for i, p := range proofs {
+		if len(p) != bytesPerG1Point {
+			return errors.Wrapf(ErrInvalidCellBatch, "proof %d has length %d", i, len(p))
+		}
+		if _, err := proofPoints[i].SetBytes(p); err != nil {
+			return errors.Wrapf(ErrInvalidCellBatch, "could not decode proof %d: %v", i, err)
+		}
+	}
+
+	evals := make([][]fr.Element, len(cells))
+	for i, cell := range cells {
+		if cellIndices[i] >= CellsPerExtBlob {
+			return errors.Wrapf(ErrInvalidCellBatch, "cell index %d is not lower than %d", cellIndices[i], CellsPerExtBlob)
+		}
+		evals[i], err = cellToEvaluations(cell)
+		if err != nil {
+			return errors.Wrapf(ErrInvalidCellBatch, "could not decode cell %d: %v", i, err)
+		}
+	}
+
+	r := cellBatchChallenge(uniqueCommitments, commitmentIndices, cellIndices, cells, proofs)
+	rPowers := make([]fr.Element, len(cells))
+	rPowers[0].SetOne()
+	for i := 1; i < len(rPowers); i++ {
+		rPowers[i].Mul(&rPowers[i-1], &r)
+	}
+
+	config := ecc.MultiExpConfig{}
+	// Left-hand side of the pairing: the random linear combination of the proofs.
+	var proofLincomb bls12381.G1Affine
+	if _, err := proofLincomb.MultiExp(proofPoints, rPowers, config); err != nil {
+		return errors.Wrap(err, "could not compute proofs linear combination")
+	}
+
+	// Sum of the commitments weighted by the challenge powers of their cells.
+	weights := make([]fr.Element, len(commitmentPoints))
+	for i := range rPowers {
+		weights[commitmentIndices[i]].Add(&weights[commitmentIndices[i]], &rPowers[i])
+	}
+	var rl bls12381.G1Affine
+	if _, err := rl.MultiExp(commitmentPoints, weights, config); err != nil {
+		return errors.Wrap(err, "could not compute commitments linear combination")
+	}
+
+	// Commitment to the random linear combination of the interpolation polynomials of the cells.
+	// The evaluations of the cells sharing an index are combined before being interpolated over their coset.
+	combined := make(map[uint64][]fr.Element)
+	for i, cellEvals := range evals {
+		acc, ok := combined[cellIndices[i]]
+		if !ok {
+			acc = make([]fr.Element, FieldElementsPerCell)
+			combined[cellIndices[i]] = acc
+		}
+		var term fr.Element
+		for j := range cellEvals {
+			term.Mul(&cellEvals[j], &rPowers[i])
+			acc[j].Add(&acc[j], &term)
+		}
+	}
+	interpolation := make([]fr.Element, FieldElementsPerCell)
+	for cellIndex, acc := range combined {
+		coeffs := setup.interpolateCoset(cellIndex, acc)
+		for j := range coeffs {
+			interpolation[j].Add(&interpolation[j], &coeffs[j])
+		}
+	}
+	var interpolationCommitment bls12381.G1Affine
+	if _, err := interpolationCommitment.MultiExp(setup.g1Monomial, interpolation, config); err != nil {
+		return errors.Wrap(err, "could not commit to the interpolation polynomial")
+	}
+
+	// Proofs weighted by the challenge powers and the vanishing polynomial constants h_k^n of their cosets.
+	shiftPowers := make([]fr.Element, len(cells))
+	for i := range cellIndices {
+		shift := setup.cosetShift(cellIndices[i])
+		shiftPowers[i].Exp(*shift, big.NewInt(FieldElementsPerCell))
+		shiftPowers[i].Mul(&shiftPowers[i], &rPowers[i])
+	}
+	var weightedProofs bls12381.G1Affine
+	if _, err := weightedProofs.MultiExp(proofPoints, shiftPowers, config); err != nil {
+		return errors.Wrap(err, "could not compute weighted proofs linear combination")
+	}
+
+	rl.Sub(&rl, &interpolationCommitment)
+	rl.Add(&rl, &weightedProofs)
+
+	ok, err := bls12381.PairingCheck(
+		[]bls12381.G1Affine{proofLincomb, rl},
+		[]bls12381.G2Affine{setup.negG2Tau, setup.g2Gen},
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not compute pairing check")
+	}
+	if !ok {
+		return ErrInvalidCellProof
+	}
+	return nil
+}
+
+// cellToEvaluations deserializes the field elements of a cell, which must all be canonical.
+func cellToEvaluations(cell []byte) ([]fr.Element, error) {
+	if len(cell) != BytesPerCell {
+		return nil, errors.Errorf("cell has length %d", len(cell))
+	}
+	evals := make([]fr.Element, FieldElementsPerCell)
+	for i := range evals {
+		if err := evals[i].SetBytesCanonical(cell[i*bytesPerFieldElement : (i+1)*bytesPerFieldElement]); err != nil {
+			return nil, err
+		}
+	}
+	return evals, nil
+}
+
+// cellBatchChallenge computes the Fiat-Shamir challenge of a cell KZG proof batch.
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/fulu/polynomial-commitments-sampling.md#compute_verify_cell_kzg_proof_batch_challenge
+func cellBatchChallenge(commitments [][]byte, commitmentIndices, cellIndices []uint64, cells, proofs [][]byte) fr.Element {
+	h := sha256.New()
+	h.Write([]byte(cellBatchChallengeDomain))
+	writeUint64 := func(v uint64) {
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], v)
+		h.Write(b[:])
+	}
+	writeUint64(fieldElementsPerBlob)
+	writeUint64(FieldElementsPerCell)
+	writeUint64(uint64(len(commitments)))
+	writeUint64(uint64(len(cellIndices)))
+	for _, c := range commitments {
+		h.Write(c)
+	}
+	for i := range cells {
+		writeUint64(commitmentIndices[i])
+		writeUint64(cellIndices[i])
+		h.Write(cells[i])
+		h.Write(proofs[i])
+	}
+	var challenge fr.Element
+	challenge.SetBytes(h.Sum(nil))
+	return challenge
+}
+
+// cosetShift returns h_k, the first element of the coset of the extended domain holding the cell at index k.
+func (s *cellVerificationSetup) cosetShift(cellIndex uint64) *fr.Element {
+	return &s.rootsBrp[cellIndex*FieldElementsPerCell]
+}
+
+// interpolateCoset returns the monomial coefficients of the polynomial of degree lower than FIELD_ELEMENTS_PER_CELL
+// taking the given values over the coset of the cell at the given index, in the bit-reversed order used by cells.
+// The j-th point of the coset is h*w^rev(j), with w the FIELD_ELEMENTS_PER_CELL-th root of unity, so the values
+// are those of q(X) = p(h*X) over the roots of unity, which are interpolated with an inverse DFT.
+func (s *cellVerificationSetup) interpolateCoset(cellIndex uint64, evals []fr.Element) []fr.Element {
+	values := make([]fr.Element, FieldElementsPerCell)
+	for j := range evals {
+		values[reverseBits(uint64(j), FieldElementsPerCell)] = evals[j]
+	}
+	var nInv fr.Element
+	nInv.SetUint64(FieldElementsPerCell)
+	nInv.Inverse(&nInv)
+	var shiftInv fr.Element
+	shiftInv.Inverse(s.cosetShift(cellIndex))
+
+	coeffs := make([]fr.Element, FieldElementsPerCell)
+	scale := nInv
+	for i := range coeffs {
+		var term fr.Element
+		for m := range values {
+			// w^-(i*m) = w^(n - i*m mod n)
+			root := s.cellRoots[(FieldElementsPerCell-(i*m)%FieldElementsPerCell)%FieldElementsPerCell]
+			term.Mul(&values[m], &root)
+			coeffs[i].Add(&coeffs[i], &term)
+		}
+		coeffs[i].Mul(&coeffs[i], &scale)
+		scale.Mul(&scale, &shiftInv)
+	}
+	return coeffs
+}
+
+// loadCellSetup lazily derives the cell verification setup from the embedded trusted setup.
+func loadCellSetup() (*cellVerificationSetup, error) {
+	cellSetupOnce.Do(func() {
+		cellSetup, cellSetupErr = newCellVerificationSetup(embeddedTrustedSetup)
+	})
+	return cellSetup, cellSetupErr
+}
+
+type jsonTrustedSetup struct {
+	G2Monomial []string `json:"g2_monomial"`
+}
+
+func newCellVerificationSetup(setupJSON []byte) (*cellVerificationSetup, error) {
+	parsed := jsonTrustedSetup{}
+	if err := json.Unmarshal(setupJSON, &parsed); err != nil {
+		return nil, errors.Wrap(err, "could not parse trusted setup JSON")
+	}
+	if len(parsed.G2Monomial) <= FieldElementsPerCell {
+		return nil, errors.New("unexpected trusted setup size")
+	}
+	s := &cellVerificationSetup{
+		g1Monomial: make([]bls12381.G1Affine, FieldElementsPerCell),
+		rootsBrp:   bitReversedRoots(fieldElementsPerExtBlob),
+		cellRoots:  roots(FieldElementsPerCell),
+	}
+	for i, p := range g1MonomialSetup {
+		b, err := hexutil.Decode(p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode G1 point %d", i)
+		}
+		if _, err := s.g1Monomial[i].SetBytes(b); err != nil {
+			return nil, errors.Wrapf(err, "could not deserialize G1 point %d", i)
+		}
+	}
+	b, err := hexutil.Decode(parsed.G2Monomial[FieldElementsPerCell])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode G2 point")
+	}
+	var g2Tau bls12381.G2Affine
+	if _, err := g2Tau.SetBytes(b); err != nil {
+		return nil, errors.Wrap(err, "could not deserialize G2 point")
+	}
+	s.negG2Tau.Neg(&g2Tau)
+	_, _, _, s.g2Gen = bls12381.Generators()
+	return s, nil
+}
+
+// roots returns the n-th roots of unity in natural order.
+func roots(n uint64) []fr.Element {
+	var exp big.Int
+	exp.SetUint64(n)
+	exp.Div(new(big.Int).Sub(fr.Modulus(), big.NewInt(1)), &exp)
+	var root fr.Element
+	root.SetUint64(primitiveRootOfUnity)
+	root.Exp(root, &exp)
+
+	result := make([]fr.Element, n)
+	result[0].SetOne()
+	for i := uint64(1); i < n; i++ {
+		result[i].Mul(&result[i-1], &root)
+	}
+	return result
+}
+
+// bitReversedRoots returns the n-th roots of unity in bit-reversed order.
+func bitReversedRoots(n uint64) []fr.Element {
+	natural := roots(n)
+	result := make([]fr.Element, n)
+	for i := range natural {
+		result[reverseBits(uint64(i), n)] = natural[i]
+	}
+	return result
+}
+
+// reverseBits reverses the log2(n) low bits of i.
+func reverseBits(i, n uint64) uint64 {
+	return bits.Reverse64(i) >> (64 - bits.TrailingZeros64(n))
+}
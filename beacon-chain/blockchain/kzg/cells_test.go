@@ -0,0 +1,271 @@
+package kzg
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	GoKZG "github.com/crate-crypto/go-kzg-4844"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+// testPolynomialDegree bounds the degree of the test polynomials. It is larger than two cells so that
+// the proofs depend on the coset of the cell.
+const testPolynomialDegree = 3 * FieldElementsPerCell
+
+// testHighCoefficients are the coefficients above FIELD_ELEMENTS_PER_CELL which are set in the test
+// polynomials, the others being zero so that only a few more monomial setup points need to be derived.
+var testHighCoefficients = []int{64, 69, 127, 128, 135, 191}
+
+// cellTestSetup derives the [tau^i]G1 points needed to commit to the test polynomials and to open them.
+type cellTestSetup struct {
+	setup      *cellVerificationSetup
+	lagrange   []bls12381.G1Affine
+	g1Monomial map[int]bls12381.G1Affine
+}
+
+func newCellTestSetup(t *testing.T) *cellTestSetup {
+	setup, err := loadCellSetup()
+	require.NoError(t, err)
+	parsed := struct {
+		G1Lagrange []string `json:"g1_lagrange"`
+	}{}
+	require.NoError(t, json.Unmarshal(embeddedTrustedSetup, &parsed))
+	lagrange := make([]bls12381.G1Affine, len(parsed.G1Lagrange))
+	for i, p := range parsed.G1Lagrange {
+		_, err := lagrange[i].SetBytes(hexutil.MustDecode(p))
+		require.NoError(t, err)
+	}
+	return &cellTestSetup{setup: setup, lagrange: lagrange, g1Monomial: make(map[int]bls12381.G1Affine)}
+}
+
+// monomialPoint returns [tau^k]G1 = sum_i w_i^k * [L_i(tau)]G1, with the Lagrange setup over the roots of unity.
+func (s *cellTestSetup) monomialPoint(t *testing.T, k int) bls12381.G1Affine {
+	if k < FieldElementsPerCell {
+		return s.setup.g1Monomial[k]
+	}
+	if p, ok := s.g1Monomial[k]; ok {
+		return p
+	}
+	domain := roots(uint64(len(s.lagrange)))
+	scalars := make([]fr.Element, len(domain))
+	for i := range domain {
+		scalars[i].Exp(domain[i], big.NewInt(int64(k)))
+	}
+	var p bls12381.G1Affine
+	_, err := p.MultiExp(s.lagrange, scalars, ecc.MultiExpConfig{})
+	require.NoError(t, err)
+	s.g1Monomial[k] = p
+	return p
+}
+
+func (s *cellTestSetup) commit(t *testing.T, coeffs []fr.Element) []byte {
+	points := make([]bls12381.G1Affine, 0, len(coeffs))
+	scalars := make([]fr.Element, 0, len(coeffs))
+	for k := range coeffs {
+		if coeffs[k].IsZero() {
+			continue
+		}
+		points = append(points, s.monomialPoint(t, k))
+		scalars = append(scalars, coeffs[k])
+	}
+	var c bls12381.G1Affine
+	_, err := c.MultiExp(points, scalars, ecc.MultiExpConfig{})
+	require.NoError(t, err)
+	b := c.Bytes()
+	return b[:]
+}
+
+func evaluate(coeffs []fr.Element, x *fr.Element) fr.Element {
+	var result fr.Element
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		result.Mul(&result, x)
+		result.Add(&result, &coeffs[i])
+	}
+	return result
+}
+
+// cellAndProof computes the cell at the given index of the extended blob of the polynomial, and its proof,
+// which commits to the quotient of the polynomial by the vanishing polynomial X^n - h^n of the cell coset.
+func (s *cellTestSetup) cellAndProof(t *testing.T, coeffs []fr.Element, cellIndex uint64) ([]byte, []byte) {
+	cell := make([]byte, 0, BytesPerCell)
+	for j := uint64(0); j < FieldElementsPerCell; j++ {
+		eval := evaluate(coeffs, &s.setup.rootsBrp[cellIndex*FieldElementsPerCell+j])
+		b := eval.Bytes()
+		cell = append(cell, b[:]...)
+	}
+
+	var shiftPower fr.Element
+	shift := s.setup.cosetShift(cellIndex)
+	shiftPower.Mul(shift, shift)
+	for i := 2; i < FieldElementsPerCell; i *= 2 {
+		shiftPower.Mul(&shiftPower, &shiftPower)
+	}
+	// Synthetic division by X^n - c, from the highest coefficient down.
+	remainder := make([]fr.Element, len(coeffs))
+	copy(remainder, coeffs)
+	quotient := make([]fr.Element, len(coeffs)-FieldElementsPerCell)
+	for i := len(coeffs) - 1; i >= FieldElementsPerCell; i-- {
+		quotient[i-FieldElementsPerCell] = remainder[i]
+		var term fr.Element
+		term.Mul(&remainder[i], &shiftPower)
+		remainder[i-FieldElementsPerCell].Add(&remainder[i-FieldElementsPerCell], &term)
+	}
+	return cell, s.commit(t, quotient)
+}
+
+func randomPolynomial(t *testing.T) []fr.Element {
+	coeffs := make([]fr.Element, testPolynomialDegree)
+	for i := 0; i < FieldElementsPerCell; i++ {
+		_, err := coeffs[i].SetRandom()
+		require.NoError(t, err)
+	}
+	for _, i := range testHighCoefficients {
+		_, err := coeffs[i].SetRandom()
+		require.NoError(t, err)
+	}
+	return coeffs
+}
+
+func TestCellVerificationSetup(t *testing.T) {
+	setup, err := loadCellSetup()
+	require.NoError(t, err)
+	parsed := jsonTrustedSetup{}
+	require.NoError(t, json.Unmarshal(embeddedTrustedSetup, &parsed))
+	g2Monomial := make([]bls12381.G2Affine, len(parsed.G2Monomial))
+	for i, p := range parsed.G2Monomial {
+		_, err := g2Monomial[i].SetBytes(hexutil.MustDecode(p))
+		require.NoError(t, err)
+	}
+	_, _, g1Gen, _ := bls12381.Generators()
+	var negG1Gen bls12381.G1Affine
+	negG1Gen.Neg(&g1Gen)
+
+	// e([tau^k]G1, G2) == e(G1, [tau^k]G2)
+	for k := range setup.g1Monomial {
+		ok, err := bls12381.PairingCheck(
+			[]bls12381.G1Affine{setup.g1Monomial[k], negG1Gen},
+			[]bls12381.G2Affine{setup.g2Gen, g2Monomial[k]},
+		)
+		require.NoError(t, err)
+		require.Equal(t, true, ok, "monomial point %d does not match the G2 setup", k)
+	}
+	// e([tau^(n-1)]G1, [tau]G2) == e(G1, [tau^n]G2)
+	ok, err := bls12381.PairingCheck(
+		[]bls12381.G1Affine{setup.g1Monomial[FieldElementsPerCell-1], g1Gen},
+		[]bls12381.G2Affine{g2Monomial[1], setup.negG2Tau},
+	)
+	require.NoError(t, err)
+	require.Equal(t, true, ok)
+}
+
+func TestVerifyCellKZGProofBatch(t *testing.T) {
+	require.NoError(t, Start())
+	s := newCellTestSetup(t)
+
+	polys := [][]fr.Element{randomPolynomial(t), randomPolynomial(t)}
+	commitments := make([][]byte, len(polys))
+	for i, poly := range polys {
+		commitments[i] = s.commit(t, poly)
+
+		// The commitment matches the one of the blob holding the polynomial evaluations.
+		var blob GoKZG.Blob
+		domain := bitReversedRoots(fieldElementsPerBlob)
+		for j := range domain {
+			eval := evaluate(poly, &domain[j])
+			b := eval.Bytes()
+			copy(blob[j*bytesPerFieldElement:], b[:])
+		}
+		expected, err := kzgContext.BlobToKZGCommitment(blob, 0)
+		require.NoError(t, err)
+		require.DeepEqual(t, expected[:], commitments[i])
+	}
+
+	type cellProof struct {
+		commitment []byte
+		index      uint64
+		cell       []byte
+		proof      []byte
+	}
+	var batch []cellProof
+	for i, poly := range polys {
+		for _, index := range []uint64{0, 1, 37, CellsPerExtBlob - 1} {
+			cell, proof := s.cellAndProof(t, poly, index)
+			batch = append(batch, cellProof{commitment: commitments[i], index: index, cell: cell, proof: proof})
+		}
+	}
+	verify := func(batch []cellProof) error {
+		commitments := make([][]byte, len(batch))
+		indices := make([]uint64, len(batch))
+		cells := make([][]byte, len(batch))
+		proofs := make([][]byte, len(batch))
+		for i, c := range batch {
+			commitments[i], indices[i], cells[i], proofs[i] = c.commitment, c.index, c.cell, c.proof
+		}
+		return VerifyCellKZGProofBatch(commitments, indices, cells, proofs)
+	}
+	clone := func() []cellProof {
+		c := make([]cellProof, len(batch))
+		copy(c, batch)
+		return c
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		require.NoError(t, verify(batch))
+		for _, c := range batch {
+			require.NoError(t, verify([]cellProof{c}))
+		}
+		// The same cell may appear several times in a batch.
+		require.NoError(t, verify(append(clone(), batch[1])))
+	})
+	t.Run("empty", func(t *testing.T) {
+		require.NoError(t, verify(nil))
+	})
+	t.Run("tampered cell", func(t *testing.T) {
+		b := clone()
+		b[2].cell = append([]byte{}, b[2].cell...)
+		b[2].cell[BytesPerCell-1] ^= 1
+		require.ErrorIs(t, verify(b), ErrInvalidCellProof)
+	})
+	t.Run("wrong cell index", func(t *testing.T) {
+		b := clone()
+		b[2].index++
+		require.ErrorIs(t, verify(b), ErrInvalidCellProof)
+	})
+	t.Run("wrong commitment", func(t *testing.T) {
+		b := clone()
+		b[0].commitment = commitments[1]
+		require.ErrorIs(t, verify(b), ErrInvalidCellProof)
+	})
+	t.Run("swapped proofs", func(t *testing.T) {
+		b := clone()
+		b[0].proof, b[1].proof = b[1].proof, b[0].proof
+		require.ErrorIs(t, verify(b), ErrInvalidCellProof)
+	})
+	t.Run("non canonical field element", func(t *testing.T) {
+		b := clone()
+		b[0].cell = append([]byte{}, b[0].cell...)
+		for i := 0; i < bytesPerFieldElement; i++ {
+			b[0].cell[i] = 0xff
+		}
+		require.ErrorIs(t, verify(b), ErrInvalidCellBatch)
+	})
+	t.Run("invalid proof point", func(t *testing.T) {
+		b := clone()
+		b[0].proof = make([]byte, bytesPerG1Point)
+		require.ErrorIs(t, verify(b), ErrInvalidCellBatch)
+	})
+	t.Run("cell index too large", func(t *testing.T) {
+		b := clone()
+		b[0].index = CellsPerExtBlob
+		require.ErrorIs(t, verify(b), ErrInvalidCellBatch)
+	})
+	t.Run("length mismatch", func(t *testing.T) {
+		err := VerifyCellKZGProofBatch(commitments, []uint64{0}, [][]byte{batch[0].cell}, [][]byte{batch[0].proof})
+		require.ErrorIs(t, err, ErrInvalidCellBatch)
+	})
+}
@@ -207,6 +207,23 @@ func WithBlobStorage(b *filesystem.BlobStorage) Option {
 	}
 }
 
+// WithDataColumnStorage sets the data column storage backend for the blockchain service.
+func WithDataColumnStorage(b *filesystem.DataColumnStorage) Option {
+	return func(s *Service) error {
+		s.dataColumnStorage = b
+		return nil
+	}
+}
+
+// WithCustodyColumns sets the column indices the node custodies. From Fulu, a block is only
+// considered available once all of these columns are persisted.
+func WithCustodyColumns(custody map[uint64]bool) Option {
+	return func(s *Service) error {
+		s.custodyColumns = custody
+		return nil
+	}
+}
+
 func WithSyncChecker(checker Checker) Option {
 	return func(s *Service) error {
 		s.cfg.SyncChecker = checker
@@ -536,6 +536,9 @@ func (s *Service) isDataAvailable(ctx context.Context, root [32]byte, signed int
 	if signed.Version() < version.Deneb {
 		return nil
 	}
+	if signed.Version() >= version.Fulu {
+		return s.areDataColumnsAvailable(ctx, root, signed)
+	}
 
 	block := signed.Block()
 	if block == nil {
@@ -604,6 +607,76 @@ func (s *Service) isDataAvailable(ctx context.Context, root [32]byte, signed int
 	}
 }
 
+// areDataColumnsAvailable blocks until all the data columns the node custodies for a Fulu block are available,
+// or an error or context cancellation occurs. Like isDataAvailable, it first consults the data column storage and then
+// waits on the dataColumnNotifiers channel for the given root for the columns that are still missing.
+func (s *Service) areDataColumnsAvailable(ctx context.Context, root [32]byte, signed interfaces.ReadOnlySignedBeaconBlock) error {
+	block := signed.Block()
+	if block == nil {
+		return errors.New("invalid nil beacon block")
+	}
+	// We are only required to check within MIN_EPOCHS_FOR_DATA_COLUMN_SIDECARS_REQUESTS
+	if slots.ToEpoch(block.Slot())+params.BeaconConfig().MinEpochsForDataColumnSidecarsRequest < slots.ToEpoch(s.CurrentSlot()) {
+		return nil
+	}
+
+	body := block.Body()
+	if body == nil {
+		return errors.New("invalid nil beacon block body")
+	}
+	kzgCommitments, err := body.BlobKzgCommitments()
+	if err != nil {
+		return errors.Wrap(err, "could not get KZG commitments")
+	}
+	if len(kzgCommitments) == 0 {
+		return nil
+	}
+	if s.dataColumnStorage == nil {
+		return errMissingDataColumnStorage
+	}
+
+	// get a map of the custody columns that are not currently available.
+	summary := s.dataColumnStorage.Summary(root)
+	missing := make(map[uint64]struct{}, len(s.custodyColumns))
+	for idx := range s.custodyColumns {
+		if !summary.HasIndex(idx) {
+			missing[idx] = struct{}{}
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	// The gossip handler for data columns writes the index of each verified column referencing the given
+	// root to the channel returned by dataColumnNotifiers.forRoot.
+	nc := s.dataColumnNotifiers.forRoot(root, block.Slot())
+	expected := len(s.custodyColumns)
+	nextSlot := slots.BeginsAt(block.Slot()+1, s.genesisTime)
+	if nextSlot.After(time.Now()) {
+		nst := time.AfterFunc(time.Until(nextSlot), func() {
+			log.WithFields(logrus.Fields{
+				"slot":            block.Slot(),
+				"root":            fmt.Sprintf("%#x", root),
+				"columnsExpected": expected,
+			}).Error("Still waiting for data column DA check at slot end.")
+		})
+		defer nst.Stop()
+	}
+	for {
+		select {
+		case idx := <-nc:
+			delete(missing, idx)
+			if len(missing) > 0 {
+				continue
+			}
+			s.dataColumnNotifiers.delete(root)
+			return nil
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "context deadline waiting for data column sidecars slot: %d, BlockRoot: %#x", block.Slot(), root)
+		}
+	}
+}
+
 func daCheckLogFields(root [32]byte, slot primitives.Slot, expected, missing int) logrus.Fields {
 	return logrus.Fields{
 		"slot":          slot,
@@ -22,3 +22,17 @@ func (s *Service) ReceiveBlob(ctx context.Context, b blocks.VerifiedROBlob) erro
 	s.sendNewBlobEvent(b.BlockRoot(), b.Index, b.Slot())
 	return nil
 }
+
+// ReceiveDataColumn saves the data column sidecar to storage and notifies any data availability
+// check waiting on the column.
+func (s *Service) ReceiveDataColumn(_ context.Context, dc blocks.VerifiedRODataColumn) error {
+	if s.dataColumnStorage == nil {
+		return errMissingDataColumnStorage
+	}
+	if err := s.dataColumnStorage.Save(dc); err != nil {
+		return err
+	}
+
+	s.dataColumnNotifiers.notifyIndex(dc.BlockRoot(), dc.ColumnIndex, dc.Slot())
+	return nil
+}
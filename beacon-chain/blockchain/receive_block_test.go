@@ -10,12 +10,19 @@ import (
 	blockchainTesting "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/fulu"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/das"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpbv1 "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
@@ -493,3 +500,134 @@ func Test_executePostFinalizationTasks(t *testing.T) {
 	// check deposit
 	require.LogsContain(t, logHook, "Finalized deposit insertion completed at index")
 }
+
+// generateFuluBlock builds a valid Fulu block at the given slot on top of the Fulu state st, committing to nblobs blobs.
+// Fulu blocks share the Electra block container, so the block is generated as an Electra block and its state root and
+// signature are recomputed once the commitments are set.
+func generateFuluBlock(t *testing.T, st state.BeaconState, keys []bls.SecretKey, slot primitives.Slot, nblobs int) interfaces.SignedBeaconBlock {
+	eb, err := util.GenerateFullBlockElectra(st, keys, util.DefaultBlockGenConfig(), slot)
+	require.NoError(t, err)
+	b := eb.Block
+	commitments := make([][]byte, nblobs)
+	for i := range commitments {
+		commitments[i] = bytesutil.PadTo([]byte{byte(i + 1)}, fieldparams.BLSPubkeyLength)
+	}
+	fb := &ethpb.SignedBeaconBlockFulu{
+		Block: &ethpb.BeaconBlockFulu{
+			Slot:          b.Slot,
+			ProposerIndex: b.ProposerIndex,
+			ParentRoot:    b.ParentRoot,
+			StateRoot:     b.StateRoot,
+			Body: &ethpb.BeaconBlockBodyFulu{
+				RandaoReveal:          b.Body.RandaoReveal,
+				Eth1Data:              b.Body.Eth1Data,
+				Graffiti:              b.Body.Graffiti,
+				ProposerSlashings:     b.Body.ProposerSlashings,
+				AttesterSlashings:     b.Body.AttesterSlashings,
+				Attestations:          b.Body.Attestations,
+				Deposits:              b.Body.Deposits,
+				VoluntaryExits:        b.Body.VoluntaryExits,
+				SyncAggregate:         b.Body.SyncAggregate,
+				ExecutionPayload:      b.Body.ExecutionPayload,
+				BlsToExecutionChanges: b.Body.BlsToExecutionChanges,
+				BlobKzgCommitments:    commitments,
+				ExecutionRequests:     b.Body.ExecutionRequests,
+			},
+		},
+		Signature: eb.Signature,
+	}
+	wsb, err := blocks.NewSignedBeaconBlock(fb)
+	require.NoError(t, err)
+	stateRoot, err := transition.CalculateStateRoot(context.Background(), st.Copy(), wsb)
+	require.NoError(t, err)
+	fb.Block.StateRoot = stateRoot[:]
+	fb.Signature, err = signing.ComputeDomainAndSign(st, slots.ToEpoch(slot), fb.Block, params.BeaconConfig().DomainBeaconProposer, keys[fb.Block.ProposerIndex])
+	require.NoError(t, err)
+	wsb, err = blocks.NewSignedBeaconBlock(fb)
+	require.NoError(t, err)
+	return wsb
+}
+
+// verifiedColumnForBlock returns a data column sidecar for the given block. Its contents are not valid, which is fine
+// because ReceiveDataColumn expects columns which have already been verified.
+func verifiedColumnForBlock(t *testing.T, blk interfaces.SignedBeaconBlock, root [32]byte, idx uint64) blocks.VerifiedRODataColumn {
+	header, err := blk.Header()
+	require.NoError(t, err)
+	commitments, err := blk.Block().Body().BlobKzgCommitments()
+	require.NoError(t, err)
+	cells := make([][]byte, len(commitments))
+	proofs := make([][]byte, len(commitments))
+	for i := range cells {
+		cells[i] = make([]byte, 2048)
+		proofs[i] = make([]byte, fieldparams.BLSPubkeyLength)
+	}
+	inclusion := make([][]byte, 4)
+	for i := range inclusion {
+		inclusion[i] = make([]byte, 32)
+	}
+	dc, err := blocks.NewRODataColumnWithRoot(&ethpb.DataColumnSidecar{
+		ColumnIndex:                  idx,
+		DataColumn:                   cells,
+		KzgCommitments:               commitments,
+		KzgProof:                     proofs,
+		SignedBlockHeader:            header,
+		KzgCommitmentsInclusionProof: inclusion,
+	}, root)
+	require.NoError(t, err)
+	return blocks.NewVerifiedRODataColumn(dc)
+}
+
+func TestService_ReceiveBlock_DataColumns(t *testing.T) {
+	custody := map[uint64]bool{3: true, 17: true, 64: true}
+	setup := func(t *testing.T) (*Service, interfaces.SignedBeaconBlock, [32]byte) {
+		s, tr := minimalTestService(t,
+			WithExitPool(voluntaryexits.NewPool()),
+			WithStateNotifier(&blockchainTesting.MockStateNotifier{RecordEvents: true}),
+			WithDataColumnStorage(filesystem.NewEphemeralDataColumnStorage(t)),
+			WithCustodyColumns(custody),
+		)
+		ctx := tr.ctx
+		st, keys := util.DeterministicGenesisStateElectra(t, 64)
+		st, err := fulu.UpgradeToFulu(st)
+		require.NoError(t, err)
+		require.NoError(t, s.saveGenesisData(ctx, st))
+		blk := generateFuluBlock(t, st, keys, 1, 2)
+		root, err := blk.Block().HashTreeRoot()
+		require.NoError(t, err)
+		return s, blk, root
+	}
+
+	t.Run("columns received from gossip", func(t *testing.T) {
+		s, blk, root := setup(t)
+		// Receive one custody column before the block, and the rest while the block waits on them.
+		require.NoError(t, s.ReceiveDataColumn(context.Background(), verifiedColumnForBlock(t, blk, root, 3)))
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			for _, idx := range []uint64{17, 64} {
+				assert.NoError(t, s.ReceiveDataColumn(context.Background(), verifiedColumnForBlock(t, blk, root, idx)))
+			}
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		require.NoError(t, s.ReceiveBlock(ctx, blk, root, nil))
+		require.Equal(t, root, bytesutil.ToBytes32(s.head.root[:]))
+	})
+	t.Run("custody column missing", func(t *testing.T) {
+		s, blk, root := setup(t)
+		for _, idx := range []uint64{3, 17, 5} {
+			require.NoError(t, s.ReceiveDataColumn(context.Background(), verifiedColumnForBlock(t, blk, root, idx)))
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		err := s.ReceiveBlock(ctx, blk, root, nil)
+		require.ErrorContains(t, "context deadline waiting for data column sidecars", err)
+		require.Equal(t, false, s.cfg.ForkChoiceStore.HasNode(root))
+	})
+	t.Run("column availability store", func(t *testing.T) {
+		s, blk, root := setup(t)
+		avs := das.NewLazilyPersistentStoreColumn(s.dataColumnStorage, nil, custody)
+		err := s.ReceiveBlock(context.Background(), blk, root, avs)
+		require.ErrorContains(t, "no sidecar in cache for custody column", err)
+		require.Equal(t, false, s.cfg.ForkChoiceStore.HasNode(root))
+	})
+}
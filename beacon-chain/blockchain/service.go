@@ -65,6 +65,9 @@ type Service struct {
 	blobNotifiers        *blobNotifierMap
 	blockBeingSynced     *currentlySyncingBlock
 	blobStorage          *filesystem.BlobStorage
+	dataColumnNotifiers  *blobNotifierMap
+	dataColumnStorage    *filesystem.DataColumnStorage
+	custodyColumns       map[uint64]bool
 }
 
 // config options for the service.
@@ -106,12 +109,22 @@ type blobNotifierMap struct {
 	sync.RWMutex
 	notifiers map[[32]byte]chan uint64
 	seenIndex map[[32]byte][]bool
+	// maxIndices returns the number of sidecar indices a block at the given slot can have.
+	// When nil, the maximum number of blobs per block is used.
+	maxIndices func(slot primitives.Slot) int
+}
+
+func (bn *blobNotifierMap) maxPerBlock(slot primitives.Slot) int {
+	if bn.maxIndices != nil {
+		return bn.maxIndices(slot)
+	}
+	return params.BeaconConfig().MaxBlobsPerBlock(slot)
 }
 
 // notifyIndex notifies a blob by its index for a given root.
 // It uses internal maps to keep track of seen indices and notifier channels.
 func (bn *blobNotifierMap) notifyIndex(root [32]byte, idx uint64, slot primitives.Slot) {
-	maxBlobsPerBlock := params.BeaconConfig().MaxBlobsPerBlock(slot)
+	maxBlobsPerBlock := bn.maxPerBlock(slot)
 	if idx >= uint64(maxBlobsPerBlock) {
 		return
 	}
@@ -141,7 +154,7 @@ func (bn *blobNotifierMap) notifyIndex(root [32]byte, idx uint64, slot primitive
 }
 
 func (bn *blobNotifierMap) forRoot(root [32]byte, slot primitives.Slot) chan uint64 {
-	maxBlobsPerBlock := params.BeaconConfig().MaxBlobsPerBlock(slot)
+	maxBlobsPerBlock := bn.maxPerBlock(slot)
 	bn.Lock()
 	defer bn.Unlock()
 	c, ok := bn.notifiers[root]
@@ -174,6 +187,13 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 		notifiers: make(map[[32]byte]chan uint64),
 		seenIndex: make(map[[32]byte][]bool),
 	}
+	cn := &blobNotifierMap{
+		notifiers: make(map[[32]byte]chan uint64),
+		seenIndex: make(map[[32]byte][]bool),
+		maxIndices: func(primitives.Slot) int {
+			return int(params.BeaconConfig().NumberOfColumns)
+		},
+	}
 	srv := &Service{
 		ctx:                  ctx,
 		cancel:               cancel,
@@ -181,6 +201,7 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 		checkpointStateCache: cache.NewCheckpointStateCache(),
 		initSyncBlocks:       make(map[[32]byte]interfaces.ReadOnlySignedBeaconBlock),
 		blobNotifiers:        bn,
+		dataColumnNotifiers:  cn,
 		cfg:                  &config{},
 		blockBeingSynced:     &currentlySyncingBlock{roots: make(map[[32]byte]struct{})},
 	}
@@ -215,6 +236,7 @@ func (s *Service) Start() {
 	}
 	s.spawnProcessAttestationsRoutine()
 	go s.runLateBlockTasks()
+	go s.runForkchoiceSnapshots()
 }
 
 // Stop the blockchain service's main event loop and associated goroutines.
@@ -234,7 +256,16 @@ func (s *Service) Stop() error {
 		s.headLock.RUnlock()
 	}
 	// Save initial sync cached blocks to the DB before stop.
-	return s.cfg.BeaconDB.SaveBlocks(s.ctx, s.getInitSyncBlocks())
+	if err := s.cfg.BeaconDB.SaveBlocks(s.ctx, s.getInitSyncBlocks()); err != nil {
+		return err
+	}
+	// Save forkchoice so that the following run does not have to replay the unfinalized blocks.
+	if s.cfg.ForkChoiceStore != nil {
+		if err := s.saveForkchoiceSnapshot(s.ctx); err != nil {
+			log.WithError(err).Error("Could not save forkchoice snapshot")
+		}
+	}
+	return nil
 }
 
 // Status always returns nil unless there is an error condition that causes
@@ -290,9 +321,32 @@ func (s *Service) StartFromSavedState(saved state.BeaconState) error {
 		return errNilFinalizedCheckpoint
 	}
 
-	fRoot := s.ensureRootNotZeros(bytesutil.ToBytes32(finalized.Root))
 	s.cfg.ForkChoiceStore.Lock()
 	defer s.cfg.ForkChoiceStore.Unlock()
+	if !s.restoreForkchoiceSnapshot(s.ctx, finalized) {
+		if err := s.initializeForkchoiceFromCheckpoints(justified, finalized); err != nil {
+			return err
+		}
+	}
+	// not attempting to save initial sync blocks here, because there shouldn't be any until
+	// after the statefeed.Initialized event is fired (below)
+	if err := s.wsVerifier.VerifyWeakSubjectivity(s.ctx, finalized.Epoch); err != nil {
+		// Exit run time if the node failed to verify weak subjectivity checkpoint.
+		return errors.Wrap(err, "could not verify initial checkpoint provided for chain sync")
+	}
+
+	vr := bytesutil.ToBytes32(saved.GenesisValidatorsRoot())
+	if err := s.clockSetter.SetClock(startup.NewClock(s.genesisTime, vr)); err != nil {
+		return errors.Wrap(err, "failed to initialize blockchain service")
+	}
+
+	return nil
+}
+
+// initializeForkchoiceFromCheckpoints rebuilds forkchoice from the justified and finalized checkpoints saved in the db,
+// inserting the finalized block as the only node. The caller must hold the forkchoice lock.
+func (s *Service) initializeForkchoiceFromCheckpoints(justified, finalized *ethpb.Checkpoint) error {
+	fRoot := s.ensureRootNotZeros(bytesutil.ToBytes32(finalized.Root))
 	if err := s.cfg.ForkChoiceStore.UpdateJustifiedCheckpoint(s.ctx, &forkchoicetypes.Checkpoint{Epoch: justified.Epoch,
 		Root: bytesutil.ToBytes32(justified.Root)}); err != nil {
 		return errors.Wrap(err, "could not update forkchoice's justified checkpoint")
@@ -329,18 +383,6 @@ func (s *Service) StartFromSavedState(saved state.BeaconState) error {
 			}
 		}
 	}
-	// not attempting to save initial sync blocks here, because there shouldn't be any until
-	// after the statefeed.Initialized event is fired (below)
-	if err := s.wsVerifier.VerifyWeakSubjectivity(s.ctx, finalized.Epoch); err != nil {
-		// Exit run time if the node failed to verify weak subjectivity checkpoint.
-		return errors.Wrap(err, "could not verify initial checkpoint provided for chain sync")
-	}
-
-	vr := bytesutil.ToBytes32(saved.GenesisValidatorsRoot())
-	if err := s.clockSetter.SetClock(startup.NewClock(s.genesisTime, vr)); err != nil {
-		return errors.Wrap(err, "failed to initialize blockchain service")
-	}
-
 	return nil
 }
 
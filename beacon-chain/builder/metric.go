@@ -27,4 +27,33 @@ var (
 			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
 		},
 	)
+	relayGetHeaderLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "relay_get_header_latency_milliseconds",
+			Help:    "Captures RPC latency for get header of each relay in milliseconds",
+			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
+		},
+		[]string{"relay"},
+	)
+	relayBidValue = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "relay_bid_value_gwei",
+			Help: "The value of the latest valid bid of each relay in gwei",
+		},
+		[]string{"relay"},
+	)
+	relayAuctionsWon = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "relay_auctions_won_total",
+			Help: "The number of bid auctions won by each relay",
+		},
+		[]string{"relay"},
+	)
+	relayFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "relay_failures_total",
+			Help: "The number of failed calls to each relay by method, including invalid bids and get header timeouts",
+		},
+		[]string{"relay", "method"},
+	)
 )
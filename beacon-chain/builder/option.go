@@ -1,6 +1,8 @@
 package builder
 
 import (
+	"strings"
+
 	"github.com/prysmaticlabs/prysm/v5/api/client/builder"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
@@ -13,25 +15,33 @@ type Option func(s *Service) error
 
 // FlagOptions for builder service flag configurations.
 func FlagOptions(c *cli.Context) ([]Option, error) {
-	endpoint := c.String(flags.MevRelayEndpoint.Name)
-	var client *builder.Client
-	if endpoint != "" {
-		var err error
-		client, err = builder.NewClient(endpoint)
+	var clients []builder.BuilderClient
+	for _, endpoint := range strings.Split(c.String(flags.MevRelayEndpoint.Name), ",") {
+		endpoint = strings.TrimSpace(endpoint)
+		if endpoint == "" {
+			continue
+		}
+		client, err := builder.NewClient(endpoint)
 		if err != nil {
 			return nil, err
 		}
+		clients = append(clients, client)
 	}
 	opts := []Option{
-		WithBuilderClient(client),
+		WithBuilderClients(clients...),
 	}
 	return opts, nil
 }
 
 // WithBuilderClient sets the builder client for the beacon chain builder service.
 func WithBuilderClient(client builder.BuilderClient) Option {
+	return WithBuilderClients(client)
+}
+
+// WithBuilderClients sets the builder clients of the relays the beacon chain builder service runs bid auctions on.
+func WithBuilderClients(clients ...builder.BuilderClient) Option {
 	return func(s *Service) error {
-		s.cfg.builderClient = client
+		s.cfg.builderClients = clients
 		return nil
 	}
 }
@@ -0,0 +1,295 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/builder"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	v1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	log "github.com/sirupsen/logrus"
+)
+
+// relay is a builder relay the service requests bids from.
+type relay struct {
+	builder.BuilderClient
+	// name identifies the relay in logs and metrics, without the credentials that may be part of its URL.
+	name string
+}
+
+func newRelay(c builder.BuilderClient) *relay {
+	name := c.NodeURL()
+	if u, err := url.Parse(name); err == nil && u.Host != "" {
+		name = u.Host
+	}
+	return &relay{BuilderClient: c, name: name}
+}
+
+// relayBid is the outcome of a bid request to a relay.
+type relayBid struct {
+	relay     *relay
+	bid       builder.SignedBid
+	value     *big.Int
+	blockHash [32]byte
+	err       error
+}
+
+// auction requests a bid from all the relays concurrently and returns the highest valid one. Bids that fail validate
+// are left out of the auction, as well as relays which do not answer before the context is done. Ties go to the
+// relay configured first.
+func (s *Service) auction(ctx context.Context, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte, validate func(builder.SignedBid) error) (builder.SignedBid, error) {
+	results := make(chan *relayBid, len(s.relays))
+	for _, r := range s.relays {
+		go func(r *relay) {
+			results <- r.getBid(ctx, slot, parentHash, pubKey, validate)
+		}(r)
+	}
+
+	var best *relayBid
+	received := make(map[*relay]*relayBid, len(s.relays))
+	for len(received) < len(s.relays) {
+		select {
+		case res := <-results:
+			received[res.relay] = res
+			if res.err != nil {
+				continue
+			}
+			if best == nil || res.value.Cmp(best.value) > 0 || (res.value.Cmp(best.value) == 0 && s.relayIndex(res.relay) < s.relayIndex(best.relay)) {
+				best = res
+			}
+		case <-ctx.Done():
+			for _, r := range s.relays {
+				if _, ok := received[r]; !ok {
+					relayFailures.WithLabelValues(r.name, "get_header").Inc()
+					received[r] = &relayBid{relay: r, err: errors.Wrapf(ctx.Err(), "relay %s did not answer in time", r.name)}
+				}
+			}
+		}
+	}
+
+	if best == nil {
+		if len(s.relays) == 1 {
+			return nil, received[s.relays[0]].err
+		}
+		msgs := make([]string, 0, len(s.relays))
+		for _, r := range s.relays {
+			msgs = append(msgs, received[r].err.Error())
+		}
+		return nil, fmt.Errorf("no valid bid from %d relays: %s", len(s.relays), strings.Join(msgs, "; "))
+	}
+
+	relayAuctionsWon.WithLabelValues(best.relay.name).Inc()
+	s.winners.set(slot, best.blockHash, best.relay)
+	if len(s.relays) > 1 {
+		log.WithFields(log.Fields{
+			"slot":      slot,
+			"relay":     best.relay.name,
+			"gweiValue": primitives.WeiToGwei(best.value),
+			"bids":      validBids(received),
+			"relays":    len(s.relays),
+		}).Info("Selected highest relay bid")
+	}
+	return best.bid, nil
+}
+
+func (s *Service) relayIndex(r *relay) int {
+	for i := range s.relays {
+		if s.relays[i] == r {
+			return i
+		}
+	}
+	return len(s.relays)
+}
+
+func validBids(results map[*relay]*relayBid) int {
+	n := 0
+	for _, res := range results {
+		if res.err == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// getBid requests a bid from the relay, and checks that it builds on the parent block, that it is signed by its
+// builder and that it passes validate.
+func (r *relay) getBid(ctx context.Context, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte, validate func(builder.SignedBid) error) *relayBid {
+	start := time.Now()
+	signedBid, err := r.GetHeader(ctx, slot, parentHash, pubKey)
+	relayGetHeaderLatency.WithLabelValues(r.name).Observe(float64(time.Since(start).Milliseconds()))
+	if err != nil {
+		relayFailures.WithLabelValues(r.name, "get_header").Inc()
+		return &relayBid{relay: r, err: errors.Wrapf(err, "could not get header from relay %s", r.name)}
+	}
+	value, blockHash, err := verifyBid(signedBid, parentHash)
+	if err != nil {
+		relayFailures.WithLabelValues(r.name, "get_header").Inc()
+		return &relayBid{relay: r, err: errors.Wrapf(err, "invalid bid from relay %s", r.name)}
+	}
+	if validate != nil {
+		if err := validate(signedBid); err != nil {
+			relayFailures.WithLabelValues(r.name, "get_header").Inc()
+			return &relayBid{relay: r, err: errors.Wrapf(err, "invalid bid from relay %s", r.name)}
+		}
+	}
+	relayBidValue.WithLabelValues(r.name).Set(float64(primitives.WeiToGwei(value)))
+	return &relayBid{relay: r, bid: signedBid, value: value, blockHash: blockHash}
+}
+
+// verifyBid returns the value and the payload block hash of the bid, after checking that the bid builds on the
+// parent block and that it is signed by its builder. The other checks of the bid are left to the proposer's
+// validation callback.
+func verifyBid(signedBid builder.SignedBid, parentHash [32]byte) (*big.Int, [32]byte, error) {
+	if signedBid == nil || signedBid.IsNil() {
+		return nil, [32]byte{}, errors.New("nil bid")
+	}
+	bid, err := signedBid.Message()
+	if err != nil {
+		return nil, [32]byte{}, errors.Wrap(err, "could not get bid")
+	}
+	if bid == nil || bid.IsNil() {
+		return nil, [32]byte{}, errors.New("nil bid")
+	}
+	value := bid.Value()
+	if value == nil || (*big.Int)(value).Sign() <= 0 {
+		return nil, [32]byte{}, errors.New("bid with 0 value")
+	}
+	header, err := bid.Header()
+	if err != nil {
+		return nil, [32]byte{}, errors.Wrap(err, "could not get bid header")
+	}
+	if bytesutil.ToBytes32(header.ParentHash()) != parentHash {
+		return nil, [32]byte{}, fmt.Errorf("incorrect parent hash %#x != %#x", header.ParentHash(), parentHash)
+	}
+	d, err := signing.ComputeDomain(params.BeaconConfig().DomainApplicationBuilder, nil /* fork version */, nil /* genesis val root */)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+	if err := signing.VerifySigningRoot(bid, bid.Pubkey(), signedBid.Signature(), d); err != nil {
+		return nil, [32]byte{}, errors.Wrap(err, "invalid builder signature")
+	}
+	return value, bytesutil.ToBytes32(header.BlockHash()), nil
+}
+
+// submitBlindedBlock submits the blinded block to the relay whose bid won the auction for its payload. When that
+// relay is unknown, for instance if the node restarted since the auction, the block is submitted to all relays.
+func (s *Service) submitBlindedBlock(ctx context.Context, b interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, *v1.BlobsBundle, error) {
+	relays := s.relays
+	if b != nil && !b.IsNil() {
+		if payload, err := b.Block().Body().Execution(); err == nil {
+			if r, ok := s.winners.get(bytesutil.ToBytes32(payload.BlockHash())); ok {
+				relays = []*relay{r}
+			}
+		}
+	}
+	if len(relays) > 1 {
+		log.WithField("relays", len(relays)).Warn("Relay of the winning bid is unknown, submitting the blinded block to all relays")
+	}
+
+	type result struct {
+		payload interfaces.ExecutionData
+		blobs   *v1.BlobsBundle
+		err     error
+	}
+	results := make(chan *result, len(relays))
+	for _, r := range relays {
+		go func(r *relay) {
+			payload, blobs, err := r.SubmitBlindedBlock(ctx, b)
+			if err != nil {
+				relayFailures.WithLabelValues(r.name, "submit_blinded_block").Inc()
+				err = errors.Wrapf(err, "could not submit blinded block to relay %s", r.name)
+			}
+			results <- &result{payload: payload, blobs: blobs, err: err}
+		}(r)
+	}
+	var err error
+	for range relays {
+		res := <-results
+		if res.err == nil {
+			return res.payload, res.blobs, nil
+		}
+		err = res.err
+	}
+	return nil, nil, err
+}
+
+// registerValidator sends the registrations to all the relays, and only fails if none of them accepted them.
+func (s *Service) registerValidator(ctx context.Context, reg []*ethpb.SignedValidatorRegistrationV1) error {
+	errs := make([]error, len(s.relays))
+	var wg sync.WaitGroup
+	for i, r := range s.relays {
+		wg.Add(1)
+		go func(i int, r *relay) {
+			defer wg.Done()
+			if err := r.RegisterValidator(ctx, reg); err != nil {
+				relayFailures.WithLabelValues(r.name, "register_validator").Inc()
+				errs[i] = errors.Wrapf(err, "could not register validators with relay %s", r.name)
+			}
+		}(i, r)
+	}
+	wg.Wait()
+
+	var failed int
+	var lastErr error
+	for _, err := range errs {
+		if err != nil {
+			failed++
+			lastErr = err
+			if len(s.relays) > 1 {
+				log.WithError(err).Warn("Failed to register validators with relay")
+			}
+		}
+	}
+	if failed == len(s.relays) {
+		return lastErr
+	}
+	return nil
+}
+
+// winningRelays remembers the relay of the winning bid of the recent auctions, by payload block hash.
+type winningRelays struct {
+	sync.Mutex
+	relays map[[32]byte]*winningRelay
+}
+
+type winningRelay struct {
+	slot  primitives.Slot
+	relay *relay
+}
+
+func newWinningRelays() *winningRelays {
+	return &winningRelays{relays: make(map[[32]byte]*winningRelay)}
+}
+
+func (w *winningRelays) set(slot primitives.Slot, blockHash [32]byte, r *relay) {
+	w.Lock()
+	defer w.Unlock()
+	// Blinded blocks are submitted within the slot of the auction, older winners are not needed anymore.
+	for h, winner := range w.relays {
+		if winner.slot+params.BeaconConfig().SlotsPerEpoch < slot {
+			delete(w.relays, h)
+		}
+	}
+	w.relays[blockHash] = &winningRelay{slot: slot, relay: r}
+}
+
+func (w *winningRelays) get(blockHash [32]byte) (*relay, bool) {
+	w.Lock()
+	defer w.Unlock()
+	winner, ok := w.relays[blockHash]
+	if !ok {
+		return nil, false
+	}
+	return winner.relay, true
+}
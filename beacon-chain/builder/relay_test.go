@@ -0,0 +1,224 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/api/client/builder"
+	blockchainTesting "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	v1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+// testRelay is a builder client returning a fixed bid, and counting the calls made to it.
+type testRelay struct {
+	url         string
+	bid         builder.SignedBid
+	err         error
+	delay       time.Duration
+	registerErr error
+	submitted   atomic.Int32
+	registered  atomic.Int32
+}
+
+func (r *testRelay) NodeURL() string {
+	return r.url
+}
+
+func (r *testRelay) GetHeader(ctx context.Context, _ primitives.Slot, _ [32]byte, _ [48]byte) (builder.SignedBid, error) {
+	select {
+	case <-time.After(r.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return r.bid, r.err
+}
+
+func (r *testRelay) RegisterValidator(context.Context, []*eth.SignedValidatorRegistrationV1) error {
+	r.registered.Add(1)
+	return r.registerErr
+}
+
+func (r *testRelay) SubmitBlindedBlock(context.Context, interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, *v1.BlobsBundle, error) {
+	r.submitted.Add(1)
+	return nil, nil, nil
+}
+
+func (r *testRelay) Status(context.Context) error {
+	return nil
+}
+
+func testBid(t *testing.T, parentHash, blockHash [32]byte, value int64, validSignature bool) builder.SignedBid {
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	bid := &eth.BuilderBidCapella{
+		Header: &v1.ExecutionPayloadHeaderCapella{
+			ParentHash:       parentHash[:],
+			FeeRecipient:     make([]byte, fieldparams.FeeRecipientLength),
+			StateRoot:        make([]byte, fieldparams.RootLength),
+			ReceiptsRoot:     make([]byte, fieldparams.RootLength),
+			LogsBloom:        make([]byte, fieldparams.LogsBloomLength),
+			PrevRandao:       make([]byte, fieldparams.RootLength),
+			ExtraData:        make([]byte, 0),
+			BaseFeePerGas:    make([]byte, fieldparams.RootLength),
+			BlockHash:        blockHash[:],
+			TransactionsRoot: make([]byte, fieldparams.RootLength),
+			WithdrawalsRoot:  make([]byte, fieldparams.RootLength),
+		},
+		Pubkey: sk.PublicKey().Marshal(),
+		Value:  bytesutil.PadTo(bytesutil.ReverseByteOrder(big.NewInt(value).Bytes()), 32),
+	}
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainApplicationBuilder, nil, nil)
+	require.NoError(t, err)
+	sr, err := signing.ComputeSigningRoot(bid, domain)
+	require.NoError(t, err)
+	if !validSignature {
+		sr = [32]byte{'x'}
+	}
+	signed, err := builder.WrappedSignedBuilderBidCapella(&eth.SignedBuilderBidCapella{Message: bid, Signature: sk.Sign(sr[:]).Marshal()})
+	require.NoError(t, err)
+	return signed
+}
+
+func testBlindedBlock(t *testing.T, blockHash [32]byte) interfaces.ReadOnlySignedBeaconBlock {
+	b := util.NewBlindedBeaconBlockCapella()
+	b.Block.Body.ExecutionPayloadHeader.BlockHash = blockHash[:]
+	wb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	return wb
+}
+
+func Test_GetHeader_HighestBidWins(t *testing.T) {
+	ctx := context.Background()
+	parentHash := [32]byte{'p'}
+	low := &testRelay{url: "http://low:18550", bid: testBid(t, parentHash, [32]byte{'a'}, 1, true)}
+	invalid := &testRelay{url: "http://invalid:18550", bid: testBid(t, parentHash, [32]byte{'b'}, 3, false)}
+	wrongParent := &testRelay{url: "http://wrong-parent:18550", bid: testBid(t, [32]byte{'q'}, [32]byte{'c'}, 4, true)}
+	failing := &testRelay{url: "http://failing:18550", err: errors.New("no bid")}
+	high := &testRelay{url: "https://0xabcd@high:18550", bid: testBid(t, parentHash, [32]byte{'d'}, 2, true)}
+	s, err := NewService(ctx, WithBuilderClients(low, invalid, wrongParent, failing, high))
+	require.NoError(t, err)
+	assert.Equal(t, "high:18550", s.relays[4].name)
+
+	bid, err := s.GetHeader(ctx, 1, parentHash, [48]byte{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, high.bid, bid)
+
+	// The blinded block is only submitted to the relay of the winning bid.
+	_, _, err = s.SubmitBlindedBlock(ctx, testBlindedBlock(t, [32]byte{'d'}))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), high.submitted.Load())
+	assert.Equal(t, int32(0), low.submitted.Load())
+
+	// It is submitted to all relays when the winning bid is unknown.
+	_, _, err = s.SubmitBlindedBlock(ctx, testBlindedBlock(t, [32]byte{'z'}))
+	require.NoError(t, err)
+	// The first successful submission is returned without waiting for the other relays.
+	for _, r := range []*testRelay{low, invalid, wrongParent, failing} {
+		for r.submitted.Load() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	assert.Equal(t, int32(2), high.submitted.Load())
+}
+
+func Test_GetHeader_TiesGoToFirstRelay(t *testing.T) {
+	ctx := context.Background()
+	parentHash := [32]byte{'p'}
+	first := &testRelay{url: "http://first:18550", bid: testBid(t, parentHash, [32]byte{'a'}, 2, true), delay: 20 * time.Millisecond}
+	second := &testRelay{url: "http://second:18550", bid: testBid(t, parentHash, [32]byte{'b'}, 2, true)}
+	s, err := NewService(ctx, WithBuilderClients(first, second))
+	require.NoError(t, err)
+
+	bid, err := s.GetHeader(ctx, 1, parentHash, [48]byte{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, first.bid, bid)
+}
+
+func Test_GetHeader_Validate(t *testing.T) {
+	ctx := context.Background()
+	parentHash := [32]byte{'p'}
+	low := &testRelay{url: "http://low:18550", bid: testBid(t, parentHash, [32]byte{'a'}, 1, true)}
+	high := &testRelay{url: "http://high:18550", bid: testBid(t, parentHash, [32]byte{'b'}, 2, true)}
+	s, err := NewService(ctx, WithBuilderClients(low, high))
+	require.NoError(t, err)
+
+	// The highest bid fails the proposer's checks, so it can't take the place of the valid one.
+	validate := func(signedBid builder.SignedBid) error {
+		if signedBid == high.bid {
+			return errors.New("incorrect timestamp")
+		}
+		return nil
+	}
+	bid, err := s.GetHeader(ctx, 1, parentHash, [48]byte{}, validate)
+	require.NoError(t, err)
+	require.Equal(t, low.bid, bid)
+
+	_, err = s.GetHeader(ctx, 1, parentHash, [48]byte{}, func(builder.SignedBid) error {
+		return errors.New("incorrect timestamp")
+	})
+	require.ErrorContains(t, "no valid bid from 2 relays", err)
+	require.ErrorContains(t, "incorrect timestamp", err)
+}
+
+func Test_GetHeader_Deadline(t *testing.T) {
+	parentHash := [32]byte{'p'}
+	slow := &testRelay{url: "http://slow:18550", bid: testBid(t, parentHash, [32]byte{'a'}, 10, true), delay: time.Second}
+	fast := &testRelay{url: "http://fast:18550", bid: testBid(t, parentHash, [32]byte{'b'}, 1, true)}
+	s, err := NewService(context.Background(), WithBuilderClients(slow, fast))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	bid, err := s.GetHeader(ctx, 1, parentHash, [48]byte{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, fast.bid, bid)
+}
+
+func Test_GetHeader_NoValidBid(t *testing.T) {
+	ctx := context.Background()
+	parentHash := [32]byte{'p'}
+	s, err := NewService(ctx, WithBuilderClients(
+		&testRelay{url: "http://failing:18550", err: errors.New("no bid")},
+		&testRelay{url: "http://invalid:18550", bid: testBid(t, parentHash, [32]byte{'a'}, 1, false)},
+	))
+	require.NoError(t, err)
+
+	_, err = s.GetHeader(ctx, 1, parentHash, [48]byte{}, nil)
+	require.ErrorContains(t, "no valid bid from 2 relays", err)
+	require.ErrorContains(t, "no bid", err)
+	require.ErrorContains(t, "invalid builder signature", err)
+}
+
+func Test_RegisterValidator_MultipleRelays(t *testing.T) {
+	ctx := context.Background()
+	pubkey := bytesutil.ToBytes48([]byte("pubkey"))
+	reg := []*eth.SignedValidatorRegistrationV1{{Message: &eth.ValidatorRegistrationV1{Pubkey: pubkey[:], FeeRecipient: make([]byte, 20)}}}
+
+	ok := &testRelay{url: "http://ok:18550"}
+	failing := &testRelay{url: "http://failing:18550", registerErr: errors.New("unavailable")}
+	s, err := NewService(ctx, WithRegistrationCache(), WithHeadFetcher(&blockchainTesting.ChainService{}), WithBuilderClients(ok, failing))
+	require.NoError(t, err)
+	require.NoError(t, s.RegisterValidator(ctx, reg))
+	assert.Equal(t, int32(1), ok.registered.Load())
+	assert.Equal(t, int32(1), failing.registered.Load())
+
+	s, err = NewService(ctx, WithRegistrationCache(), WithHeadFetcher(&blockchainTesting.ChainService{}), WithBuilderClients(failing, failing))
+	require.NoError(t, err)
+	require.ErrorContains(t, "unavailable", s.RegisterValidator(ctx, reg))
+}
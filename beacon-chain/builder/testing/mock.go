@@ -71,7 +71,18 @@ func (s *MockBuilderService) SubmitBlindedBlock(_ context.Context, b interfaces.
 }
 
 // GetHeader for mocking.
-func (s *MockBuilderService) GetHeader(_ context.Context, slot primitives.Slot, _ [32]byte, _ [48]byte) (builder.SignedBid, error) {
+func (s *MockBuilderService) GetHeader(_ context.Context, slot primitives.Slot, _ [32]byte, _ [48]byte, validate func(builder.SignedBid) error) (builder.SignedBid, error) {
+	w, err := s.bid(slot)
+	if err != nil || validate == nil {
+		return w, err
+	}
+	if err := validate(w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (s *MockBuilderService) bid(slot primitives.Slot) (builder.SignedBid, error) {
 	if slots.ToEpoch(slot) >= params.BeaconConfig().DenebForkEpoch || s.BidDeneb != nil {
 		return builder.WrappedSignedBuilderBidDeneb(s.BidDeneb)
 	}
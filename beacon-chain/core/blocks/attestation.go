@@ -205,6 +205,26 @@ func ProcessAttestationNoVerifySignature(
 	return beaconState, nil
 }
 
+// VerifyAttestationSignature converts an attestation into an indexed attestation, using the committees
+// of the given state, and verifies its aggregate signature.
+func VerifyAttestationSignature(ctx context.Context, beaconState state.ReadOnlyBeaconState, att ethpb.Att) error {
+	ctx, span := trace.StartSpan(ctx, "core.VerifyAttestationSignature")
+	defer span.End()
+
+	if err := helpers.ValidateNilAttestation(att); err != nil {
+		return err
+	}
+	committees, err := helpers.AttestationCommittees(ctx, beaconState, att)
+	if err != nil {
+		return err
+	}
+	indexedAtt, err := attestation.ConvertToIndexed(ctx, att, committees...)
+	if err != nil {
+		return err
+	}
+	return VerifyIndexedAttestation(ctx, beaconState, indexedAtt)
+}
+
 // VerifyIndexedAttestation determines the validity of an indexed attestation.
 //
 // Spec pseudocode definition:
@@ -8,6 +8,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	state_native "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -566,6 +567,83 @@ func TestVerifyAttestations_HandlesPlannedFork(t *testing.T) {
 	att2.Signature = bls.AggregateSignatures(sigs).Marshal()
 }
 
+func TestVerifyAttestationSignature(t *testing.T) {
+	ctx := context.Background()
+	numOfValidators := uint64(params.BeaconConfig().SlotsPerEpoch.Mul(4))
+	validators := make([]*ethpb.Validator, numOfValidators)
+	_, keys, err := util.DeterministicDepositsAndKeys(numOfValidators)
+	require.NoError(t, err)
+	for i := 0; i < len(validators); i++ {
+		validators[i] = &ethpb.Validator{
+			ExitEpoch:             params.BeaconConfig().FarFutureEpoch,
+			PublicKey:             keys[i].PublicKey().Marshal(),
+			WithdrawalCredentials: make([]byte, 32),
+		}
+	}
+	sign := func(st state.ReadOnlyBeaconState, att ethpb.Att, committee []primitives.ValidatorIndex) {
+		domain, err := signing.Domain(st.Fork(), st.Fork().Epoch, params.BeaconConfig().DomainBeaconAttester, st.GenesisValidatorsRoot())
+		require.NoError(t, err)
+		root, err := signing.ComputeSigningRoot(att.GetData(), domain)
+		require.NoError(t, err)
+		var sigs []bls.Signature
+		for i, u := range committee {
+			if att.GetAggregationBits().BitAt(uint64(i)) {
+				sigs = append(sigs, keys[u].Sign(root[:]))
+			}
+		}
+		att.SetSignature(bls.AggregateSignatures(sigs).Marshal())
+	}
+
+	t.Run("pre-Electra", func(t *testing.T) {
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(5))
+		require.NoError(t, st.SetValidators(validators))
+
+		comm, err := helpers.BeaconCommitteeFromState(ctx, st, 1 /*slot*/, 1 /*committeeIndex*/)
+		require.NoError(t, err)
+		att := util.HydrateAttestation(&ethpb.Attestation{
+			AggregationBits: bitfield.NewBitlist(uint64(len(comm))),
+			Data: &ethpb.AttestationData{
+				Slot:           1,
+				CommitteeIndex: 1,
+			},
+		})
+		att.AggregationBits.SetBitAt(0, true)
+		att.AggregationBits.SetBitAt(2, true)
+		sign(st, att, comm)
+		require.NoError(t, blocks.VerifyAttestationSignature(ctx, st, att))
+
+		// The signature does not match the attesters of another committee.
+		att.Data.CommitteeIndex = 0
+		require.ErrorContains(t, "signature did not verify", blocks.VerifyAttestationSignature(ctx, st, att))
+	})
+	t.Run("post-Electra", func(t *testing.T) {
+		st, err := util.NewBeaconStateElectra()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(5))
+		require.NoError(t, st.SetValidators(validators))
+
+		comm, err := helpers.BeaconCommitteeFromState(ctx, st, 1 /*slot*/, 1 /*committeeIndex*/)
+		require.NoError(t, err)
+		commBits := primitives.NewAttestationCommitteeBits()
+		commBits.SetBitAt(1, true)
+		att := util.HydrateAttestationElectra(&ethpb.AttestationElectra{
+			AggregationBits: bitfield.NewBitlist(uint64(len(comm))),
+			CommitteeBits:   commBits,
+			Data: &ethpb.AttestationData{
+				Slot: 1,
+			},
+		})
+		att.AggregationBits.SetBitAt(1, true)
+		sign(st, att, comm)
+		require.NoError(t, blocks.VerifyAttestationSignature(ctx, st, att))
+
+		att.AggregationBits.SetBitAt(0, true)
+		require.ErrorContains(t, "signature did not verify", blocks.VerifyAttestationSignature(ctx, st, att))
+	})
+}
+
 func TestRetrieveAttestationSignatureSet_VerifiesMultipleAttestations(t *testing.T) {
 	ctx := context.Background()
 	numOfValidators := uint64(params.BeaconConfig().SlotsPerEpoch.Mul(4))
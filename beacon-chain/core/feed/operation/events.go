@@ -3,6 +3,7 @@ package operation
 
 import (
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 )
 
@@ -32,6 +33,13 @@ const (
 
 	// AttesterSlashingReceived is sent after an attester slashing is received from gossip or rpc
 	AttesterSlashingReceived = 8
+
+	// BlockGossipReceived is sent after a block passed gossip validation, before it is imported.
+	BlockGossipReceived = 9
+
+	// SingleAttReceived is sent after an unaggregated attestation since Electra passed gossip validation,
+	// with the index of its attester.
+	SingleAttReceived = 10
 )
 
 // UnAggregatedAttReceivedData is the data sent with UnaggregatedAttReceived events.
@@ -77,3 +85,15 @@ type ProposerSlashingReceivedData struct {
 type AttesterSlashingReceivedData struct {
 	AttesterSlashing ethpb.AttSlashing
 }
+
+// BlockGossipReceivedData is the data sent with BlockGossipReceived events.
+type BlockGossipReceivedData struct {
+	// SignedBlock is the block received from gossip.
+	SignedBlock interfaces.ReadOnlySignedBeaconBlock
+}
+
+// SingleAttReceivedData is the data sent with SingleAttReceived events.
+type SingleAttReceivedData struct {
+	// Attestation is the attestation of a single validator, as sent on gossip since Electra.
+	Attestation *ethpb.SingleAttestation
+}
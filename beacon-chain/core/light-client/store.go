@@ -0,0 +1,662 @@
+package light_client
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+	fssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	light_client "github.com/prysmaticlabs/prysm/v5/consensus-types/light-client"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/container/trie"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/network/forks"
+	enginev1 "github.com/prysmaticlabs/prysm/v5/proto/engine/v1"
+	pb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"google.golang.org/protobuf/proto"
+)
+
+// Generalized indices of the light client proofs in the beacon state and block body.
+const (
+	currentSyncCommitteeGIndex        = 54
+	nextSyncCommitteeGIndex           = 55
+	finalizedRootGIndex               = 105
+	currentSyncCommitteeGIndexElectra = 86
+	nextSyncCommitteeGIndexElectra    = 87
+	finalizedRootGIndexElectra        = 169
+	executionPayloadGIndex            = 25
+)
+
+var (
+	// ErrInvalidBootstrap is returned when a bootstrap does not match the trusted block root or has an invalid proof.
+	ErrInvalidBootstrap = errors.New("invalid light client bootstrap")
+	// ErrInvalidUpdate is returned when an update cannot be applied to the light client store.
+	ErrInvalidUpdate = errors.New("invalid light client update")
+)
+
+// Store is the state of a light client following the chain, as defined by the light client sync protocol.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#lightclientstore
+type Store struct {
+	// FinalizedHeader is the header of the latest finalized block the light client knows of.
+	FinalizedHeader interfaces.LightClientHeader
+	// CurrentSyncCommittee is the sync committee of the period of the finalized header.
+	CurrentSyncCommittee *pb.SyncCommittee
+	// NextSyncCommittee is the sync committee of the period after the finalized header, nil while it is not known.
+	NextSyncCommittee *pb.SyncCommittee
+	// BestValidUpdate is the best update seen since the finalized header was last updated, applied after a timeout
+	// when the chain does not finalize.
+	BestValidUpdate interfaces.LightClientUpdate
+	// OptimisticHeader is the header of the latest block the light client knows of.
+	OptimisticHeader interfaces.LightClientHeader
+	// PreviousMaxActiveParticipants and CurrentMaxActiveParticipants track the maximum sync committee participation
+	// of the updates seen in the previous and current periods, from which the safety threshold is derived.
+	PreviousMaxActiveParticipants uint64
+	CurrentMaxActiveParticipants  uint64
+}
+
+// NewStore initializes a light client store from the bootstrap of the block with the trusted root.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#initialize_light_client_store
+func NewStore(trustedBlockRoot [32]byte, bootstrap interfaces.LightClientBootstrap) (*Store, error) {
+	header := bootstrap.Header()
+	if err := validateHeader(header); err != nil {
+		return nil, errors.Wrap(err, "invalid bootstrap header")
+	}
+	root, err := header.Beacon().HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute bootstrap header root")
+	}
+	if root != trustedBlockRoot {
+		return nil, errors.Wrapf(ErrInvalidBootstrap, "header root %#x does not match trusted block root %#x", root, trustedBlockRoot)
+	}
+
+	var branch [][]byte
+	var gIndex uint64
+	if bootstrap.Version() >= version.Electra {
+		b, err := bootstrap.CurrentSyncCommitteeBranchElectra()
+		if err != nil {
+			return nil, err
+		}
+		branch, gIndex = branchToBytes(b[:]), currentSyncCommitteeGIndexElectra
+	} else {
+		b, err := bootstrap.CurrentSyncCommitteeBranch()
+		if err != nil {
+			return nil, err
+		}
+		branch, gIndex = branchToBytes(b[:]), currentSyncCommitteeGIndex
+	}
+	if !verifyBranch(header.Beacon().StateRoot, bootstrap.CurrentSyncCommittee(), gIndex, branch) {
+		return nil, errors.Wrap(ErrInvalidBootstrap, "invalid current sync committee branch")
+	}
+
+	return &Store{
+		FinalizedHeader:      header,
+		CurrentSyncCommittee: bootstrap.CurrentSyncCommittee(),
+		OptimisticHeader:     header,
+	}, nil
+}
+
+// ValidateUpdate checks that the update can be applied to the store: that it is relevant, that its finalized header
+// and next sync committee are proven against the state of its attested header, and that a known sync committee
+// signed its attested header.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#validate_light_client_update
+func (s *Store) ValidateUpdate(update interfaces.LightClientUpdate, currentSlot primitives.Slot, genesisValidatorsRoot []byte) error {
+	syncAggregate := update.SyncAggregate()
+	if syncAggregate == nil {
+		return errors.Wrap(ErrInvalidUpdate, "missing sync aggregate")
+	}
+	participants := syncAggregate.SyncCommitteeBits.Count()
+	if participants < params.BeaconConfig().MinSyncCommitteeParticipants {
+		return errors.Wrapf(ErrInvalidUpdate, "%d sync committee participants is less than the minimum of %d", participants, params.BeaconConfig().MinSyncCommitteeParticipants)
+	}
+
+	attestedHeader := update.AttestedHeader()
+	if err := validateHeader(attestedHeader); err != nil {
+		return errors.Wrap(err, "invalid attested header")
+	}
+	attestedSlot := attestedHeader.Beacon().Slot
+	finalizedSlot := update.FinalizedHeader().Beacon().Slot
+	if currentSlot < update.SignatureSlot() || update.SignatureSlot() <= attestedSlot || attestedSlot < finalizedSlot {
+		return errors.Wrapf(ErrInvalidUpdate, "inconsistent slots: current=%d signature=%d attested=%d finalized=%d", currentSlot, update.SignatureSlot(), attestedSlot, finalizedSlot)
+	}
+	storePeriod := syncCommitteePeriodAtSlot(s.FinalizedHeader.Beacon().Slot)
+	signaturePeriod := syncCommitteePeriodAtSlot(update.SignatureSlot())
+	if signaturePeriod != storePeriod && (s.NextSyncCommittee == nil || signaturePeriod != storePeriod+1) {
+		return errors.Wrapf(ErrInvalidUpdate, "signature period %d is not covered by the sync committees known at period %d", signaturePeriod, storePeriod)
+	}
+
+	attestedPeriod := syncCommitteePeriodAtSlot(attestedSlot)
+	isSyncCommitteeUpdate, err := HasRelevantSyncCommittee(update)
+	if err != nil {
+		return err
+	}
+	hasNextSyncCommittee := s.NextSyncCommittee == nil && isSyncCommitteeUpdate && attestedPeriod == storePeriod
+	if attestedSlot <= s.FinalizedHeader.Beacon().Slot && !hasNextSyncCommittee {
+		return errors.Wrap(ErrInvalidUpdate, "update is not newer than the finalized header and has no new sync committee")
+	}
+
+	// Without a proof, the finalized header and the next sync committee must be empty, as they are in finality and
+	// optimistic updates.
+	isFinalityUpdate, err := HasFinality(update)
+	if err != nil {
+		return err
+	}
+	if !isFinalityUpdate {
+		empty, err := isEmptyHeader(update.FinalizedHeader())
+		if err != nil {
+			return err
+		}
+		if !empty {
+			return errors.Wrap(ErrInvalidUpdate, "finalized header without a finality branch")
+		}
+	} else {
+		// The genesis finalized checkpoint root is represented as a zero hash.
+		var finalizedRoot [32]byte
+		if finalizedSlot != params.BeaconConfig().GenesisSlot {
+			if err := validateHeader(update.FinalizedHeader()); err != nil {
+				return errors.Wrap(err, "invalid finalized header")
+			}
+			finalizedRoot, err = update.FinalizedHeader().Beacon().HashTreeRoot()
+			if err != nil {
+				return errors.Wrap(err, "could not compute finalized header root")
+			}
+		}
+		branch, gIndex, err := finalityBranch(update)
+		if err != nil {
+			return err
+		}
+		if !verifyBranch(attestedHeader.Beacon().StateRoot, rootLeaf(finalizedRoot), gIndex, branch) {
+			return errors.Wrap(ErrInvalidUpdate, "invalid finality branch")
+		}
+	}
+	if !isSyncCommitteeUpdate {
+		if sc := update.NextSyncCommittee(); sc != nil && !proto.Equal(sc, emptySyncCommittee()) {
+			return errors.Wrap(ErrInvalidUpdate, "next sync committee without a next sync committee branch")
+		}
+	} else {
+		if attestedPeriod == storePeriod && s.NextSyncCommittee != nil && !proto.Equal(update.NextSyncCommittee(), s.NextSyncCommittee) {
+			return errors.Wrap(ErrInvalidUpdate, "next sync committee does not match the known one")
+		}
+		branch, gIndex, err := nextSyncCommitteeBranch(update)
+		if err != nil {
+			return err
+		}
+		if !verifyBranch(attestedHeader.Beacon().StateRoot, update.NextSyncCommittee(), gIndex, branch) {
+			return errors.Wrap(ErrInvalidUpdate, "invalid next sync committee branch")
+		}
+	}
+
+	committee := s.CurrentSyncCommittee
+	if signaturePeriod != storePeriod {
+		committee = s.NextSyncCommittee
+	}
+	return verifySyncAggregate(committee, syncAggregate, update.SignatureSlot(), attestedHeader.Beacon(), genesisValidatorsRoot)
+}
+
+// ProcessUpdate validates the update and updates the store with it: the optimistic header advances once more than
+// half of the recent maximum participation signed the update, and the finalized header once a supermajority did.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#process_light_client_update
+func (s *Store) ProcessUpdate(update interfaces.LightClientUpdate, currentSlot primitives.Slot, genesisValidatorsRoot []byte) error {
+	if err := s.ValidateUpdate(update, currentSlot, genesisValidatorsRoot); err != nil {
+		return err
+	}
+	bits := update.SyncAggregate().SyncCommitteeBits
+	participants := bits.Count()
+
+	// Keep the best update in case the store has to be force updated with it after the update timeout.
+	if s.BestValidUpdate == nil {
+		s.BestValidUpdate = update
+	} else {
+		better, err := IsBetterUpdate(update, s.BestValidUpdate)
+		if err != nil {
+			return err
+		}
+		if better {
+			s.BestValidUpdate = update
+		}
+	}
+
+	s.CurrentMaxActiveParticipants = max(s.CurrentMaxActiveParticipants, participants)
+	if participants > s.safetyThreshold() && update.AttestedHeader().Beacon().Slot > s.OptimisticHeader.Beacon().Slot {
+		s.OptimisticHeader = update.AttestedHeader()
+	}
+
+	isSyncCommitteeUpdate, err := HasRelevantSyncCommittee(update)
+	if err != nil {
+		return err
+	}
+	isFinalityUpdate, err := HasFinality(update)
+	if err != nil {
+		return err
+	}
+	hasFinalizedNextSyncCommittee := s.NextSyncCommittee == nil && isSyncCommitteeUpdate && isFinalityUpdate &&
+		syncCommitteePeriodAtSlot(update.FinalizedHeader().Beacon().Slot) == syncCommitteePeriodAtSlot(update.AttestedHeader().Beacon().Slot)
+	if participants*3 >= bits.Len()*2 && (update.FinalizedHeader().Beacon().Slot > s.FinalizedHeader.Beacon().Slot || hasFinalizedNextSyncCommittee) {
+		if err := s.applyUpdate(update); err != nil {
+			return err
+		}
+		s.BestValidUpdate = nil
+	}
+	return nil
+}
+
+// ProcessFinalityUpdate processes a finality update, which is an update without a next sync committee.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#process_light_client_finality_update
+func (s *Store) ProcessFinalityUpdate(update interfaces.LightClientFinalityUpdate, currentSlot primitives.Slot, genesisValidatorsRoot []byte) error {
+	u, err := updateFromFinalityUpdate(update)
+	if err != nil {
+		return err
+	}
+	return s.ProcessUpdate(u, currentSlot, genesisValidatorsRoot)
+}
+
+// ProcessOptimisticUpdate processes an optimistic update, which is an update without a next sync committee nor
+// a finalized header.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#process_light_client_optimistic_update
+func (s *Store) ProcessOptimisticUpdate(update interfaces.LightClientOptimisticUpdate, currentSlot primitives.Slot, genesisValidatorsRoot []byte) error {
+	u, err := updateFromOptimisticUpdate(update)
+	if err != nil {
+		return err
+	}
+	return s.ProcessUpdate(u, currentSlot, genesisValidatorsRoot)
+}
+
+// ProcessForceUpdate applies the best valid update when the chain did not finalize for a whole sync committee period,
+// so that the light client can move on to the next sync committees. The attested header of the update is then
+// treated as finalized if its finalized header is not newer than the store's one.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#process_light_client_store_force_update
+func (s *Store) ProcessForceUpdate(currentSlot primitives.Slot) error {
+	cfg := params.BeaconConfig()
+	updateTimeout := cfg.SlotsPerEpoch.Mul(uint64(cfg.EpochsPerSyncCommitteePeriod))
+	if currentSlot <= s.FinalizedHeader.Beacon().Slot+updateTimeout || s.BestValidUpdate == nil {
+		return nil
+	}
+	if s.BestValidUpdate.FinalizedHeader().Beacon().Slot <= s.FinalizedHeader.Beacon().Slot {
+		if err := s.BestValidUpdate.SetFinalizedHeader(s.BestValidUpdate.AttestedHeader()); err != nil {
+			return err
+		}
+	}
+	if err := s.applyUpdate(s.BestValidUpdate); err != nil {
+		return err
+	}
+	s.BestValidUpdate = nil
+	return nil
+}
+
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#apply_light_client_update
+func (s *Store) applyUpdate(update interfaces.LightClientUpdate) error {
+	storePeriod := syncCommitteePeriodAtSlot(s.FinalizedHeader.Beacon().Slot)
+	finalizedPeriod := syncCommitteePeriodAtSlot(update.FinalizedHeader().Beacon().Slot)
+	isSyncCommitteeUpdate, err := HasRelevantSyncCommittee(update)
+	if err != nil {
+		return err
+	}
+	var nextSyncCommittee *pb.SyncCommittee
+	if isSyncCommitteeUpdate {
+		nextSyncCommittee = update.NextSyncCommittee()
+	}
+
+	if s.NextSyncCommittee == nil {
+		if finalizedPeriod != storePeriod {
+			return errors.Wrapf(ErrInvalidUpdate, "finalized period %d is not the store period %d", finalizedPeriod, storePeriod)
+		}
+		s.NextSyncCommittee = nextSyncCommittee
+	} else if finalizedPeriod == storePeriod+1 {
+		s.CurrentSyncCommittee = s.NextSyncCommittee
+		s.NextSyncCommittee = nextSyncCommittee
+		s.PreviousMaxActiveParticipants = s.CurrentMaxActiveParticipants
+		s.CurrentMaxActiveParticipants = 0
+	}
+	if update.FinalizedHeader().Beacon().Slot > s.FinalizedHeader.Beacon().Slot {
+		s.FinalizedHeader = update.FinalizedHeader()
+		if s.FinalizedHeader.Beacon().Slot > s.OptimisticHeader.Beacon().Slot {
+			s.OptimisticHeader = s.FinalizedHeader
+		}
+	}
+	return nil
+}
+
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/sync-protocol.md#get_safety_threshold
+func (s *Store) safetyThreshold() uint64 {
+	return max(s.PreviousMaxActiveParticipants, s.CurrentMaxActiveParticipants) / 2
+}
+
+// validateHeader checks that the execution payload header, if any, is proven against the block body.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/capella/light-client/sync-protocol.md#modified-is_valid_light_client_header
+func validateHeader(header interfaces.LightClientHeader) error {
+	if header.Version() < version.Capella {
+		return nil
+	}
+	payload, err := header.Execution()
+	if err != nil {
+		return err
+	}
+	b, err := header.ExecutionBranch()
+	if err != nil {
+		return err
+	}
+	branch := branchToBytes(b[:])
+
+	epoch := slots.ToEpoch(header.Beacon().Slot)
+	if header.Version() >= version.Deneb && epoch < params.BeaconConfig().DenebForkEpoch {
+		blobGasUsed, err := payload.BlobGasUsed()
+		if err != nil {
+			return err
+		}
+		excessBlobGas, err := payload.ExcessBlobGas()
+		if err != nil {
+			return err
+		}
+		if blobGasUsed != 0 || excessBlobGas != 0 {
+			return errors.Wrap(ErrInvalidUpdate, "header before deneb has blob gas fields")
+		}
+	}
+	if epoch < params.BeaconConfig().CapellaForkEpoch {
+		empty, err := execution.EmptyExecutionPayloadHeader(header.Version())
+		if err != nil {
+			return err
+		}
+		emptyRoot, err := empty.(fssz.HashRoot).HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		root, err := payload.HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		if root != emptyRoot || b != (interfaces.LightClientExecutionBranch{}) {
+			return errors.Wrap(ErrInvalidUpdate, "header before capella has an execution payload header")
+		}
+		return nil
+	}
+	if !verifyBranch(header.Beacon().BodyRoot, payload, executionPayloadGIndex, branch) {
+		return errors.Wrap(ErrInvalidUpdate, "invalid execution branch")
+	}
+	return nil
+}
+
+// isEmptyHeader reports whether the header is the default light client header of its version.
+func isEmptyHeader(header interfaces.LightClientHeader) (bool, error) {
+	if !proto.Equal(header.Beacon(), emptyBeaconBlockHeader()) {
+		return false, nil
+	}
+	if header.Version() < version.Capella {
+		return true, nil
+	}
+	payload, err := header.Execution()
+	if err != nil {
+		return false, err
+	}
+	b, err := header.ExecutionBranch()
+	if err != nil {
+		return false, err
+	}
+	empty, err := execution.EmptyExecutionPayloadHeader(header.Version())
+	if err != nil {
+		return false, err
+	}
+	emptyRoot, err := empty.(fssz.HashRoot).HashTreeRoot()
+	if err != nil {
+		return false, err
+	}
+	root, err := payload.HashTreeRoot()
+	if err != nil {
+		return false, err
+	}
+	return root == emptyRoot && b == (interfaces.LightClientExecutionBranch{}), nil
+}
+
+func verifySyncAggregate(committee *pb.SyncCommittee, syncAggregate *pb.SyncAggregate, signatureSlot primitives.Slot, header *pb.BeaconBlockHeader, genesisValidatorsRoot []byte) error {
+	bits := syncAggregate.SyncCommitteeBits
+	if committee == nil || bits.Len() != uint64(len(committee.Pubkeys)) {
+		return errors.Wrap(ErrInvalidUpdate, "sync committee bits do not match the sync committee")
+	}
+	pubKeys := make([]bls.PublicKey, 0, bits.Count())
+	for i, pk := range committee.Pubkeys {
+		if !bits.BitAt(uint64(i)) {
+			continue
+		}
+		pubKey, err := bls.PublicKeyFromBytes(pk)
+		if err != nil {
+			return errors.Wrap(err, "could not decode sync committee public key")
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+	sig, err := bls.SignatureFromBytes(syncAggregate.SyncCommitteeSignature)
+	if err != nil {
+		return errors.Wrap(err, "could not decode sync committee signature")
+	}
+
+	// The sync committee signs the block of the slot before the signature slot.
+	forkVersionSlot := max(signatureSlot, 1) - 1
+	fork, err := forks.Fork(slots.ToEpoch(forkVersionSlot))
+	if err != nil {
+		return err
+	}
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainSyncCommittee, fork.CurrentVersion, genesisValidatorsRoot)
+	if err != nil {
+		return err
+	}
+	signingRoot, err := signing.ComputeSigningRoot(header, domain)
+	if err != nil {
+		return err
+	}
+	if !sig.FastAggregateVerify(pubKeys, signingRoot) {
+		return errors.Wrap(ErrInvalidUpdate, "invalid sync committee signature")
+	}
+	return nil
+}
+
+func finalityBranch(update interfaces.LightClientUpdate) ([][]byte, uint64, error) {
+	if update.Version() >= version.Electra {
+		b, err := update.FinalityBranchElectra()
+		if err != nil {
+			return nil, 0, err
+		}
+		return branchToBytes(b[:]), finalizedRootGIndexElectra, nil
+	}
+	b, err := update.FinalityBranch()
+	if err != nil {
+		return nil, 0, err
+	}
+	return branchToBytes(b[:]), finalizedRootGIndex, nil
+}
+
+func nextSyncCommitteeBranch(update interfaces.LightClientUpdate) ([][]byte, uint64, error) {
+	if update.Version() >= version.Electra {
+		b, err := update.NextSyncCommitteeBranchElectra()
+		if err != nil {
+			return nil, 0, err
+		}
+		return branchToBytes(b[:]), nextSyncCommitteeGIndexElectra, nil
+	}
+	b, err := update.NextSyncCommitteeBranch()
+	if err != nil {
+		return nil, 0, err
+	}
+	return branchToBytes(b[:]), nextSyncCommitteeGIndex, nil
+}
+
+// verifyBranch checks the merkle branch of the object at the generalized index against the root.
+func verifyBranch(root []byte, obj fssz.HashRoot, gIndex uint64, branch [][]byte) bool {
+	leaf, err := obj.HashTreeRoot()
+	if err != nil {
+		return false
+	}
+	return trie.VerifyMerkleProof(root, leaf[:], gIndex, branch)
+}
+
+// rootLeaf wraps a root to verify it as a merkle branch leaf.
+type rootLeaf [32]byte
+
+func (r rootLeaf) HashTreeRoot() ([32]byte, error) { return r, nil }
+
+func (r rootLeaf) HashTreeRootWith(*fssz.Hasher) error {
+	return errors.New("not implemented")
+}
+
+func branchToBytes(branch [][32]byte) [][]byte {
+	result := make([][]byte, len(branch))
+	for i := range branch {
+		result[i] = bytes.Clone(branch[i][:])
+	}
+	return result
+}
+
+func syncCommitteePeriodAtSlot(slot primitives.Slot) uint64 {
+	return slots.SyncCommitteePeriod(slots.ToEpoch(slot))
+}
+
+// updateFromFinalityUpdate converts the finality update to an update with an empty next sync committee.
+func updateFromFinalityUpdate(update interfaces.LightClientFinalityUpdate) (interfaces.LightClientUpdate, error) {
+	var m proto.Message
+	switch p := update.Proto().(type) {
+	case *pb.LightClientFinalityUpdateAltair:
+		m = &pb.LightClientUpdateAltair{
+			AttestedHeader:          p.AttestedHeader,
+			NextSyncCommittee:       emptySyncCommittee(),
+			NextSyncCommitteeBranch: emptyBranch(fieldparams.SyncCommitteeBranchDepth),
+			FinalizedHeader:         p.FinalizedHeader,
+			FinalityBranch:          p.FinalityBranch,
+			SyncAggregate:           p.SyncAggregate,
+			SignatureSlot:           p.SignatureSlot,
+		}
+	case *pb.LightClientFinalityUpdateCapella:
+		m = &pb.LightClientUpdateCapella{
+			AttestedHeader:          p.AttestedHeader,
+			NextSyncCommittee:       emptySyncCommittee(),
+			NextSyncCommitteeBranch: emptyBranch(fieldparams.SyncCommitteeBranchDepth),
+			FinalizedHeader:         p.FinalizedHeader,
+			FinalityBranch:          p.FinalityBranch,
+			SyncAggregate:           p.SyncAggregate,
+			SignatureSlot:           p.SignatureSlot,
+		}
+	case *pb.LightClientFinalityUpdateDeneb:
+		m = &pb.LightClientUpdateDeneb{
+			AttestedHeader:          p.AttestedHeader,
+			NextSyncCommittee:       emptySyncCommittee(),
+			NextSyncCommitteeBranch: emptyBranch(fieldparams.SyncCommitteeBranchDepth),
+			FinalizedHeader:         p.FinalizedHeader,
+			FinalityBranch:          p.FinalityBranch,
+			SyncAggregate:           p.SyncAggregate,
+			SignatureSlot:           p.SignatureSlot,
+		}
+	case *pb.LightClientFinalityUpdateElectra:
+		m = &pb.LightClientUpdateElectra{
+			AttestedHeader:          p.AttestedHeader,
+			NextSyncCommittee:       emptySyncCommittee(),
+			NextSyncCommitteeBranch: emptyBranch(fieldparams.SyncCommitteeBranchDepthElectra),
+			FinalizedHeader:         p.FinalizedHeader,
+			FinalityBranch:          p.FinalityBranch,
+			SyncAggregate:           p.SyncAggregate,
+			SignatureSlot:           p.SignatureSlot,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported light client finality update type %T", p)
+	}
+	return light_client.NewWrappedUpdate(m)
+}
+
+// updateFromOptimisticUpdate converts the optimistic update to an update with an empty next sync committee and
+// an empty finalized header.
+func updateFromOptimisticUpdate(update interfaces.LightClientOptimisticUpdate) (interfaces.LightClientUpdate, error) {
+	var m proto.Message
+	switch p := update.Proto().(type) {
+	case *pb.LightClientOptimisticUpdateAltair:
+		m = &pb.LightClientUpdateAltair{
+			AttestedHeader:          p.AttestedHeader,
+			NextSyncCommittee:       emptySyncCommittee(),
+			NextSyncCommitteeBranch: emptyBranch(fieldparams.SyncCommitteeBranchDepth),
+			FinalizedHeader:         &pb.LightClientHeaderAltair{Beacon: emptyBeaconBlockHeader()},
+			FinalityBranch:          emptyBranch(fieldparams.FinalityBranchDepth),
+			SyncAggregate:           p.SyncAggregate,
+			SignatureSlot:           p.SignatureSlot,
+		}
+	case *pb.LightClientOptimisticUpdateCapella:
+		payloadHeader, err := execution.EmptyExecutionPayloadHeader(version.Capella)
+		if err != nil {
+			return nil, err
+		}
+		m = &pb.LightClientUpdateCapella{
+			AttestedHeader:          p.AttestedHeader,
+			NextSyncCommittee:       emptySyncCommittee(),
+			NextSyncCommitteeBranch: emptyBranch(fieldparams.SyncCommitteeBranchDepth),
+			FinalizedHeader: &pb.LightClientHeaderCapella{
+				Beacon:          emptyBeaconBlockHeader(),
+				Execution:       payloadHeader.(*enginev1.ExecutionPayloadHeaderCapella),
+				ExecutionBranch: emptyPayloadProof(),
+			},
+			FinalityBranch: emptyBranch(fieldparams.FinalityBranchDepth),
+			SyncAggregate:  p.SyncAggregate,
+			SignatureSlot:  p.SignatureSlot,
+		}
+	case *pb.LightClientOptimisticUpdateDeneb:
+		payloadHeader, err := execution.EmptyExecutionPayloadHeader(version.Deneb)
+		if err != nil {
+			return nil, err
+		}
+		finalizedHeader := &pb.LightClientHeaderDeneb{
+			Beacon:          emptyBeaconBlockHeader(),
+			Execution:       payloadHeader.(*enginev1.ExecutionPayloadHeaderDeneb),
+			ExecutionBranch: emptyPayloadProof(),
+		}
+		// The optimistic update type did not change in Electra, unlike the update type.
+		if update.Version() >= version.Electra || slots.ToEpoch(p.AttestedHeader.Beacon.Slot) >= params.BeaconConfig().ElectraForkEpoch {
+			m = &pb.LightClientUpdateElectra{
+				AttestedHeader:          p.AttestedHeader,
+				NextSyncCommittee:       emptySyncCommittee(),
+				NextSyncCommitteeBranch: emptyBranch(fieldparams.SyncCommitteeBranchDepthElectra),
+				FinalizedHeader:         finalizedHeader,
+				FinalityBranch:          emptyBranch(fieldparams.FinalityBranchDepthElectra),
+				SyncAggregate:           p.SyncAggregate,
+				SignatureSlot:           p.SignatureSlot,
+			}
+		} else {
+			m = &pb.LightClientUpdateDeneb{
+				AttestedHeader:          p.AttestedHeader,
+				NextSyncCommittee:       emptySyncCommittee(),
+				NextSyncCommitteeBranch: emptyBranch(fieldparams.SyncCommitteeBranchDepth),
+				FinalizedHeader:         finalizedHeader,
+				FinalityBranch:          emptyBranch(fieldparams.FinalityBranchDepth),
+				SyncAggregate:           p.SyncAggregate,
+				SignatureSlot:           p.SignatureSlot,
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported light client optimistic update type %T", p)
+	}
+	return light_client.NewWrappedUpdate(m)
+}
+
+func emptySyncCommittee() *pb.SyncCommittee {
+	pubKeys := make([][]byte, params.BeaconConfig().SyncCommitteeSize)
+	for i := range pubKeys {
+		pubKeys[i] = make([]byte, fieldparams.BLSPubkeyLength)
+	}
+	return &pb.SyncCommittee{
+		Pubkeys:         pubKeys,
+		AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
+	}
+}
+
+func emptyBeaconBlockHeader() *pb.BeaconBlockHeader {
+	return &pb.BeaconBlockHeader{
+		ParentRoot: make([]byte, fieldparams.RootLength),
+		StateRoot:  make([]byte, fieldparams.RootLength),
+		BodyRoot:   make([]byte, fieldparams.RootLength),
+	}
+}
+
+func emptyBranch(depth int) [][]byte {
+	branch := make([][]byte, depth)
+	for i := range branch {
+		branch[i] = make([]byte, fieldparams.RootLength)
+	}
+	return branch
+}
@@ -0,0 +1,267 @@
+package light_client_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	light_client "github.com/prysmaticlabs/prysm/v5/consensus-types/light-client"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/network/forks"
+	pb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/interop"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+var testGenesisValidatorsRoot = make([]byte, 32)
+
+// testChain holds the sync committee keys and the headers of a chain signed by that sync committee.
+type testChain struct {
+	t         *testing.T
+	keys      []bls.SecretKey
+	committee *pb.SyncCommittee
+	// bootstrap is the bootstrap of the block with root bootstrapRoot.
+	bootstrap     interfaces.LightClientBootstrap
+	bootstrapRoot [32]byte
+}
+
+func newTestChain(t *testing.T) *testChain {
+	keys, pubKeys, err := interop.DeterministicallyGenerateKeys(0, params.BeaconConfig().SyncCommitteeSize)
+	require.NoError(t, err)
+	committee := &pb.SyncCommittee{
+		Pubkeys:         make([][]byte, len(pubKeys)),
+		AggregatePubkey: bls.AggregateMultiplePubkeys(pubKeys).Marshal(),
+	}
+	for i, pk := range pubKeys {
+		committee.Pubkeys[i] = pk.Marshal()
+	}
+	c := &testChain{t: t, keys: keys, committee: committee}
+
+	st := c.state()
+	stateRoot, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	branch, err := st.CurrentSyncCommitteeProof(context.Background())
+	require.NoError(t, err)
+	header := testHeader(8, stateRoot[:])
+	c.bootstrapRoot, err = header.HashTreeRoot()
+	require.NoError(t, err)
+	c.bootstrap, err = light_client.NewWrappedBootstrap(&pb.LightClientBootstrapAltair{
+		Header:                     &pb.LightClientHeaderAltair{Beacon: header},
+		CurrentSyncCommittee:       committee,
+		CurrentSyncCommitteeBranch: branch,
+	})
+	require.NoError(t, err)
+	return c
+}
+
+// state returns a state whose current and next sync committees are the test sync committee.
+func (c *testChain) state() state.BeaconState {
+	st, err := util.NewBeaconStateAltair()
+	require.NoError(c.t, err)
+	require.NoError(c.t, st.SetCurrentSyncCommittee(c.committee))
+	require.NoError(c.t, st.SetNextSyncCommittee(c.committee))
+	return st
+}
+
+// update returns an update with proofs of its finalized header and next sync committee, signed by the given number
+// of sync committee members.
+func (c *testChain) update(attestedSlot, finalizedSlot primitives.Slot, participants uint64) interfaces.LightClientUpdate {
+	ctx := context.Background()
+	finalizedHeader := testHeader(finalizedSlot, make([]byte, 32))
+	finalizedRoot, err := finalizedHeader.HashTreeRoot()
+	require.NoError(c.t, err)
+
+	st := c.state()
+	require.NoError(c.t, st.SetFinalizedCheckpoint(&pb.Checkpoint{Epoch: slots.ToEpoch(finalizedSlot), Root: finalizedRoot[:]}))
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(c.t, err)
+	finalityBranch, err := st.FinalizedRootProof(ctx)
+	require.NoError(c.t, err)
+	nextSyncCommitteeBranch, err := st.NextSyncCommitteeProof(ctx)
+	require.NoError(c.t, err)
+
+	attestedHeader := testHeader(attestedSlot, stateRoot[:])
+	update, err := light_client.NewWrappedUpdate(&pb.LightClientUpdateAltair{
+		AttestedHeader:          &pb.LightClientHeaderAltair{Beacon: attestedHeader},
+		NextSyncCommittee:       c.committee,
+		NextSyncCommitteeBranch: nextSyncCommitteeBranch,
+		FinalizedHeader:         &pb.LightClientHeaderAltair{Beacon: finalizedHeader},
+		FinalityBranch:          finalityBranch,
+		SyncAggregate:           c.sign(attestedHeader, attestedSlot+1, participants),
+		SignatureSlot:           attestedSlot + 1,
+	})
+	require.NoError(c.t, err)
+	return update
+}
+
+func (c *testChain) sign(header *pb.BeaconBlockHeader, signatureSlot primitives.Slot, participants uint64) *pb.SyncAggregate {
+	fork, err := forks.Fork(slots.ToEpoch(signatureSlot - 1))
+	require.NoError(c.t, err)
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainSyncCommittee, fork.CurrentVersion, testGenesisValidatorsRoot)
+	require.NoError(c.t, err)
+	signingRoot, err := signing.ComputeSigningRoot(header, domain)
+	require.NoError(c.t, err)
+
+	bits := bitfield.NewBitvector512()
+	sigs := make([]bls.Signature, participants)
+	for i := uint64(0); i < participants; i++ {
+		bits.SetBitAt(i, true)
+		sigs[i] = c.keys[i].Sign(signingRoot[:])
+	}
+	sig := bls.NewAggregateSignature()
+	if participants > 0 {
+		sig = bls.AggregateSignatures(sigs)
+	}
+	return &pb.SyncAggregate{
+		SyncCommitteeBits:      bits,
+		SyncCommitteeSignature: sig.Marshal(),
+	}
+}
+
+func testHeader(slot primitives.Slot, stateRoot []byte) *pb.BeaconBlockHeader {
+	return &pb.BeaconBlockHeader{
+		Slot:       slot,
+		ParentRoot: make([]byte, 32),
+		StateRoot:  stateRoot,
+		BodyRoot:   make([]byte, 32),
+	}
+}
+
+// zeroBranch returns a branch of the given depth made of zero hashes, which stands for a missing proof.
+func zeroBranch(depth int) [][]byte {
+	branch := make([][]byte, depth)
+	for i := range branch {
+		branch[i] = make([]byte, 32)
+	}
+	return branch
+}
+
+func TestNewStore(t *testing.T) {
+	c := newTestChain(t)
+
+	s, err := lightClient.NewStore(c.bootstrapRoot, c.bootstrap)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(8), s.FinalizedHeader.Beacon().Slot)
+	require.Equal(t, primitives.Slot(8), s.OptimisticHeader.Beacon().Slot)
+	require.DeepSSZEqual(t, c.committee, s.CurrentSyncCommittee)
+	require.Equal(t, true, s.NextSyncCommittee == nil)
+
+	_, err = lightClient.NewStore([32]byte{'a'}, c.bootstrap)
+	require.ErrorIs(t, err, lightClient.ErrInvalidBootstrap)
+
+	bootstrap, err := light_client.NewWrappedBootstrap(&pb.LightClientBootstrapAltair{
+		Header:                     c.bootstrap.Header().Proto().(*pb.LightClientHeaderAltair),
+		CurrentSyncCommittee:       c.committee,
+		CurrentSyncCommitteeBranch: zeroBranch(fieldparams.SyncCommitteeBranchDepth),
+	})
+	require.NoError(t, err)
+	_, err = lightClient.NewStore(c.bootstrapRoot, bootstrap)
+	require.ErrorIs(t, err, lightClient.ErrInvalidBootstrap)
+}
+
+func TestStore_ProcessUpdate(t *testing.T) {
+	c := newTestChain(t)
+	currentSlot := primitives.Slot(100)
+
+	t.Run("supermajority finalizes", func(t *testing.T) {
+		s, err := lightClient.NewStore(c.bootstrapRoot, c.bootstrap)
+		require.NoError(t, err)
+		require.NoError(t, s.ProcessUpdate(c.update(40, 16, 512), currentSlot, testGenesisValidatorsRoot))
+		require.Equal(t, primitives.Slot(16), s.FinalizedHeader.Beacon().Slot)
+		require.Equal(t, primitives.Slot(40), s.OptimisticHeader.Beacon().Slot)
+		require.DeepSSZEqual(t, c.committee, s.NextSyncCommittee)
+		require.Equal(t, true, s.BestValidUpdate == nil)
+		require.Equal(t, uint64(512), s.CurrentMaxActiveParticipants)
+	})
+	t.Run("without supermajority", func(t *testing.T) {
+		s, err := lightClient.NewStore(c.bootstrapRoot, c.bootstrap)
+		require.NoError(t, err)
+		require.NoError(t, s.ProcessUpdate(c.update(40, 16, 300), currentSlot, testGenesisValidatorsRoot))
+		require.Equal(t, primitives.Slot(8), s.FinalizedHeader.Beacon().Slot)
+		require.Equal(t, primitives.Slot(40), s.OptimisticHeader.Beacon().Slot)
+		require.Equal(t, primitives.Slot(40), s.BestValidUpdate.AttestedHeader().Beacon().Slot)
+
+		// The best update is applied once the finalized header is older than the update timeout.
+		require.NoError(t, s.ProcessForceUpdate(currentSlot))
+		require.Equal(t, primitives.Slot(8), s.FinalizedHeader.Beacon().Slot)
+		cfg := params.BeaconConfig()
+		timeout := cfg.SlotsPerEpoch.Mul(uint64(cfg.EpochsPerSyncCommitteePeriod))
+		require.NoError(t, s.ProcessForceUpdate(8+timeout+1))
+		require.Equal(t, primitives.Slot(16), s.FinalizedHeader.Beacon().Slot)
+		require.Equal(t, true, s.BestValidUpdate == nil)
+	})
+	t.Run("too few participants", func(t *testing.T) {
+		s, err := lightClient.NewStore(c.bootstrapRoot, c.bootstrap)
+		require.NoError(t, err)
+		err = s.ProcessUpdate(c.update(40, 16, 0), currentSlot, testGenesisValidatorsRoot)
+		require.ErrorIs(t, err, lightClient.ErrInvalidUpdate)
+	})
+	t.Run("signature from the future", func(t *testing.T) {
+		s, err := lightClient.NewStore(c.bootstrapRoot, c.bootstrap)
+		require.NoError(t, err)
+		err = s.ProcessUpdate(c.update(40, 16, 512), 40, testGenesisValidatorsRoot)
+		require.ErrorIs(t, err, lightClient.ErrInvalidUpdate)
+	})
+	t.Run("invalid signature", func(t *testing.T) {
+		s, err := lightClient.NewStore(c.bootstrapRoot, c.bootstrap)
+		require.NoError(t, err)
+		err = s.ProcessUpdate(c.update(40, 16, 512), currentSlot, bytes.Repeat([]byte{'a'}, 32))
+		require.ErrorIs(t, err, lightClient.ErrInvalidUpdate)
+	})
+	t.Run("finalized header without finality branch", func(t *testing.T) {
+		s, err := lightClient.NewStore(c.bootstrapRoot, c.bootstrap)
+		require.NoError(t, err)
+		update := c.update(40, 16, 512)
+		require.NoError(t, update.SetFinalityBranch(zeroBranch(fieldparams.FinalityBranchDepth)))
+		err = s.ProcessUpdate(update, currentSlot, testGenesisValidatorsRoot)
+		require.ErrorIs(t, err, lightClient.ErrInvalidUpdate)
+		require.Equal(t, primitives.Slot(8), s.FinalizedHeader.Beacon().Slot)
+	})
+	t.Run("next sync committee without branch", func(t *testing.T) {
+		s, err := lightClient.NewStore(c.bootstrapRoot, c.bootstrap)
+		require.NoError(t, err)
+		update := c.update(40, 16, 512)
+		require.NoError(t, update.SetNextSyncCommitteeBranch(zeroBranch(fieldparams.SyncCommitteeBranchDepth)))
+		err = s.ProcessUpdate(update, currentSlot, testGenesisValidatorsRoot)
+		require.ErrorIs(t, err, lightClient.ErrInvalidUpdate)
+	})
+	t.Run("invalid finality branch", func(t *testing.T) {
+		s, err := lightClient.NewStore(c.bootstrapRoot, c.bootstrap)
+		require.NoError(t, err)
+		update := c.update(40, 16, 512)
+		require.NoError(t, update.SetFinalizedHeader(update.AttestedHeader()))
+		err = s.ProcessUpdate(update, currentSlot, testGenesisValidatorsRoot)
+		require.ErrorIs(t, err, lightClient.ErrInvalidUpdate)
+	})
+}
+
+func TestStore_ProcessFinalityAndOptimisticUpdates(t *testing.T) {
+	c := newTestChain(t)
+	currentSlot := primitives.Slot(100)
+	s, err := lightClient.NewStore(c.bootstrapRoot, c.bootstrap)
+	require.NoError(t, err)
+
+	optimisticUpdate, err := light_client.NewOptimisticUpdateFromUpdate(c.update(40, 16, 512))
+	require.NoError(t, err)
+	require.NoError(t, s.ProcessOptimisticUpdate(optimisticUpdate, currentSlot, testGenesisValidatorsRoot))
+	require.Equal(t, primitives.Slot(8), s.FinalizedHeader.Beacon().Slot)
+	require.Equal(t, primitives.Slot(40), s.OptimisticHeader.Beacon().Slot)
+
+	finalityUpdate, err := light_client.NewFinalityUpdateFromUpdate(c.update(48, 24, 512))
+	require.NoError(t, err)
+	require.NoError(t, s.ProcessFinalityUpdate(finalityUpdate, currentSlot, testGenesisValidatorsRoot))
+	require.Equal(t, primitives.Slot(24), s.FinalizedHeader.Beacon().Slot)
+	require.Equal(t, primitives.Slot(48), s.OptimisticHeader.Beacon().Slot)
+	// Finality updates carry no sync committee.
+	require.Equal(t, true, s.NextSyncCommittee == nil)
+}
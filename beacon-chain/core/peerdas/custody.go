@@ -0,0 +1,118 @@
+package peerdas
+
+import (
+	"encoding/binary"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/holiman/uint256"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+)
+
+var (
+	// ErrCustodyGroupTooLarge is returned when a custody group is not lower than NUMBER_OF_CUSTODY_GROUPS.
+	ErrCustodyGroupTooLarge = errors.New("custody group too large")
+	// ErrCustodyGroupCountTooLarge is returned when the custody group count is larger than NUMBER_OF_CUSTODY_GROUPS.
+	ErrCustodyGroupCountTooLarge = errors.New("custody group count too large")
+)
+
+// CustodyGroups computes the custody groups a node with the given ID should custody.
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/fulu/das-core.md#get_custody_groups
+func CustodyGroups(nodeID enode.ID, custodyGroupCount uint64) ([]uint64, error) {
+	numberOfCustodyGroups := params.BeaconConfig().NumberOfCustodyGroups
+	if custodyGroupCount > numberOfCustodyGroups {
+		return nil, errors.Wrapf(ErrCustodyGroupCountTooLarge, "%d > %d", custodyGroupCount, numberOfCustodyGroups)
+	}
+
+	// Skip the computation if all groups are custodied.
+	if custodyGroupCount == numberOfCustodyGroups {
+		groups := make([]uint64, numberOfCustodyGroups)
+		for i := range groups {
+			groups[i] = uint64(i)
+		}
+		return groups, nil
+	}
+
+	one := uint256.NewInt(1)
+	seen := make(map[uint64]bool, custodyGroupCount)
+	groups := make([]uint64, 0, custodyGroupCount)
+	currentID := new(uint256.Int).SetBytes(nodeID.Bytes())
+	for uint64(len(groups)) < custodyGroupCount {
+		// The node ID is serialized as a little endian uint256 before hashing.
+		idBytes := currentID.Bytes32()
+		h := hash.Hash(bytesutil.ReverseByteOrder(idBytes[:]))
+		group := binary.LittleEndian.Uint64(h[:8]) % numberOfCustodyGroups
+		if !seen[group] {
+			seen[group] = true
+			groups = append(groups, group)
+		}
+		// Increment the ID, wrapping around to 0 on overflow as the spec requires.
+		currentID.Add(currentID, one)
+	}
+	slices.Sort(groups)
+	return groups, nil
+}
+
+// ComputeColumnsForCustodyGroup returns the columns that belong to the given custody group.
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/fulu/das-core.md#compute_columns_for_custody_group
+func ComputeColumnsForCustodyGroup(custodyGroup uint64) ([]uint64, error) {
+	cfg := params.BeaconConfig()
+	if custodyGroup >= cfg.NumberOfCustodyGroups {
+		return nil, errors.Wrapf(ErrCustodyGroupTooLarge, "%d >= %d", custodyGroup, cfg.NumberOfCustodyGroups)
+	}
+	columnsPerGroup := cfg.NumberOfColumns / cfg.NumberOfCustodyGroups
+	columns := make([]uint64, 0, columnsPerGroup)
+	for i := uint64(0); i < columnsPerGroup; i++ {
+		columns = append(columns, cfg.NumberOfCustodyGroups*i+custodyGroup)
+	}
+	return columns, nil
+}
+
+// CustodyColumns returns the set of column indices belonging to the given custody groups.
+func CustodyColumns(custodyGroups []uint64) (map[uint64]bool, error) {
+	columns := make(map[uint64]bool)
+	for _, group := range custodyGroups {
+		groupColumns, err := ComputeColumnsForCustodyGroup(group)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range groupColumns {
+			columns[c] = true
+		}
+	}
+	return columns, nil
+}
+
+// NodeCustodyColumns returns the set of columns sampled by a node with the given ID which custodies the minimum
+// number of custody groups.
+func NodeCustodyColumns(nodeID enode.ID) (map[uint64]bool, error) {
+	groups, err := CustodyGroups(nodeID, CustodyGroupSamplingSize(params.BeaconConfig().CustodyRequirement))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute custody groups")
+	}
+	return CustodyColumns(groups)
+}
+
+// ComputeSubnetForDataColumnSidecar returns the gossip subnet a data column sidecar with the given index is published to.
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/fulu/p2p-interface.md#compute_subnet_for_data_column_sidecar
+func ComputeSubnetForDataColumnSidecar(columnIndex uint64) uint64 {
+	return columnIndex % params.BeaconConfig().DataColumnSidecarSubnetCount
+}
+
+// DataColumnSubnets returns the set of gossip subnets covering the given columns.
+func DataColumnSubnets(columns map[uint64]bool) map[uint64]bool {
+	subnets := make(map[uint64]bool, len(columns))
+	for c := range columns {
+		subnets[ComputeSubnetForDataColumnSidecar(c)] = true
+	}
+	return subnets
+}
+
+// CustodyGroupSamplingSize returns the number of custody groups a node samples, which is the larger of
+// SAMPLES_PER_SLOT and its custody group count.
+func CustodyGroupSamplingSize(custodyGroupCount uint64) uint64 {
+	return max(params.BeaconConfig().SamplesPerSlot, custodyGroupCount)
+}
@@ -0,0 +1,80 @@
+package peerdas_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestCustodyGroups(t *testing.T) {
+	numberOfCustodyGroups := params.BeaconConfig().NumberOfCustodyGroups
+	var maxID enode.ID
+	for i := range maxID {
+		maxID[i] = 0xff
+	}
+	for _, id := range []enode.ID{{}, {0x01, 0x02}, maxID} {
+		groups, err := peerdas.CustodyGroups(id, 4)
+		require.NoError(t, err)
+		require.Equal(t, 4, len(groups))
+		require.Equal(t, true, slices.IsSorted(groups))
+		require.Equal(t, 4, len(slices.Compact(slices.Clone(groups))))
+		for _, g := range groups {
+			require.Equal(t, true, g < numberOfCustodyGroups)
+		}
+
+		// The computation is deterministic, and a larger count is a superset of a smaller one.
+		again, err := peerdas.CustodyGroups(id, 4)
+		require.NoError(t, err)
+		require.DeepEqual(t, groups, again)
+		more, err := peerdas.CustodyGroups(id, 8)
+		require.NoError(t, err)
+		for _, g := range groups {
+			require.Equal(t, true, slices.Contains(more, g))
+		}
+	}
+
+	all, err := peerdas.CustodyGroups(enode.ID{}, numberOfCustodyGroups)
+	require.NoError(t, err)
+	require.Equal(t, int(numberOfCustodyGroups), len(all))
+
+	_, err = peerdas.CustodyGroups(enode.ID{}, numberOfCustodyGroups+1)
+	require.ErrorIs(t, err, peerdas.ErrCustodyGroupCountTooLarge)
+}
+
+func TestComputeColumnsForCustodyGroup(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.NumberOfColumns = 128
+	cfg.NumberOfCustodyGroups = 32
+	params.OverrideBeaconConfig(cfg)
+
+	columns, err := peerdas.ComputeColumnsForCustodyGroup(3)
+	require.NoError(t, err)
+	require.DeepEqual(t, []uint64{3, 35, 67, 99}, columns)
+
+	_, err = peerdas.ComputeColumnsForCustodyGroup(32)
+	require.ErrorIs(t, err, peerdas.ErrCustodyGroupTooLarge)
+
+	set, err := peerdas.CustodyColumns([]uint64{0, 1})
+	require.NoError(t, err)
+	require.Equal(t, 8, len(set))
+	require.Equal(t, true, set[33])
+}
+
+func TestDataColumnSubnets(t *testing.T) {
+	subnetCount := params.BeaconConfig().DataColumnSidecarSubnetCount
+	require.Equal(t, uint64(5), peerdas.ComputeSubnetForDataColumnSidecar(5))
+	require.Equal(t, uint64(5), peerdas.ComputeSubnetForDataColumnSidecar(subnetCount+5))
+	subnets := peerdas.DataColumnSubnets(map[uint64]bool{1: true, subnetCount + 1: true, 2: true})
+	require.Equal(t, 2, len(subnets))
+}
+
+func TestCustodyGroupSamplingSize(t *testing.T) {
+	samples := params.BeaconConfig().SamplesPerSlot
+	require.Equal(t, samples, peerdas.CustodyGroupSamplingSize(samples-1))
+	require.Equal(t, samples+1, peerdas.CustodyGroupSamplingSize(samples+1))
+}
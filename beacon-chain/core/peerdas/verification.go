@@ -0,0 +1,76 @@
+package peerdas
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+)
+
+var (
+	// ErrIndexTooLarge is returned when a data column sidecar index is not lower than NUMBER_OF_COLUMNS.
+	ErrIndexTooLarge = errors.New("column index is larger than the specified columns count")
+	// ErrNoKzgCommitments is returned when a data column sidecar does not contain any KZG commitment.
+	ErrNoKzgCommitments = errors.New("no KZG commitments found")
+	// ErrTooManyKzgCommitments is returned when a data column sidecar contains more commitments than blobs allowed in the block.
+	ErrTooManyKzgCommitments = errors.New("too many KZG commitments")
+	// ErrMismatchLength is returned when the column, commitments and proofs of a sidecar do not have the same length.
+	ErrMismatchLength = errors.New("mismatch in the length of the column, commitments or proofs")
+	// ErrInvalidInclusionProof is returned when the KZG commitments inclusion proof of a sidecar is invalid.
+	ErrInvalidInclusionProof = errors.New("invalid KZG commitments inclusion proof")
+)
+
+// VerifyDataColumnSidecar performs the structural checks of a data column sidecar.
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/fulu/p2p-interface.md#verify_data_column_sidecar
+func VerifyDataColumnSidecar(sidecar blocks.RODataColumn) error {
+	if sidecar.ColumnIndex >= params.BeaconConfig().NumberOfColumns {
+		return errors.Wrapf(ErrIndexTooLarge, "index=%d", sidecar.ColumnIndex)
+	}
+	if len(sidecar.KzgCommitments) == 0 {
+		return ErrNoKzgCommitments
+	}
+	maxBlobsPerBlock := params.BeaconConfig().MaxBlobsPerBlock(sidecar.Slot())
+	if len(sidecar.KzgCommitments) > maxBlobsPerBlock {
+		return errors.Wrapf(ErrTooManyKzgCommitments, "%d > %d", len(sidecar.KzgCommitments), maxBlobsPerBlock)
+	}
+	if len(sidecar.DataColumn) != len(sidecar.KzgCommitments) || len(sidecar.KzgCommitments) != len(sidecar.KzgProof) {
+		return ErrMismatchLength
+	}
+	return nil
+}
+
+// VerifyDataColumnSidecarInclusionProof verifies that the KZG commitments of the sidecar are included in the block body.
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/fulu/p2p-interface.md#verify_data_column_sidecar_inclusion_proof
+func VerifyDataColumnSidecarInclusionProof(sidecar blocks.RODataColumn) error {
+	if err := blocks.VerifyKZGCommitmentsInclusionProof(sidecar); err != nil {
+		return errors.Wrap(ErrInvalidInclusionProof, err.Error())
+	}
+	return nil
+}
+
+// VerifyDataColumnsSidecarKZGProofs verifies the cell KZG proofs of the given sidecars.
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/fulu/p2p-interface.md#verify_data_column_sidecar_kzg_proofs
+func VerifyDataColumnsSidecarKZGProofs(sidecars []blocks.RODataColumn) error {
+	// Count the number of cells to verify.
+	count := 0
+	for _, sidecar := range sidecars {
+		if len(sidecar.DataColumn) != len(sidecar.KzgCommitments) || len(sidecar.KzgCommitments) != len(sidecar.KzgProof) {
+			return ErrMismatchLength
+		}
+		count += len(sidecar.DataColumn)
+	}
+
+	commitments := make([][]byte, 0, count)
+	cellIndices := make([]uint64, 0, count)
+	cells := make([][]byte, 0, count)
+	proofs := make([][]byte, 0, count)
+	for _, sidecar := range sidecars {
+		for i := range sidecar.DataColumn {
+			commitments = append(commitments, sidecar.KzgCommitments[i])
+			cellIndices = append(cellIndices, sidecar.ColumnIndex)
+			cells = append(cells, sidecar.DataColumn[i])
+			proofs = append(proofs, sidecar.KzgProof[i])
+		}
+	}
+	return kzg.VerifyCellKZGProofBatch(commitments, cellIndices, cells, proofs)
+}
@@ -0,0 +1,56 @@
+package peerdas_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/kzg"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestVerifyDataColumnSidecar(t *testing.T) {
+	_, columns := util.GenerateTestFuluBlockWithColumns(t, [32]byte{}, 1, 2)
+	require.NoError(t, peerdas.VerifyDataColumnSidecar(columns[0]))
+
+	tooLarge := columns[1]
+	tooLarge.ColumnIndex = params.BeaconConfig().NumberOfColumns
+	require.ErrorIs(t, peerdas.VerifyDataColumnSidecar(tooLarge), peerdas.ErrIndexTooLarge)
+
+	mismatch := columns[2]
+	mismatch.KzgProof = mismatch.KzgProof[:1]
+	require.ErrorIs(t, peerdas.VerifyDataColumnSidecar(mismatch), peerdas.ErrMismatchLength)
+
+	_, empty := util.GenerateTestFuluBlockWithColumns(t, [32]byte{}, 1, 0)
+	require.ErrorIs(t, peerdas.VerifyDataColumnSidecar(empty[0]), peerdas.ErrNoKzgCommitments)
+}
+
+func TestVerifyDataColumnSidecarInclusionProof(t *testing.T) {
+	_, columns := util.GenerateTestFuluBlockWithColumns(t, [32]byte{}, 1, 3)
+	require.NoError(t, peerdas.VerifyDataColumnSidecarInclusionProof(columns[5]))
+
+	bad := columns[6]
+	bad.KzgCommitments = bad.KzgCommitments[:2]
+	require.ErrorIs(t, peerdas.VerifyDataColumnSidecarInclusionProof(bad), peerdas.ErrInvalidInclusionProof)
+}
+
+func TestVerifyDataColumnsSidecarKZGProofs(t *testing.T) {
+	require.NoError(t, peerdas.VerifyDataColumnsSidecarKZGProofs(nil))
+	_, columns := util.GenerateTestFuluBlockWithColumns(t, [32]byte{}, 1, 3)
+	require.NoError(t, peerdas.VerifyDataColumnsSidecarKZGProofs(columns[:1]))
+	require.NoError(t, peerdas.VerifyDataColumnsSidecarKZGProofs(columns[3:10]))
+
+	tampered := columns[4]
+	tampered.DataColumn[1][kzg.BytesPerCell-1] ^= 1
+	require.ErrorIs(t, peerdas.VerifyDataColumnsSidecarKZGProofs(columns[3:10]), kzg.ErrInvalidCellProof)
+
+	swapped := columns[11]
+	swapped.DataColumn[0], swapped.DataColumn[2] = swapped.DataColumn[2], swapped.DataColumn[0]
+	require.ErrorIs(t, peerdas.VerifyDataColumnsSidecarKZGProofs([]blocks.RODataColumn{swapped}), kzg.ErrInvalidCellProof)
+
+	mismatch := columns[12]
+	mismatch.KzgProof = mismatch.KzgProof[:1]
+	require.ErrorIs(t, peerdas.VerifyDataColumnsSidecarKZGProofs([]blocks.RODataColumn{mismatch}), peerdas.ErrMismatchLength)
+}
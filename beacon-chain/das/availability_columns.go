@@ -0,0 +1,195 @@
+package das
+
+import (
+	"context"
+	"fmt"
+
+	errors "github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/runtime/logging"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	errMixedColumnRoots     = errors.New("DataColumnSidecars must all be for the same block")
+	errBlobsAfterFulu       = errors.New("BlobSidecars cannot be persisted in a column-backed AvailabilityStore")
+	errMissingColumnSidecar = errors.New("no sidecar in cache for custody column")
+)
+
+// LazilyPersistentStoreColumn is an implementation of AvailabilityStore for Fulu blocks, where data availability
+// is established by the set of data columns the node custodies rather than by the full set of blobs.
+// Like LazilyPersistentStore, columns passed to PersistColumns are held in memory until IsDataAvailable
+// is called for their block, at which time they undergo full verification and are saved to disk.
+type LazilyPersistentStoreColumn struct {
+	store    *filesystem.DataColumnStorage
+	cache    map[cacheKey]map[uint64]blocks.RODataColumn
+	custody  map[uint64]bool
+	verifier DataColumnBatchVerifier
+}
+
+var _ AvailabilityStore = &LazilyPersistentStoreColumn{}
+
+// DataColumnBatchVerifier enables LazilyPersistentStoreColumn to manage the verification process
+// going from RODataColumn->VerifiedRODataColumn for all of the columns of a block at once.
+type DataColumnBatchVerifier interface {
+	VerifiedRODataColumns(ctx context.Context, blk blocks.ROBlock, dcs []blocks.RODataColumn) ([]blocks.VerifiedRODataColumn, error)
+}
+
+// NewLazilyPersistentStoreColumn creates a new LazilyPersistentStoreColumn. The custody argument is the set of
+// column indices the node is responsible for; IsDataAvailable will only succeed once all of them are persisted.
+func NewLazilyPersistentStoreColumn(store *filesystem.DataColumnStorage, verifier DataColumnBatchVerifier, custody map[uint64]bool) *LazilyPersistentStoreColumn {
+	return &LazilyPersistentStoreColumn{
+		store:    store,
+		cache:    make(map[cacheKey]map[uint64]blocks.RODataColumn),
+		custody:  custody,
+		verifier: verifier,
+	}
+}
+
+// Persist satisfies the AvailabilityStore interface. Blocks from Fulu onward carry their data in columns,
+// so any blobs given to a column-backed store indicate a caller error.
+func (s *LazilyPersistentStoreColumn) Persist(_ primitives.Slot, sc ...blocks.ROBlob) error {
+	if len(sc) == 0 {
+		return nil
+	}
+	return errBlobsAfterFulu
+}
+
+// PersistColumns adds columns to the working column cache. Columns stored in this cache will be persisted
+// for at least as long as the node is running. Columns the node does not custody are ignored.
+func (s *LazilyPersistentStoreColumn) PersistColumns(current primitives.Slot, dcs ...blocks.RODataColumn) error {
+	if len(dcs) == 0 {
+		return nil
+	}
+	first := dcs[0].BlockRoot()
+	for i := 1; i < len(dcs); i++ {
+		if first != dcs[i].BlockRoot() {
+			return errMixedColumnRoots
+		}
+	}
+	if !withinColumnDAPeriod(slots.ToEpoch(dcs[0].Slot()), slots.ToEpoch(current)) {
+		return nil
+	}
+	key := cacheKey{slot: dcs[0].Slot(), root: first}
+	entry, ok := s.cache[key]
+	if !ok {
+		entry = make(map[uint64]blocks.RODataColumn)
+		s.cache[key] = entry
+	}
+	for i := range dcs {
+		idx := dcs[i].ColumnIndex
+		if !s.custody[idx] {
+			continue
+		}
+		if _, ok := entry[idx]; ok {
+			return errors.Wrapf(ErrDuplicateSidecar, "root=%#x, column=%d", first, idx)
+		}
+		entry[idx] = dcs[i]
+	}
+	return nil
+}
+
+// IsDataAvailable returns nil if all the custody columns for the given block are persisted to disk and have been
+// verified. Columns already on disk are assumed to have been previously verified against the block.
+func (s *LazilyPersistentStoreColumn) IsDataAvailable(ctx context.Context, current primitives.Slot, b blocks.ROBlock) error {
+	if b.Version() < version.Fulu {
+		return nil
+	}
+	if !withinColumnDAPeriod(slots.ToEpoch(b.Block().Slot()), slots.ToEpoch(current)) {
+		return nil
+	}
+	commitments, err := b.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		return errors.Wrapf(err, "could not check data availability for block %#x", b.Root())
+	}
+	// Return early for blocks which do not have any commitments.
+	if len(commitments) == 0 {
+		return nil
+	}
+
+	key := keyFromBlock(b)
+	entry := s.cache[key]
+	defer delete(s.cache, key)
+	root := b.Root()
+	summary := s.store.Summary(root)
+
+	// Gather the custody columns that are not yet on disk, failing fast if any are missing.
+	columns := make([]blocks.RODataColumn, 0, len(s.custody))
+	for idx := range s.custody {
+		if summary.HasIndex(idx) {
+			continue
+		}
+		dc, ok := entry[idx]
+		if !ok {
+			return errors.Wrapf(errMissingColumnSidecar, "root=%#x, column=%d", root, idx)
+		}
+		columns = append(columns, dc)
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+
+	verified, err := s.verifier.VerifiedRODataColumns(ctx, b, columns)
+	if err != nil {
+		var me verification.VerificationMultiError
+		ok := errors.As(err, &me)
+		if ok {
+			fails := me.Failures()
+			lf := make(log.Fields, len(fails))
+			for i := range fails {
+				lf[fmt.Sprintf("fail_%d", i)] = fails[i].Error()
+			}
+			log.WithFields(lf).WithFields(logging.DataColumnFields(columns[0])).
+				Debug("invalid DataColumnSidecars received")
+		}
+		return errors.Wrapf(err, "invalid DataColumnSidecars received for block %#x", root)
+	}
+	if err := s.store.Save(verified...); err != nil {
+		return errors.Wrapf(err, "failed to save DataColumnSidecars for block %#x", root)
+	}
+	// All custody columns are persisted - da check succeeds.
+	return nil
+}
+
+// ForkAvailabilityStore is an AvailabilityStore for a range of blocks which may span the Fulu fork. Blocks before
+// Fulu are checked against their blobs, and blocks from Fulu onward against the columns the node custodies.
+type ForkAvailabilityStore struct {
+	blobs   AvailabilityStore
+	columns *LazilyPersistentStoreColumn
+}
+
+var _ AvailabilityStore = &ForkAvailabilityStore{}
+
+// NewForkAvailabilityStore creates a ForkAvailabilityStore from a blob-backed and a column-backed AvailabilityStore.
+func NewForkAvailabilityStore(blobs AvailabilityStore, columns *LazilyPersistentStoreColumn) *ForkAvailabilityStore {
+	return &ForkAvailabilityStore{blobs: blobs, columns: columns}
+}
+
+// Persist adds blobs to the blob-backed store.
+func (s *ForkAvailabilityStore) Persist(current primitives.Slot, sc ...blocks.ROBlob) error {
+	return s.blobs.Persist(current, sc...)
+}
+
+// PersistColumns adds columns to the column-backed store.
+func (s *ForkAvailabilityStore) PersistColumns(current primitives.Slot, dcs ...blocks.RODataColumn) error {
+	return s.columns.PersistColumns(current, dcs...)
+}
+
+// IsDataAvailable checks the block against the store for its fork.
+func (s *ForkAvailabilityStore) IsDataAvailable(ctx context.Context, current primitives.Slot, b blocks.ROBlock) error {
+	if b.Version() >= version.Fulu {
+		return s.columns.IsDataAvailable(ctx, current, b)
+	}
+	return s.blobs.IsDataAvailable(ctx, current, b)
+}
+
+// withinColumnDAPeriod checks if the block epoch is within MIN_EPOCHS_FOR_DATA_COLUMN_SIDECARS_REQUESTS of the given current epoch.
+func withinColumnDAPeriod(block, current primitives.Epoch) bool {
+	return block+params.BeaconConfig().MinEpochsForDataColumnSidecarsRequest >= current
+}
@@ -0,0 +1,82 @@
+package das
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type mockColumnBatchVerifier struct {
+	t        *testing.T
+	err      error
+	verified []blocks.RODataColumn
+}
+
+func (m *mockColumnBatchVerifier) VerifiedRODataColumns(_ context.Context, _ blocks.ROBlock, dcs []blocks.RODataColumn) ([]blocks.VerifiedRODataColumn, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.verified = append(m.verified, dcs...)
+	return verification.FakeVerifyDataColumnSliceForTest(m.t, dcs), nil
+}
+
+func TestLazilyPersistentStoreColumn(t *testing.T) {
+	blk, columns := util.GenerateTestFuluBlockWithColumns(t, [32]byte{}, 1, 2)
+	custody := map[uint64]bool{1: true, 5: true, 9: true}
+	current := blk.Block().Slot()
+
+	t.Run("persist blobs rejected", func(t *testing.T) {
+		as := NewLazilyPersistentStoreColumn(filesystem.NewEphemeralDataColumnStorage(t), &mockColumnBatchVerifier{t: t}, custody)
+		require.NoError(t, as.Persist(current))
+		_, blobs := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 1)
+		require.ErrorIs(t, as.Persist(current, blobs...), errBlobsAfterFulu)
+	})
+	t.Run("missing custody column", func(t *testing.T) {
+		as := NewLazilyPersistentStoreColumn(filesystem.NewEphemeralDataColumnStorage(t), &mockColumnBatchVerifier{t: t}, custody)
+		require.NoError(t, as.PersistColumns(current, columns[1], columns[5]))
+		require.ErrorIs(t, as.IsDataAvailable(context.Background(), current, blk), errMissingColumnSidecar)
+	})
+	t.Run("verification failure", func(t *testing.T) {
+		verr := errors.New("derp")
+		as := NewLazilyPersistentStoreColumn(filesystem.NewEphemeralDataColumnStorage(t), &mockColumnBatchVerifier{t: t, err: verr}, custody)
+		require.NoError(t, as.PersistColumns(current, columns...))
+		require.ErrorIs(t, as.IsDataAvailable(context.Background(), current, blk), verr)
+	})
+	t.Run("duplicate column", func(t *testing.T) {
+		as := NewLazilyPersistentStoreColumn(filesystem.NewEphemeralDataColumnStorage(t), &mockColumnBatchVerifier{t: t}, custody)
+		require.NoError(t, as.PersistColumns(current, columns[1]))
+		require.ErrorIs(t, as.PersistColumns(current, columns[1]), ErrDuplicateSidecar)
+	})
+	t.Run("mixed roots", func(t *testing.T) {
+		_, other := util.GenerateTestFuluBlockWithColumns(t, [32]byte{1}, 1, 1)
+		as := NewLazilyPersistentStoreColumn(filesystem.NewEphemeralDataColumnStorage(t), &mockColumnBatchVerifier{t: t}, custody)
+		require.ErrorIs(t, as.PersistColumns(current, columns[1], other[5]), errMixedColumnRoots)
+	})
+	t.Run("available", func(t *testing.T) {
+		store := filesystem.NewEphemeralDataColumnStorage(t)
+		v := &mockColumnBatchVerifier{t: t}
+		as := NewLazilyPersistentStoreColumn(store, v, custody)
+		require.NoError(t, as.PersistColumns(current, columns...))
+		require.NoError(t, as.IsDataAvailable(context.Background(), current, blk))
+		// Only the custody columns are verified and saved.
+		require.Equal(t, len(custody), len(v.verified))
+		summary := store.Summary(blk.Root())
+		require.Equal(t, true, summary.AllAvailable(custody))
+		require.Equal(t, len(custody), summary.Count())
+
+		// Once saved, a subsequent check is satisfied by the columns on disk.
+		require.NoError(t, as.IsDataAvailable(context.Background(), current, blk))
+		require.Equal(t, len(custody), len(v.verified))
+	})
+	t.Run("pre-fulu block", func(t *testing.T) {
+		dblk, _ := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 1, 1)
+		as := NewLazilyPersistentStoreColumn(filesystem.NewEphemeralDataColumnStorage(t), &mockColumnBatchVerifier{t: t}, custody)
+		require.NoError(t, as.IsDataAvailable(context.Background(), current, dblk))
+	})
+}
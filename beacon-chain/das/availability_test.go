@@ -124,12 +124,12 @@ func TestLazilyPersistent_Missing(t *testing.T) {
 	// Only one commitment persisted, should return error with other indices
 	require.NoError(t, as.Persist(1, scs[2]))
 	err := as.IsDataAvailable(ctx, 1, blk)
-	require.ErrorIs(t, err, errMissingSidecar)
+	require.ErrorIs(t, err, ErrMissingSidecar)
 
 	// All but one persisted, return missing idx
 	require.NoError(t, as.Persist(1, scs[0]))
 	err = as.IsDataAvailable(ctx, 1, blk)
-	require.ErrorIs(t, err, errMissingSidecar)
+	require.ErrorIs(t, err, ErrMissingSidecar)
 
 	// All persisted, return nil
 	require.NoError(t, as.Persist(1, scs...))
@@ -14,7 +14,7 @@ var (
 	ErrDuplicateSidecar   = errors.New("duplicate sidecar stashed in AvailabilityStore")
 	errIndexOutOfBounds   = errors.New("sidecar.index > MAX_BLOBS_PER_BLOCK")
 	errCommitmentMismatch = errors.New("KzgCommitment of sidecar in cache did not match block commitment")
-	errMissingSidecar     = errors.New("no sidecar in cache for block commitment")
+	ErrMissingSidecar     = errors.New("no sidecar in cache for block commitment")
 )
 
 // cacheKey includes the slot so that we can easily iterate through the cache and compare
@@ -104,14 +104,14 @@ func (e *cacheEntry) filter(root [32]byte, kc [][]byte, slot primitives.Slot) ([
 			continue
 		}
 		if kc[i] == nil {
-			if e.scs[i] != nil {
+			if e.stashed(i) != nil {
 				return nil, errors.Wrapf(errCommitmentMismatch, "root=%#x, index=%#x, commitment=%#x, no block commitment", root, i, e.scs[i].KzgCommitment)
 			}
 			continue
 		}
 
-		if e.scs[i] == nil {
-			return nil, errors.Wrapf(errMissingSidecar, "root=%#x, index=%#x", root, i)
+		if e.stashed(i) == nil {
+			return nil, errors.Wrapf(ErrMissingSidecar, "root=%#x, index=%#x", root, i)
 		}
 		if !bytes.Equal(kc[i], e.scs[i].KzgCommitment) {
 			return nil, errors.Wrapf(errCommitmentMismatch, "root=%#x, index=%#x, commitment=%#x, block commitment=%#x", root, i, e.scs[i].KzgCommitment, kc[i])
@@ -121,3 +121,12 @@ func (e *cacheEntry) filter(root [32]byte, kc [][]byte, slot primitives.Slot) ([
 
 	return scs, nil
 }
+
+// stashed returns the BlobSidecar stashed at the given index, or nil if there is none. The sidecar slice is only
+// allocated once the first BlobSidecar is stashed.
+func (e *cacheEntry) stashed(idx uint64) *blocks.ROBlob {
+	if idx >= uint64(len(e.scs)) {
+		return nil
+	}
+	return e.scs[idx]
+}
@@ -144,7 +144,15 @@ func TestFilter(t *testing.T) {
 				entry.scs[5] = nil
 				return entry, commits, expected
 			},
-			err: errMissingSidecar,
+			err: ErrMissingSidecar,
+		},
+		{
+			name: "no sidecars stashed",
+			setup: func(t *testing.T) (*cacheEntry, [][]byte, []blocks.ROBlob) {
+				_, commits, expected := filterTestCaseSetup(denebSlot, 6, []int{0, 1}, 4)(t)
+				return &cacheEntry{}, commits, expected
+			},
+			err: ErrMissingSidecar,
 		},
 		{
 			name: "commitments mismatch - different bytes",
@@ -84,7 +84,10 @@ func NewBlobStorage(opts ...BlobStorageOption) (*BlobStorage, error) {
 		return nil, errors.Wrapf(err, "failed to create blob storage at %s", b.base)
 	}
 	b.fs = afero.NewBasePathFs(afero.NewOsFs(), b.base)
-	pruner, err := newBlobPruner(b.fs, b.retentionEpochs)
+	if b.layout == nil {
+		b.layout = flatLayout{}
+	}
+	pruner, err := newBlobPruner(b.fs, b.retentionEpochs, withLayout(b.layout))
 	if err != nil {
 		return nil, err
 	}
@@ -98,6 +101,7 @@ type BlobStorage struct {
 	retentionEpochs primitives.Epoch
 	fsync           bool
 	fs              afero.Fs
+	layout          fsLayout
 	pruner          *blobPruner
 }
 
@@ -135,8 +139,8 @@ func (bs *BlobStorage) WaitForSummarizer(ctx context.Context) (BlobStorageSummar
 // Save saves blobs given a list of sidecars.
 func (bs *BlobStorage) Save(sidecar blocks.VerifiedROBlob) error {
 	startTime := time.Now()
-	fname := namerForSidecar(sidecar)
-	sszPath := fname.path()
+	ident := identForSidecar(sidecar.BlockRoot(), sidecar.Slot(), sidecar.Index)
+	sszPath := bs.layout.sszPath(ident)
 	exists, err := afero.Exists(bs.fs, sszPath)
 	if err != nil {
 		return err
@@ -159,10 +163,10 @@ func (bs *BlobStorage) Save(sidecar blocks.VerifiedROBlob) error {
 		return errSidecarEmptySSZData
 	}
 
-	if err := bs.fs.MkdirAll(fname.dir(), directoryPermissions); err != nil {
+	if err := bs.fs.MkdirAll(bs.layout.dir(ident), directoryPermissions); err != nil {
 		return err
 	}
-	partPath := fname.partPath(fmt.Sprintf("%p", sidecarData))
+	partPath := bs.layout.partPath(ident, fmt.Sprintf("%p", sidecarData))
 
 	partialMoved := false
 	// Ensure the partial file is deleted.
@@ -228,9 +232,12 @@ func (bs *BlobStorage) Save(sidecar blocks.VerifiedROBlob) error {
 // value is always a VerifiedROBlob.
 func (bs *BlobStorage) Get(root [32]byte, idx uint64) (blocks.VerifiedROBlob, error) {
 	startTime := time.Now()
-	expected := blobNamer{root: root, index: idx}
-	encoded, err := afero.ReadFile(bs.fs, expected.path())
 	var v blocks.VerifiedROBlob
+	ident, err := bs.ident(root, idx)
+	if err != nil {
+		return v, err
+	}
+	encoded, err := afero.ReadFile(bs.fs, bs.layout.sszPath(ident))
 	if err != nil {
 		return v, err
 	}
@@ -250,8 +257,46 @@ func (bs *BlobStorage) Get(root [32]byte, idx uint64) (blocks.VerifiedROBlob, er
 
 // Remove removes all blobs for a given root.
 func (bs *BlobStorage) Remove(root [32]byte) error {
-	rootDir := blobNamer{root: root}.dir()
-	return bs.fs.RemoveAll(rootDir)
+	ident, err := bs.ident(root, 0)
+	if err != nil {
+		if errors.Is(err, errIdentNotCached) {
+			// If the cache doesn't know about the root, there is nothing on disk to remove.
+			return nil
+		}
+		return err
+	}
+	if err := bs.fs.RemoveAll(bs.layout.dir(ident)); err != nil {
+		return err
+	}
+	// The by-epoch layout relies on the cache to find the directory for a root, so it must not outlive the files.
+	if bs.pruner != nil {
+		bs.pruner.cache.evict(root)
+	}
+	return nil
+}
+
+// ident builds the blobIdent for the given root and index. If the layout needs to know the epoch of the block
+// to find its sidecars, the slot is looked up in the pruner cache, or on disk while the cache is not warmed up.
+func (bs *BlobStorage) ident(root [32]byte, idx uint64) (blobIdent, error) {
+	if !bs.layout.needsEpoch() {
+		return blobIdent{root: root, index: idx}, nil
+	}
+	if bs.pruner == nil {
+		return blobIdent{}, errIdentNotCached
+	}
+	slot, ok := bs.pruner.cache.slot(root)
+	if ok {
+		return identForSidecar(root, slot, idx), nil
+	}
+	if bs.pruner.cacheWarmed() {
+		return blobIdent{}, errors.Wrapf(errIdentNotCached, "root=%#x", root)
+	}
+	// Until the cache is warmed up, a root missing from it may still be on disk, so its slot is read from there.
+	slot, err := rootSlotOnDisk(bs.fs, root)
+	if err != nil {
+		return blobIdent{}, err
+	}
+	return identForSidecar(root, slot, idx), nil
 }
 
 // Indices generates a bitmap representing which BlobSidecar.Index values are present on disk for a given root.
@@ -261,7 +306,7 @@ func (bs *BlobStorage) Indices(root [32]byte, s primitives.Slot) ([]bool, error)
 	maxBlobsPerBlock := params.BeaconConfig().MaxBlobsPerBlock(s)
 	mask := make([]bool, maxBlobsPerBlock)
 
-	rootDir := blobNamer{root: root}.dir()
+	rootDir := bs.layout.dir(identForSidecar(root, s, 0))
 	entries, err := afero.ReadDir(bs.fs, rootDir)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -0,0 +1,437 @@
+package filesystem
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/logging"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
+)
+
+var (
+	errColumnIndexOutOfBounds = errors.New("data column index >= NUMBER_OF_COLUMNS")
+	errNoDataColumnBasePath   = errors.New("DataColumnStorage base path not specified in init")
+)
+
+// DataColumnStorageOption is a functional option for configuring a DataColumnStorage.
+type DataColumnStorageOption func(*DataColumnStorage) error
+
+// WithDataColumnBasePath is a required option that sets the base path of data column storage.
+func WithDataColumnBasePath(base string) DataColumnStorageOption {
+	return func(ds *DataColumnStorage) error {
+		ds.base = base
+		return nil
+	}
+}
+
+// WithDataColumnRetentionEpochs is an option that changes the number of epochs data columns will be persisted.
+func WithDataColumnRetentionEpochs(e primitives.Epoch) DataColumnStorageOption {
+	return func(ds *DataColumnStorage) error {
+		ds.retentionEpochs = e
+		return nil
+	}
+}
+
+// NewDataColumnStorage creates a new instance of the DataColumnStorage object. Data columns are always stored
+// using the by-epoch layout, so that pruning can remove whole epoch directories without reading any files.
+func NewDataColumnStorage(opts ...DataColumnStorageOption) (*DataColumnStorage, error) {
+	ds := &DataColumnStorage{retentionEpochs: params.BeaconConfig().MinEpochsForDataColumnSidecarsRequest}
+	for _, o := range opts {
+		if err := o(ds); err != nil {
+			return nil, errors.Wrap(err, "failed to create data column storage")
+		}
+	}
+	if ds.base == "" {
+		return nil, errNoDataColumnBasePath
+	}
+	ds.base = path.Clean(ds.base)
+	if err := file.MkdirAll(ds.base); err != nil {
+		return nil, errors.Wrapf(err, "failed to create data column storage at %s", ds.base)
+	}
+	ds.fs = afero.NewBasePathFs(afero.NewOsFs(), ds.base)
+	ds.cache = newDataColumnStorageCache()
+	return ds, nil
+}
+
+// DataColumnStorage is the concrete implementation of the filesystem backend for saving and retrieving DataColumnSidecars.
+type DataColumnStorage struct {
+	base            string
+	retentionEpochs primitives.Epoch
+	fs              afero.Fs
+	cache           *dataColumnStorageCache
+
+	pruneMu      sync.Mutex
+	highestEpoch primitives.Epoch
+}
+
+// WarmCache populates the cache of stored data columns from the directory listing, and prunes any columns
+// that have fallen outside of the retention period.
+func (ds *DataColumnStorage) WarmCache() {
+	go func() {
+		start := time.Now()
+		log.Info("Data column filesystem cache warm-up started")
+		if _, err := ds.prune(0); err != nil {
+			log.WithError(err).Error("Error encountered while warming up data column storage cache")
+		}
+		log.WithField("elapsed", time.Since(start)).Info("Data column filesystem cache warm-up complete")
+	}()
+}
+
+// Summary returns the DataColumnStorageSummary for the given block root.
+func (ds *DataColumnStorage) Summary(root [32]byte) DataColumnStorageSummary {
+	return ds.cache.Summary(root)
+}
+
+// Save saves the given data column sidecars to disk.
+func (ds *DataColumnStorage) Save(columns ...blocks.VerifiedRODataColumn) error {
+	for _, c := range columns {
+		if err := ds.save(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ds *DataColumnStorage) save(sidecar blocks.VerifiedRODataColumn) error {
+	startTime := time.Now()
+	if sidecar.ColumnIndex >= params.BeaconConfig().NumberOfColumns {
+		return errors.Wrapf(errColumnIndexOutOfBounds, "index=%d", sidecar.ColumnIndex)
+	}
+	ident := identForSidecar(sidecar.BlockRoot(), sidecar.Slot(), sidecar.ColumnIndex)
+	layout := byEpochLayout{}
+	sszPath := layout.sszPath(ident)
+	exists, err := afero.Exists(ds.fs, sszPath)
+	if err != nil {
+		return err
+	}
+	if exists {
+		log.WithFields(logging.DataColumnFields(sidecar.RODataColumn)).Debug("Ignoring a duplicate data column sidecar save attempt")
+		return ds.cache.ensure(sidecar.BlockRoot(), ident.epoch, sidecar.ColumnIndex)
+	}
+
+	sidecarData, err := sidecar.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize data column sidecar data")
+	}
+	if len(sidecarData) == 0 {
+		return errSidecarEmptySSZData
+	}
+	if err := ds.fs.MkdirAll(layout.dir(ident), directoryPermissions); err != nil {
+		return err
+	}
+	partPath := layout.partPath(ident, fmt.Sprintf("%p", sidecarData))
+	if err := afero.WriteFile(ds.fs, partPath, sidecarData, 0600); err != nil {
+		_ = ds.fs.Remove(partPath)
+		return errors.Wrap(err, "failed to write partial file")
+	}
+	// Atomically rename the partial file to its final name.
+	if err := ds.fs.Rename(partPath, sszPath); err != nil {
+		_ = ds.fs.Remove(partPath)
+		return errors.Wrap(err, "failed to rename partial file to final name")
+	}
+	if err := ds.cache.ensure(sidecar.BlockRoot(), ident.epoch, sidecar.ColumnIndex); err != nil {
+		return err
+	}
+	dataColumnsWrittenCounter.Inc()
+	dataColumnSaveLatency.Observe(float64(time.Since(startTime).Milliseconds()))
+	ds.notify(ident.epoch)
+	return nil
+}
+
+// Get retrieves a single DataColumnSidecar by its root and index.
+// Since DataColumnStorage only writes columns that have undergone full verification, the return
+// value is always a VerifiedRODataColumn.
+func (ds *DataColumnStorage) Get(root [32]byte, idx uint64) (blocks.VerifiedRODataColumn, error) {
+	var v blocks.VerifiedRODataColumn
+	sum := ds.cache.Summary(root)
+	if !sum.HasIndex(idx) {
+		return v, errors.Wrapf(errIdentNotCached, "root=%#x, index=%d", root, idx)
+	}
+	encoded, err := afero.ReadFile(ds.fs, byEpochLayout{}.sszPath(blobIdent{root: root, epoch: sum.epoch, index: idx}))
+	if err != nil {
+		return v, err
+	}
+	s := &ethpb.DataColumnSidecar{}
+	if err := s.UnmarshalSSZ(encoded); err != nil {
+		return v, err
+	}
+	ro, err := blocks.NewRODataColumnWithRoot(s, root)
+	if err != nil {
+		return v, err
+	}
+	return verification.DataColumnSidecarNoop(ro)
+}
+
+// Remove removes all data columns for a given root.
+func (ds *DataColumnStorage) Remove(root [32]byte) error {
+	sum := ds.cache.Summary(root)
+	if sum.Count() == 0 {
+		return nil
+	}
+	if err := ds.fs.RemoveAll(byEpochLayout{}.dir(blobIdent{root: root, epoch: sum.epoch})); err != nil {
+		return err
+	}
+	ds.cache.evict(root)
+	return nil
+}
+
+// Clear deletes all files on the filesystem.
+func (ds *DataColumnStorage) Clear() error {
+	dirs, err := listDir(ds.fs, ".")
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if err := ds.fs.RemoveAll(dir); err != nil {
+			return err
+		}
+	}
+	ds.cache.clear()
+	return nil
+}
+
+// WithinRetentionPeriod checks if the requested epoch is within the data column retention period.
+func (ds *DataColumnStorage) WithinRetentionPeriod(requested, current primitives.Epoch) bool {
+	if requested > math.MaxUint64-ds.retentionEpochs {
+		return true
+	}
+	return requested+ds.retentionEpochs >= current
+}
+
+// notify triggers a background prune the first time a column is saved for a new highest epoch.
+func (ds *DataColumnStorage) notify(epoch primitives.Epoch) {
+	ds.pruneMu.Lock()
+	if epoch <= ds.highestEpoch {
+		ds.pruneMu.Unlock()
+		return
+	}
+	ds.highestEpoch = epoch
+	ds.pruneMu.Unlock()
+	if epoch <= ds.retentionEpochs {
+		return
+	}
+	pruneBefore, err := slots.EpochStart(epoch - ds.retentionEpochs)
+	if err != nil {
+		log.WithError(err).Error("Could not compute data column prune slot")
+		return
+	}
+	go func() {
+		if _, err := ds.prune(pruneBefore); err != nil {
+			log.WithError(err).Error("Failed to prune data columns")
+		}
+	}()
+}
+
+// prune removes all epoch directories older than the epoch of pruneBefore. When pruneBefore is zero, nothing is
+// removed and the directory listing is only used to populate the cache.
+func (ds *DataColumnStorage) prune(pruneBefore primitives.Slot) (int, error) {
+	ds.pruneMu.Lock()
+	defer ds.pruneMu.Unlock()
+	start := time.Now()
+	exists, err := afero.DirExists(ds.fs, byEpochBaseDir)
+	if err != nil || !exists {
+		return 0, err
+	}
+	pruneEpoch := slots.ToEpoch(pruneBefore)
+	periods, err := listDir(ds.fs, byEpochBaseDir)
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to list data column directory")
+	}
+	pruned := 0
+	for _, pd := range periods {
+		periodPath := path.Join(byEpochBaseDir, pd)
+		epochs, err := listDir(ds.fs, periodPath)
+		if err != nil {
+			return pruned, errors.Wrapf(err, "unable to list period directory %s", periodPath)
+		}
+		remaining := len(epochs)
+		for _, ed := range epochs {
+			epochPath := path.Join(periodPath, ed)
+			epoch, err := epochFromDir(epochPath)
+			if err != nil {
+				log.WithError(err).WithField("directory", epochPath).Warn("Ignoring unexpected directory in data column storage")
+				continue
+			}
+			if pruneBefore == 0 || epoch >= pruneEpoch {
+				if err := ds.warmEpoch(epochPath, epoch); err != nil {
+					return pruned, err
+				}
+				continue
+			}
+			n, err := ds.removeEpoch(epochPath)
+			pruned += n
+			if err != nil {
+				return pruned, err
+			}
+			remaining -= 1
+		}
+		if remaining == 0 && pruneBefore != 0 {
+			if err := ds.fs.Remove(periodPath); err != nil {
+				return pruned, errors.Wrapf(err, "unable to remove empty period directory %s", periodPath)
+			}
+		}
+	}
+	if pruneBefore != 0 {
+		log.WithFields(logrus.Fields{
+			"upToEpoch":    pruneEpoch,
+			"duration":     time.Since(start).String(),
+			"filesRemoved": pruned,
+		}).Debug("Pruned old data columns")
+		dataColumnsPrunedCounter.Add(float64(pruned))
+	}
+	return pruned, nil
+}
+
+func (ds *DataColumnStorage) warmEpoch(dir string, epoch primitives.Epoch) error {
+	roots, err := listDir(ds.fs, dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list roots in directory %s", dir)
+	}
+	for _, rd := range filter(roots, filterRoot) {
+		rootPath := path.Join(dir, rd)
+		root, err := rootFromDir(rootPath)
+		if err != nil {
+			return err
+		}
+		entries, err := listDir(ds.fs, rootPath)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list data columns in directory %s", rootPath)
+		}
+		for _, fname := range filter(entries, filterSsz) {
+			idx, err := idxFromPath(fname)
+			if err != nil {
+				return errors.Wrapf(err, "index could not be determined for data column file %s", fname)
+			}
+			if err := ds.cache.ensure(root, epoch, idx); err != nil {
+				return errors.Wrapf(err, "could not update cache for data column file %s", fname)
+			}
+		}
+	}
+	return nil
+}
+
+func (ds *DataColumnStorage) removeEpoch(dir string) (int, error) {
+	roots, err := listDir(ds.fs, dir)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to list roots in directory %s", dir)
+	}
+	removed := 0
+	for _, rd := range roots {
+		rootPath := path.Join(dir, rd)
+		entries, err := listDir(ds.fs, rootPath)
+		if err != nil {
+			return removed, errors.Wrapf(err, "failed to list data columns in directory %s", rootPath)
+		}
+		if err := ds.fs.RemoveAll(rootPath); err != nil {
+			return removed, errors.Wrapf(err, "unable to remove data column directory %s", rootPath)
+		}
+		removed += len(filter(entries, filterSsz))
+		if root, err := rootFromDir(rootPath); err == nil {
+			ds.cache.evict(root)
+		}
+	}
+	if err := ds.fs.Remove(dir); err != nil {
+		return removed, errors.Wrapf(err, "unable to remove epoch directory %s", dir)
+	}
+	return removed, nil
+}
+
+// DataColumnStorageSummary represents cached information about the DataColumnSidecars on disk for a block root.
+type DataColumnStorageSummary struct {
+	epoch primitives.Epoch
+	mask  []bool
+}
+
+// HasIndex returns true if the DataColumnSidecar at the given index is available in the filesystem.
+func (s DataColumnStorageSummary) HasIndex(idx uint64) bool {
+	if idx >= uint64(len(s.mask)) {
+		return false
+	}
+	return s.mask[idx]
+}
+
+// AllAvailable returns true if all of the given column indices are available in the filesystem.
+func (s DataColumnStorageSummary) AllAvailable(indices map[uint64]bool) bool {
+	for idx := range indices {
+		if !s.HasIndex(idx) {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns the number of columns stored for the block root.
+func (s DataColumnStorageSummary) Count() int {
+	n := 0
+	for i := range s.mask {
+		if s.mask[i] {
+			n += 1
+		}
+	}
+	return n
+}
+
+type dataColumnStorageCache struct {
+	mu    sync.RWMutex
+	cache map[[32]byte]DataColumnStorageSummary
+}
+
+func newDataColumnStorageCache() *dataColumnStorageCache {
+	return &dataColumnStorageCache{cache: make(map[[32]byte]DataColumnStorageSummary)}
+}
+
+func (c *dataColumnStorageCache) Summary(root [32]byte) DataColumnStorageSummary {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cache[root]
+}
+
+func (c *dataColumnStorageCache) ensure(root [32]byte, epoch primitives.Epoch, idx uint64) error {
+	numberOfColumns := params.BeaconConfig().NumberOfColumns
+	if idx >= numberOfColumns {
+		return errColumnIndexOutOfBounds
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v := c.cache[root]
+	v.epoch = epoch
+	if v.mask == nil {
+		v.mask = make([]bool, numberOfColumns)
+	}
+	if !v.mask[idx] {
+		dataColumnDiskCount.Inc()
+	}
+	v.mask[idx] = true
+	c.cache[root] = v
+	return nil
+}
+
+func (c *dataColumnStorageCache) evict(root [32]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.cache[root]
+	if !ok {
+		return
+	}
+	dataColumnDiskCount.Sub(float64(v.Count()))
+	delete(c.cache, root)
+}
+
+func (c *dataColumnStorageCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache = make(map[[32]byte]DataColumnStorageSummary)
+	dataColumnDiskCount.Set(0)
+}
@@ -0,0 +1,95 @@
+package filesystem
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/spf13/afero"
+)
+
+func testColumnsAtSlot(t *testing.T, slot primitives.Slot) []blocks.VerifiedRODataColumn {
+	_, columns := util.GenerateTestFuluBlockWithColumns(t, [32]byte{}, slot, 1)
+	return verification.FakeVerifyDataColumnSliceForTest(t, columns)
+}
+
+func TestDataColumnStorage_SaveGetRemove(t *testing.T) {
+	fs, ds := NewEphemeralDataColumnStorageWithFs(t)
+	columns := testColumnsAtSlot(t, 100)
+	require.NoError(t, ds.Save(columns[3], columns[7]))
+
+	c := columns[7]
+	root := c.BlockRoot()
+	exists, err := afero.Exists(fs, byEpochLayout{}.sszPath(identForSidecar(root, c.Slot(), c.ColumnIndex)))
+	require.NoError(t, err)
+	require.Equal(t, true, exists)
+
+	sum := ds.Summary(root)
+	require.Equal(t, 2, sum.Count())
+	require.Equal(t, true, sum.HasIndex(3))
+	require.Equal(t, false, sum.HasIndex(4))
+	require.Equal(t, true, sum.AllAvailable(map[uint64]bool{3: true, 7: true}))
+	require.Equal(t, false, sum.AllAvailable(map[uint64]bool{3: true, 8: true}))
+
+	got, err := ds.Get(root, 7)
+	require.NoError(t, err)
+	require.DeepEqual(t, c.DataColumnSidecar, got.DataColumnSidecar)
+
+	// Saving a duplicate is a no-op.
+	require.NoError(t, ds.Save(c))
+	require.Equal(t, 2, ds.Summary(root).Count())
+
+	_, err = ds.Get(root, 4)
+	require.ErrorIs(t, err, errIdentNotCached)
+
+	require.NoError(t, ds.Remove(root))
+	require.Equal(t, 0, ds.Summary(root).Count())
+	_, err = ds.Get(root, 7)
+	require.ErrorIs(t, err, errIdentNotCached)
+}
+
+func TestDataColumnStorage_SaveIndexOutOfBounds(t *testing.T) {
+	ds := NewEphemeralDataColumnStorage(t)
+	c := testColumnsAtSlot(t, 1)[0]
+	c.ColumnIndex = params.BeaconConfig().NumberOfColumns
+	require.ErrorIs(t, ds.Save(c), errColumnIndexOutOfBounds)
+}
+
+func TestDataColumnStorage_WarmAndPrune(t *testing.T) {
+	fs, ds := NewEphemeralDataColumnStorageWithFs(t)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	old := testColumnsAtSlot(t, slotsPerEpoch)
+	recent := testColumnsAtSlot(t, 10*slotsPerEpoch)
+	require.NoError(t, ds.Save(old[0], old[1]))
+	require.NoError(t, ds.Save(recent[0]))
+
+	// A fresh storage instance over the same filesystem should rebuild its cache from the directory listing.
+	warm := &DataColumnStorage{fs: fs, retentionEpochs: ds.retentionEpochs, cache: newDataColumnStorageCache()}
+	pruned, err := warm.prune(0)
+	require.NoError(t, err)
+	require.Equal(t, 0, pruned)
+	require.Equal(t, 2, warm.Summary(old[0].BlockRoot()).Count())
+	require.Equal(t, 1, warm.Summary(recent[0].BlockRoot()).Count())
+
+	pruned, err = warm.prune(5 * slotsPerEpoch)
+	require.NoError(t, err)
+	require.Equal(t, 2, pruned)
+	require.Equal(t, 0, warm.Summary(old[0].BlockRoot()).Count())
+	require.Equal(t, 1, warm.Summary(recent[0].BlockRoot()).Count())
+	exists, err := afero.DirExists(fs, byEpochLayout{}.dir(identForSidecar(old[0].BlockRoot(), old[0].Slot(), 0)))
+	require.NoError(t, err)
+	require.Equal(t, false, exists)
+	_, err = warm.Get(recent[0].BlockRoot(), recent[0].ColumnIndex)
+	require.NoError(t, err)
+}
+
+func TestDataColumnStorage_WithinRetentionPeriod(t *testing.T) {
+	ds := NewEphemeralDataColumnStorage(t)
+	retention := ds.retentionEpochs
+	require.Equal(t, true, ds.WithinRetentionPeriod(10, 10+retention))
+	require.Equal(t, false, ds.WithinRetentionPeriod(10, 11+retention))
+}
@@ -0,0 +1,145 @@
+package filesystem
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+const (
+	// LayoutNameFlat is the original blob storage layout, with one directory per block root
+	// directly under the base path: <root>/<index>.ssz
+	LayoutNameFlat = "flat"
+	// LayoutNameByEpoch groups block root directories by epoch, and epochs by period:
+	// by-epoch/<period>/<epoch>/<root>/<index>.ssz
+	LayoutNameByEpoch = "by-epoch"
+
+	// epochsPerPeriod is the number of epoch directories grouped under each period directory in the by-epoch layout.
+	epochsPerPeriod = primitives.Epoch(4096)
+	// byEpochBaseDir is the subdirectory of the base path holding the by-epoch layout. Keeping it in a separate
+	// subdirectory allows the flat layout to exist alongside it while it is being migrated.
+	byEpochBaseDir = "by-epoch"
+)
+
+var (
+	errInvalidLayoutName = errors.New("unknown blob storage layout name")
+	errIdentNotCached    = errors.Wrap(os.ErrNotExist, "blob storage cache does not know the slot for this root")
+	errInvalidEpochDir   = errors.New("could not parse epoch from directory name")
+)
+
+// LayoutNames lists the valid values for the blob storage layout.
+var LayoutNames = []string{LayoutNameFlat, LayoutNameByEpoch}
+
+// WithLayout is an option that selects the on-disk layout used for blob storage. If the by-epoch layout is chosen,
+// any blobs found in the flat layout are migrated in the background when the cache is warmed.
+func WithLayout(name string) BlobStorageOption {
+	return func(b *BlobStorage) error {
+		l, err := newLayout(name)
+		if err != nil {
+			return err
+		}
+		b.layout = l
+		return nil
+	}
+}
+
+// blobIdent contains all the information needed to locate a blob sidecar on disk, regardless of layout.
+type blobIdent struct {
+	root  [32]byte
+	epoch primitives.Epoch
+	index uint64
+}
+
+func identForSidecar(root [32]byte, slot primitives.Slot, index uint64) blobIdent {
+	return blobIdent{root: root, epoch: slots.ToEpoch(slot), index: index}
+}
+
+// fsLayout determines where blob sidecars are written to and read from within the base directory.
+type fsLayout interface {
+	name() string
+	// needsEpoch is true if the layout can only locate sidecars when the epoch of the block root is known.
+	needsEpoch() bool
+	dir(n blobIdent) string
+	sszPath(n blobIdent) string
+	partPath(n blobIdent, entropy string) string
+}
+
+func newLayout(name string) (fsLayout, error) {
+	switch name {
+	case LayoutNameFlat:
+		return flatLayout{}, nil
+	case LayoutNameByEpoch:
+		return byEpochLayout{}, nil
+	default:
+		return nil, errors.Wrapf(errInvalidLayoutName, "name=%s", name)
+	}
+}
+
+type flatLayout struct{}
+
+var _ fsLayout = flatLayout{}
+
+func (flatLayout) name() string {
+	return LayoutNameFlat
+}
+
+func (flatLayout) needsEpoch() bool {
+	return false
+}
+
+func (flatLayout) dir(n blobIdent) string {
+	return blobNamer{root: n.root, index: n.index}.dir()
+}
+
+func (flatLayout) sszPath(n blobIdent) string {
+	return blobNamer{root: n.root, index: n.index}.path()
+}
+
+func (flatLayout) partPath(n blobIdent, entropy string) string {
+	return blobNamer{root: n.root, index: n.index}.partPath(entropy)
+}
+
+type byEpochLayout struct{}
+
+var _ fsLayout = byEpochLayout{}
+
+func (byEpochLayout) name() string {
+	return LayoutNameByEpoch
+}
+
+func (byEpochLayout) needsEpoch() bool {
+	return true
+}
+
+func (byEpochLayout) dir(n blobIdent) string {
+	return path.Join(epochDir(n.epoch), rootString(n.root))
+}
+
+func (l byEpochLayout) sszPath(n blobIdent) string {
+	return path.Join(l.dir(n), fmt.Sprintf("%d.%s", n.index, sszExt))
+}
+
+func (l byEpochLayout) partPath(n blobIdent, entropy string) string {
+	return path.Join(l.dir(n), fmt.Sprintf("%s-%d.%s", entropy, n.index, partExt))
+}
+
+func periodDir(epoch primitives.Epoch) string {
+	return path.Join(byEpochBaseDir, strconv.FormatUint(uint64(epoch/epochsPerPeriod), 10))
+}
+
+func epochDir(epoch primitives.Epoch) string {
+	return path.Join(periodDir(epoch), strconv.FormatUint(uint64(epoch), 10))
+}
+
+func epochFromDir(dir string) (primitives.Epoch, error) {
+	e, err := strconv.ParseUint(path.Base(dir), 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(errInvalidEpochDir, "dir=%s", dir)
+	}
+	return primitives.Epoch(e), nil
+}
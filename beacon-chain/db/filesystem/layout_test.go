@@ -0,0 +1,198 @@
+package filesystem
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/spf13/afero"
+)
+
+func testSidecarsAtSlot(t *testing.T, slot primitives.Slot, n int) []blocks.VerifiedROBlob {
+	_, sidecars := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, slot, n)
+	scs, err := verification.BlobSidecarSliceNoop(sidecars)
+	require.NoError(t, err)
+	return scs
+}
+
+func byEpochStorage(t *testing.T, fs afero.Fs, opts ...prunerOpt) *BlobStorage {
+	opts = append([]prunerOpt{withLayout(byEpochLayout{})}, opts...)
+	pruner, err := newBlobPruner(fs, params.BeaconConfig().MinEpochsForBlobsSidecarsRequest, opts...)
+	require.NoError(t, err)
+	return &BlobStorage{fs: fs, layout: byEpochLayout{}, pruner: pruner}
+}
+
+func TestNewLayout(t *testing.T) {
+	for _, name := range LayoutNames {
+		l, err := newLayout(name)
+		require.NoError(t, err)
+		require.Equal(t, name, l.name())
+	}
+	_, err := newLayout("bogus")
+	require.ErrorIs(t, err, errInvalidLayoutName)
+}
+
+func TestByEpochLayout_Paths(t *testing.T) {
+	root := [32]byte{0xde, 0xad}
+	n := identForSidecar(root, primitives.Slot(4097*uint64(params.BeaconConfig().SlotsPerEpoch)), 3)
+	l := byEpochLayout{}
+	require.Equal(t, "by-epoch/1/4097/"+rootString(root), l.dir(n))
+	require.Equal(t, "by-epoch/1/4097/"+rootString(root)+"/3.ssz", l.sszPath(n))
+	e, err := epochFromDir("by-epoch/1/4097")
+	require.NoError(t, err)
+	require.Equal(t, primitives.Epoch(4097), e)
+	_, err = epochFromDir("by-epoch/1/derp")
+	require.ErrorIs(t, err, errInvalidEpochDir)
+}
+
+func TestByEpochLayout_SaveGetRemove(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	bs := byEpochStorage(t, fs, withWarmedCache())
+	scs := testSidecarsAtSlot(t, 100, 2)
+	for _, sc := range scs {
+		require.NoError(t, bs.Save(sc))
+	}
+	sc := scs[1]
+	exists, err := afero.Exists(fs, byEpochLayout{}.sszPath(identForSidecar(sc.BlockRoot(), sc.Slot(), sc.Index)))
+	require.NoError(t, err)
+	require.Equal(t, true, exists)
+
+	got, err := bs.Get(sc.BlockRoot(), sc.Index)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, sc.BlobSidecar, got.BlobSidecar)
+	idx, err := bs.Indices(sc.BlockRoot(), sc.Slot())
+	require.NoError(t, err)
+	require.Equal(t, true, idx[0] && idx[1])
+
+	require.NoError(t, bs.Remove(sc.BlockRoot()))
+	_, err = bs.Get(sc.BlockRoot(), sc.Index)
+	require.ErrorIs(t, err, errIdentNotCached)
+	// Removing an unknown root is not an error.
+	require.NoError(t, bs.Remove([32]byte{0x01}))
+}
+
+func TestMigrateFlatToByEpoch(t *testing.T) {
+	fs, flat := NewEphemeralBlobStorageWithFs(t)
+	scs := testSidecarsAtSlot(t, 100, 2)
+	for _, sc := range scs {
+		require.NoError(t, flat.Save(sc))
+	}
+	root := scs[0].BlockRoot()
+	// Simulate an interrupted migration, where one file was already moved.
+	moved := identForSidecar(root, scs[0].Slot(), 0)
+	require.NoError(t, fs.MkdirAll(byEpochLayout{}.dir(moved), directoryPermissions))
+	require.NoError(t, fs.Rename(flatLayout{}.sszPath(moved), byEpochLayout{}.sszPath(moved)))
+	// A directory with only a leftover .part file is cleaned up.
+	partDir := rootString([32]byte{0x02})
+	require.NoError(t, fs.MkdirAll(partDir, directoryPermissions))
+	require.NoError(t, afero.WriteFile(fs, partDir+"/abc-0.part", []byte{}, 0600))
+
+	bs := byEpochStorage(t, fs)
+	require.NoError(t, bs.pruner.warmCache())
+
+	exists, err := afero.DirExists(fs, rootString(root))
+	require.NoError(t, err)
+	require.Equal(t, false, exists)
+	exists, err = afero.DirExists(fs, partDir)
+	require.NoError(t, err)
+	require.Equal(t, false, exists)
+	for _, sc := range scs {
+		got, err := bs.Get(sc.BlockRoot(), sc.Index)
+		require.NoError(t, err)
+		require.DeepSSZEqual(t, sc.BlobSidecar, got.BlobSidecar)
+	}
+	require.Equal(t, true, bs.pruner.cache.Summary(root).AllAvailable(2))
+}
+
+func TestByEpochLayout_Prune(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	bs := byEpochStorage(t, fs, withWarmedCache())
+	spe := params.BeaconConfig().SlotsPerEpoch
+	old := testSidecarsAtSlot(t, spe+1, 2)
+	keep := testSidecarsAtSlot(t, 3*spe, 1)
+	for _, sc := range append(old, keep...) {
+		require.NoError(t, bs.Save(sc))
+	}
+
+	require.NoError(t, bs.pruner.prune(2*spe))
+	oldRoot, keepRoot := old[0].BlockRoot(), keep[0].BlockRoot()
+	exists, err := afero.DirExists(fs, epochDir(1))
+	require.NoError(t, err)
+	require.Equal(t, false, exists)
+	_, ok := bs.pruner.cache.slot(oldRoot)
+	require.Equal(t, false, ok)
+	_, err = bs.Get(keepRoot, 0)
+	require.NoError(t, err)
+
+	// A fresh pruner warming up from the same directory should find the retained root.
+	warm := byEpochStorage(t, fs)
+	require.NoError(t, warm.pruner.warmCache())
+	require.Equal(t, true, warm.pruner.cache.Summary(keepRoot).HasIndex(0))
+	_, ok = warm.pruner.cache.slot(oldRoot)
+	require.Equal(t, false, ok)
+	_, err = warm.Get(keepRoot, 0)
+	require.NoError(t, err)
+}
+
+func TestByEpochLayout_GetRemoveBeforeWarmCache(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	saved := byEpochStorage(t, fs, withWarmedCache())
+	scs := testSidecarsAtSlot(t, 100, 2)
+	for _, sc := range scs {
+		require.NoError(t, saved.Save(sc))
+	}
+	root := scs[0].BlockRoot()
+
+	// A node restarting with the same directory serves and removes the sidecars before warming up its cache.
+	bs := byEpochStorage(t, fs)
+	for _, sc := range scs {
+		got, err := bs.Get(root, sc.Index)
+		require.NoError(t, err)
+		require.DeepSSZEqual(t, sc.BlobSidecar, got.BlobSidecar)
+	}
+	_, err := bs.Get([32]byte{0x01}, 0)
+	require.ErrorIs(t, err, errIdentNotCached)
+	require.NoError(t, bs.Remove(root))
+	exists, err := afero.DirExists(fs, byEpochLayout{}.dir(identForSidecar(root, scs[0].Slot(), 0)))
+	require.NoError(t, err)
+	require.Equal(t, false, exists)
+	require.NoError(t, bs.Remove([32]byte{0x01}))
+}
+
+func TestByEpochLayout_PruneFromOldestEpoch(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	bs := byEpochStorage(t, fs, withWarmedCache())
+	spe := params.BeaconConfig().SlotsPerEpoch
+	first := testSidecarsAtSlot(t, spe+1, 1)
+	second := testSidecarsAtSlot(t, primitives.Slot(epochsPerPeriod)*spe, 1)
+	for _, sc := range append(first, second...) {
+		require.NoError(t, bs.Save(sc))
+	}
+	require.Equal(t, uint64(1), bs.pruner.oldestEpoch.Load())
+
+	require.NoError(t, bs.pruner.prune(2*spe))
+	require.Equal(t, uint64(2), bs.pruner.oldestEpoch.Load())
+	exists, err := afero.DirExists(fs, epochDir(1))
+	require.NoError(t, err)
+	require.Equal(t, false, exists)
+
+	// A sidecar saved for an epoch older than the oldest one is still pruned.
+	backfilled := testSidecarsAtSlot(t, 0, 1)
+	require.NoError(t, bs.Save(backfilled[0]))
+	require.Equal(t, uint64(0), bs.pruner.oldestEpoch.Load())
+
+	next := primitives.Slot(epochsPerPeriod+1) * spe
+	require.NoError(t, bs.pruner.prune(next))
+	require.Equal(t, uint64(epochsPerPeriod+1), bs.pruner.oldestEpoch.Load())
+	for _, dir := range []string{epochDir(0), periodDir(0), epochDir(epochsPerPeriod)} {
+		exists, err := afero.DirExists(fs, dir)
+		require.NoError(t, err)
+		require.Equal(t, false, exists, dir)
+	}
+	_, err = bs.Get(second[0].BlockRoot(), 0)
+	require.ErrorIs(t, err, errIdentNotCached)
+}
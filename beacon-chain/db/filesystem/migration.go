@@ -0,0 +1,75 @@
+package filesystem
+
+import (
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// migrateFlatToByEpoch moves every root directory found in the flat layout into the by-epoch layout.
+// Files are renamed one at a time rather than moving the whole root directory, so that a migration interrupted by
+// a restart can be resumed; files that already exist at their destination are left in place and the flat copy
+// is removed.
+func migrateFlatToByEpoch(fs afero.Fs) error {
+	start := time.Now()
+	entries, err := listDir(fs, ".")
+	if err != nil {
+		return errors.Wrap(err, "unable to list root blobs directory")
+	}
+	dirs := filter(entries, filterRoot)
+	if len(dirs) == 0 {
+		return nil
+	}
+	migrated := 0
+	for _, dir := range dirs {
+		if err := migrateRootDir(fs, dir); err != nil {
+			return errors.Wrapf(err, "failed to migrate blob directory %s", dir)
+		}
+		migrated += 1
+	}
+	log.WithField("directories", migrated).
+		WithField("duration", time.Since(start).String()).
+		Info("Migrated blob storage to the by-epoch layout")
+	return nil
+}
+
+func migrateRootDir(fs afero.Fs, dir string) error {
+	root, err := rootFromDir(dir)
+	if err != nil {
+		return err
+	}
+	entries, err := listDir(fs, dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list blobs in directory %s", dir)
+	}
+	scFiles := filter(entries, filterSsz)
+	// A directory without any ssz files only holds leftover .part files and can simply be removed.
+	if len(scFiles) == 0 {
+		return fs.RemoveAll(dir)
+	}
+	slot, err := slotFromFile(path.Join(dir, scFiles[0]), fs)
+	if err != nil {
+		return errors.Wrapf(err, "slot could not be read from blob file %s", scFiles[0])
+	}
+	layout := byEpochLayout{}
+	target := layout.dir(identForSidecar(root, slot, 0))
+	if err := fs.MkdirAll(target, directoryPermissions); err != nil {
+		return errors.Wrapf(err, "failed to create directory %s", target)
+	}
+	for _, fname := range scFiles {
+		src, dst := path.Join(dir, fname), path.Join(target, fname)
+		exists, err := afero.Exists(fs, dst)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if err := fs.Rename(src, dst); err != nil {
+			return errors.Wrapf(err, "unable to move %s to %s", src, dst)
+		}
+	}
+	return fs.RemoveAll(dir)
+}
@@ -16,7 +16,7 @@ func NewEphemeralBlobStorage(t testing.TB) *BlobStorage {
 	if err != nil {
 		t.Fatal("test setup issue", err)
 	}
-	return &BlobStorage{fs: fs, pruner: pruner}
+	return &BlobStorage{fs: fs, layout: flatLayout{}, pruner: pruner}
 }
 
 // NewEphemeralBlobStorageWithFs can be used by tests that want access to the virtual filesystem
@@ -27,7 +27,7 @@ func NewEphemeralBlobStorageWithFs(t testing.TB) (afero.Fs, *BlobStorage) {
 	if err != nil {
 		t.Fatal("test setup issue", err)
 	}
-	return fs, &BlobStorage{fs: fs, pruner: pruner}
+	return fs, &BlobStorage{fs: fs, layout: flatLayout{}, pruner: pruner}
 }
 
 type BlobMocker struct {
@@ -58,7 +58,7 @@ func (bm *BlobMocker) CreateFakeIndices(root [32]byte, indices ...uint64) error
 // BlockMocker encapsulates things blob path construction to avoid leaking implementation details.
 func NewEphemeralBlobStorageWithMocker(_ testing.TB) (*BlobMocker, *BlobStorage) {
 	fs := afero.NewMemMapFs()
-	bs := &BlobStorage{fs: fs}
+	bs := &BlobStorage{fs: fs, layout: flatLayout{}}
 	return &BlobMocker{fs: fs, bs: bs}, bs
 }
 
@@ -73,3 +73,21 @@ func NewMockBlobStorageSummarizer(t *testing.T, set map[[32]byte][]int) BlobStor
 	}
 	return c
 }
+
+// NewEphemeralDataColumnStorage should only be used for tests.
+// The instance of DataColumnStorage returned is backed by an in-memory virtual filesystem.
+func NewEphemeralDataColumnStorage(t testing.TB) *DataColumnStorage {
+	_, ds := NewEphemeralDataColumnStorageWithFs(t)
+	return ds
+}
+
+// NewEphemeralDataColumnStorageWithFs can be used by tests that want access to the virtual filesystem
+// in order to interact with it outside the parameters of the DataColumnStorage api.
+func NewEphemeralDataColumnStorageWithFs(_ testing.TB) (afero.Fs, *DataColumnStorage) {
+	fs := afero.NewMemMapFs()
+	return fs, &DataColumnStorage{
+		fs:              fs,
+		retentionEpochs: params.BeaconConfig().MinEpochsForDataColumnSidecarsRequest,
+		cache:           newDataColumnStorageCache(),
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/binary"
 	"io"
+	"math"
 	"path"
 	"path/filepath"
 	"strconv"
@@ -35,10 +36,22 @@ type blobPruner struct {
 	cacheReady   chan struct{}
 	warmed       bool
 	fs           afero.Fs
+	layout       fsLayout
+	// oldestEpoch is the oldest epoch which may have a directory in the by-epoch layout. Once the epoch
+	// directories have been listed, pruning only visits the epochs from there up to the pruning boundary.
+	oldestEpoch  atomic.Uint64
+	epochsListed bool
 }
 
 type prunerOpt func(*blobPruner) error
 
+func withLayout(l fsLayout) prunerOpt {
+	return func(p *blobPruner) error {
+		p.layout = l
+		return nil
+	}
+}
+
 func withWarmedCache() prunerOpt {
 	return func(p *blobPruner) error {
 		return p.warmCache()
@@ -51,7 +64,8 @@ func newBlobPruner(fs afero.Fs, retain primitives.Epoch, opts ...prunerOpt) (*bl
 		return nil, errors.Wrap(err, "could not set retentionSlots")
 	}
 	cw := make(chan struct{})
-	p := &blobPruner{fs: fs, windowSize: r, cache: newBlobStorageCache(), cacheReady: cw}
+	p := &blobPruner{fs: fs, windowSize: r, cache: newBlobStorageCache(), cacheReady: cw, layout: flatLayout{}}
+	p.oldestEpoch.Store(math.MaxUint64)
 	for _, o := range opts {
 		if err := o(p); err != nil {
 			return nil, err
@@ -66,6 +80,7 @@ func (p *blobPruner) notify(root [32]byte, latest primitives.Slot, idx uint64) e
 	if err := p.cache.ensure(root, latest, idx); err != nil {
 		return err
 	}
+	p.lowerOldestEpoch(slots.ToEpoch(latest))
 	pruned := uint64(windowMin(latest, p.windowSize))
 	if p.prunedBefore.Swap(pruned) == pruned {
 		return nil
@@ -98,12 +113,27 @@ func (p *blobPruner) warmCache() error {
 		}
 		p.Unlock()
 	}()
+	if p.layout.name() == LayoutNameByEpoch {
+		if err := migrateFlatToByEpoch(p.fs); err != nil {
+			return errors.Wrap(err, "failed to migrate blobs to the by-epoch layout")
+		}
+	}
 	if err := p.prune(0); err != nil {
 		return err
 	}
 	return nil
 }
 
+// cacheWarmed is true once the cache knows about every blob on disk.
+func (p *blobPruner) cacheWarmed() bool {
+	select {
+	case <-p.cacheReady:
+		return true
+	default:
+		return false
+	}
+}
+
 func (p *blobPruner) waitForCache(ctx context.Context) (*blobStorageCache, error) {
 	select {
 	case <-p.cacheReady:
@@ -136,6 +166,18 @@ func (p *blobPruner) prune(pruneBefore primitives.Slot) error {
 		}()
 	}
 
+	if p.layout.name() == LayoutNameByEpoch {
+		var err error
+		totalPruned, totalErr, err = p.pruneEpochs(pruneBefore)
+		if err != nil {
+			return err
+		}
+		if totalErr > 0 {
+			return errors.Wrapf(errPruningFailures, "pruning failed for %d epoch directories", totalErr)
+		}
+		return nil
+	}
+
 	entries, err := listDir(p.fs, ".")
 	if err != nil {
 		return errors.Wrap(err, "unable to list root blobs directory")
@@ -226,6 +268,249 @@ func (p *blobPruner) tryPruneDir(dir string, pruneBefore primitives.Slot) (int,
 	return len(scFiles), nil
 }
 
+// pruneEpochs is the by-epoch layout counterpart of the root directory scan done for the flat layout. Because
+// the epoch of every root is encoded in its path, whole epoch (and period) directories can be removed without
+// reading any blob files. The epoch directories are listed when pruneBefore is zero, to populate the cache, or
+// the first time pruning runs. Afterwards only the epochs from the oldest remaining one are visited.
+func (p *blobPruner) pruneEpochs(pruneBefore primitives.Slot) (pruned int, failed int, err error) {
+	pruneEpoch := slots.ToEpoch(pruneBefore)
+	if pruneBefore != 0 && p.epochsListed {
+		pruned, failed = p.pruneOldestEpochs(pruneEpoch)
+		return pruned, failed, nil
+	}
+	exists, err := afero.DirExists(p.fs, byEpochBaseDir)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "unable to check for directory %s", byEpochBaseDir)
+	}
+	if !exists {
+		p.epochsListed = true
+		return 0, 0, nil
+	}
+	periods, err := listDir(p.fs, byEpochBaseDir)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "unable to list by-epoch blobs directory")
+	}
+	before, oldest := p.oldestEpoch.Load(), uint64(math.MaxUint64)
+	for _, pd := range periods {
+		periodPath := path.Join(byEpochBaseDir, pd)
+		epochs, err := listDir(p.fs, periodPath)
+		if err != nil {
+			failed += 1
+			log.WithError(err).WithField("directory", periodPath).Error("Unable to list period directory")
+			continue
+		}
+		remaining := len(epochs)
+		for _, ed := range epochs {
+			epochPath := path.Join(periodPath, ed)
+			epoch, err := epochFromDir(epochPath)
+			if err != nil {
+				failed += 1
+				log.WithError(err).WithField("directory", epochPath).Error("Unable to prune directory")
+				continue
+			}
+			if pruneBefore == 0 || epoch >= pruneEpoch {
+				oldest = min(oldest, uint64(epoch))
+				if err := p.warmEpoch(epochPath, epoch); err != nil {
+					failed += 1
+					log.WithError(err).WithField("directory", epochPath).Error("Unable to populate cache for directory")
+				}
+				continue
+			}
+			n, err := p.removeEpoch(epochPath)
+			pruned += n
+			if err != nil {
+				oldest = min(oldest, uint64(epoch))
+				failed += 1
+				log.WithError(err).WithField("directory", epochPath).Error("Unable to prune directory")
+				continue
+			}
+			remaining -= 1
+		}
+		if remaining == 0 && pruneBefore != 0 {
+			if err := p.fs.Remove(periodPath); err != nil {
+				log.WithError(err).WithField("directory", periodPath).Error("Unable to remove empty period directory")
+			}
+		}
+	}
+	if failed == 0 {
+		p.epochsListed = true
+		if pruneBefore != 0 {
+			p.oldestEpoch.CompareAndSwap(before, oldest)
+		}
+	}
+	p.lowerOldestEpoch(primitives.Epoch(oldest))
+	return pruned, failed, nil
+}
+
+// pruneOldestEpochs removes the epoch directories from the oldest remaining epoch up to pruneEpoch, skipping
+// over the periods without a directory.
+func (p *blobPruner) pruneOldestEpochs(pruneEpoch primitives.Epoch) (pruned int, failed int) {
+	oldest := p.oldestEpoch.Load()
+	if oldest >= uint64(pruneEpoch) {
+		return 0, 0
+	}
+	next := pruneEpoch
+	for epoch := primitives.Epoch(oldest); epoch < pruneEpoch; epoch++ {
+		periodPath := periodDir(epoch)
+		periodEnd := (epoch/epochsPerPeriod + 1) * epochsPerPeriod
+		exists, err := afero.DirExists(p.fs, periodPath)
+		if err != nil {
+			failed += 1
+			next = min(next, epoch)
+			log.WithError(err).WithField("directory", periodPath).Error("Unable to check for period directory")
+			continue
+		}
+		if !exists {
+			epoch = periodEnd - 1
+			continue
+		}
+		epochPath := epochDir(epoch)
+		exists, err = afero.DirExists(p.fs, epochPath)
+		if err != nil {
+			failed += 1
+			next = min(next, epoch)
+			log.WithError(err).WithField("directory", epochPath).Error("Unable to check for epoch directory")
+			continue
+		}
+		if exists {
+			n, err := p.removeEpoch(epochPath)
+			pruned += n
+			if err != nil {
+				failed += 1
+				next = min(next, epoch)
+				log.WithError(err).WithField("directory", epochPath).Error("Unable to prune directory")
+				continue
+			}
+		}
+		if epoch+1 == periodEnd && next == pruneEpoch {
+			if err := p.fs.Remove(periodPath); err != nil {
+				log.WithError(err).WithField("directory", periodPath).Error("Unable to remove empty period directory")
+			}
+		}
+	}
+	// Sidecars saved for an older epoch in the meantime lowered the oldest epoch, which must then be kept.
+	p.oldestEpoch.CompareAndSwap(oldest, uint64(next))
+	return pruned, failed
+}
+
+// lowerOldestEpoch records that a directory may exist for the given epoch.
+func (p *blobPruner) lowerOldestEpoch(epoch primitives.Epoch) {
+	for {
+		oldest := p.oldestEpoch.Load()
+		if uint64(epoch) >= oldest || p.oldestEpoch.CompareAndSwap(oldest, uint64(epoch)) {
+			return
+		}
+	}
+}
+
+// rootSlotOnDisk searches the epoch directories of the by-epoch layout for the directory of the given root,
+// and reads the slot from one of its sidecars.
+func rootSlotOnDisk(fs afero.Fs, root [32]byte) (primitives.Slot, error) {
+	notFound := errors.Wrapf(errIdentNotCached, "root=%#x", root)
+	exists, err := afero.DirExists(fs, byEpochBaseDir)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to check for directory %s", byEpochBaseDir)
+	}
+	if !exists {
+		return 0, notFound
+	}
+	periods, err := listDir(fs, byEpochBaseDir)
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to list by-epoch blobs directory")
+	}
+	for _, pd := range periods {
+		epochs, err := listDir(fs, path.Join(byEpochBaseDir, pd))
+		if err != nil {
+			return 0, errors.Wrapf(err, "unable to list period directory %s", pd)
+		}
+		for _, ed := range epochs {
+			rootPath := path.Join(byEpochBaseDir, pd, ed, rootString(root))
+			exists, err := afero.DirExists(fs, rootPath)
+			if err != nil {
+				return 0, errors.Wrapf(err, "unable to check for directory %s", rootPath)
+			}
+			if !exists {
+				continue
+			}
+			entries, err := listDir(fs, rootPath)
+			if err != nil {
+				return 0, errors.Wrapf(err, "failed to list blobs in directory %s", rootPath)
+			}
+			scFiles := filter(entries, filterSsz)
+			if len(scFiles) == 0 {
+				continue
+			}
+			return slotFromFile(path.Join(rootPath, scFiles[0]), fs)
+		}
+	}
+	return 0, notFound
+}
+
+// warmEpoch populates the cache for every root under the given epoch directory, using the first slot of the
+// epoch as the slot for each root. The exact slot is not needed to locate files in the by-epoch layout.
+func (p *blobPruner) warmEpoch(dir string, epoch primitives.Epoch) error {
+	slot, err := slots.EpochStart(epoch)
+	if err != nil {
+		return err
+	}
+	roots, err := listDir(p.fs, dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list roots in directory %s", dir)
+	}
+	for _, rd := range filter(roots, filterRoot) {
+		rootPath := path.Join(dir, rd)
+		root, err := rootFromDir(rootPath)
+		if err != nil {
+			return err
+		}
+		if _, ok := p.cache.slot(root); ok {
+			continue
+		}
+		entries, err := listDir(p.fs, rootPath)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list blobs in directory %s", rootPath)
+		}
+		for _, fname := range filter(entries, filterSsz) {
+			idx, err := idxFromPath(fname)
+			if err != nil {
+				return errors.Wrapf(err, "index could not be determined for blob file %s", fname)
+			}
+			if err := p.cache.ensure(root, slot, idx); err != nil {
+				return errors.Wrapf(err, "could not update prune cache for blob file %s", fname)
+			}
+		}
+	}
+	return nil
+}
+
+// removeEpoch deletes an epoch directory and evicts all of the roots it contains from the cache. It returns the
+// number of blob files that were removed.
+func (p *blobPruner) removeEpoch(dir string) (int, error) {
+	roots, err := listDir(p.fs, dir)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to list roots in directory %s", dir)
+	}
+	removed := 0
+	for _, rd := range roots {
+		rootPath := path.Join(dir, rd)
+		entries, err := listDir(p.fs, rootPath)
+		if err != nil {
+			return removed, errors.Wrapf(err, "failed to list blobs in directory %s", rootPath)
+		}
+		if err := p.fs.RemoveAll(rootPath); err != nil {
+			return removed, errors.Wrapf(err, "unable to remove blob directory %s", rootPath)
+		}
+		removed += len(filter(entries, filterSsz))
+		if root, err := rootFromDir(rootPath); err == nil {
+			p.cache.evict(root)
+		}
+	}
+	if err := p.fs.Remove(dir); err != nil {
+		return removed, errors.Wrapf(err, "unable to remove epoch directory %s", dir)
+	}
+	return removed, nil
+}
+
 func idxFromPath(fname string) (uint64, error) {
 	fname = path.Base(fname)
 
@@ -41,6 +41,8 @@ type ReadOnlyDatabase interface {
 	StateSummary(ctx context.Context, blockRoot [32]byte) (*ethpb.StateSummary, error)
 	HasStateSummary(ctx context.Context, blockRoot [32]byte) bool
 	HighestSlotStatesBelow(ctx context.Context, slot primitives.Slot) ([]state.ReadOnlyBeaconState, error)
+	HierarchicalStateForSlot(ctx context.Context, slot primitives.Slot) (state.BeaconState, error)
+	StateDiffInterval() primitives.Slot
 	// Checkpoint operations.
 	JustifiedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
 	FinalizedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
@@ -49,6 +51,8 @@ type ReadOnlyDatabase interface {
 	LastArchivedRoot(ctx context.Context) [32]byte
 	LastArchivedSlot(ctx context.Context) (primitives.Slot, error)
 	LastValidatedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
+	// Fork choice snapshot operations.
+	ForkchoiceSnapshot(ctx context.Context) ([]byte, error)
 	// Deposit contract related handlers.
 	DepositContractAddress(ctx context.Context) ([]byte, error)
 	// ExecutionChainData operations.
@@ -64,6 +68,9 @@ type ReadOnlyDatabase interface {
 	// origin checkpoint sync support
 	OriginCheckpointBlockRoot(ctx context.Context) ([32]byte, error)
 	BackfillStatus(context.Context) (*dbval.BackfillStatus, error)
+	BlobBackfillStatus(context.Context) (*dbval.BlobBackfillStatus, error)
+	// history pruning support
+	AvailableBlock(primitives.Slot) bool
 }
 
 // NoHeadAccessDatabase defines a struct without access to chain head data.
@@ -83,10 +90,13 @@ type NoHeadAccessDatabase interface {
 	DeleteStates(ctx context.Context, blockRoots [][32]byte) error
 	SaveStateSummary(ctx context.Context, summary *ethpb.StateSummary) error
 	SaveStateSummaries(ctx context.Context, summaries []*ethpb.StateSummary) error
+	SaveHierarchicalState(ctx context.Context, state state.ReadOnlyBeaconState) error
 	// Checkpoint operations.
 	SaveJustifiedCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error
 	SaveFinalizedCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error
 	SaveLastValidatedCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error
+	// Fork choice snapshot operations.
+	SaveForkchoiceSnapshot(ctx context.Context, enc []byte) error
 	// Deposit contract related handlers.
 	SaveDepositContractAddress(ctx context.Context, addr common.Address) error
 	// SaveExecutionChainData operations.
@@ -119,6 +129,7 @@ type HeadAccessDatabase interface {
 	// Support for checkpoint sync and backfill.
 	SaveOrigin(ctx context.Context, serState, serBlock []byte) error
 	SaveBackfillStatus(context.Context, *dbval.BackfillStatus) error
+	SaveBlobBackfillStatus(context.Context, *dbval.BlobBackfillStatus) error
 	BackfillFinalizedIndex(ctx context.Context, blocks []blocks.ROBlock, finalizedChildRoot [32]byte) error
 }
 
@@ -147,6 +158,20 @@ type SlasherDatabase interface {
 	BlockProposalForValidator(
 		ctx context.Context, validatorIdx primitives.ValidatorIndex, slot primitives.Slot,
 	) (*slashertypes.SignedBlockHeaderWrapper, error)
+	AttestationRecordsForValidator(
+		ctx context.Context, validatorIdx primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch,
+	) ([]*slashertypes.IndexedAttestationWrapper, error)
+	BlockProposalsForValidator(
+		ctx context.Context, validatorIdx primitives.ValidatorIndex, startSlot, endSlot primitives.Slot,
+	) ([]*slashertypes.SignedBlockHeaderWrapper, error)
+	SaveAttesterSlashings(ctx context.Context, slashings []ethpb.AttSlashing) error
+	SaveProposerSlashings(ctx context.Context, slashings []*ethpb.ProposerSlashing) error
+	AttesterSlashings(
+		ctx context.Context, startEpoch, endEpoch primitives.Epoch,
+	) ([]ethpb.AttSlashing, error)
+	ProposerSlashings(
+		ctx context.Context, startSlot, endSlot primitives.Slot,
+	) ([]*ethpb.ProposerSlashing, error)
 	CheckAttesterDoubleVotes(
 		ctx context.Context, attestations []*slashertypes.IndexedAttestationWrapper,
 	) ([]*slashertypes.AttesterDoubleVote, error)
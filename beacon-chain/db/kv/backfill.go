@@ -42,3 +42,30 @@ func (s *Store) BackfillStatus(ctx context.Context) (*dbval.BackfillStatus, erro
 	})
 	return bf, err
 }
+
+// SaveBlobBackfillStatus writes the given BlobBackfillStatus to a single key in the db. This value is used by the
+// blob backfill process to keep track of the range of blocks whose BlobSidecars are known to be on disk.
+func (s *Store) SaveBlobBackfillStatus(ctx context.Context, bf *dbval.BlobBackfillStatus) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveBlobBackfillStatus")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(blocksBucket)
+		return bucket.Put(blobBackfillStatusKey, bf.Marshal())
+	})
+}
+
+// BlobBackfillStatus retrieves the most recently saved version of the BlobBackfillStatus value.
+func (s *Store) BlobBackfillStatus(ctx context.Context) (*dbval.BlobBackfillStatus, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.BlobBackfillStatus")
+	defer span.End()
+	bf := &dbval.BlobBackfillStatus{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(blocksBucket)
+		bs := bucket.Get(blobBackfillStatusKey)
+		if len(bs) == 0 {
+			return errors.Wrap(ErrNotFound, "BlobBackfillStatus not found")
+		}
+		return bf.Unmarshal(bs)
+	})
+	return bf, err
+}
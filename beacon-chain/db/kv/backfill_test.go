@@ -33,3 +33,18 @@ func TestBackfillRoundtrip(t *testing.T) {
 	require.DeepEqual(t, b.LowRoot, dbub.LowRoot)
 	require.DeepEqual(t, b.LowParentRoot, dbub.LowParentRoot)
 }
+
+func TestBlobBackfillRoundtrip(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+	_, err := db.BlobBackfillStatus(ctx)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	b := &dbval.BlobBackfillStatus{LowSlot: 23, HighSlot: 42}
+	require.NoError(t, db.SaveBlobBackfillStatus(ctx, b))
+	dbub, err := db.BlobBackfillStatus(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, b, dbub)
+
+	require.ErrorContains(t, "lower than low slot", dbub.Unmarshal((&dbval.BlobBackfillStatus{LowSlot: 2, HighSlot: 1}).Marshal()))
+}
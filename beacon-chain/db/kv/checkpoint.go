@@ -85,8 +85,12 @@ func (s *Store) SaveFinalizedCheckpoint(ctx context.Context, checkpoint *ethpb.C
 
 		return s.updateFinalizedBlockRoots(ctx, tx, checkpoint)
 	})
-	tracing.AnnotateError(span, err)
-	return err
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return err
+	}
+	s.notifyFinalized(checkpoint.Epoch)
+	return nil
 }
 
 func (s *Store) saveCheckpoint(ctx context.Context, key []byte, checkpoint *ethpb.Checkpoint) error {
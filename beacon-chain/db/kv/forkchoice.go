@@ -0,0 +1,39 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ErrNotFoundForkchoiceSnapshot means no fork choice snapshot has been saved.
+var ErrNotFoundForkchoiceSnapshot = errors.Wrap(ErrNotFound, "forkchoice snapshot")
+
+// SaveForkchoiceSnapshot saves a serialized fork choice store, replacing any previous snapshot.
+func (s *Store) SaveForkchoiceSnapshot(ctx context.Context, enc []byte) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveForkchoiceSnapshot")
+	defer span.End()
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(chainMetadataBucket).Put(forkchoiceSnapshotKey, snappy.Encode(nil, enc))
+	})
+}
+
+// ForkchoiceSnapshot returns the serialized fork choice store saved by SaveForkchoiceSnapshot.
+func (s *Store) ForkchoiceSnapshot(ctx context.Context) ([]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ForkchoiceSnapshot")
+	defer span.End()
+	var enc []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(chainMetadataBucket).Get(forkchoiceSnapshotKey)
+		if v == nil {
+			return ErrNotFoundForkchoiceSnapshot
+		}
+		var err error
+		enc, err = snappy.Decode(nil, v)
+		return err
+	})
+	return enc, err
+}
@@ -0,0 +1,22 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestStore_ForkchoiceSnapshot(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	_, err := db.ForkchoiceSnapshot(ctx)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, db.SaveForkchoiceSnapshot(ctx, []byte("first")))
+	require.NoError(t, db.SaveForkchoiceSnapshot(ctx, []byte("second")))
+	enc, err := db.ForkchoiceSnapshot(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte("second"), enc)
+}
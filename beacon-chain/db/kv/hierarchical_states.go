@@ -0,0 +1,293 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math/bits"
+	"slices"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	statenative "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+// Hierarchical states are cold states stored by slot, at the multiples of a list of power of 2 intervals.
+// The states at the multiples of the largest interval are stored in full. The states at the multiples of each
+// smaller interval are stored as a diff against the state at the previous multiple of the next larger interval.
+// Any of them can be rebuilt from a full state and at most one diff per smaller interval.
+const (
+	hierarchicalSnapshot byte = iota
+	hierarchicalDiff
+)
+
+var (
+	errStateDiffsDisabled      = errors.New("state diffs are not enabled")
+	errNotHierarchicalSlot     = errors.New("slot is not a multiple of the smallest state diff interval")
+	errCorruptHierarchicalData = errors.New("corrupt hierarchical state")
+	errStateDiffExponents      = errors.New("state diff exponents do not match the hierarchical states in the db")
+)
+
+var hierarchicalStateSaved = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "db_hierarchical_states_saved_total",
+	Help: "Number of hierarchical states saved to the db, by kind (snapshot or diff).",
+}, []string{"kind"})
+
+// WithStateDiffExponents enables the storage of cold states as hierarchical state diffs, at the multiples of
+// 2^exponent slots for each of the given exponents.
+func WithStateDiffExponents(exponents []uint64) KVStoreOption {
+	return func(s *Store) {
+		intervals := make([]primitives.Slot, 0, len(exponents))
+		for _, e := range exponents {
+			intervals = append(intervals, primitives.Slot(1)<<e)
+		}
+		// Largest interval first, so that the index of an interval is its level in the hierarchy.
+		slices.Sort(intervals)
+		slices.Reverse(intervals)
+		s.stateDiffIntervals = slices.Compact(intervals)
+	}
+}
+
+// StateDiffInterval returns the smallest interval at which hierarchical states are saved,
+// or 0 if state diffs are not enabled.
+func (s *Store) StateDiffInterval() primitives.Slot {
+	if len(s.stateDiffIntervals) == 0 {
+		return 0
+	}
+	return s.stateDiffIntervals[len(s.stateDiffIntervals)-1]
+}
+
+// stateDiffExponents returns the exponents of the state diff intervals, largest first.
+func (s *Store) stateDiffExponents() []uint64 {
+	exponents := make([]uint64, len(s.stateDiffIntervals))
+	for i, interval := range s.stateDiffIntervals {
+		exponents[i] = uint64(bits.TrailingZeros64(uint64(interval)))
+	}
+	return exponents
+}
+
+func encodeStateDiffExponents(exponents []uint64) []byte {
+	enc := make([]byte, 0, 8*len(exponents))
+	for _, e := range exponents {
+		enc = binary.BigEndian.AppendUint64(enc, e)
+	}
+	return enc
+}
+
+// checkStateDiffExponents makes sure that the configured state diff exponents are the ones the hierarchical states
+// in the db were saved with, since states can't be rebuilt from diffs saved for other intervals.
+func (s *Store) checkStateDiffExponents() error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(chainMetadataBucket).Get(stateDiffExponentsKey)
+		if enc == nil || bytes.Equal(enc, encodeStateDiffExponents(s.stateDiffExponents())) {
+			return nil
+		}
+		saved := make([]uint64, 0, len(enc)/8)
+		for i := 0; i+8 <= len(enc); i += 8 {
+			saved = append(saved, binary.BigEndian.Uint64(enc[i:i+8]))
+		}
+		if len(s.stateDiffIntervals) == 0 {
+			return errors.Wrapf(errStateDiffExponents, "db has hierarchical states saved with exponents %v, which must still be configured", saved)
+		}
+		return errors.Wrapf(errStateDiffExponents, "db has hierarchical states saved with exponents %v, configured exponents are %v", saved, s.stateDiffExponents())
+	})
+}
+
+// SaveHierarchicalState saves the given state, whose slot must be a multiple of StateDiffInterval, as a full state
+// or as a diff against a previously saved hierarchical state. A full state is saved when there is no such state,
+// for instance for the first states saved after checkpoint sync, or when the fork changed in between.
+func (s *Store) SaveHierarchicalState(ctx context.Context, st state.ReadOnlyBeaconState) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveHierarchicalState")
+	defer span.End()
+	if len(s.stateDiffIntervals) == 0 {
+		return errStateDiffsDisabled
+	}
+	slot := st.Slot()
+	level := slices.IndexFunc(s.stateDiffIntervals, func(i primitives.Slot) bool { return slot%i == 0 })
+	if level < 0 {
+		return errors.Wrapf(errNotHierarchicalSlot, "slot %d", slot)
+	}
+	target, ok := st.ToProtoUnsafe().(proto.Message)
+	if !ok {
+		return errors.New("state is not backed by a proto message")
+	}
+
+	enc, kind, err := s.encodeHierarchicalState(ctx, level, slot, st.Version(), target)
+	if err != nil {
+		return err
+	}
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		// Record the exponents with the first hierarchical state, so that the db is not opened with others later.
+		bkt := tx.Bucket(chainMetadataBucket)
+		if bkt.Get(stateDiffExponentsKey) == nil {
+			if err := bkt.Put(stateDiffExponentsKey, encodeStateDiffExponents(s.stateDiffExponents())); err != nil {
+				return err
+			}
+		}
+		return tx.Bucket(stateDiffBucket).Put(bytesutil.SlotToBytesBigEndian(slot), enc)
+	}); err != nil {
+		return err
+	}
+	hierarchicalStateSaved.WithLabelValues(kind).Inc()
+	return nil
+}
+
+func (s *Store) encodeHierarchicalState(ctx context.Context, level int, slot primitives.Slot, v int, target proto.Message) ([]byte, string, error) {
+	if level > 0 {
+		baseSlot := slot - slot%s.stateDiffIntervals[level-1]
+		base, baseVersion, err := s.hierarchicalStateProto(ctx, baseSlot)
+		if err != nil && !errors.Is(err, ErrNotFoundState) {
+			return nil, "", errors.Wrapf(err, "could not load base state at slot %d", baseSlot)
+		}
+		if err == nil && baseVersion == v {
+			diff, err := computeStateDiff(base, target)
+			if err != nil {
+				return nil, "", errors.Wrapf(err, "could not compute diff of state at slot %d against slot %d", slot, baseSlot)
+			}
+			enc := []byte{hierarchicalDiff, byte(v)}
+			enc = binary.BigEndian.AppendUint64(enc, uint64(baseSlot))
+			return append(enc, snappy.Encode(nil, diff)...), "diff", nil
+		}
+	}
+	m, ok := target.(sszMarshaler)
+	if !ok {
+		return nil, "", errors.Errorf("state proto %T has no ssz encoding", target)
+	}
+	b, err := m.MarshalSSZ()
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "could not marshal state at slot %d", slot)
+	}
+	return append([]byte{hierarchicalSnapshot, byte(v)}, snappy.Encode(nil, b)...), "snapshot", nil
+}
+
+// HierarchicalStateForSlot returns the hierarchical state at the highest multiple of StateDiffInterval that is not
+// greater than the given slot. ErrNotFoundState is returned if that state was not saved, or if state diffs are not
+// enabled.
+func (s *Store) HierarchicalStateForSlot(ctx context.Context, slot primitives.Slot) (state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.HierarchicalStateForSlot")
+	defer span.End()
+	interval := s.StateDiffInterval()
+	if interval == 0 {
+		return nil, errors.Wrap(ErrNotFoundState, errStateDiffsDisabled.Error())
+	}
+	anchor := slot - slot%interval
+	p, v, err := s.hierarchicalStateProto(ctx, anchor)
+	if err != nil {
+		return nil, err
+	}
+	return initializeStateFromProto(v, p)
+}
+
+// hierarchicalStateProto decodes the hierarchical state saved at the given slot, applying the diffs between it and
+// the full state it is based on.
+func (s *Store) hierarchicalStateProto(ctx context.Context, slot primitives.Slot) (proto.Message, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	var enc []byte
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		enc = bytes.Clone(tx.Bucket(stateDiffBucket).Get(bytesutil.SlotToBytesBigEndian(slot)))
+		return nil
+	}); err != nil {
+		return nil, 0, err
+	}
+	if len(enc) == 0 {
+		return nil, 0, errors.Wrapf(ErrNotFoundState, "no hierarchical state at slot %d", slot)
+	}
+	if len(enc) < 2 {
+		return nil, 0, errCorruptHierarchicalData
+	}
+	kind, v := enc[0], int(enc[1])
+	switch kind {
+	case hierarchicalSnapshot:
+		b, err := snappy.Decode(nil, enc[2:])
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "could not decompress state at slot %d", slot)
+		}
+		p, err := newStateProto(v)
+		if err != nil {
+			return nil, 0, err
+		}
+		if err := p.(sszUnmarshaler).UnmarshalSSZ(b); err != nil {
+			return nil, 0, errors.Wrapf(err, "could not unmarshal state at slot %d", slot)
+		}
+		return p, v, nil
+	case hierarchicalDiff:
+		if len(enc) < 10 {
+			return nil, 0, errCorruptHierarchicalData
+		}
+		baseSlot := primitives.Slot(binary.BigEndian.Uint64(enc[2:10]))
+		if baseSlot >= slot {
+			return nil, 0, errors.Wrapf(errCorruptHierarchicalData, "diff at slot %d is based on slot %d", slot, baseSlot)
+		}
+		diff, err := snappy.Decode(nil, enc[10:])
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "could not decompress state diff at slot %d", slot)
+		}
+		p, baseVersion, err := s.hierarchicalStateProto(ctx, baseSlot)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "could not load base of state diff at slot %d", slot)
+		}
+		if baseVersion != v {
+			return nil, 0, errors.Wrapf(errCorruptHierarchicalData, "%s diff at slot %d is based on a %s state", version.String(v), slot, version.String(baseVersion))
+		}
+		if err := applyStateDiff(p, diff); err != nil {
+			return nil, 0, errors.Wrapf(err, "could not apply state diff at slot %d", slot)
+		}
+		return p, v, nil
+	default:
+		return nil, 0, errors.Wrapf(errCorruptHierarchicalData, "unknown kind %d at slot %d", kind, slot)
+	}
+}
+
+func newStateProto(v int) (proto.Message, error) {
+	switch v {
+	case version.Phase0:
+		return &ethpb.BeaconState{}, nil
+	case version.Altair:
+		return &ethpb.BeaconStateAltair{}, nil
+	case version.Bellatrix:
+		return &ethpb.BeaconStateBellatrix{}, nil
+	case version.Capella:
+		return &ethpb.BeaconStateCapella{}, nil
+	case version.Deneb:
+		return &ethpb.BeaconStateDeneb{}, nil
+	case version.Electra:
+		return &ethpb.BeaconStateElectra{}, nil
+	case version.Fulu:
+		return &ethpb.BeaconStateFulu{}, nil
+	default:
+		return nil, errors.Errorf("unsupported state version %d", v)
+	}
+}
+
+func initializeStateFromProto(v int, p proto.Message) (state.BeaconState, error) {
+	switch pb := p.(type) {
+	case *ethpb.BeaconState:
+		return statenative.InitializeFromProtoUnsafePhase0(pb)
+	case *ethpb.BeaconStateAltair:
+		return statenative.InitializeFromProtoUnsafeAltair(pb)
+	case *ethpb.BeaconStateBellatrix:
+		return statenative.InitializeFromProtoUnsafeBellatrix(pb)
+	case *ethpb.BeaconStateCapella:
+		return statenative.InitializeFromProtoUnsafeCapella(pb)
+	case *ethpb.BeaconStateDeneb:
+		return statenative.InitializeFromProtoUnsafeDeneb(pb)
+	case *ethpb.BeaconStateElectra:
+		return statenative.InitializeFromProtoUnsafeElectra(pb)
+	case *ethpb.BeaconStateFulu:
+		return statenative.InitializeFromProtoUnsafeFulu(pb)
+	default:
+		return nil, errors.Errorf("unsupported %s state proto %T", version.String(v), p)
+	}
+}
@@ -0,0 +1,119 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestStore_StateDiffsDisabled(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	require.Equal(t, primitives.Slot(0), db.StateDiffInterval())
+	st, _ := util.DeterministicGenesisState(t, 8)
+	require.ErrorIs(t, db.SaveHierarchicalState(ctx, st), errStateDiffsDisabled)
+	_, err := db.HierarchicalStateForSlot(ctx, 0)
+	require.ErrorIs(t, err, ErrNotFoundState)
+}
+
+func TestStore_HierarchicalStates(t *testing.T) {
+	ctx := context.Background()
+	// Full states every 64 slots, diffs every 32 slots.
+	db, err := NewKVStore(ctx, t.TempDir(), WithStateDiffExponents([]uint64{5, 6, 5}))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	require.Equal(t, primitives.Slot(32), db.StateDiffInterval())
+
+	genesis, _ := util.DeterministicGenesisState(t, 64)
+	atSlot := func(t *testing.T, st state.BeaconState, slot primitives.Slot) state.BeaconState {
+		st = mutateStateForDiff(t, st.Copy())
+		require.NoError(t, st.SetSlot(slot))
+		return st
+	}
+	kindAt := func(t *testing.T, slot primitives.Slot) byte {
+		var kind byte
+		require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+			kind = tx.Bucket(stateDiffBucket).Get(bytesutil.SlotToBytesBigEndian(slot))[0]
+			return nil
+		}))
+		return kind
+	}
+	requireStateAt := func(t *testing.T, want state.BeaconState, slot primitives.Slot) {
+		got, err := db.HierarchicalStateForSlot(ctx, slot)
+		require.NoError(t, err)
+		require.Equal(t, want.Version(), got.Version())
+		wantRoot, err := want.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		gotRoot, err := got.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		require.Equal(t, wantRoot, gotRoot)
+	}
+
+	s64 := atSlot(t, genesis, 64)
+	require.NoError(t, db.SaveHierarchicalState(ctx, s64))
+	require.Equal(t, hierarchicalSnapshot, kindAt(t, 64))
+	s96 := atSlot(t, s64, 96)
+	require.NoError(t, db.SaveHierarchicalState(ctx, s96))
+	require.Equal(t, hierarchicalDiff, kindAt(t, 96))
+
+	requireStateAt(t, s64, 64)
+	requireStateAt(t, s64, 95)
+	requireStateAt(t, s96, 96)
+	requireStateAt(t, s96, 127)
+	_, err = db.HierarchicalStateForSlot(ctx, 128)
+	require.ErrorIs(t, err, ErrNotFoundState)
+	_, err = db.HierarchicalStateForSlot(ctx, 63)
+	require.ErrorIs(t, err, ErrNotFoundState)
+
+	require.ErrorIs(t, db.SaveHierarchicalState(ctx, atSlot(t, s96, 100)), errNotHierarchicalSlot)
+
+	// Without a state at slot 128 to diff against, the state at slot 160 is saved in full.
+	s160 := atSlot(t, s96, 160)
+	require.NoError(t, db.SaveHierarchicalState(ctx, s160))
+	require.Equal(t, hierarchicalSnapshot, kindAt(t, 160))
+	requireStateAt(t, s160, 160)
+
+	// The state is saved in full when the fork changed since the state it would be diffed against.
+	require.NoError(t, db.SaveHierarchicalState(ctx, atSlot(t, s160, 192)))
+	altair, _ := util.DeterministicGenesisStateAltair(t, 64)
+	s224 := atSlot(t, altair, 224)
+	require.NoError(t, db.SaveHierarchicalState(ctx, s224))
+	require.Equal(t, hierarchicalSnapshot, kindAt(t, 224))
+	require.Equal(t, version.Altair, s224.Version())
+	requireStateAt(t, s224, 224)
+}
+
+func TestStore_StateDiffExponentsMismatch(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	open := func(t *testing.T, opts ...KVStoreOption) error {
+		db, err := NewKVStore(ctx, dir, opts...)
+		if err != nil {
+			return err
+		}
+		require.NoError(t, db.Close())
+		return nil
+	}
+
+	// The exponents can change until a hierarchical state is saved.
+	require.NoError(t, open(t, WithStateDiffExponents([]uint64{5, 7})))
+	db, err := NewKVStore(ctx, dir, WithStateDiffExponents([]uint64{5, 6}))
+	require.NoError(t, err)
+	st, _ := util.DeterministicGenesisState(t, 8)
+	require.NoError(t, st.SetSlot(64))
+	require.NoError(t, db.SaveHierarchicalState(ctx, st))
+	require.NoError(t, db.Close())
+
+	require.NoError(t, open(t, WithStateDiffExponents([]uint64{6, 5})))
+	require.ErrorIs(t, open(t, WithStateDiffExponents([]uint64{5, 7})), errStateDiffExponents)
+	require.ErrorIs(t, open(t), errStateDiffExponents)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"sync/atomic"
 	"time"
 
 	"github.com/dgraph-io/ristretto"
@@ -18,6 +19,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/config/features"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/io/file"
 	bolt "go.etcd.io/bbolt"
 )
@@ -90,6 +92,9 @@ type Store struct {
 	validatorEntryCache *ristretto.Cache
 	stateSummaryCache   *stateSummaryCache
 	ctx                 context.Context
+	pruner              *historyPruner
+	historyPrunedBefore atomic.Uint64
+	stateDiffIntervals  []primitives.Slot
 }
 
 // StoreDatafilePath is the canonical construction of a full
@@ -120,6 +125,7 @@ var Buckets = [][]byte{
 
 	feeRecipientBucket,
 	registrationBucket,
+	stateDiffBucket,
 }
 
 // KVStoreOption is a functional option that modifies a kv.Store.
@@ -184,11 +190,24 @@ func NewKVStore(ctx context.Context, dirPath string, opts ...KVStoreOption) (*St
 	for _, o := range opts {
 		o(kv)
 	}
+	if kv.pruner != nil {
+		kv.pruner.ctx, kv.pruner.cancel = context.WithCancel(ctx)
+	}
 	if err := kv.db.Update(func(tx *bolt.Tx) error {
 		return createBuckets(tx, Buckets...)
 	}); err != nil {
 		return nil, err
 	}
+	if err := kv.loadHistoryPrunedBefore(); err != nil {
+		return nil, errors.Wrap(err, "could not load history pruning progress")
+	}
+	if err := kv.checkStateDiffExponents(); err != nil {
+		// Release the db lock, the db can't be used with this configuration.
+		if cerr := kv.db.Close(); cerr != nil {
+			log.WithError(cerr).Error("Could not close db")
+		}
+		return nil, err
+	}
 	if err = prometheus.Register(createBoltCollector(kv.db)); err != nil {
 		return nil, err
 	}
@@ -219,6 +238,11 @@ func (s *Store) ClearDB() error {
 func (s *Store) Close() error {
 	prometheus.Unregister(createBoltCollector(s.db))
 
+	// Stop history pruning first, so that it doesn't start a transaction on the closed db.
+	if s.pruner != nil {
+		s.pruner.stop()
+	}
+
 	// Before DB closes, we should dump the cached state summary objects to DB.
 	if err := s.saveCachedStateSummariesDB(s.ctx); err != nil {
 		return err
@@ -0,0 +1,344 @@
+package kv
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+)
+
+// pruneBatchSize is the maximum number of slot index entries that are removed in a single bolt transaction.
+// Keeping the transactions small avoids holding the bolt write lock for long periods of time.
+const pruneBatchSize = 256
+
+var (
+	historyPrunedBlocksCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "db_history_pruned_blocks_total",
+		Help: "Number of finalized blocks removed from the db by history pruning.",
+	})
+	historyPrunedStatesCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "db_history_pruned_states_total",
+		Help: "Number of finalized states removed from the db by history pruning.",
+	})
+	historyPrunedBeforeGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "db_history_pruned_before_slot",
+		Help: "Lowest non-genesis slot for which blocks are retained in the db.",
+	})
+)
+
+// WithHistoryRetention enables pruning of finalized blocks, states and their indices which are more than
+// the given number of epochs behind the finalized checkpoint.
+func WithHistoryRetention(epochs primitives.Epoch) KVStoreOption {
+	return func(s *Store) {
+		s.pruner = &historyPruner{retention: epochs}
+	}
+}
+
+type historyPruner struct {
+	sync.Mutex
+	retention primitives.Epoch
+	target    atomic.Uint64
+
+	// runLock guards starting pruning goroutines against stop, so that none is added to wg once stop is waiting.
+	runLock sync.Mutex
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// start runs f in the background with the pruner's context, unless the pruner was stopped.
+func (p *historyPruner) start(f func(ctx context.Context)) {
+	p.runLock.Lock()
+	defer p.runLock.Unlock()
+	if p.ctx.Err() != nil {
+		return
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		f(p.ctx)
+	}()
+}
+
+// stop cancels pruning and waits for the goroutine in progress, which returns once its current batch is committed.
+func (p *historyPruner) stop() {
+	p.runLock.Lock()
+	p.cancel()
+	p.runLock.Unlock()
+	p.wg.Wait()
+}
+
+// AvailableBlock reports whether history pruning has left the block for the given slot in the db.
+// The genesis block is never pruned. This method satisfies the coverage.AvailableBlocker interface.
+func (s *Store) AvailableBlock(sl primitives.Slot) bool {
+	return sl == 0 || uint64(sl) >= s.historyPrunedBefore.Load()
+}
+
+// HistoryPrunedBefore returns the slot that history pruning has progressed to. Blocks and states with
+// a slot lower than this value (other than genesis) are no longer kept in the db.
+func (s *Store) HistoryPrunedBefore() primitives.Slot {
+	return primitives.Slot(s.historyPrunedBefore.Load())
+}
+
+func (s *Store) loadHistoryPrunedBefore() error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(chainMetadataBucket).Get(historyPrunedBeforeKey)
+		if enc == nil {
+			return nil
+		}
+		pb := bytesutil.BytesToSlotBigEndian(enc)
+		s.historyPrunedBefore.Store(uint64(pb))
+		historyPrunedBeforeGauge.Set(float64(pb))
+		return nil
+	})
+}
+
+// notifyFinalized tells the pruner about a new finalized epoch. If the retention window has moved
+// forward, pruning runs in the background so that finalization is not held up by the deletions.
+func (s *Store) notifyFinalized(finalized primitives.Epoch) {
+	if s.pruner == nil || finalized <= s.pruner.retention {
+		return
+	}
+	before, err := slots.EpochStart(finalized - s.pruner.retention)
+	if err != nil {
+		log.WithError(err).Error("Could not compute history pruning boundary")
+		return
+	}
+	if s.pruner.target.Swap(uint64(before)) == uint64(before) {
+		return
+	}
+	s.pruner.start(func(ctx context.Context) {
+		s.pruner.Lock()
+		defer s.pruner.Unlock()
+		if err := s.pruneHistory(ctx, before); err != nil && !errors.Is(err, context.Canceled) {
+			log.WithError(err).WithField("slot", before).Error("Failed to prune finalized history")
+		}
+	})
+}
+
+// pruneHistory deletes blocks, states, state summaries and their indices for all slots lower than the
+// slot of the highest saved state at or below the given slot. The state at that boundary, along with
+// the genesis and checkpoint sync origin blocks and states, is always kept so that stategen can
+// continue to replay the retained part of the chain.
+func (s *Store) pruneHistory(ctx context.Context, before primitives.Slot) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.pruneHistory")
+	defer span.End()
+
+	start := time.Now()
+	boundary, keep, err := s.pruneBoundary(ctx, before)
+	if err != nil {
+		return err
+	}
+	if boundary <= primitives.Slot(s.historyPrunedBefore.Load()) {
+		return nil
+	}
+
+	// Always scan from genesis, so that the boundary state kept by a previous pass is cleaned up as well.
+	// Slot index entries that were already pruned are gone, so seeking past them is cheap.
+	next := params.BeaconConfig().GenesisSlot + 1
+	var totalBlocks, totalStates int
+	for next < boundary {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var nb, ns int
+		err := s.db.Update(func(tx *bolt.Tx) error {
+			var err error
+			next, nb, ns, err = s.pruneHistoryBatch(ctx, tx, next, boundary, keep)
+			return err
+		})
+		if err != nil {
+			return errors.Wrapf(err, "could not prune history below slot %d", boundary)
+		}
+		if uint64(next) > s.historyPrunedBefore.Load() {
+			s.historyPrunedBefore.Store(uint64(next))
+			historyPrunedBeforeGauge.Set(float64(next))
+		}
+		historyPrunedBlocksCounter.Add(float64(nb))
+		historyPrunedStatesCounter.Add(float64(ns))
+		totalBlocks += nb
+		totalStates += ns
+	}
+
+	log.WithFields(logrus.Fields{
+		"prunedBefore":  boundary,
+		"blocksRemoved": totalBlocks,
+		"statesRemoved": totalStates,
+		"duration":      time.Since(start).String(),
+	}).Debug("Pruned finalized history")
+	return nil
+}
+
+// pruneBoundary finds the slot of the highest saved state at or below the given slot, along with the
+// set of roots that must survive pruning.
+func (s *Store) pruneBoundary(ctx context.Context, before primitives.Slot) (primitives.Slot, map[[32]byte]bool, error) {
+	var boundary primitives.Slot
+	keep := make(map[[32]byte]bool)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(stateSlotIndicesBucket).Cursor()
+		k, v := c.Seek(bytesutil.SlotToBytesBigEndian(before + 1))
+		if k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+		for ; k != nil; k, v = c.Prev() {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			sl := bytesutil.BytesToSlotBigEndian(k)
+			if sl > before {
+				continue
+			}
+			boundary = sl
+			// Multiple states may be indexed at the boundary slot, keep all of them.
+			roots, err := splitRoots(v)
+			if err != nil {
+				return errors.Wrapf(err, "could not parse roots in state slot index for slot %d", sl)
+			}
+			for _, r := range roots {
+				keep[r] = true
+			}
+			break
+		}
+		bkt := tx.Bucket(blocksBucket)
+		if gr := bkt.Get(genesisBlockRootKey); gr != nil {
+			keep[bytesutil.ToBytes32(gr)] = true
+		}
+		if or := bkt.Get(originCheckpointBlockRootKey); or != nil {
+			keep[bytesutil.ToBytes32(or)] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return boundary, keep, nil
+}
+
+// pruneHistoryBatch removes up to pruneBatchSize slot index entries in the range [from, to), returning the
+// slot that pruning progressed to along with the number of blocks and states that were deleted.
+// The caller is responsible for never including the genesis slot in the range.
+func (s *Store) pruneHistoryBatch(ctx context.Context, tx *bolt.Tx, from, to primitives.Slot, keep map[[32]byte]bool) (primitives.Slot, int, int, error) {
+	idxBkt := tx.Bucket(blockSlotIndicesBucket)
+	c := idxBkt.Cursor()
+
+	type slotRoots struct {
+		key   []byte
+		roots [][32]byte
+	}
+	entries := make([]slotRoots, 0, pruneBatchSize)
+	progress := to
+	for k, v := c.Seek(bytesutil.SlotToBytesBigEndian(from)); k != nil; k, v = c.Next() {
+		sl := bytesutil.BytesToSlotBigEndian(k)
+		if sl >= to {
+			break
+		}
+		if len(entries) == pruneBatchSize {
+			progress = sl
+			break
+		}
+		roots, err := splitRoots(v)
+		if err != nil {
+			return from, 0, 0, errors.Wrapf(err, "could not parse roots in slot index for slot %d", sl)
+		}
+		entries = append(entries, slotRoots{key: bytesutil.SafeCopyBytes(k), roots: roots})
+	}
+
+	var nb, ns int
+	for _, e := range entries {
+		if ctx.Err() != nil {
+			return from, 0, 0, ctx.Err()
+		}
+		kept := make([]byte, 0)
+		for _, r := range e.roots {
+			if keep[r] {
+				kept = append(kept, r[:]...)
+				continue
+			}
+			deleted, err := s.pruneState(ctx, tx, r)
+			if err != nil {
+				return from, 0, 0, err
+			}
+			if deleted {
+				ns++
+			}
+			if err := s.pruneBlock(tx, r); err != nil {
+				return from, 0, 0, err
+			}
+			nb++
+		}
+		if len(kept) > 0 {
+			if err := idxBkt.Put(e.key, kept); err != nil {
+				return from, 0, 0, err
+			}
+			continue
+		}
+		if err := idxBkt.Delete(e.key); err != nil {
+			return from, 0, 0, err
+		}
+	}
+
+	if uint64(progress) > s.historyPrunedBefore.Load() {
+		if err := tx.Bucket(chainMetadataBucket).Put(historyPrunedBeforeKey, bytesutil.SlotToBytesBigEndian(progress)); err != nil {
+			return from, 0, 0, err
+		}
+	}
+	return progress, nb, ns, nil
+}
+
+// pruneBlock removes the block for the given root along with its index entries.
+func (s *Store) pruneBlock(tx *bolt.Tx, root [32]byte) error {
+	if err := tx.Bucket(blocksBucket).Delete(root[:]); err != nil {
+		return err
+	}
+	if err := tx.Bucket(blockParentRootIndicesBucket).Delete(root[:]); err != nil {
+		return err
+	}
+	if err := tx.Bucket(finalizedBlockRootsIndexBucket).Delete(root[:]); err != nil {
+		return err
+	}
+	s.blockCache.Del(string(root[:]))
+	return nil
+}
+
+// pruneState removes the state and state summary for the given root, along with the state slot index
+// and validator hash entries. The returned bool is true if a full state was removed.
+func (s *Store) pruneState(ctx context.Context, tx *bolt.Tx, root [32]byte) (bool, error) {
+	defer func() {
+		s.stateSummaryCache.delete(root)
+	}()
+	stBkt := tx.Bucket(stateBucket)
+	if stBkt.Get(root[:]) == nil {
+		return false, tx.Bucket(stateSummaryBucket).Delete(root[:])
+	}
+
+	slot, err := s.slotByBlockRoot(ctx, tx, root[:])
+	if err != nil {
+		return false, errors.Wrapf(err, "could not determine slot of state for root %#x", root)
+	}
+	if err := deleteValueForIndices(ctx, createStateIndicesFromStateSlot(ctx, slot), root[:], tx); err != nil {
+		return false, errors.Wrap(err, "could not delete root for DB indices")
+	}
+	idxBkt := tx.Bucket(blockRootValidatorHashesBucket)
+	if hashes := idxBkt.Get(root[:]); len(hashes) > 0 {
+		if err := idxBkt.Delete(root[:]); err != nil {
+			return false, err
+		}
+	}
+	if err := tx.Bucket(stateSummaryBucket).Delete(root[:]); err != nil {
+		return false, err
+	}
+	return true, stBkt.Delete(root[:])
+}
@@ -0,0 +1,105 @@
+package kv
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestStore_PruneHistory(t *testing.T) {
+	ctx := context.Background()
+	slotsPerEpoch := uint64(params.BeaconConfig().SlotsPerEpoch)
+	db := setupDB(t)
+
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+	blks := makeBlocks(t, 0, slotsPerEpoch*4, genesisBlockRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	roots := make([][32]byte, len(blks))
+	for i, blk := range blks {
+		r, err := blk.Block().HashTreeRoot()
+		require.NoError(t, err)
+		roots[i] = r
+	}
+
+	// Save a state at the first and second epoch boundaries. The second one is the highest state below
+	// the requested prune slot, so everything below it should be removed and it should be kept.
+	pruned, boundary := roots[slotsPerEpoch-1], roots[2*slotsPerEpoch-1]
+	for _, idx := range []uint64{slotsPerEpoch - 1, 2*slotsPerEpoch - 1} {
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(blks[idx].Block().Slot()))
+		require.NoError(t, db.SaveState(ctx, st, roots[idx]))
+	}
+
+	require.Equal(t, true, db.AvailableBlock(1))
+	before := primitives.Slot(2*slotsPerEpoch + 5)
+	require.NoError(t, db.pruneHistory(ctx, before))
+
+	boundarySlot := blks[2*slotsPerEpoch-1].Block().Slot()
+	require.Equal(t, boundarySlot, db.HistoryPrunedBefore())
+	require.Equal(t, true, db.AvailableBlock(0))
+	require.Equal(t, false, db.AvailableBlock(boundarySlot-1))
+	require.Equal(t, true, db.AvailableBlock(boundarySlot))
+
+	require.Equal(t, false, db.HasBlock(ctx, roots[0]))
+	require.Equal(t, false, db.HasBlock(ctx, pruned))
+	require.Equal(t, false, db.HasState(ctx, pruned))
+	require.Equal(t, true, db.HasBlock(ctx, boundary))
+	require.Equal(t, true, db.HasState(ctx, boundary))
+	require.Equal(t, true, db.HasBlock(ctx, roots[2*slotsPerEpoch]))
+
+	_, rs, err := db.BlockRootsBySlot(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(rs))
+
+	// Pruning to the same boundary again is a no-op.
+	require.NoError(t, db.pruneHistory(ctx, before))
+	require.Equal(t, true, db.HasState(ctx, boundary))
+
+	// Progress is persisted across restarts.
+	require.NoError(t, db.loadHistoryPrunedBefore())
+	require.Equal(t, boundarySlot, db.HistoryPrunedBefore())
+}
+
+func TestStore_PruneHistoryNoStateBelow(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+	blks := makeBlocks(t, 0, 10, genesisBlockRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+
+	// Without a saved state below the prune slot, nothing can be pruned without breaking state replay.
+	require.NoError(t, db.pruneHistory(ctx, 8))
+	require.Equal(t, primitives.Slot(0), db.HistoryPrunedBefore())
+	r, err := blks[0].Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, true, db.HasBlock(ctx, r))
+}
+
+func TestStore_CloseStopsHistoryPruning(t *testing.T) {
+	db, err := NewKVStore(context.Background(), t.TempDir(), WithHistoryRetention(1))
+	require.NoError(t, err)
+
+	// A pruning goroutine in progress is canceled, and Close waits for it to return before closing the db.
+	var stopped atomic.Bool
+	db.pruner.start(func(ctx context.Context) {
+		<-ctx.Done()
+		stopped.Store(true)
+	})
+	require.NoError(t, db.Close())
+	require.Equal(t, true, stopped.Load())
+
+	// Pruning is not started anymore once the db is closed.
+	var started atomic.Bool
+	db.pruner.start(func(context.Context) {
+		started.Store(true)
+	})
+	db.pruner.wg.Wait()
+	require.Equal(t, false, started.Load())
+}
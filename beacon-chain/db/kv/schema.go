@@ -16,6 +16,7 @@ var (
 	stateValidatorsBucket = []byte("state-validators")
 	feeRecipientBucket    = []byte("fee-recipient")
 	registrationBucket    = []byte("registration")
+	stateDiffBucket       = []byte("state-diff")
 
 	// Light Client Updates Bucket
 	lightClientUpdatesBucket   = []byte("light-client-updates")
@@ -61,6 +62,14 @@ var (
 	originCheckpointBlockRootKey = []byte("origin-checkpoint-block-root")
 	// tracking data about an ongoing backfill
 	backfillStatusKey = []byte("backfill-status")
+	// tracking data about an ongoing blob sidecar backfill
+	blobBackfillStatusKey = []byte("blob-backfill-status")
+	// lowest non-genesis slot retained by history pruning
+	historyPrunedBeforeKey = []byte("history-pruned-before")
+	// serialized fork choice store used to speed up restarts
+	forkchoiceSnapshotKey = []byte("forkchoice-snapshot")
+	// exponents of the intervals the hierarchical states were saved with
+	stateDiffExponentsKey = []byte("state-diff-exponents")
 
 	// Deprecated: This index key was migrated in PR 6461. Do not use, except for migrations.
 	lastArchivedIndexKey = []byte("last-archived")
@@ -0,0 +1,310 @@
+package kv
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// A state diff records the fields of a beacon state proto that differ from a base state of the same fork, so that
+// the state can be rebuilt from the base. Each changed field is encoded as its proto field number, an op and the op
+// payload. The encoding walks the fields of the proto descriptor, so it covers every fork without fork specific code.
+const (
+	// diffOpSet replaces a singular field. The payload is the new value.
+	diffOpSet = 1
+	// diffOpClear unsets a singular message field.
+	diffOpClear = 2
+	// diffOpXor updates a bytes field with the same length, like the participation flags. The payload is the xor of
+	// the old and new values, which is mostly zero bytes and compresses well.
+	diffOpXor = 3
+	// diffOpList updates a repeated field. The payload starts with the new length of the list. Lists of integers,
+	// like the balances, are followed by the xor of every old and new element. Other lists, like the validators,
+	// are followed by the number of changed elements and their indices and new values.
+	diffOpList = 4
+)
+
+var errCorruptStateDiff = errors.New("corrupt state diff")
+
+type sszMarshaler interface {
+	MarshalSSZ() ([]byte, error)
+}
+
+type sszUnmarshaler interface {
+	UnmarshalSSZ([]byte) error
+}
+
+// computeStateDiff returns the diff that turns base into target. Both must be the same kind of state proto.
+func computeStateDiff(base, target proto.Message) ([]byte, error) {
+	bm, tm := base.ProtoReflect(), target.ProtoReflect()
+	if bm.Descriptor().FullName() != tm.Descriptor().FullName() {
+		return nil, errors.Errorf("cannot diff %s against %s", tm.Descriptor().FullName(), bm.Descriptor().FullName())
+	}
+	var diff []byte
+	fields := tm.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		op, payload, changed, err := diffField(fd, bm, tm)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not diff field %s", fd.Name())
+		}
+		if !changed {
+			continue
+		}
+		diff = protowire.AppendVarint(diff, uint64(fd.Number()))
+		diff = protowire.AppendVarint(diff, op)
+		diff = protowire.AppendBytes(diff, payload)
+	}
+	return diff, nil
+}
+
+func diffField(fd protoreflect.FieldDescriptor, bm, tm protoreflect.Message) (uint64, []byte, bool, error) {
+	if fd.IsList() {
+		return diffList(fd, bm.Get(fd).List(), tm.Get(fd).List())
+	}
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		if !tm.Has(fd) {
+			return diffOpClear, nil, bm.Has(fd), nil
+		}
+		n, err := marshalDiffMessage(tm.Get(fd).Message().Interface())
+		if err != nil {
+			return 0, nil, false, err
+		}
+		if bm.Has(fd) {
+			o, err := marshalDiffMessage(bm.Get(fd).Message().Interface())
+			if err != nil {
+				return 0, nil, false, err
+			}
+			if bytes.Equal(o, n) {
+				return 0, nil, false, nil
+			}
+		}
+		return diffOpSet, n, true, nil
+	case protoreflect.BytesKind:
+		o, n := bm.Get(fd).Bytes(), tm.Get(fd).Bytes()
+		if bytes.Equal(o, n) {
+			return 0, nil, false, nil
+		}
+		if len(o) != len(n) {
+			return diffOpSet, n, true, nil
+		}
+		x := make([]byte, len(n))
+		for i := range n {
+			x[i] = o[i] ^ n[i]
+		}
+		return diffOpXor, x, true, nil
+	case protoreflect.Uint64Kind:
+		o, n := bm.Get(fd).Uint(), tm.Get(fd).Uint()
+		if o == n {
+			return 0, nil, false, nil
+		}
+		return diffOpSet, protowire.AppendVarint(nil, n), true, nil
+	default:
+		return 0, nil, false, errors.Errorf("unsupported field kind %s", fd.Kind())
+	}
+}
+
+func diffList(fd protoreflect.FieldDescriptor, ol, nl protoreflect.List) (uint64, []byte, bool, error) {
+	payload := protowire.AppendVarint(nil, uint64(nl.Len()))
+	changed := ol.Len() != nl.Len()
+	switch fd.Kind() {
+	case protoreflect.Uint64Kind:
+		for i := 0; i < nl.Len(); i++ {
+			v := nl.Get(i).Uint()
+			if i < ol.Len() {
+				v ^= ol.Get(i).Uint()
+			}
+			changed = changed || v != 0
+			payload = binary.LittleEndian.AppendUint64(payload, v)
+		}
+		return diffOpList, payload, changed, nil
+	case protoreflect.BytesKind, protoreflect.MessageKind:
+		var count uint64
+		var entries []byte
+		for i := 0; i < nl.Len(); i++ {
+			n, err := listElementBytes(fd, nl.Get(i))
+			if err != nil {
+				return 0, nil, false, err
+			}
+			if i < ol.Len() {
+				o, err := listElementBytes(fd, ol.Get(i))
+				if err != nil {
+					return 0, nil, false, err
+				}
+				if bytes.Equal(o, n) {
+					continue
+				}
+			}
+			entries = protowire.AppendVarint(entries, uint64(i))
+			entries = protowire.AppendBytes(entries, n)
+			count++
+		}
+		if count == 0 && !changed {
+			return 0, nil, false, nil
+		}
+		payload = protowire.AppendVarint(payload, count)
+		return diffOpList, append(payload, entries...), true, nil
+	default:
+		return 0, nil, false, errors.Errorf("unsupported list kind %s", fd.Kind())
+	}
+}
+
+// applyStateDiff applies a diff computed by computeStateDiff to base, which is modified in place.
+func applyStateDiff(base proto.Message, diff []byte) error {
+	pm := base.ProtoReflect()
+	fields := pm.Descriptor().Fields()
+	for len(diff) > 0 {
+		num, n := protowire.ConsumeVarint(diff)
+		if n < 0 {
+			return errCorruptStateDiff
+		}
+		diff = diff[n:]
+		op, n := protowire.ConsumeVarint(diff)
+		if n < 0 {
+			return errCorruptStateDiff
+		}
+		diff = diff[n:]
+		payload, n := protowire.ConsumeBytes(diff)
+		if n < 0 {
+			return errCorruptStateDiff
+		}
+		diff = diff[n:]
+		fd := fields.ByNumber(protowire.Number(num))
+		if fd == nil {
+			return errors.Wrapf(errCorruptStateDiff, "unknown field number %d in %s", num, pm.Descriptor().FullName())
+		}
+		if err := applyField(pm, fd, op, payload); err != nil {
+			return errors.Wrapf(err, "could not apply diff to field %s", fd.Name())
+		}
+	}
+	return nil
+}
+
+func applyField(pm protoreflect.Message, fd protoreflect.FieldDescriptor, op uint64, payload []byte) error {
+	switch op {
+	case diffOpClear:
+		pm.Clear(fd)
+		return nil
+	case diffOpSet:
+		switch fd.Kind() {
+		case protoreflect.MessageKind:
+			v := pm.NewField(fd)
+			if err := unmarshalDiffMessage(v.Message().Interface(), payload); err != nil {
+				return err
+			}
+			pm.Set(fd, v)
+		case protoreflect.BytesKind:
+			pm.Set(fd, protoreflect.ValueOfBytes(bytes.Clone(payload)))
+		case protoreflect.Uint64Kind:
+			v, n := protowire.ConsumeVarint(payload)
+			if n < 0 {
+				return errCorruptStateDiff
+			}
+			pm.Set(fd, protoreflect.ValueOfUint64(v))
+		default:
+			return errors.Errorf("unsupported field kind %s", fd.Kind())
+		}
+		return nil
+	case diffOpXor:
+		o := pm.Get(fd).Bytes()
+		if len(o) != len(payload) {
+			return errors.Wrapf(errCorruptStateDiff, "xor length %d does not match field length %d", len(payload), len(o))
+		}
+		x := make([]byte, len(o))
+		for i := range o {
+			x[i] = o[i] ^ payload[i]
+		}
+		pm.Set(fd, protoreflect.ValueOfBytes(x))
+		return nil
+	case diffOpList:
+		return applyList(pm.Mutable(fd).List(), fd, payload)
+	default:
+		return errors.Wrapf(errCorruptStateDiff, "unknown op %d", op)
+	}
+}
+
+func applyList(l protoreflect.List, fd protoreflect.FieldDescriptor, payload []byte) error {
+	length, n := protowire.ConsumeVarint(payload)
+	if n < 0 {
+		return errCorruptStateDiff
+	}
+	payload = payload[n:]
+	if length < uint64(l.Len()) {
+		l.Truncate(int(length))
+	}
+	if fd.Kind() == protoreflect.Uint64Kind {
+		if uint64(len(payload)) != 8*length {
+			return errors.Wrapf(errCorruptStateDiff, "%d bytes of list elements for a list of length %d", len(payload), length)
+		}
+		for i := 0; i < int(length); i++ {
+			v := binary.LittleEndian.Uint64(payload[8*i:])
+			if i < l.Len() {
+				l.Set(i, protoreflect.ValueOfUint64(v^l.Get(i).Uint()))
+			} else {
+				l.Append(protoreflect.ValueOfUint64(v))
+			}
+		}
+		return nil
+	}
+	// Appended elements are always part of the diff, so they can be added as placeholders first.
+	for uint64(l.Len()) < length {
+		l.Append(l.NewElement())
+	}
+	count, n := protowire.ConsumeVarint(payload)
+	if n < 0 {
+		return errCorruptStateDiff
+	}
+	payload = payload[n:]
+	for j := uint64(0); j < count; j++ {
+		i, n := protowire.ConsumeVarint(payload)
+		if n < 0 || i >= length {
+			return errCorruptStateDiff
+		}
+		payload = payload[n:]
+		b, n := protowire.ConsumeBytes(payload)
+		if n < 0 {
+			return errCorruptStateDiff
+		}
+		payload = payload[n:]
+		if fd.Kind() == protoreflect.MessageKind {
+			v := l.NewElement()
+			if err := unmarshalDiffMessage(v.Message().Interface(), b); err != nil {
+				return err
+			}
+			l.Set(int(i), v)
+		} else {
+			l.Set(int(i), protoreflect.ValueOfBytes(bytes.Clone(b)))
+		}
+	}
+	if len(payload) != 0 {
+		return errors.Wrap(errCorruptStateDiff, "trailing bytes after list elements")
+	}
+	return nil
+}
+
+func listElementBytes(fd protoreflect.FieldDescriptor, v protoreflect.Value) ([]byte, error) {
+	if fd.Kind() == protoreflect.MessageKind {
+		return marshalDiffMessage(v.Message().Interface())
+	}
+	return v.Bytes(), nil
+}
+
+// marshalDiffMessage uses the SSZ encoding of the message when it has one, because it is faster and more compact
+// than the proto encoding for the types found in the beacon state.
+func marshalDiffMessage(m proto.Message) ([]byte, error) {
+	if s, ok := m.(sszMarshaler); ok {
+		return s.MarshalSSZ()
+	}
+	return proto.MarshalOptions{Deterministic: true}.Marshal(m)
+}
+
+func unmarshalDiffMessage(m proto.Message, b []byte) error {
+	if s, ok := m.(sszUnmarshaler); ok {
+		return s.UnmarshalSSZ(b)
+	}
+	return proto.Unmarshal(b, m)
+}
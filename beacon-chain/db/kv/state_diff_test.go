@@ -0,0 +1,91 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestStateDiff_Roundtrip(t *testing.T) {
+	cases := []struct {
+		name  string
+		state func(t *testing.T) state.BeaconState
+	}{
+		{name: "phase0", state: func(t *testing.T) state.BeaconState { st, _ := util.DeterministicGenesisState(t, 64); return st }},
+		{name: "altair", state: func(t *testing.T) state.BeaconState { st, _ := util.DeterministicGenesisStateAltair(t, 64); return st }},
+		{name: "capella", state: func(t *testing.T) state.BeaconState { st, _ := util.DeterministicGenesisStateCapella(t, 64); return st }},
+		{name: "electra", state: func(t *testing.T) state.BeaconState { st, _ := util.DeterministicGenesisStateElectra(t, 64); return st }},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			base := c.state(t)
+			target := mutateStateForDiff(t, base.Copy())
+			bp, tp := base.ToProto().(proto.Message), target.ToProto().(proto.Message)
+
+			diff, err := computeStateDiff(bp, tp)
+			require.NoError(t, err)
+			full, err := tp.(sszMarshaler).MarshalSSZ()
+			require.NoError(t, err)
+			require.Equal(t, true, len(diff) < len(full)/2, "diff of %d bytes is not much smaller than the %d bytes state", len(diff), len(full))
+			got := proto.Clone(bp)
+			require.NoError(t, applyStateDiff(got, diff))
+			require.Equal(t, true, proto.Equal(tp, got), "state rebuilt from the diff does not match the target")
+
+			// The reverse diff shrinks the lists grown by the mutations.
+			diff, err = computeStateDiff(tp, bp)
+			require.NoError(t, err)
+			got = proto.Clone(tp)
+			require.NoError(t, applyStateDiff(got, diff))
+			require.Equal(t, true, proto.Equal(bp, got), "state rebuilt from the reverse diff does not match the base")
+
+			// A state diffed against itself has an empty diff.
+			diff, err = computeStateDiff(tp, proto.Clone(tp))
+			require.NoError(t, err)
+			require.Equal(t, 0, len(diff))
+		})
+	}
+}
+
+func TestStateDiff_Errors(t *testing.T) {
+	phase0, _ := util.DeterministicGenesisState(t, 8)
+	altair, _ := util.DeterministicGenesisStateAltair(t, 8)
+	_, err := computeStateDiff(phase0.ToProto().(proto.Message), altair.ToProto().(proto.Message))
+	require.ErrorContains(t, "cannot diff", err)
+
+	p := phase0.ToProto().(proto.Message)
+	require.ErrorIs(t, applyStateDiff(p, []byte{0xff}), errCorruptStateDiff)
+	// Field number 1000 does not exist.
+	require.ErrorIs(t, applyStateDiff(p, []byte{0xe8, 0x07, diffOpSet, 0}), errCorruptStateDiff)
+}
+
+func mutateStateForDiff(t *testing.T, st state.BeaconState) state.BeaconState {
+	require.NoError(t, st.SetSlot(st.Slot()+params.BeaconConfig().SlotsPerEpoch))
+	require.NoError(t, st.UpdateBalancesAtIndex(3, 31_000_000_000))
+	require.NoError(t, st.UpdateBlockRootAtIndex(2, [32]byte{'r'}))
+	v, err := st.ValidatorAtIndex(1)
+	require.NoError(t, err)
+	v.EffectiveBalance -= params.BeaconConfig().EffectiveBalanceIncrement
+	require.NoError(t, st.UpdateValidatorAtIndex(1, v))
+	require.NoError(t, st.AppendValidator(&ethpb.Validator{
+		PublicKey:             make([]byte, 48),
+		WithdrawalCredentials: make([]byte, 32),
+		EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
+	}))
+	require.NoError(t, st.AppendBalance(params.BeaconConfig().MaxEffectiveBalance))
+	if st.Version() >= version.Altair {
+		require.NoError(t, st.ModifyCurrentParticipationBits(func(val []byte) ([]byte, error) {
+			val[5] = 7
+			return val, nil
+		}))
+		require.NoError(t, st.AppendCurrentParticipationBits(0))
+		require.NoError(t, st.AppendPreviousParticipationBits(0))
+		require.NoError(t, st.AppendInactivityScore(0))
+	}
+	return st
+}
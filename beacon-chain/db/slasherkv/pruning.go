@@ -49,6 +49,11 @@ func (s *Store) PruneAttestationsAtEpoch(
 	}
 
 	if err = s.db.Update(func(tx *bolt.Tx) error {
+		// Detected attester slashings are kept for as long as the attestations they were built from.
+		if err := pruneSlashings(tx, attesterSlashingsBucket, encodedEndPruneEpoch); err != nil {
+			return err
+		}
+
 		signingRootsBkt := tx.Bucket(attestationDataRootsBucket)
 		attRecordsBkt := tx.Bucket(attestationRecordsBucket)
 		c := signingRootsBkt.Cursor()
@@ -125,6 +130,11 @@ func (s *Store) PruneProposalsAtEpoch(
 	}
 
 	if err = s.db.Update(func(tx *bolt.Tx) error {
+		// Detected proposer slashings are kept for as long as the proposals they were built from.
+		if err := pruneSlashings(tx, proposerSlashingsBucket, encodedEndPruneSlot); err != nil {
+			return err
+		}
+
 		proposalBkt := tx.Bucket(proposalRecordsBucket)
 		c := proposalBkt.Cursor()
 		// We begin a pruning iteration starting from the first item in the bucket.
@@ -24,4 +24,12 @@ var (
 	// value: (encoded) SignedBlockHeaderWrapper
 	proposalRecordsBucket = []byte("proposal-records")
 	slasherChunksBucket   = []byte("slasher-chunks")
+
+	// key: (encoded) Target Epoch + AttesterSlashing HashTreeRoot
+	// value: version + (encoded + compressed) AttesterSlashing
+	attesterSlashingsBucket = []byte("attester-slashings")
+
+	// key: Slot + ProposerSlashing HashTreeRoot
+	// value: (encoded + compressed) ProposerSlashing
+	proposerSlashingsBucket = []byte("proposer-slashings")
 )
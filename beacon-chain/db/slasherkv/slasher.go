@@ -258,6 +258,49 @@ func (s *Store) AttestationRecordForValidator(
 	return record, err
 }
 
+// AttestationRecordsForValidator retrieves the attestation records we have stored in the database
+// for a validator with a target epoch in the inclusive range [startEpoch, endEpoch], sorted by target epoch.
+func (s *Store) AttestationRecordsForValidator(
+	ctx context.Context, validatorIdx primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch,
+) ([]*slashertypes.IndexedAttestationWrapper, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.AttestationRecordsForValidator")
+	defer span.End()
+	if startEpoch > endEpoch {
+		return nil, fmt.Errorf("start epoch %d is greater than end epoch %d", startEpoch, endEpoch)
+	}
+	records := make([]*slashertypes.IndexedAttestationWrapper, 0)
+	encIdx := encodeValidatorIndex(validatorIdx)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		signingRootsBkt := tx.Bucket(attestationDataRootsBucket)
+		attRecordsBkt := tx.Bucket(attestationRecordsBucket)
+		// Keys are (target_epoch ++ validator_index), so we look up every epoch of the range
+		// rather than scanning the records of all the other validators.
+		for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			key := append(encodeTargetEpoch(epoch), encIdx...)
+			attRecordKey := signingRootsBkt.Get(key)
+			if attRecordKey != nil {
+				indexedAttBytes := attRecordsBkt.Get(attRecordKey)
+				if indexedAttBytes != nil {
+					decoded, err := decodeAttestationRecord(indexedAttBytes)
+					if err != nil {
+						return err
+					}
+					records = append(records, decoded)
+				}
+			}
+			// Avoid overflowing when the end epoch is the maximum epoch.
+			if epoch == endEpoch {
+				break
+			}
+		}
+		return nil
+	})
+	return records, err
+}
+
 // SaveAttestationRecordsForValidators saves attestation records for the specified indices.
 // If multiple attestations are provided for the same validator index + target epoch combination,
 // then only the first one is (arbitrarily) saved in the `attestationDataRootsBucket` bucket.
@@ -545,6 +588,44 @@ func (s *Store) BlockProposalForValidator(
 	return record, err
 }
 
+// BlockProposalsForValidator retrieves the proposal records we have stored in the database
+// for a validator with a slot in the inclusive range [startSlot, endSlot], sorted by slot.
+func (s *Store) BlockProposalsForValidator(
+	ctx context.Context, validatorIdx primitives.ValidatorIndex, startSlot, endSlot primitives.Slot,
+) ([]*slashertypes.SignedBlockHeaderWrapper, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.BlockProposalsForValidator")
+	defer span.End()
+	if startSlot > endSlot {
+		return nil, fmt.Errorf("start slot %d is greater than end slot %d", startSlot, endSlot)
+	}
+	records := make([]*slashertypes.SignedBlockHeaderWrapper, 0)
+	encIdx := encodeValidatorIndex(validatorIdx)
+	encEndSlot := encodeSlot(endSlot)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		// Proposals are keyed by (slot ++ validator_index) and there is usually a single
+		// proposal per slot, so we scan the slot range and filter on the validator index.
+		c := tx.Bucket(proposalRecordsBucket).Cursor()
+		for k, v := c.Seek(keyForValidatorProposal(startSlot, 0)); k != nil; k, v = c.Next() {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if uint64PrefixGreaterThan(k, encEndSlot) {
+				return nil
+			}
+			if !suffixForAttestationRecordsKey(k, encIdx) {
+				continue
+			}
+			decoded, err := decodeProposalRecord(v)
+			if err != nil {
+				return err
+			}
+			records = append(records, decoded)
+		}
+		return nil
+	})
+	return records, err
+}
+
 // SaveBlockProposals takes in a list of block proposals and saves them to our
 // proposal records bucket in the database.
 // If multiple proposals are provided for the same slot + validatorIndex combination,
@@ -317,6 +317,62 @@ func TestStore_ExistingBlockProposals(t *testing.T) {
 	}
 }
 
+func TestStore_AttestationRecordsForValidator(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	attWrappers := []*slashertypes.IndexedAttestationWrapper{
+		createAttestationWrapper(0, 1, []uint64{1, 2}, []byte{1}),
+		createAttestationWrapper(1, 2, []uint64{2}, []byte{2}),
+		createAttestationWrapper(2, 3, []uint64{1}, []byte{3}),
+		createAttestationWrapper(3, 5, []uint64{1}, []byte{4}),
+	}
+	require.NoError(t, beaconDB.SaveAttestationRecordsForValidators(ctx, attWrappers))
+
+	records, err := beaconDB.AttestationRecordsForValidator(ctx, 1, 0, 10)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*slashertypes.IndexedAttestationWrapper{attWrappers[0], attWrappers[2], attWrappers[3]}, records)
+
+	records, err = beaconDB.AttestationRecordsForValidator(ctx, 1, 2, 3)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*slashertypes.IndexedAttestationWrapper{attWrappers[2]}, records)
+
+	records, err = beaconDB.AttestationRecordsForValidator(ctx, 3, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(records))
+
+	_, err = beaconDB.AttestationRecordsForValidator(ctx, 1, 3, 2)
+	require.ErrorContains(t, "start epoch 3 is greater than end epoch 2", err)
+}
+
+func TestStore_BlockProposalsForValidator(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	proposals := []*slashertypes.SignedBlockHeaderWrapper{
+		createProposalWrapper(t, 1, 1, []byte{1}),
+		createProposalWrapper(t, 2, 2, []byte{1}),
+		createProposalWrapper(t, 3, 1, []byte{1}),
+		createProposalWrapper(t, 5, 1, []byte{1}),
+	}
+	require.NoError(t, beaconDB.SaveBlockProposals(ctx, proposals))
+
+	records, err := beaconDB.BlockProposalsForValidator(ctx, 1, 0, 10)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*slashertypes.SignedBlockHeaderWrapper{proposals[0], proposals[2], proposals[3]}, records)
+
+	records, err = beaconDB.BlockProposalsForValidator(ctx, 1, 2, 4)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*slashertypes.SignedBlockHeaderWrapper{proposals[2]}, records)
+
+	records, err = beaconDB.BlockProposalsForValidator(ctx, 3, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(records))
+
+	_, err = beaconDB.BlockProposalsForValidator(ctx, 1, 3, 2)
+	require.ErrorContains(t, "start slot 3 is greater than end slot 2", err)
+}
+
 func Test_encodeDecodeProposalRecord(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,217 @@
+package slasherkv
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	bolt "go.etcd.io/bbolt"
+)
+
+// SaveAttesterSlashings saves the attester slashings detected by the slasher.
+// Slashings are keyed by the highest target epoch of their two attestations,
+// so saving the same slashing twice is a no-op.
+func (s *Store) SaveAttesterSlashings(ctx context.Context, slashings []ethpb.AttSlashing) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveAttesterSlashings")
+	defer span.End()
+
+	encodedKeys := make([][]byte, len(slashings))
+	encodedSlashings := make([][]byte, len(slashings))
+	for i, slashing := range slashings {
+		key, err := keyForAttesterSlashing(slashing)
+		if err != nil {
+			return err
+		}
+		enc, err := encodeAttesterSlashing(slashing)
+		if err != nil {
+			return err
+		}
+		encodedKeys[i] = key
+		encodedSlashings[i] = enc
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(attesterSlashingsBucket)
+		for i := range encodedKeys {
+			if err := bkt.Put(encodedKeys[i], encodedSlashings[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SaveProposerSlashings saves the proposer slashings detected by the slasher.
+// Slashings are keyed by slot, so saving the same slashing twice is a no-op.
+func (s *Store) SaveProposerSlashings(ctx context.Context, slashings []*ethpb.ProposerSlashing) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveProposerSlashings")
+	defer span.End()
+
+	encodedKeys := make([][]byte, len(slashings))
+	encodedSlashings := make([][]byte, len(slashings))
+	for i, slashing := range slashings {
+		if slashing == nil || slashing.Header_1 == nil || slashing.Header_1.Header == nil {
+			return errors.New("nil proposer slashing")
+		}
+		root, err := slashing.HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		enc, err := slashing.MarshalSSZ()
+		if err != nil {
+			return err
+		}
+		encodedKeys[i] = append(encodeSlot(slashing.Header_1.Header.Slot), root[:]...)
+		encodedSlashings[i] = snappy.Encode(nil, enc)
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(proposerSlashingsBucket)
+		for i := range encodedKeys {
+			if err := bkt.Put(encodedKeys[i], encodedSlashings[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AttesterSlashings retrieves the attester slashings detected by the slasher
+// with a target epoch in the inclusive range [startEpoch, endEpoch].
+func (s *Store) AttesterSlashings(
+	ctx context.Context, startEpoch, endEpoch primitives.Epoch,
+) ([]ethpb.AttSlashing, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.AttesterSlashings")
+	defer span.End()
+	if startEpoch > endEpoch {
+		return nil, fmt.Errorf("start epoch %d is greater than end epoch %d", startEpoch, endEpoch)
+	}
+
+	slashings := make([]ethpb.AttSlashing, 0)
+	encEndEpoch := encodeTargetEpoch(endEpoch)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(attesterSlashingsBucket).Cursor()
+		for k, v := c.Seek(encodeTargetEpoch(startEpoch)); k != nil; k, v = c.Next() {
+			if uint64PrefixGreaterThan(k, encEndEpoch) {
+				return nil
+			}
+			slashing, err := decodeAttesterSlashing(v)
+			if err != nil {
+				return err
+			}
+			slashings = append(slashings, slashing)
+		}
+		return nil
+	})
+	return slashings, err
+}
+
+// ProposerSlashings retrieves the proposer slashings detected by the slasher
+// with a slot in the inclusive range [startSlot, endSlot].
+func (s *Store) ProposerSlashings(
+	ctx context.Context, startSlot, endSlot primitives.Slot,
+) ([]*ethpb.ProposerSlashing, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ProposerSlashings")
+	defer span.End()
+	if startSlot > endSlot {
+		return nil, fmt.Errorf("start slot %d is greater than end slot %d", startSlot, endSlot)
+	}
+
+	slashings := make([]*ethpb.ProposerSlashing, 0)
+	encEndSlot := encodeSlot(endSlot)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(proposerSlashingsBucket).Cursor()
+		for k, v := c.Seek(encodeSlot(startSlot)); k != nil; k, v = c.Next() {
+			if uint64PrefixGreaterThan(k, encEndSlot) {
+				return nil
+			}
+			enc, err := snappy.Decode(nil, v)
+			if err != nil {
+				return err
+			}
+			slashing := &ethpb.ProposerSlashing{}
+			if err := slashing.UnmarshalSSZ(enc); err != nil {
+				return err
+			}
+			slashings = append(slashings, slashing)
+		}
+		return nil
+	})
+	return slashings, err
+}
+
+// pruneSlashings deletes all the slashings of a bucket with a key prefix
+// less than or equal to the specified one.
+func pruneSlashings(tx *bolt.Tx, bucket, encodedMaxPrefix []byte) error {
+	bkt := tx.Bucket(bucket)
+	c := bkt.Cursor()
+	for k, _ := c.First(); k != nil; k, _ = c.First() {
+		if uint64PrefixGreaterThan(k, encodedMaxPrefix) {
+			return nil
+		}
+		if err := bkt.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keyForAttesterSlashing returns the disk key of an attester slashing,
+// consisting of the highest target epoch of its attestations + its hash tree root.
+func keyForAttesterSlashing(slashing ethpb.AttSlashing) ([]byte, error) {
+	if slashing == nil || slashing.IsNil() {
+		return nil, errors.New("nil attester slashing")
+	}
+	root, err := slashing.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	targetEpoch := max(
+		slashing.FirstAttestation().GetData().Target.Epoch,
+		slashing.SecondAttestation().GetData().Target.Epoch,
+	)
+	return append(encodeTargetEpoch(targetEpoch), root[:]...), nil
+}
+
+// Encode an attester slashing to bytes.
+// The output consists in the version of the slashing concatenated with the compressed slashing.
+func encodeAttesterSlashing(slashing ethpb.AttSlashing) ([]byte, error) {
+	enc, err := slashing.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{byte(slashing.Version())}, snappy.Encode(nil, enc)...), nil
+}
+
+// Decode an attester slashing from bytes.
+// The input consists in the version of the slashing concatenated with the compressed slashing.
+func decodeAttesterSlashing(encoded []byte) (ethpb.AttSlashing, error) {
+	if len(encoded) < 1 {
+		return nil, errors.New("empty encoded attester slashing")
+	}
+	var slashing ethpb.AttSlashing = &ethpb.AttesterSlashing{}
+	if int(encoded[0]) >= version.Electra {
+		slashing = &ethpb.AttesterSlashingElectra{}
+	}
+	enc, err := snappy.Decode(nil, encoded[1:])
+	if err != nil {
+		return nil, err
+	}
+	if err := slashing.UnmarshalSSZ(enc); err != nil {
+		return nil, err
+	}
+	return slashing, nil
+}
+
+// Encodes a slot into big-endian bytes.
+func encodeSlot(slot primitives.Slot) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(slot))
+	return buf
+}
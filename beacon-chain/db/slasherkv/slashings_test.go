@@ -0,0 +1,129 @@
+package slasherkv
+
+import (
+	"context"
+	"testing"
+
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestStore_AttesterSlashings_SaveRetrieve(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	doubleVote := &ethpb.AttesterSlashing{
+		Attestation_1: createAttestationWrapper(1, 2, []uint64{1}, []byte{1}).IndexedAttestation.(*ethpb.IndexedAttestation),
+		Attestation_2: createAttestationWrapper(1, 2, []uint64{1}, []byte{2}).IndexedAttestation.(*ethpb.IndexedAttestation),
+	}
+	att1 := createAttestationWrapper(3, 4, []uint64{2}, nil).IndexedAttestation
+	att2 := createAttestationWrapper(2, 5, []uint64{2}, nil).IndexedAttestation
+	surround := &ethpb.AttesterSlashingElectra{
+		Attestation_1: &ethpb.IndexedAttestationElectra{
+			AttestingIndices: att1.GetAttestingIndices(),
+			Data:             att1.GetData(),
+			Signature:        att1.GetSignature(),
+		},
+		Attestation_2: &ethpb.IndexedAttestationElectra{
+			AttestingIndices: att2.GetAttestingIndices(),
+			Data:             att2.GetData(),
+			Signature:        att2.GetSignature(),
+		},
+	}
+
+	slashings, err := beaconDB.AttesterSlashings(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(slashings))
+
+	require.NoError(t, beaconDB.SaveAttesterSlashings(ctx, []ethpb.AttSlashing{doubleVote, surround}))
+	// Saving the same slashing again does not duplicate it.
+	require.NoError(t, beaconDB.SaveAttesterSlashings(ctx, []ethpb.AttSlashing{doubleVote}))
+
+	slashings, err = beaconDB.AttesterSlashings(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(slashings))
+	require.Equal(t, version.Phase0, slashings[0].Version())
+	require.DeepEqual(t, doubleVote, slashings[0])
+	require.Equal(t, version.Electra, slashings[1].Version())
+	require.DeepEqual(t, surround, slashings[1])
+
+	// The surround vote is keyed by its highest target epoch.
+	slashings, err = beaconDB.AttesterSlashings(ctx, 3, 4)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(slashings))
+	slashings, err = beaconDB.AttesterSlashings(ctx, 5, 5)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(slashings))
+	require.DeepEqual(t, surround, slashings[0])
+
+	_, err = beaconDB.AttesterSlashings(ctx, 5, 4)
+	require.ErrorContains(t, "start epoch 5 is greater than end epoch 4", err)
+}
+
+func TestStore_ProposerSlashings_SaveRetrieve(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	slashings := make([]*ethpb.ProposerSlashing, 0, 3)
+	for _, slot := range []primitives.Slot{1, 2, 3} {
+		slashings = append(slashings, &ethpb.ProposerSlashing{
+			Header_1: createProposalWrapper(t, slot, 1, []byte{1}).SignedBeaconBlockHeader,
+			Header_2: createProposalWrapper(t, slot, 1, []byte{2}).SignedBeaconBlockHeader,
+		})
+	}
+	require.NoError(t, beaconDB.SaveProposerSlashings(ctx, slashings))
+	require.NoError(t, beaconDB.SaveProposerSlashings(ctx, slashings[:1]))
+
+	retrieved, err := beaconDB.ProposerSlashings(ctx, 0, 10)
+	require.NoError(t, err)
+	require.DeepEqual(t, slashings, retrieved)
+
+	retrieved, err = beaconDB.ProposerSlashings(ctx, 2, 2)
+	require.NoError(t, err)
+	require.DeepEqual(t, slashings[1:2], retrieved)
+
+	require.ErrorContains(t, "nil proposer slashing", beaconDB.SaveProposerSlashings(ctx, []*ethpb.ProposerSlashing{{}}))
+}
+
+func TestStore_PruneSlashings(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+
+	attesterSlashings := make([]ethpb.AttSlashing, 0)
+	proposerSlashings := make([]*ethpb.ProposerSlashing, 0)
+	for epoch := primitives.Epoch(1); epoch <= 4; epoch++ {
+		attWrapper := createAttestationWrapper(epoch-1, epoch, []uint64{1}, []byte{1})
+		require.NoError(t, beaconDB.SaveAttestationRecordsForValidators(ctx, []*slashertypes.IndexedAttestationWrapper{attWrapper}))
+		attesterSlashings = append(attesterSlashings, &ethpb.AttesterSlashing{
+			Attestation_1: attWrapper.IndexedAttestation.(*ethpb.IndexedAttestation),
+			Attestation_2: createAttestationWrapper(epoch-1, epoch, []uint64{1}, []byte{2}).IndexedAttestation.(*ethpb.IndexedAttestation),
+		})
+
+		slot, err := slots.EpochStart(epoch)
+		require.NoError(t, err)
+		proposal := createProposalWrapper(t, slot, 1, []byte{1})
+		require.NoError(t, beaconDB.SaveBlockProposals(ctx, []*slashertypes.SignedBlockHeaderWrapper{proposal}))
+		proposerSlashings = append(proposerSlashings, &ethpb.ProposerSlashing{
+			Header_1: proposal.SignedBeaconBlockHeader,
+			Header_2: createProposalWrapper(t, slot, 1, []byte{2}).SignedBeaconBlockHeader,
+		})
+	}
+	require.NoError(t, beaconDB.SaveAttesterSlashings(ctx, attesterSlashings))
+	require.NoError(t, beaconDB.SaveProposerSlashings(ctx, proposerSlashings))
+
+	_, err := beaconDB.PruneAttestationsAtEpoch(ctx, 2)
+	require.NoError(t, err)
+	_, err = beaconDB.PruneProposalsAtEpoch(ctx, 2)
+	require.NoError(t, err)
+
+	retrievedAtt, err := beaconDB.AttesterSlashings(ctx, 0, 10)
+	require.NoError(t, err)
+	require.DeepEqual(t, attesterSlashings[2:], retrievedAtt)
+	retrievedProp, err := beaconDB.ProposerSlashings(ctx, 0, 1000)
+	require.NoError(t, err)
+	require.DeepEqual(t, proposerSlashings[2:], retrievedProp)
+}
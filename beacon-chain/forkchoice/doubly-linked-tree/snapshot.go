@@ -0,0 +1,357 @@
+package doublylinkedtree
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// snapshotVersion is bumped whenever the layout written by Snapshot changes.
+const snapshotVersion uint8 = 1
+
+var (
+	errSnapshotVersion      = errors.New("unsupported forkchoice snapshot version")
+	errSnapshotTruncated    = errors.New("forkchoice snapshot is truncated")
+	errSnapshotEmpty        = errors.New("forkchoice snapshot has no nodes")
+	errSnapshotDuplicate    = errors.New("forkchoice snapshot contains a duplicate node")
+	errSnapshotOrphan       = errors.New("forkchoice snapshot node parent is missing")
+	errSnapshotUnknownRoot  = errors.New("forkchoice snapshot references an unknown node")
+	errSnapshotTrailingData = errors.New("forkchoice snapshot has trailing data")
+)
+
+// Snapshot serializes the full fork choice store: the node tree with weights and optimistic status,
+// checkpoints, proposer boost information, validator votes and balances. Nodes are written in tree
+// order, so that every node's parent precedes it. The caller must hold the read lock.
+func (f *ForkChoice) Snapshot() ([]byte, error) {
+	s := f.store
+	if s.treeRootNode == nil {
+		return nil, errSnapshotEmpty
+	}
+	w := &snapshotWriter{}
+	w.u8(snapshotVersion)
+
+	w.checkpoint(s.justifiedCheckpoint)
+	w.checkpoint(s.unrealizedJustifiedCheckpoint)
+	w.checkpoint(s.unrealizedFinalizedCheckpoint)
+	w.checkpoint(s.prevJustifiedCheckpoint)
+	w.checkpoint(s.finalizedCheckpoint)
+	w.root(s.proposerBoostRoot)
+	w.root(s.previousProposerBoostRoot)
+	w.u64(s.previousProposerBoostScore)
+	w.u64(s.committeeWeight)
+	w.root(s.originRoot)
+	w.u64(s.genesisTime)
+	w.nodeRef(s.headNode)
+	w.nodeRef(s.highestReceivedNode)
+	for _, slot := range s.receivedBlocksLastEpoch {
+		w.u64(uint64(slot))
+	}
+	w.bool(s.allTipsAreInvalid)
+	w.u64(uint64(len(s.slashedIndices)))
+	for idx := range s.slashedIndices {
+		w.u64(uint64(idx))
+	}
+
+	nodes := make([]*Node, 0, len(s.nodeByRoot))
+	nodes = appendTree(nodes, s.treeRootNode)
+	w.u64(uint64(len(nodes)))
+	for _, n := range nodes {
+		w.u64(uint64(n.slot))
+		w.root(n.root)
+		w.root(n.payloadHash)
+		w.nodeRef(n.parent)
+		w.nodeRef(n.target)
+		w.nodeRef(n.bestDescendant)
+		w.u64(uint64(n.justifiedEpoch))
+		w.u64(uint64(n.unrealizedJustifiedEpoch))
+		w.u64(uint64(n.finalizedEpoch))
+		w.u64(uint64(n.unrealizedFinalizedEpoch))
+		w.u64(n.balance)
+		w.u64(n.weight)
+		w.bool(n.optimistic)
+		w.u64(n.timestamp)
+	}
+
+	w.u64(uint64(len(f.votes)))
+	for _, v := range f.votes {
+		w.root(v.currentRoot)
+		w.root(v.nextRoot)
+		w.u64(uint64(v.nextEpoch))
+	}
+	w.u64s(f.balances)
+	w.u64s(f.justifiedBalances)
+	w.u64(f.numActiveValidators)
+	return w.buf.Bytes(), nil
+}
+
+// RestoreSnapshot replaces the contents of the fork choice store with a snapshot produced by Snapshot.
+// The snapshot is checked for internal consistency before the store is modified, so on error the store
+// is left untouched. The balances handler is preserved. The caller must hold the lock.
+func (f *ForkChoice) RestoreSnapshot(ctx context.Context, enc []byte) error {
+	r := &snapshotReader{r: bytes.NewReader(enc)}
+	if v := r.u8(); r.err == nil && v != snapshotVersion {
+		return errors.Wrapf(errSnapshotVersion, "version=%d", v)
+	}
+	s := &Store{
+		justifiedCheckpoint:           r.checkpoint(),
+		unrealizedJustifiedCheckpoint: r.checkpoint(),
+		unrealizedFinalizedCheckpoint: r.checkpoint(),
+		prevJustifiedCheckpoint:       r.checkpoint(),
+		finalizedCheckpoint:           r.checkpoint(),
+		proposerBoostRoot:             r.root(),
+		previousProposerBoostRoot:     r.root(),
+		previousProposerBoostScore:    r.u64(),
+		committeeWeight:               r.u64(),
+		originRoot:                    r.root(),
+		genesisTime:                   r.u64(),
+		nodeByRoot:                    make(map[[fieldparams.RootLength]byte]*Node),
+		nodeByPayload:                 make(map[[fieldparams.RootLength]byte]*Node),
+		slashedIndices:                make(map[primitives.ValidatorIndex]bool),
+	}
+	headRoot, hasHead := r.nodeRef()
+	highestRoot, hasHighest := r.nodeRef()
+	for i := range s.receivedBlocksLastEpoch {
+		s.receivedBlocksLastEpoch[i] = primitives.Slot(r.u64())
+	}
+	s.allTipsAreInvalid = r.bool()
+	numSlashed := r.length(8)
+	for i := uint64(0); i < numSlashed; i++ {
+		s.slashedIndices[primitives.ValidatorIndex(r.u64())] = true
+	}
+
+	// Each node is at least 8 fields of 8 bytes, which bounds the allocation for a corrupt length.
+	numNodes := r.length(8 * 8)
+	if r.err == nil && numNodes == 0 {
+		return errSnapshotEmpty
+	}
+	type nodeRefs struct {
+		target, bestDescendant       [32]byte
+		hasTarget, hasBestDescendant bool
+	}
+	refs := make(map[*Node]nodeRefs, numNodes)
+	for i := uint64(0); i < numNodes && r.err == nil; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n := &Node{slot: primitives.Slot(r.u64()), root: r.root(), payloadHash: r.root()}
+		parentRoot, hasParent := r.nodeRef()
+		var nr nodeRefs
+		nr.target, nr.hasTarget = r.nodeRef()
+		nr.bestDescendant, nr.hasBestDescendant = r.nodeRef()
+		n.justifiedEpoch = primitives.Epoch(r.u64())
+		n.unrealizedJustifiedEpoch = primitives.Epoch(r.u64())
+		n.finalizedEpoch = primitives.Epoch(r.u64())
+		n.unrealizedFinalizedEpoch = primitives.Epoch(r.u64())
+		n.balance = r.u64()
+		n.weight = r.u64()
+		n.optimistic = r.bool()
+		n.timestamp = r.u64()
+		if r.err != nil {
+			break
+		}
+		if _, ok := s.nodeByRoot[n.root]; ok {
+			return errors.Wrapf(errSnapshotDuplicate, "root=%#x", n.root)
+		}
+		if i == 0 {
+			if hasParent {
+				return errors.Wrapf(errSnapshotOrphan, "tree root %#x has a parent", n.root)
+			}
+			s.treeRootNode = n
+		} else {
+			parent, ok := s.nodeByRoot[parentRoot]
+			if !hasParent || !ok {
+				return errors.Wrapf(errSnapshotOrphan, "root=%#x, parent=%#x", n.root, parentRoot)
+			}
+			n.parent = parent
+			parent.children = append(parent.children, n)
+		}
+		s.nodeByRoot[n.root] = n
+		s.nodeByPayload[n.payloadHash] = n
+		refs[n] = nr
+	}
+
+	numVotes := r.length(2*fieldparams.RootLength + 8)
+	votes := make([]Vote, 0, numVotes)
+	for i := uint64(0); i < numVotes && r.err == nil; i++ {
+		votes = append(votes, Vote{currentRoot: r.root(), nextRoot: r.root(), nextEpoch: primitives.Epoch(r.u64())})
+	}
+	balances := r.u64s()
+	justifiedBalances := r.u64s()
+	numActive := r.u64()
+	if r.err != nil {
+		return r.err
+	}
+	if r.r.Len() != 0 {
+		return errSnapshotTrailingData
+	}
+
+	// Resolve the references between nodes now that the whole tree is known.
+	for n, nr := range refs {
+		if nr.hasTarget {
+			// The target of the oldest nodes may have been pruned along with the finalized ancestors.
+			n.target = s.nodeByRoot[nr.target]
+		}
+		if nr.hasBestDescendant {
+			bd, ok := s.nodeByRoot[nr.bestDescendant]
+			if !ok {
+				return errors.Wrapf(errSnapshotUnknownRoot, "best descendant %#x of %#x", nr.bestDescendant, n.root)
+			}
+			n.bestDescendant = bd
+		}
+	}
+	var ok bool
+	if !hasHead {
+		return errors.Wrap(errSnapshotUnknownRoot, "missing head")
+	}
+	if s.headNode, ok = s.nodeByRoot[headRoot]; !ok {
+		return errors.Wrapf(errSnapshotUnknownRoot, "head %#x", headRoot)
+	}
+	if hasHighest {
+		if s.highestReceivedNode, ok = s.nodeByRoot[highestRoot]; !ok {
+			return errors.Wrapf(errSnapshotUnknownRoot, "highest received node %#x", highestRoot)
+		}
+	}
+
+	f.store = s
+	f.votes = votes
+	f.balances = balances
+	f.justifiedBalances = justifiedBalances
+	f.numActiveValidators = numActive
+	nodeCount.Set(float64(len(s.nodeByRoot)))
+	headSlotNumber.Set(float64(s.headNode.slot))
+	return nil
+}
+
+// appendTree appends the node and all of its descendants, parents first.
+func appendTree(nodes []*Node, n *Node) []*Node {
+	nodes = append(nodes, n)
+	for _, child := range n.children {
+		nodes = appendTree(nodes, child)
+	}
+	return nodes
+}
+
+type snapshotWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *snapshotWriter) u8(v uint8) {
+	w.buf.WriteByte(v)
+}
+
+func (w *snapshotWriter) bool(v bool) {
+	if v {
+		w.u8(1)
+	} else {
+		w.u8(0)
+	}
+}
+
+func (w *snapshotWriter) u64(v uint64) {
+	w.buf.Write(binary.LittleEndian.AppendUint64(nil, v))
+}
+
+func (w *snapshotWriter) u64s(vs []uint64) {
+	w.u64(uint64(len(vs)))
+	for _, v := range vs {
+		w.u64(v)
+	}
+}
+
+func (w *snapshotWriter) root(r [fieldparams.RootLength]byte) {
+	w.buf.Write(r[:])
+}
+
+func (w *snapshotWriter) checkpoint(cp *forkchoicetypes.Checkpoint) {
+	if cp == nil {
+		cp = &forkchoicetypes.Checkpoint{}
+	}
+	w.u64(uint64(cp.Epoch))
+	w.root(cp.Root)
+}
+
+// nodeRef writes a possibly nil reference to another node as a presence flag followed by its root.
+func (w *snapshotWriter) nodeRef(n *Node) {
+	if n == nil {
+		w.bool(false)
+		w.root([fieldparams.RootLength]byte{})
+		return
+	}
+	w.bool(true)
+	w.root(n.root)
+}
+
+// snapshotReader decodes the values written by snapshotWriter. The first error is retained and
+// all subsequent reads return zero values, so callers only need to check err once.
+type snapshotReader struct {
+	r   *bytes.Reader
+	err error
+}
+
+func (r *snapshotReader) read(b []byte) {
+	if r.err != nil {
+		return
+	}
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		r.err = errSnapshotTruncated
+	}
+}
+
+func (r *snapshotReader) u8() uint8 {
+	var b [1]byte
+	r.read(b[:])
+	return b[0]
+}
+
+func (r *snapshotReader) bool() bool {
+	return r.u8() == 1
+}
+
+func (r *snapshotReader) u64() uint64 {
+	var b [8]byte
+	r.read(b[:])
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// length reads a collection length, failing if the remaining input cannot hold that many items of
+// at least minSize bytes each.
+func (r *snapshotReader) length(minSize int) uint64 {
+	l := r.u64()
+	if r.err == nil && l > uint64(r.r.Len()/minSize) {
+		r.err = errSnapshotTruncated
+	}
+	if r.err != nil {
+		return 0
+	}
+	return l
+}
+
+func (r *snapshotReader) u64s() []uint64 {
+	l := r.length(8)
+	vs := make([]uint64, l)
+	for i := range vs {
+		vs[i] = r.u64()
+	}
+	return vs
+}
+
+func (r *snapshotReader) root() [fieldparams.RootLength]byte {
+	var b [fieldparams.RootLength]byte
+	r.read(b[:])
+	return b
+}
+
+func (r *snapshotReader) checkpoint() *forkchoicetypes.Checkpoint {
+	return &forkchoicetypes.Checkpoint{Epoch: primitives.Epoch(r.u64()), Root: r.root()}
+}
+
+func (r *snapshotReader) nodeRef() ([fieldparams.RootLength]byte, bool) {
+	ok := r.bool()
+	return r.root(), ok
+}
@@ -0,0 +1,94 @@
+package doublylinkedtree
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestForkChoice_SnapshotRestore(t *testing.T) {
+	ctx := context.Background()
+	f := setup(0, 0)
+	// 0 <- 1 <- 2 <- 3
+	//       \- 4
+	chain := []struct {
+		slot   uint64
+		root   uint64
+		parent uint64
+	}{{1, 1, 0}, {2, 2, 1}, {3, 3, 2}, {2, 4, 1}}
+	for _, c := range chain {
+		parent := indexToHash(c.parent)
+		if c.parent == 0 {
+			parent = params.BeaconConfig().ZeroHash
+		}
+		st, roblock, err := prepareForkchoiceState(ctx, primitives.Slot(c.slot), indexToHash(c.root), parent, indexToHash(100+c.root), 0, 0)
+		require.NoError(t, err)
+		require.NoError(t, f.InsertNode(ctx, st, roblock))
+	}
+	require.NoError(t, f.SetOptimisticToValid(ctx, indexToHash(2)))
+	f.justifiedBalances = []uint64{10, 20, 30}
+	f.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(4), 0)
+	f.ProcessAttestation(ctx, []uint64{2}, indexToHash(3), 0)
+	f.InsertSlashedIndex(ctx, 1)
+	head, err := f.Head(ctx)
+	require.NoError(t, err)
+
+	enc, err := f.Snapshot()
+	require.NoError(t, err)
+
+	restored := New()
+	restored.SetBalancesByRooter(func(_ context.Context, _ [32]byte) ([]uint64, error) { return restored.justifiedBalances, nil })
+	require.NoError(t, restored.RestoreSnapshot(ctx, enc))
+
+	want, err := f.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	got, err := restored.ForkChoiceDump(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, want, got)
+	require.Equal(t, f.NodeCount(), restored.NodeCount())
+	require.DeepEqual(t, f.votes, restored.votes)
+	require.DeepEqual(t, f.balances, restored.balances)
+	require.Equal(t, true, restored.store.slashedIndices[1])
+	optimistic, err := restored.IsOptimistic(indexToHash(2))
+	require.NoError(t, err)
+	require.Equal(t, false, optimistic)
+	optimistic, err = restored.IsOptimistic(indexToHash(3))
+	require.NoError(t, err)
+	require.Equal(t, true, optimistic)
+	require.Equal(t, restored.store.nodeByRoot[indexToHash(4)], restored.store.nodeByPayload[indexToHash(104)])
+
+	restoredHead, err := restored.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, head, restoredHead)
+
+	// The restored store keeps accepting new blocks.
+	st, roblock, err := prepareForkchoiceState(ctx, 4, indexToHash(5), indexToHash(3), indexToHash(105), 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, restored.InsertNode(ctx, st, roblock))
+	require.Equal(t, true, restored.HasNode(indexToHash(5)))
+}
+
+func TestForkChoice_RestoreSnapshotInvalid(t *testing.T) {
+	ctx := context.Background()
+	f := setup(0, 0)
+	st, roblock, err := prepareForkchoiceState(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, params.BeaconConfig().ZeroHash, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, roblock))
+	enc, err := f.Snapshot()
+	require.NoError(t, err)
+
+	restored := New()
+	require.ErrorIs(t, restored.RestoreSnapshot(ctx, enc[:len(enc)-1]), errSnapshotTruncated)
+	require.ErrorIs(t, restored.RestoreSnapshot(ctx, append(enc, 0)), errSnapshotTrailingData)
+	bad := append([]byte{}, enc...)
+	bad[0] = snapshotVersion + 1
+	require.ErrorIs(t, restored.RestoreSnapshot(ctx, bad), errSnapshotVersion)
+	// A failed restore leaves the store untouched.
+	require.Equal(t, 0, restored.NodeCount())
+
+	_, err = New().Snapshot()
+	require.ErrorIs(t, err, errSnapshotEmpty)
+}
@@ -25,6 +25,7 @@ type ForkChoicer interface {
 	AttestationProcessor // to track new attestation for fork choice.
 	Getter               // to retrieve fork choice information.
 	Setter               // to set fork choice information.
+	Snapshotter          // to persist fork choice across restarts.
 }
 
 // RLocker represents forkchoice's internal RWMutex read-only lock/unlock methods.
@@ -51,6 +52,12 @@ type AttestationProcessor interface {
 	ProcessAttestation(context.Context, []uint64, [32]byte, primitives.Epoch)
 }
 
+// Snapshotter serializes the fork choice store so it can be persisted and restored across restarts.
+type Snapshotter interface {
+	Snapshot() ([]byte, error)
+	RestoreSnapshot(context.Context, []byte) error
+}
+
 // Getter returns fork choice related information.
 type Getter interface {
 	FastGetter
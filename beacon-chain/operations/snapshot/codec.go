@@ -0,0 +1,129 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	ssz "github.com/prysmaticlabs/fastssz"
+)
+
+// recordKind identifies the pool and concrete type of an object in the snapshot.
+type recordKind uint8
+
+const (
+	kindAggregatedAtt recordKind = iota + 1
+	kindAggregatedAttElectra
+	kindUnaggregatedAtt
+	kindUnaggregatedAttElectra
+	kindVoluntaryExit
+	kindBLSToExecChange
+	kindProposerSlashing
+	kindAttesterSlashing
+	kindAttesterSlashingElectra
+	kindSyncCommitteeMessage
+	kindSyncCommitteeContribution
+)
+
+// String returns a human-readable name for the kind, used in logs.
+func (k recordKind) String() string {
+	switch k {
+	case kindAggregatedAtt, kindAggregatedAttElectra:
+		return "aggregated_attestation"
+	case kindUnaggregatedAtt, kindUnaggregatedAttElectra:
+		return "unaggregated_attestation"
+	case kindVoluntaryExit:
+		return "voluntary_exit"
+	case kindBLSToExecChange:
+		return "bls_to_execution_change"
+	case kindProposerSlashing:
+		return "proposer_slashing"
+	case kindAttesterSlashing, kindAttesterSlashingElectra:
+		return "attester_slashing"
+	case kindSyncCommitteeMessage:
+		return "sync_committee_message"
+	case kindSyncCommitteeContribution:
+		return "sync_committee_contribution"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(k))
+	}
+}
+
+var (
+	snapshotMagic = [4]byte{'o', 'p', 'p', 's'}
+
+	errBadMagic           = errors.New("operation pool snapshot has an invalid header")
+	errUnsupportedVersion = errors.New("unsupported operation pool snapshot version")
+	errTruncatedRecord    = errors.New("operation pool snapshot record is truncated")
+)
+
+const (
+	snapshotVersion uint8 = 1
+	headerSize            = len(snapshotMagic) + 1
+	// recordHeaderSize is the size of the kind byte followed by the little-endian uint32 payload length.
+	recordHeaderSize = 5
+)
+
+// record is a single pool object along with the kind needed to decode it.
+type record struct {
+	kind recordKind
+	obj  ssz.Marshaler
+}
+
+// rawRecord is a record read from disk whose payload has not yet been decoded.
+type rawRecord struct {
+	kind recordKind
+	enc  []byte
+}
+
+// encodeSnapshot serializes the records into the snapshot file format: a 4-byte magic value and a version byte,
+// followed by each record as a kind byte, a little-endian uint32 length, and the ssz-encoded object.
+func encodeSnapshot(records []record) ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, headerSize))
+	buf.Write(snapshotMagic[:])
+	buf.WriteByte(snapshotVersion)
+	hdr := make([]byte, recordHeaderSize)
+	for _, r := range records {
+		enc, err := r.obj.MarshalSSZ()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not marshal %s", r.kind)
+		}
+		hdr[0] = byte(r.kind)
+		binary.LittleEndian.PutUint32(hdr[1:], uint32(len(enc)))
+		buf.Write(hdr)
+		buf.Write(enc)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeSnapshot splits a snapshot into its raw records, validating the header and record framing.
+func decodeSnapshot(b []byte) ([]rawRecord, error) {
+	if len(b) < headerSize || !bytes.Equal(b[:len(snapshotMagic)], snapshotMagic[:]) {
+		return nil, errBadMagic
+	}
+	if v := b[len(snapshotMagic)]; v != snapshotVersion {
+		return nil, errors.Wrapf(errUnsupportedVersion, "version=%d", v)
+	}
+	r := bytes.NewReader(b[headerSize:])
+	records := make([]rawRecord, 0)
+	hdr := make([]byte, recordHeaderSize)
+	for {
+		if _, err := io.ReadFull(r, hdr); err != nil {
+			if errors.Is(err, io.EOF) {
+				return records, nil
+			}
+			return nil, errTruncatedRecord
+		}
+		size := binary.LittleEndian.Uint32(hdr[1:])
+		if uint64(size) > uint64(r.Len()) {
+			return nil, errTruncatedRecord
+		}
+		enc := make([]byte, size)
+		if _, err := io.ReadFull(r, enc); err != nil {
+			return nil, errTruncatedRecord
+		}
+		records = append(records, rawRecord{kind: recordKind(hdr[0]), enc: enc})
+	}
+}
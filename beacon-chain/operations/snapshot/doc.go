@@ -0,0 +1,6 @@
+// Package snapshot persists the contents of the beacon node's operation pools
+// (attestations, slashings, voluntary exits, BLS to execution changes and sync
+// committee objects) to disk, so that pending operations survive a restart.
+// On startup the snapshot is reloaded and every object is revalidated against
+// the head state before being reinserted into its pool.
+package snapshot
@@ -0,0 +1,7 @@
+package snapshot
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "pool/snapshot")
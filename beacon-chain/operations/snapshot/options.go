@@ -0,0 +1,87 @@
+package snapshot
+
+import (
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/blstoexec"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/synccommittee"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+)
+
+// Option is a functional option for the operation pool snapshot service.
+type Option func(s *Service) error
+
+// WithPath sets the file the pool snapshot is written to and restored from.
+func WithPath(path string) Option {
+	return func(s *Service) error {
+		s.cfg.path = path
+		return nil
+	}
+}
+
+// WithInterval sets how often the pools are snapshotted while the node is running.
+func WithInterval(interval time.Duration) Option {
+	return func(s *Service) error {
+		s.cfg.interval = interval
+		return nil
+	}
+}
+
+// WithHeadFetcher sets the source of the head state used to collect and revalidate pool objects.
+func WithHeadFetcher(h HeadStateFetcher) Option {
+	return func(s *Service) error {
+		s.cfg.headFetcher = h
+		return nil
+	}
+}
+
+// WithClockWaiter sets the waiter used to block the restore until the chain has started.
+func WithClockWaiter(cw startup.ClockWaiter) Option {
+	return func(s *Service) error {
+		s.cfg.clockWaiter = cw
+		return nil
+	}
+}
+
+// WithAttestationPool to persist aggregated and unaggregated attestations.
+func WithAttestationPool(p attestations.Pool) Option {
+	return func(s *Service) error {
+		s.cfg.attPool = p
+		return nil
+	}
+}
+
+// WithExitPool to persist voluntary exits.
+func WithExitPool(p voluntaryexits.PoolManager) Option {
+	return func(s *Service) error {
+		s.cfg.exitPool = p
+		return nil
+	}
+}
+
+// WithSlashingPool to persist proposer and attester slashings.
+func WithSlashingPool(p slashings.PoolManager) Option {
+	return func(s *Service) error {
+		s.cfg.slashingPool = p
+		return nil
+	}
+}
+
+// WithBLSToExecPool to persist BLS to execution changes.
+func WithBLSToExecPool(p blstoexec.PoolManager) Option {
+	return func(s *Service) error {
+		s.cfg.blsToExecPool = p
+		return nil
+	}
+}
+
+// WithSyncCommitteePool to persist sync committee messages and contributions.
+func WithSyncCommitteePool(p synccommittee.Pool) Option {
+	return func(s *Service) error {
+		s.cfg.syncCommitteePool = p
+		return nil
+	}
+}
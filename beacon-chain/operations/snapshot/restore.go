@@ -0,0 +1,306 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	coretime "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	errAlreadyExited   = errors.New("validator has already initiated an exit")
+	errAlreadyIncluded = errors.New("all attesters already participated in the target epoch")
+	errExpired         = errors.New("object is too old to be useful")
+	errNotInCommittee  = errors.New("validator is not in the sync committee")
+	errUnknownKind     = errors.New("unknown snapshot record kind")
+	errPoolNotEnabled  = errors.New("pool for record kind is not configured")
+)
+
+// restore reads the snapshot file, revalidates each object against the head state and inserts the
+// valid ones into their pools. Objects which were already included on chain, or are otherwise no
+// longer valid, are discarded. A missing snapshot file is not an error.
+func (s *Service) restore(ctx context.Context) error {
+	b, err := os.ReadFile(s.cfg.path) // #nosec G304 -- path is set from the data directory by the node.
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "could not read snapshot from %s", s.cfg.path)
+	}
+	records, err := decodeSnapshot(b)
+	if err != nil {
+		return errors.Wrapf(err, "could not decode snapshot from %s", s.cfg.path)
+	}
+	st, err := s.cfg.headFetcher.HeadStateReadOnly(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head state")
+	}
+
+	restored := make(map[string]int)
+	discarded := 0
+	for _, r := range records {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.restoreRecord(ctx, st, r); err != nil {
+			discarded++
+			log.WithError(err).WithField("kind", r.kind.String()).Debug("Discarding operation from pool snapshot")
+			continue
+		}
+		restored[r.kind.String()]++
+	}
+	fields := logrus.Fields{"discarded": discarded}
+	for k, v := range restored {
+		fields[k] = v
+	}
+	log.WithFields(fields).Info("Restored operation pools from snapshot")
+	return nil
+}
+
+// restoreRecord decodes and validates a single record, inserting it into its pool if it is still valid.
+func (s *Service) restoreRecord(ctx context.Context, st state.ReadOnlyBeaconState, r rawRecord) error {
+	switch r.kind {
+	case kindAggregatedAtt, kindAggregatedAttElectra, kindUnaggregatedAtt, kindUnaggregatedAttElectra:
+		if s.cfg.attPool == nil {
+			return errPoolNotEnabled
+		}
+		var att ethpb.Att = &ethpb.Attestation{}
+		if r.kind == kindAggregatedAttElectra || r.kind == kindUnaggregatedAttElectra {
+			att = &ethpb.AttestationElectra{}
+		}
+		if err := att.UnmarshalSSZ(r.enc); err != nil {
+			return err
+		}
+		if err := helpers.ValidateAttestationTime(att.GetData().Slot, s.clock.GenesisTime(), params.BeaconConfig().MaximumGossipClockDisparityDuration()); err != nil {
+			return err
+		}
+		if err := verifyAttestationNotIncluded(ctx, st, att); err != nil {
+			return err
+		}
+		if err := blocks.VerifyAttestationSignature(ctx, st, att); err != nil {
+			return err
+		}
+		if r.kind == kindAggregatedAtt || r.kind == kindAggregatedAttElectra {
+			return s.cfg.attPool.SaveAggregatedAttestation(att)
+		}
+		return s.cfg.attPool.SaveUnaggregatedAttestation(att)
+	case kindVoluntaryExit:
+		if s.cfg.exitPool == nil {
+			return errPoolNotEnabled
+		}
+		exit := &ethpb.SignedVoluntaryExit{}
+		if err := exit.UnmarshalSSZ(r.enc); err != nil {
+			return err
+		}
+		val, err := st.ValidatorAtIndexReadOnly(exit.Exit.ValidatorIndex)
+		if err != nil {
+			return err
+		}
+		if val.ExitEpoch() != params.BeaconConfig().FarFutureEpoch {
+			return errAlreadyExited
+		}
+		if err := blocks.VerifyExitAndSignature(val, st, exit); err != nil {
+			return err
+		}
+		s.cfg.exitPool.InsertVoluntaryExit(exit)
+		return nil
+	case kindBLSToExecChange:
+		if s.cfg.blsToExecPool == nil {
+			return errPoolNotEnabled
+		}
+		change := &ethpb.SignedBLSToExecutionChange{}
+		if err := change.UnmarshalSSZ(r.enc); err != nil {
+			return err
+		}
+		// Changes which were already applied fail validation, as the withdrawal credentials no longer have the BLS prefix.
+		if _, err := blocks.ValidateBLSToExecutionChange(st, change); err != nil {
+			return err
+		}
+		if err := blocks.VerifyBLSChangeSignature(st, change); err != nil {
+			return err
+		}
+		s.cfg.blsToExecPool.InsertBLSToExecChange(change)
+		return nil
+	case kindProposerSlashing:
+		if s.cfg.slashingPool == nil {
+			return errPoolNotEnabled
+		}
+		ps := &ethpb.ProposerSlashing{}
+		if err := ps.UnmarshalSSZ(r.enc); err != nil {
+			return err
+		}
+		return s.cfg.slashingPool.InsertProposerSlashing(ctx, st, ps)
+	case kindAttesterSlashing, kindAttesterSlashingElectra:
+		if s.cfg.slashingPool == nil {
+			return errPoolNotEnabled
+		}
+		var as ethpb.AttSlashing = &ethpb.AttesterSlashing{}
+		if r.kind == kindAttesterSlashingElectra {
+			as = &ethpb.AttesterSlashingElectra{}
+		}
+		if err := as.UnmarshalSSZ(r.enc); err != nil {
+			return err
+		}
+		return s.cfg.slashingPool.InsertAttesterSlashing(ctx, st, as)
+	case kindSyncCommitteeMessage:
+		if s.cfg.syncCommitteePool == nil {
+			return errPoolNotEnabled
+		}
+		msg := &ethpb.SyncCommitteeMessage{}
+		if err := msg.UnmarshalSSZ(r.enc); err != nil {
+			return err
+		}
+		if msg.Slot+syncCommitteeSlots < s.clock.CurrentSlot() {
+			return errExpired
+		}
+		if err := verifySyncCommitteeMessage(st, msg); err != nil {
+			return err
+		}
+		return s.cfg.syncCommitteePool.SaveSyncCommitteeMessage(msg)
+	case kindSyncCommitteeContribution:
+		if s.cfg.syncCommitteePool == nil {
+			return errPoolNotEnabled
+		}
+		c := &ethpb.SyncCommitteeContribution{}
+		if err := c.UnmarshalSSZ(r.enc); err != nil {
+			return err
+		}
+		if c.Slot+syncCommitteeSlots < s.clock.CurrentSlot() {
+			return errExpired
+		}
+		if err := verifySyncCommitteeContribution(st, c); err != nil {
+			return err
+		}
+		return s.cfg.syncCommitteePool.SaveSyncCommitteeContribution(c)
+	default:
+		return errors.Wrapf(errUnknownKind, "kind=%d", r.kind)
+	}
+}
+
+// verifyAttestationNotIncluded returns errAlreadyIncluded when every attester of the attestation already
+// participated in its target epoch according to the head state, meaning that the attestation, or another one
+// carrying the same votes, was already included on chain.
+func verifyAttestationNotIncluded(ctx context.Context, st state.ReadOnlyBeaconState, att ethpb.Att) error {
+	if st.Version() < version.Altair {
+		return nil
+	}
+	var participation []byte
+	var err error
+	switch att.GetData().Target.Epoch {
+	case coretime.CurrentEpoch(st):
+		participation, err = st.CurrentEpochParticipation()
+	case coretime.PrevEpoch(st):
+		participation, err = st.PreviousEpochParticipation()
+	default:
+		// The head state has no participation record for the target epoch.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	committees, err := helpers.AttestationCommittees(ctx, st, att)
+	if err != nil {
+		return err
+	}
+	indices, err := attestation.AttestingIndices(att, committees...)
+	if err != nil {
+		return err
+	}
+	for _, idx := range indices {
+		if idx >= uint64(len(participation)) || participation[idx] == 0 {
+			return nil
+		}
+	}
+	return errAlreadyIncluded
+}
+
+// verifySyncCommitteeMessage verifies that the message was signed by a member of the sync committee of its slot.
+func verifySyncCommitteeMessage(st state.ReadOnlyBeaconState, msg *ethpb.SyncCommitteeMessage) error {
+	committee, err := syncCommitteeAtSlot(st, msg.Slot)
+	if err != nil {
+		return err
+	}
+	pubkey := st.PubkeyAtIndex(msg.ValidatorIndex)
+	inCommittee := false
+	for _, pk := range committee.Pubkeys {
+		if bytes.Equal(pk, pubkey[:]) {
+			inCommittee = true
+			break
+		}
+	}
+	if !inCommittee {
+		return errNotInCommittee
+	}
+	root := primitives.SSZBytes(msg.BlockRoot)
+	return signing.ComputeDomainVerifySigningRoot(st, msg.ValidatorIndex, slots.ToEpoch(msg.Slot), &root, params.BeaconConfig().DomainSyncCommittee, msg.Signature)
+}
+
+// verifySyncCommitteeContribution verifies the aggregate signature of the participants of the contribution,
+// taken from the subcommittee of the sync committee of its slot.
+func verifySyncCommitteeContribution(st state.ReadOnlyBeaconState, c *ethpb.SyncCommitteeContribution) error {
+	if c.AggregationBits.Count() == 0 {
+		return errors.New("contribution has no participant")
+	}
+	committee, err := syncCommitteeAtSlot(st, c.Slot)
+	if err != nil {
+		return err
+	}
+	subcommittee, err := altair.SyncSubCommitteePubkeys(committee, primitives.CommitteeIndex(c.SubcommitteeIndex))
+	if err != nil {
+		return err
+	}
+	pubkeys := make([][]byte, 0, c.AggregationBits.Count())
+	for i, pk := range subcommittee {
+		if c.AggregationBits.BitAt(uint64(i)) {
+			pubkeys = append(pubkeys, pk)
+		}
+	}
+	aggKey, err := bls.AggregatePublicKeys(pubkeys)
+	if err != nil {
+		return err
+	}
+	sig, err := bls.SignatureFromBytes(c.Signature)
+	if err != nil {
+		return err
+	}
+	d, err := signing.Domain(st.Fork(), slots.ToEpoch(c.Slot), params.BeaconConfig().DomainSyncCommittee, st.GenesisValidatorsRoot())
+	if err != nil {
+		return err
+	}
+	root := primitives.SSZBytes(c.BlockRoot)
+	signingRoot, err := signing.ComputeSigningRoot(&root, d)
+	if err != nil {
+		return err
+	}
+	if !sig.Verify(aggKey, signingRoot[:]) {
+		return signing.ErrSigFailedToVerify
+	}
+	return nil
+}
+
+// syncCommitteeAtSlot returns the sync committee signing for the given slot, which is the committee
+// of the period of the next slot, as the messages of a slot are included in the block of the next one.
+func syncCommitteeAtSlot(st state.ReadOnlyBeaconState, slot primitives.Slot) (*ethpb.SyncCommittee, error) {
+	if st.Version() < version.Altair {
+		return nil, errors.New("head state has no sync committee")
+	}
+	if slots.SyncCommitteePeriod(slots.ToEpoch(slot+1)) == slots.SyncCommitteePeriod(coretime.CurrentEpoch(st)) {
+		return st.CurrentSyncCommittee()
+	}
+	return st.NextSyncCommittee()
+}
@@ -0,0 +1,239 @@
+package snapshot
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/blstoexec"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/synccommittee"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/io/file"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+// DefaultFileName is the name of the snapshot file within the beacon node data directory.
+const DefaultFileName = "operation-pools.ssz"
+
+// syncCommitteeSlots is how many slots before the current slot sync committee objects are kept for.
+// Sync committee messages for a slot are only useful to the proposer of the following slot.
+const syncCommitteeSlots = 1
+
+var errNoSnapshotPath = errors.New("no operation pool snapshot path specified")
+
+// HeadStateFetcher is the subset of the blockchain service used to collect and revalidate pool objects.
+type HeadStateFetcher interface {
+	HeadStateReadOnly(ctx context.Context) (state.ReadOnlyBeaconState, error)
+}
+
+type config struct {
+	path              string
+	interval          time.Duration
+	headFetcher       HeadStateFetcher
+	clockWaiter       startup.ClockWaiter
+	attPool           attestations.Pool
+	exitPool          voluntaryexits.PoolManager
+	slashingPool      slashings.PoolManager
+	blsToExecPool     blstoexec.PoolManager
+	syncCommitteePool synccommittee.Pool
+}
+
+// Service periodically writes the contents of the operation pools to disk, writes a final snapshot
+// when the node shuts down, and restores the pools from the snapshot once the chain has started.
+type Service struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	cfg    *config
+	clock  *startup.Clock
+	// saveLock serializes snapshot writes and guards restored.
+	saveLock sync.Mutex
+	// restored is set once the restore attempt has completed. Until then the pools do not yet
+	// hold the previous snapshot's contents, so writing a snapshot would discard it.
+	restored bool
+}
+
+// NewService initializes the operation pool snapshot service.
+func NewService(ctx context.Context, opts ...Option) (*Service, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Service{
+		ctx:    ctx,
+		cancel: cancel,
+		cfg: &config{
+			interval: time.Duration(uint64(params.BeaconConfig().SlotsPerEpoch)*params.BeaconConfig().SecondsPerSlot) * time.Second,
+		},
+	}
+	for _, o := range opts {
+		if err := o(s); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	if s.cfg.path == "" {
+		cancel()
+		return nil, errNoSnapshotPath
+	}
+	return s, nil
+}
+
+// Start waits for the chain to start, restores the pools and then snapshots them periodically.
+func (s *Service) Start() {
+	go s.run()
+}
+
+// Stop writes a final snapshot of the pools.
+func (s *Service) Stop() error {
+	s.cancel()
+	s.saveLock.Lock()
+	restored := s.restored
+	s.saveLock.Unlock()
+	if !restored {
+		return nil
+	}
+	return s.save(context.Background())
+}
+
+// Status of the service.
+func (*Service) Status() error {
+	return nil
+}
+
+func (s *Service) run() {
+	clock, err := s.cfg.clockWaiter.WaitForClock(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not wait for clock, operation pools will not be restored")
+		return
+	}
+	s.clock = clock
+	if err := s.restore(s.ctx); err != nil {
+		log.WithError(err).Error("Could not restore operation pools from snapshot")
+	}
+	s.saveLock.Lock()
+	s.restored = true
+	s.saveLock.Unlock()
+
+	ticker := time.NewTicker(s.cfg.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.save(s.ctx); err != nil {
+				log.WithError(err).Error("Could not save operation pool snapshot")
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// save writes the current contents of the pools to the snapshot file. The snapshot is first written
+// to a temporary file which is then renamed over the previous snapshot, so a crash mid-write leaves
+// the previous snapshot intact.
+func (s *Service) save(ctx context.Context) error {
+	s.saveLock.Lock()
+	defer s.saveLock.Unlock()
+
+	st, err := s.cfg.headFetcher.HeadStateReadOnly(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head state")
+	}
+	records, err := s.collect(ctx, st)
+	if err != nil {
+		return err
+	}
+	enc, err := encodeSnapshot(records)
+	if err != nil {
+		return err
+	}
+	tmp := s.cfg.path + ".tmp"
+	if err := file.WriteFile(tmp, enc); err != nil {
+		return errors.Wrapf(err, "could not write snapshot to %s", tmp)
+	}
+	if err := os.Rename(tmp, s.cfg.path); err != nil {
+		return errors.Wrapf(err, "could not move snapshot to %s", s.cfg.path)
+	}
+	log.WithField("count", len(records)).Debug("Saved operation pool snapshot")
+	return nil
+}
+
+// collect gathers the pending objects from each configured pool.
+func (s *Service) collect(ctx context.Context, st state.ReadOnlyBeaconState) ([]record, error) {
+	records := make([]record, 0)
+	if s.cfg.attPool != nil {
+		for _, att := range s.cfg.attPool.AggregatedAttestations() {
+			records = append(records, attRecord(att, kindAggregatedAtt, kindAggregatedAttElectra))
+		}
+		unaggregated, err := s.cfg.attPool.UnaggregatedAttestations()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get unaggregated attestations")
+		}
+		for _, att := range unaggregated {
+			records = append(records, attRecord(att, kindUnaggregatedAtt, kindUnaggregatedAttElectra))
+		}
+	}
+	if s.cfg.exitPool != nil {
+		exits, err := s.cfg.exitPool.PendingExits()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get pending voluntary exits")
+		}
+		for _, e := range exits {
+			records = append(records, record{kind: kindVoluntaryExit, obj: e})
+		}
+	}
+	if s.cfg.blsToExecPool != nil {
+		changes, err := s.cfg.blsToExecPool.PendingBLSToExecChanges()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get pending BLS to execution changes")
+		}
+		for _, c := range changes {
+			records = append(records, record{kind: kindBLSToExecChange, obj: c})
+		}
+	}
+	if s.cfg.slashingPool != nil {
+		for _, ps := range s.cfg.slashingPool.PendingProposerSlashings(ctx, st, true /* no limit */) {
+			records = append(records, record{kind: kindProposerSlashing, obj: ps})
+		}
+		for _, as := range s.cfg.slashingPool.PendingAttesterSlashings(ctx, st, true /* no limit */) {
+			kind := kindAttesterSlashing
+			if as.Version() >= version.Electra {
+				kind = kindAttesterSlashingElectra
+			}
+			records = append(records, record{kind: kind, obj: as})
+		}
+	}
+	if s.cfg.syncCommitteePool != nil && s.clock != nil {
+		current := s.clock.CurrentSlot()
+		start := current.SubSlot(syncCommitteeSlots)
+		for slot := start; slot <= current; slot++ {
+			msgs, err := s.cfg.syncCommitteePool.SyncCommitteeMessages(slot)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not get sync committee messages for slot %d", slot)
+			}
+			for _, m := range msgs {
+				records = append(records, record{kind: kindSyncCommitteeMessage, obj: m})
+			}
+			contributions, err := s.cfg.syncCommitteePool.SyncCommitteeContributions(slot)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not get sync committee contributions for slot %d", slot)
+			}
+			for _, c := range contributions {
+				records = append(records, record{kind: kindSyncCommitteeContribution, obj: c})
+			}
+		}
+	}
+	return records, nil
+}
+
+func attRecord(att ethpb.Att, phase0Kind, electraKind recordKind) record {
+	if att.Version() >= version.Electra {
+		return record{kind: electraKind, obj: att}
+	}
+	return record{kind: phase0Kind, obj: att}
+}
@@ -0,0 +1,253 @@
+package snapshot
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	coretime "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/blstoexec"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/synccommittee"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/operations/voluntaryexits"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+type mockHeadFetcher struct {
+	st state.ReadOnlyBeaconState
+}
+
+func (m *mockHeadFetcher) HeadStateReadOnly(_ context.Context) (state.ReadOnlyBeaconState, error) {
+	return m.st, nil
+}
+
+type testPools struct {
+	att  attestations.Pool
+	exit *voluntaryexits.Pool
+	bls  *blstoexec.Pool
+	slsh *slashings.Pool
+	sync synccommittee.Pool
+}
+
+func newTestPools() *testPools {
+	return &testPools{
+		att:  attestations.NewPool(),
+		exit: voluntaryexits.NewPool(),
+		bls:  blstoexec.NewPool(),
+		slsh: slashings.NewPool(),
+		sync: synccommittee.NewPool(),
+	}
+}
+
+func newTestService(t *testing.T, path string, st state.ReadOnlyBeaconState, clock *startup.Clock, p *testPools) *Service {
+	s, err := NewService(context.Background(),
+		WithPath(path),
+		WithHeadFetcher(&mockHeadFetcher{st: st}),
+		WithAttestationPool(p.att),
+		WithExitPool(p.exit),
+		WithBLSToExecPool(p.bls),
+		WithSlashingPool(p.slsh),
+		WithSyncCommitteePool(p.sync),
+	)
+	require.NoError(t, err)
+	s.clock = clock
+	return s
+}
+
+func TestSaveRestore(t *testing.T) {
+	ctx := context.Background()
+	stateSlot := primitives.Slot(uint64(params.BeaconConfig().ShardCommitteePeriod) * uint64(params.BeaconConfig().SlotsPerEpoch))
+	st, keys := util.DeterministicGenesisStateCapella(t, 256)
+	require.NoError(t, st.SetSlot(stateSlot))
+	// Validator 1 has already exited, so its exit was included on chain.
+	exited, err := st.ValidatorAtIndex(1)
+	require.NoError(t, err)
+	exited.ExitEpoch = 1
+	require.NoError(t, st.UpdateValidatorAtIndex(1, exited))
+
+	exits := make([]*ethpb.SignedVoluntaryExit, 2)
+	for i := range exits {
+		msg := &ethpb.VoluntaryExit{ValidatorIndex: primitives.ValidatorIndex(i)}
+		sig, err := signing.ComputeDomainAndSign(st, coretime.CurrentEpoch(st), msg, params.BeaconConfig().DomainVoluntaryExit, keys[i])
+		require.NoError(t, err)
+		exits[i] = &ethpb.SignedVoluntaryExit{Exit: msg, Signature: sig}
+	}
+
+	genesis := time.Now().Add(-time.Duration(uint64(stateSlot)*params.BeaconConfig().SecondsPerSlot) * time.Second)
+	clock := startup.NewClock(genesis, [32]byte{})
+	current := clock.CurrentSlot()
+
+	// Attestations from the committee at the previous slot, each signed by the attesters at the given committee positions.
+	committee, err := helpers.BeaconCommitteeFromState(ctx, st, current-1, 0)
+	require.NoError(t, err)
+	newAttestation := func(signers []primitives.ValidatorIndex, positions ...uint64) *ethpb.Attestation {
+		att := util.HydrateAttestation(&ethpb.Attestation{AggregationBits: bitfield.NewBitlist(uint64(len(committee)))})
+		att.Data.Slot = current - 1
+		att.Data.Target.Epoch = coretime.CurrentEpoch(st)
+		sigs := make([]bls.Signature, len(signers))
+		for i, signer := range signers {
+			sig, err := signing.ComputeDomainAndSign(st, att.Data.Target.Epoch, att.Data, params.BeaconConfig().DomainBeaconAttester, keys[signer])
+			require.NoError(t, err)
+			sigs[i], err = bls.SignatureFromBytes(sig)
+			require.NoError(t, err)
+		}
+		for _, pos := range positions {
+			att.AggregationBits.SetBitAt(pos, true)
+		}
+		att.Signature = bls.AggregateSignatures(sigs).Marshal()
+		return att
+	}
+	aggregated := newAttestation([]primitives.ValidatorIndex{committee[0], committee[1]}, 0, 1)
+	unaggregated := newAttestation([]primitives.ValidatorIndex{committee[2]}, 2)
+	badSignature := newAttestation([]primitives.ValidatorIndex{committee[2]}, 3)
+	// The vote of the attester of this attestation was already included on chain.
+	included := newAttestation([]primitives.ValidatorIndex{committee[4]}, 4)
+	participation, err := st.CurrentEpochParticipation()
+	require.NoError(t, err)
+	participation[committee[4]] = 1 << params.BeaconConfig().TimelyTargetFlagIndex
+	require.NoError(t, st.SetCurrentParticipationBits(participation))
+
+	// Sync committee messages and contributions. The sync committee of the deterministic state is made of the
+	// validators in order, so validator i is at position i of the first subcommittee.
+	blockRoot := primitives.SSZBytes(bytesutil.PadTo([]byte{'r'}, 32))
+	signBlockRoot := func(idx primitives.ValidatorIndex) bls.Signature {
+		sig, err := signing.ComputeDomainAndSign(st, slots.ToEpoch(current), &blockRoot, params.BeaconConfig().DomainSyncCommittee, keys[idx])
+		require.NoError(t, err)
+		s, err := bls.SignatureFromBytes(sig)
+		require.NoError(t, err)
+		return s
+	}
+	syncMsg := &ethpb.SyncCommitteeMessage{Slot: current, BlockRoot: blockRoot, ValidatorIndex: 5, Signature: signBlockRoot(5).Marshal()}
+	badSyncMsg := &ethpb.SyncCommitteeMessage{Slot: current, BlockRoot: blockRoot, ValidatorIndex: 6, Signature: signBlockRoot(7).Marshal()}
+	newContribution := func(signers ...primitives.ValidatorIndex) *ethpb.SyncCommitteeContribution {
+		bits := ethpb.NewSyncCommitteeAggregationBits()
+		sigs := make([]bls.Signature, len(signers))
+		for i, signer := range signers {
+			bits.SetBitAt(uint64(signer), true)
+			sigs[i] = signBlockRoot(signer)
+		}
+		return &ethpb.SyncCommitteeContribution{
+			Slot:            current,
+			BlockRoot:       blockRoot,
+			AggregationBits: bits,
+			Signature:       bls.AggregateSignatures(sigs).Marshal(),
+		}
+	}
+	contribution := newContribution(0, 1)
+	badContribution := newContribution(2, 3)
+	badContribution.AggregationBits.SetBitAt(4, true)
+
+	path := filepath.Join(t.TempDir(), DefaultFileName)
+	src := newTestPools()
+	require.NoError(t, src.att.SaveAggregatedAttestation(aggregated))
+	for _, att := range []*ethpb.Attestation{unaggregated, badSignature, included} {
+		require.NoError(t, src.att.SaveUnaggregatedAttestation(att))
+	}
+	for _, e := range exits {
+		src.exit.InsertVoluntaryExit(e)
+	}
+	require.NoError(t, src.sync.SaveSyncCommitteeMessage(syncMsg))
+	require.NoError(t, src.sync.SaveSyncCommitteeMessage(badSyncMsg))
+	require.NoError(t, src.sync.SaveSyncCommitteeContribution(contribution))
+	require.NoError(t, src.sync.SaveSyncCommitteeContribution(badContribution))
+	require.NoError(t, newTestService(t, path, st, clock, src).save(ctx))
+
+	t.Run("restores valid objects", func(t *testing.T) {
+		dst := newTestPools()
+		require.NoError(t, newTestService(t, path, st, clock, dst).restore(ctx))
+		require.Equal(t, 1, dst.att.AggregatedAttestationCount())
+		// The attestation with an invalid signature and the one already included are discarded.
+		require.Equal(t, 1, dst.att.UnaggregatedAttestationCount())
+		atts, err := dst.att.UnaggregatedAttestations()
+		require.NoError(t, err)
+		require.DeepEqual(t, unaggregated, atts[0])
+		pending, err := dst.exit.PendingExits()
+		require.NoError(t, err)
+		// The exit for the already exited validator is discarded.
+		require.Equal(t, 1, len(pending))
+		require.DeepEqual(t, exits[0], pending[0])
+		msgs, err := dst.sync.SyncCommitteeMessages(current)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(msgs))
+		require.DeepEqual(t, syncMsg, msgs[0])
+		contributions, err := dst.sync.SyncCommitteeContributions(current)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(contributions))
+		require.DeepEqual(t, contribution, contributions[0])
+	})
+	t.Run("discards expired objects", func(t *testing.T) {
+		later := startup.NewClock(genesis.Add(-2*time.Duration(uint64(params.BeaconConfig().SlotsPerEpoch)*params.BeaconConfig().SecondsPerSlot)*time.Second), [32]byte{})
+		dst := newTestPools()
+		require.NoError(t, newTestService(t, path, st, later, dst).restore(ctx))
+		require.Equal(t, 0, dst.att.AggregatedAttestationCount())
+		require.Equal(t, 0, dst.att.UnaggregatedAttestationCount())
+		msgs, err := dst.sync.SyncCommitteeMessages(current)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(msgs))
+		pending, err := dst.exit.PendingExits()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(pending))
+	})
+	t.Run("missing snapshot", func(t *testing.T) {
+		dst := newTestPools()
+		missing := filepath.Join(t.TempDir(), DefaultFileName)
+		require.NoError(t, newTestService(t, missing, st, clock, dst).restore(ctx))
+		require.Equal(t, 0, dst.att.AggregatedAttestationCount())
+	})
+}
+
+func TestStop_BeforeRestore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultFileName)
+	prev := []byte("previous snapshot")
+	require.NoError(t, os.WriteFile(path, prev, params.BeaconIoConfig().ReadWritePermissions))
+
+	s := newTestService(t, path, nil, nil, newTestPools())
+	require.NoError(t, s.Stop())
+	// The pools were never restored, so the previous snapshot must not be overwritten.
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.DeepEqual(t, prev, b)
+}
+
+func TestNewService_NoPath(t *testing.T) {
+	_, err := NewService(context.Background())
+	require.ErrorIs(t, err, errNoSnapshotPath)
+}
+
+func TestDecodeSnapshot(t *testing.T) {
+	exit := &ethpb.SignedVoluntaryExit{Exit: &ethpb.VoluntaryExit{Epoch: 1, ValidatorIndex: 2}, Signature: make([]byte, 96)}
+	enc, err := encodeSnapshot([]record{{kind: kindVoluntaryExit, obj: exit}})
+	require.NoError(t, err)
+
+	records, err := decodeSnapshot(enc)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(records))
+	require.Equal(t, kindVoluntaryExit, records[0].kind)
+	decoded := &ethpb.SignedVoluntaryExit{}
+	require.NoError(t, decoded.UnmarshalSSZ(records[0].enc))
+	require.DeepEqual(t, exit, decoded)
+
+	_, err = decodeSnapshot(enc[:len(enc)-1])
+	require.ErrorIs(t, err, errTruncatedRecord)
+	_, err = decodeSnapshot([]byte("nope!"))
+	require.ErrorIs(t, err, errBadMagic)
+	bad := append([]byte{}, enc...)
+	bad[len(snapshotMagic)] = snapshotVersion + 1
+	_, err = decodeSnapshot(bad)
+	require.ErrorIs(t, err, errUnsupportedVersion)
+}
@@ -268,6 +268,36 @@ func (s *Service) internalBroadcastBlob(ctx context.Context, subnet uint64, blob
 	}
 }
 
+// BroadcastDataColumn broadcasts a data column sidecar to the p2p network, the message is assumed to be
+// broadcasted to the current fork and to the input subnet.
+func (s *Service) BroadcastDataColumn(ctx context.Context, subnet uint64, column *ethpb.DataColumnSidecar) error {
+	ctx, span := trace.StartSpan(ctx, "p2p.BroadcastDataColumn")
+	defer span.End()
+	if column == nil {
+		return errors.New("attempted to broadcast nil data column sidecar")
+	}
+	forkDigest, err := s.currentForkDigest()
+	if err != nil {
+		err := errors.Wrap(err, "could not retrieve fork digest")
+		tracing.AnnotateError(span, err)
+		return err
+	}
+
+	// Non-blocking broadcast. Peers are not yet discoverable by custody subnet, so the column is published to
+	// whichever mesh peers are subscribed to the subnet.
+	go func() {
+		_, span := trace.StartSpan(ctx, "p2p.internalBroadcastDataColumn")
+		defer span.End()
+		ctx := trace.NewContext(context.Background(), span) // clear parent context / deadline.
+		if err := s.broadcastObject(ctx, column, dataColumnSubnetToTopic(subnet, forkDigest)); err != nil {
+			log.WithError(err).Error("Failed to broadcast data column sidecar")
+			tracing.AnnotateError(span, err)
+		}
+	}()
+
+	return nil
+}
+
 // method to broadcast messages to other peers in our gossip mesh.
 func (s *Service) broadcastObject(ctx context.Context, obj ssz.Marshaler, topic string) error {
 	ctx, span := trace.StartSpan(ctx, "p2p.broadcastObject")
@@ -308,3 +338,7 @@ func syncCommitteeToTopic(subnet uint64, forkDigest [4]byte) string {
 func blobSubnetToTopic(subnet uint64, forkDigest [4]byte) string {
 	return fmt.Sprintf(BlobSubnetTopicFormat, forkDigest, subnet)
 }
+
+func dataColumnSubnetToTopic(subnet uint64, forkDigest [4]byte) string {
+	return fmt.Sprintf(DataColumnSubnetTopicFormat, forkDigest, subnet)
+}
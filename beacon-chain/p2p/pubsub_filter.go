@@ -27,8 +27,10 @@ var _ pubsub.SubscriptionFilter = (*Service)(nil)
 // -> 4 SyncCommitteeSubnets   * 2 = 8
 // -> BlsToExecutionChange     * 2 = 2
 // -> 6 BlobSidecar            * 2 = 12
+// -> LightClientFinality      * 2 = 2
+// -> LightClientOptimistic    * 2 = 2
 // -------------------------------------
-// TOTAL                           = 162
+// TOTAL                           = 166
 const pubsubSubscriptionRequestLimit = 200
 
 // CanSubscribe returns true if the topic is of interest and we could subscribe to it.
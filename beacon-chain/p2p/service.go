@@ -363,6 +363,11 @@ func (s *Service) ENR() *enr.Record {
 	return s.dv5Listener.Self().Record()
 }
 
+// NodeID returns the discovery node ID derived from the local node's key.
+func (s *Service) NodeID() enode.ID {
+	return enode.PubkeyToIDV4(&s.privKey.PublicKey)
+}
+
 // DiscoveryAddresses represents our enr addresses as multiaddresses.
 func (s *Service) DiscoveryAddresses() ([]multiaddr.Multiaddr, error) {
 	if s.dv5Listener == nil {
@@ -1,6 +1,7 @@
 package types
 
 import (
+	ssz "github.com/prysmaticlabs/fastssz"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
@@ -33,6 +34,12 @@ var (
 	// AggregateAttestationMap maps the fork-version to the underlying data type for that
 	// particular fork period.
 	AggregateAttestationMap map[[4]byte]func() (ethpb.SignedAggregateAttAndProof, error)
+	// LightClientFinalityUpdateMap maps the fork-version to the underlying data type for that
+	// particular fork period. Light client updates only exist from Altair onwards.
+	LightClientFinalityUpdateMap map[[4]byte]func() (ssz.Unmarshaler, error)
+	// LightClientOptimisticUpdateMap maps the fork-version to the underlying data type for that
+	// particular fork period. Light client updates only exist from Altair onwards.
+	LightClientOptimisticUpdateMap map[[4]byte]func() (ssz.Unmarshaler, error)
 )
 
 // InitializeDataMaps initializes all the relevant object maps. This function is called to
@@ -151,4 +158,48 @@ func InitializeDataMaps() {
 			return &ethpb.SignedAggregateAttestationAndProofElectra{}, nil
 		},
 	}
+
+	// Reset our light client finality update map.
+	LightClientFinalityUpdateMap = map[[4]byte]func() (ssz.Unmarshaler, error){
+		bytesutil.ToBytes4(params.BeaconConfig().AltairForkVersion): func() (ssz.Unmarshaler, error) {
+			return &ethpb.LightClientFinalityUpdateAltair{}, nil
+		},
+		bytesutil.ToBytes4(params.BeaconConfig().BellatrixForkVersion): func() (ssz.Unmarshaler, error) {
+			return &ethpb.LightClientFinalityUpdateAltair{}, nil
+		},
+		bytesutil.ToBytes4(params.BeaconConfig().CapellaForkVersion): func() (ssz.Unmarshaler, error) {
+			return &ethpb.LightClientFinalityUpdateCapella{}, nil
+		},
+		bytesutil.ToBytes4(params.BeaconConfig().DenebForkVersion): func() (ssz.Unmarshaler, error) {
+			return &ethpb.LightClientFinalityUpdateDeneb{}, nil
+		},
+		bytesutil.ToBytes4(params.BeaconConfig().ElectraForkVersion): func() (ssz.Unmarshaler, error) {
+			return &ethpb.LightClientFinalityUpdateElectra{}, nil
+		},
+		bytesutil.ToBytes4(params.BeaconConfig().FuluForkVersion): func() (ssz.Unmarshaler, error) {
+			return &ethpb.LightClientFinalityUpdateElectra{}, nil
+		},
+	}
+
+	// Reset our light client optimistic update map.
+	LightClientOptimisticUpdateMap = map[[4]byte]func() (ssz.Unmarshaler, error){
+		bytesutil.ToBytes4(params.BeaconConfig().AltairForkVersion): func() (ssz.Unmarshaler, error) {
+			return &ethpb.LightClientOptimisticUpdateAltair{}, nil
+		},
+		bytesutil.ToBytes4(params.BeaconConfig().BellatrixForkVersion): func() (ssz.Unmarshaler, error) {
+			return &ethpb.LightClientOptimisticUpdateAltair{}, nil
+		},
+		bytesutil.ToBytes4(params.BeaconConfig().CapellaForkVersion): func() (ssz.Unmarshaler, error) {
+			return &ethpb.LightClientOptimisticUpdateCapella{}, nil
+		},
+		bytesutil.ToBytes4(params.BeaconConfig().DenebForkVersion): func() (ssz.Unmarshaler, error) {
+			return &ethpb.LightClientOptimisticUpdateDeneb{}, nil
+		},
+		bytesutil.ToBytes4(params.BeaconConfig().ElectraForkVersion): func() (ssz.Unmarshaler, error) {
+			return &ethpb.LightClientOptimisticUpdateDeneb{}, nil
+		},
+		bytesutil.ToBytes4(params.BeaconConfig().FuluForkVersion): func() (ssz.Unmarshaler, error) {
+			return &ethpb.LightClientOptimisticUpdateDeneb{}, nil
+		},
+	}
 }
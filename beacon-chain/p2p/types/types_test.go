@@ -80,6 +80,26 @@ func TestBlobSidecarsByRootReq_MarshalSSZ(t *testing.T) {
 	}
 }
 
+func TestDataColumnSidecarsByRootReq_MarshalSSZ(t *testing.T) {
+	ids := make([]*eth.DataColumnIdentifier, 10)
+	for i := range ids {
+		ids[i] = &eth.DataColumnIdentifier{
+			BlockRoot:   bytesutil.PadTo([]byte{byte(i)}, 32),
+			ColumnIndex: uint64(i),
+		}
+	}
+	r := DataColumnSidecarsByRootReq(ids)
+	by, err := r.MarshalSSZ()
+	require.NoError(t, err)
+	got := &DataColumnSidecarsByRootReq{}
+	require.NoError(t, got.UnmarshalSSZ(by))
+	require.Equal(t, len(ids), len(*got))
+	for i, gid := range *got {
+		require.DeepEqual(t, ids[i], gid)
+	}
+	require.ErrorIs(t, got.UnmarshalSSZ(append(by, byte(0))), ssz.ErrIncorrectByteSize)
+}
+
 func TestBeaconBlockByRootsReq_Limit(t *testing.T) {
 	fixedRoots := make([][32]byte, 0)
 	for i := uint64(0); i < params.BeaconConfig().MaxRequestBlocks+100; i++ {
@@ -142,6 +162,30 @@ func roundTripTestErrorMessage(t *testing.T) {
 	assert.DeepEqual(t, []byte(newVal), errMsg)
 }
 
+func TestLightClientBootstrapReq_MarshalSSZ(t *testing.T) {
+	req := LightClientBootstrapReq(bytesutil.ToBytes32([]byte("root")))
+	by, err := req.MarshalSSZ()
+	require.NoError(t, err)
+	require.Equal(t, req.SizeSSZ(), len(by))
+
+	var got LightClientBootstrapReq
+	require.NoError(t, got.UnmarshalSSZ(by))
+	require.Equal(t, req, got)
+	require.ErrorIs(t, got.UnmarshalSSZ(by[1:]), ssz.ErrIncorrectByteSize)
+}
+
+func TestLightClientUpdatesByRangeReq_MarshalSSZ(t *testing.T) {
+	req := &LightClientUpdatesByRangeReq{StartPeriod: 300, Count: 7}
+	by, err := req.MarshalSSZ()
+	require.NoError(t, err)
+	require.Equal(t, "2c010000000000000700000000000000", hex.EncodeToString(by))
+
+	got := &LightClientUpdatesByRangeReq{}
+	require.NoError(t, got.UnmarshalSSZ(by))
+	require.DeepEqual(t, req, got)
+	require.ErrorIs(t, got.UnmarshalSSZ(append(by, 0)), ssz.ErrIncorrectByteSize)
+}
+
 func TestSSZBytes_HashTreeRoot(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -8,6 +8,8 @@ import (
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
@@ -20,6 +22,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	ethpbalpha "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/time/slots"
 )
 
@@ -333,3 +336,147 @@ func (s *Server) stateForSyncCommittee(ctx context.Context, w http.ResponseWrite
 	}
 	return st, true
 }
+
+// GetPendingDeposits returns the pending deposits queue of the state with the given 'stateId'.
+func (s *Server) GetPendingDeposits(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetPendingDeposits")
+	defer span.End()
+
+	st, ok := s.electraStateFromRequest(ctx, w, r)
+	if !ok {
+		return
+	}
+	deposits, err := st.PendingDeposits()
+	if err != nil {
+		httputil.HandleError(w, "Could not get pending deposits: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if httputil.RespondWithSsz(r) {
+		writePendingQueueSsz(w, st, deposits, "pending_deposits.ssz")
+		return
+	}
+	isOptimistic, isFinalized, ok := s.stateMetadata(ctx, w, r, st)
+	if !ok {
+		return
+	}
+	w.Header().Set(api.VersionHeader, version.String(st.Version()))
+	httputil.WriteJson(w, &structs.GetPendingDepositsResponse{
+		Version:             version.String(st.Version()),
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+		Data:                structs.PendingDepositsFromConsensus(deposits),
+	})
+}
+
+// GetPendingPartialWithdrawals returns the pending partial withdrawals queue of the state with the given 'stateId'.
+func (s *Server) GetPendingPartialWithdrawals(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetPendingPartialWithdrawals")
+	defer span.End()
+
+	st, ok := s.electraStateFromRequest(ctx, w, r)
+	if !ok {
+		return
+	}
+	withdrawals, err := st.PendingPartialWithdrawals()
+	if err != nil {
+		httputil.HandleError(w, "Could not get pending partial withdrawals: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if httputil.RespondWithSsz(r) {
+		writePendingQueueSsz(w, st, withdrawals, "pending_partial_withdrawals.ssz")
+		return
+	}
+	isOptimistic, isFinalized, ok := s.stateMetadata(ctx, w, r, st)
+	if !ok {
+		return
+	}
+	w.Header().Set(api.VersionHeader, version.String(st.Version()))
+	httputil.WriteJson(w, &structs.GetPendingPartialWithdrawalsResponse{
+		Version:             version.String(st.Version()),
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+		Data:                structs.PendingPartialWithdrawalsFromConsensus(withdrawals),
+	})
+}
+
+// GetPendingConsolidations returns the pending consolidations queue of the state with the given 'stateId'.
+func (s *Server) GetPendingConsolidations(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetPendingConsolidations")
+	defer span.End()
+
+	st, ok := s.electraStateFromRequest(ctx, w, r)
+	if !ok {
+		return
+	}
+	consolidations, err := st.PendingConsolidations()
+	if err != nil {
+		httputil.HandleError(w, "Could not get pending consolidations: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if httputil.RespondWithSsz(r) {
+		writePendingQueueSsz(w, st, consolidations, "pending_consolidations.ssz")
+		return
+	}
+	isOptimistic, isFinalized, ok := s.stateMetadata(ctx, w, r, st)
+	if !ok {
+		return
+	}
+	w.Header().Set(api.VersionHeader, version.String(st.Version()))
+	httputil.WriteJson(w, &structs.GetPendingConsolidationsResponse{
+		Version:             version.String(st.Version()),
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+		Data:                structs.PendingConsolidationsFromConsensus(consolidations),
+	})
+}
+
+// electraStateFromRequest fetches the state for the 'state_id' path parameter, writing an error response
+// when the state cannot be found or predates Electra, as the pending queues only exist from Electra onward.
+func (s *Server) electraStateFromRequest(ctx context.Context, w http.ResponseWriter, r *http.Request) (state.BeaconState, bool) {
+	stateId := r.PathValue("state_id")
+	if stateId == "" {
+		httputil.HandleError(w, "state_id is required in URL params", http.StatusBadRequest)
+		return nil, false
+	}
+	st, err := s.Stater.State(ctx, []byte(stateId))
+	if err != nil {
+		shared.WriteStateFetchError(w, err)
+		return nil, false
+	}
+	if st.Version() < version.Electra {
+		httputil.HandleError(w, "State is not available before Electra: state version is "+version.String(st.Version()), http.StatusBadRequest)
+		return nil, false
+	}
+	return st, true
+}
+
+// stateMetadata returns the execution_optimistic and finalized values for the requested state.
+func (s *Server) stateMetadata(ctx context.Context, w http.ResponseWriter, r *http.Request, st state.BeaconState) (bool, bool, bool) {
+	isOptimistic, err := helpers.IsOptimistic(ctx, []byte(r.PathValue("state_id")), s.OptimisticModeFetcher, s.Stater, s.ChainInfoFetcher, s.BeaconDB)
+	if err != nil {
+		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
+		return false, false, false
+	}
+	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		httputil.HandleError(w, "Could not calculate root of latest block header: "+err.Error(), http.StatusInternalServerError)
+		return false, false, false
+	}
+	return isOptimistic, s.FinalizationFetcher.IsFinalized(ctx, blockRoot), true
+}
+
+// writePendingQueueSsz writes the SSZ encoding of a list of fixed size queue items, which is the
+// concatenation of the encoding of each item.
+func writePendingQueueSsz[T ssz.Marshaler](w http.ResponseWriter, st state.BeaconState, items []T, fileName string) {
+	var enc []byte
+	for _, item := range items {
+		b, err := item.MarshalSSZ()
+		if err != nil {
+			httputil.HandleError(w, "Could not marshal queue item: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		enc = append(enc, b...)
+	}
+	w.Header().Set(api.VersionHeader, version.String(st.Version()))
+	httputil.WriteSsz(w, enc, fileName)
+}
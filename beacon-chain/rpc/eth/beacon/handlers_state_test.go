@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	chainMock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	dbTest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
@@ -647,3 +648,134 @@ func TestGetSyncCommittees_Future(t *testing.T) {
 		}
 	}
 }
+
+func TestGetPendingQueues(t *testing.T) {
+	deposits := []*ethpbalpha.PendingDeposit{
+		{
+			PublicKey:             bytesutil.PadTo([]byte("pubkey1"), 48),
+			WithdrawalCredentials: bytesutil.PadTo([]byte("creds1"), 32),
+			Amount:                32_000_000_000,
+			Signature:             bytesutil.PadTo([]byte("sig1"), 96),
+			Slot:                  10,
+		},
+		{
+			PublicKey:             bytesutil.PadTo([]byte("pubkey2"), 48),
+			WithdrawalCredentials: bytesutil.PadTo([]byte("creds2"), 32),
+			Amount:                1_000_000_000,
+			Signature:             bytesutil.PadTo([]byte("sig2"), 96),
+			Slot:                  11,
+		},
+	}
+	withdrawals := []*ethpbalpha.PendingPartialWithdrawal{
+		{Index: 1, Amount: 100, WithdrawableEpoch: 5},
+	}
+	consolidations := []*ethpbalpha.PendingConsolidation{
+		{SourceIndex: 2, TargetIndex: 3},
+		{SourceIndex: 4, TargetIndex: 5},
+	}
+	st, err := util.NewBeaconStateElectra()
+	require.NoError(t, err)
+	require.NoError(t, st.SetPendingDeposits(deposits))
+	require.NoError(t, st.AppendPendingPartialWithdrawal(withdrawals[0]))
+	require.NoError(t, st.SetPendingConsolidations(consolidations))
+
+	chainService := &chainMock.ChainService{}
+	s := &Server{
+		Stater:                &testutil.MockStater{BeaconState: st},
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+		BeaconDB:              dbTest.SetupDB(t),
+	}
+	newRequest := func(path string, ssz bool) *http.Request {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/"+path, nil)
+		request.SetPathValue("state_id", "head")
+		if ssz {
+			request.Header.Set("Accept", api.OctetStreamMediaType)
+		}
+		return request
+	}
+
+	t.Run("pending deposits json", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetPendingDeposits(writer, newRequest("pending_deposits", false))
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "electra", writer.Header().Get(api.VersionHeader))
+		resp := &structs.GetPendingDepositsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "electra", resp.Version)
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, hexutil.Encode(deposits[1].PublicKey), resp.Data[1].Pubkey)
+		assert.Equal(t, "1000000000", resp.Data[1].Amount)
+		assert.Equal(t, "11", resp.Data[1].Slot)
+	})
+	t.Run("pending deposits ssz", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetPendingDeposits(writer, newRequest("pending_deposits", true))
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "electra", writer.Header().Get(api.VersionHeader))
+		var expected []byte
+		for _, d := range deposits {
+			enc, err := d.MarshalSSZ()
+			require.NoError(t, err)
+			expected = append(expected, enc...)
+		}
+		assert.DeepEqual(t, expected, writer.Body.Bytes())
+	})
+	t.Run("pending partial withdrawals json", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetPendingPartialWithdrawals(writer, newRequest("pending_partial_withdrawals", false))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetPendingPartialWithdrawalsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "1", resp.Data[0].Index)
+		assert.Equal(t, "100", resp.Data[0].Amount)
+		assert.Equal(t, "5", resp.Data[0].WithdrawableEpoch)
+	})
+	t.Run("pending consolidations ssz", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetPendingConsolidations(writer, newRequest("pending_consolidations", true))
+		require.Equal(t, http.StatusOK, writer.Code)
+		var expected []byte
+		for _, c := range consolidations {
+			enc, err := c.MarshalSSZ()
+			require.NoError(t, err)
+			expected = append(expected, enc...)
+		}
+		assert.DeepEqual(t, expected, writer.Body.Bytes())
+	})
+	t.Run("pending consolidations json", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetPendingConsolidations(writer, newRequest("pending_consolidations", false))
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetPendingConsolidationsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "4", resp.Data[1].SourceIndex)
+		assert.Equal(t, "5", resp.Data[1].TargetIndex)
+	})
+	t.Run("pre-electra state", func(t *testing.T) {
+		denebSt, err := util.NewBeaconStateDeneb()
+		require.NoError(t, err)
+		s := &Server{Stater: &testutil.MockStater{BeaconState: denebSt}}
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetPendingDeposits(writer, newRequest("pending_deposits", false))
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "not available before Electra", e.Message)
+	})
+	t.Run("state_id missing", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/states/{state_id}/pending_deposits", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetPendingDeposits(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
+	ssz "github.com/prysmaticlabs/fastssz"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
@@ -324,6 +325,123 @@ func (s *Server) GetValidatorBalances(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJson(w, resp)
 }
 
+// GetValidatorIdentities returns the index, pubkey and activation epoch of the requested validators,
+// or of all validators in the state when no IDs are requested.
+func (s *Server) GetValidatorIdentities(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetValidatorIdentities")
+	defer span.End()
+
+	stateId := r.PathValue("state_id")
+	if stateId == "" {
+		httputil.HandleError(w, "state_id is required in URL params", http.StatusBadRequest)
+		return
+	}
+	var rawIds []string
+	err := json.NewDecoder(r.Body).Decode(&rawIds)
+	if err != nil && !errors.Is(err, io.EOF) {
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	st, err := s.Stater.State(ctx, []byte(stateId))
+	if err != nil {
+		shared.WriteStateFetchError(w, err)
+		return
+	}
+	// Pubkeys are resolved through the state's pubkey index cache.
+	ids, ok := decodeIds(w, st, rawIds, true /* ignore unknown */)
+	if !ok {
+		return
+	}
+
+	var identities []validatorIdentity
+	switch {
+	case len(rawIds) > 0 && len(ids) == 0:
+		// return no data if all IDs are ignored
+		identities = []validatorIdentity{}
+	case len(ids) == 0:
+		identities = make([]validatorIdentity, 0, st.NumValidators())
+		err = st.ReadFromEveryValidator(func(idx int, val state.ReadOnlyValidator) error {
+			identities = append(identities, newValidatorIdentity(primitives.ValidatorIndex(idx), val))
+			return nil
+		})
+		if err != nil {
+			httputil.HandleError(w, "Could not read validators: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		identities = make([]validatorIdentity, len(ids))
+		for i, id := range ids {
+			val, err := st.ValidatorAtIndexReadOnly(id)
+			if err != nil {
+				httputil.HandleError(w, fmt.Sprintf("Could not get validator at index %d: %s", id, err.Error()), http.StatusInternalServerError)
+				return
+			}
+			identities[i] = newValidatorIdentity(id, val)
+		}
+	}
+
+	if httputil.RespondWithSsz(r) {
+		sszResp := make([]byte, 0, len(identities)*validatorIdentitySSZSize)
+		for _, id := range identities {
+			sszResp = id.marshalSSZTo(sszResp)
+		}
+		httputil.WriteSsz(w, sszResp, "validator_identities.ssz")
+		return
+	}
+
+	isOptimistic, err := helpers.IsOptimistic(ctx, []byte(stateId), s.OptimisticModeFetcher, s.Stater, s.ChainInfoFetcher, s.BeaconDB)
+	if err != nil {
+		httputil.HandleError(w, "Could not check optimistic status: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	blockRoot, err := st.LatestBlockHeader().HashTreeRoot()
+	if err != nil {
+		httputil.HandleError(w, "Could not calculate root of latest block header: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := make([]*structs.ValidatorIdentity, len(identities))
+	for i, id := range identities {
+		data[i] = &structs.ValidatorIdentity{
+			Index:           strconv.FormatUint(uint64(id.index), 10),
+			Pubkey:          hexutil.Encode(id.pubkey[:]),
+			ActivationEpoch: strconv.FormatUint(uint64(id.activationEpoch), 10),
+		}
+	}
+	resp := &structs.GetValidatorIdentitiesResponse{
+		Data:                data,
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           s.FinalizationFetcher.IsFinalized(ctx, blockRoot),
+	}
+	httputil.WriteJson(w, resp)
+}
+
+// validatorIdentitySSZSize is the size of the SSZ encoding of a ValidatorIdentity container:
+// index (uint64), pubkey (Bytes48) and activation_epoch (uint64).
+const validatorIdentitySSZSize = 8 + fieldparams.BLSPubkeyLength + 8
+
+type validatorIdentity struct {
+	index           primitives.ValidatorIndex
+	pubkey          [fieldparams.BLSPubkeyLength]byte
+	activationEpoch primitives.Epoch
+}
+
+func newValidatorIdentity(idx primitives.ValidatorIndex, val state.ReadOnlyValidator) validatorIdentity {
+	return validatorIdentity{
+		index:           idx,
+		pubkey:          val.PublicKey(),
+		activationEpoch: val.ActivationEpoch(),
+	}
+}
+
+// marshalSSZTo appends the SSZ encoding of the identity to dst. The response is a list of fixed size
+// containers, so the encoding of the list is the concatenation of the encoded identities.
+func (v validatorIdentity) marshalSSZTo(dst []byte) []byte {
+	dst = ssz.MarshalUint64(dst, uint64(v.index))
+	dst = append(dst, v.pubkey[:]...)
+	return ssz.MarshalUint64(dst, uint64(v.activationEpoch))
+}
+
 // decodeIds takes in a list of validator ID strings (as either a pubkey or a validator index)
 // and returns the corresponding validator indices. It can be configured to ignore well-formed but unknown indices.
 func decodeIds(w http.ResponseWriter, st state.BeaconState, rawIds []string, ignoreUnknown bool) ([]primitives.ValidatorIndex, bool) {
@@ -2,6 +2,7 @@ package beacon
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -11,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	chainMock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
@@ -1183,3 +1185,93 @@ func TestGetValidatorBalances(t *testing.T) {
 		assert.StringContains(t, "Could not decode request body", e.Message)
 	})
 }
+
+func TestGetValidatorIdentities(t *testing.T) {
+	count := uint64(4)
+	st, _ := util.DeterministicGenesisState(t, count)
+	val, err := st.ValidatorAtIndex(2)
+	require.NoError(t, err)
+	val.ActivationEpoch = 7
+	require.NoError(t, st.UpdateValidatorAtIndex(2, val))
+
+	chainService := &chainMock.ChainService{}
+	s := Server{
+		Stater: &testutil.MockStater{
+			BeaconState: st,
+		},
+		HeadFetcher:           chainService,
+		OptimisticModeFetcher: chainService,
+		FinalizationFetcher:   chainService,
+	}
+	newRequest := func(body string) *http.Request {
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/eth/v1/beacon/states/{state_id}/validator_identities", strings.NewReader(body))
+		request.SetPathValue("state_id", "head")
+		return request
+	}
+
+	t.Run("get all", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetValidatorIdentities(writer, newRequest(""))
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetValidatorIdentitiesResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 4, len(resp.Data))
+		pubkey := st.PubkeyAtIndex(3)
+		assert.Equal(t, "3", resp.Data[3].Index)
+		assert.Equal(t, hexutil.Encode(pubkey[:]), resp.Data[3].Pubkey)
+		assert.Equal(t, "0", resp.Data[3].ActivationEpoch)
+	})
+	t.Run("get by index and pubkey", func(t *testing.T) {
+		pubkey := st.PubkeyAtIndex(2)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetValidatorIdentities(writer, newRequest(fmt.Sprintf(`["1","%s"]`, hexutil.Encode(pubkey[:]))))
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetValidatorIdentitiesResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "1", resp.Data[0].Index)
+		assert.Equal(t, "2", resp.Data[1].Index)
+		assert.Equal(t, hexutil.Encode(pubkey[:]), resp.Data[1].Pubkey)
+		assert.Equal(t, "7", resp.Data[1].ActivationEpoch)
+	})
+	t.Run("unknown pubkey is ignored", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetValidatorIdentities(writer, newRequest(fmt.Sprintf(`["%s"]`, hexutil.Encode(make([]byte, 48)))))
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetValidatorIdentitiesResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 0, len(resp.Data))
+	})
+	t.Run("ssz", func(t *testing.T) {
+		request := newRequest(`["2"]`)
+		request.Header.Set("Accept", api.OctetStreamMediaType)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetValidatorIdentities(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		b := writer.Body.Bytes()
+		require.Equal(t, validatorIdentitySSZSize, len(b))
+		pubkey := st.PubkeyAtIndex(2)
+		assert.Equal(t, uint64(2), binary.LittleEndian.Uint64(b[:8]))
+		assert.DeepEqual(t, pubkey[:], b[8:56])
+		assert.Equal(t, uint64(7), binary.LittleEndian.Uint64(b[56:]))
+	})
+	t.Run("invalid body", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetValidatorIdentities(writer, newRequest("foo"))
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Could not decode request body", e.Message)
+	})
+	t.Run("invalid id", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetValidatorIdentities(writer, newRequest(`["foo"]`))
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
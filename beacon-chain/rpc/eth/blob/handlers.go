@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
 	field_params "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
@@ -35,21 +36,8 @@ func (s *Server) Blobs(w http.ResponseWriter, r *http.Request) {
 
 	verifiedBlobs, rpcErr := s.Blocker.Blobs(ctx, blockId, indices)
 	if rpcErr != nil {
-		code := core.ErrorReasonToHTTP(rpcErr.Reason)
-		switch code {
-		case http.StatusBadRequest:
-			httputil.HandleError(w, "Invalid block ID: "+rpcErr.Err.Error(), code)
-			return
-		case http.StatusNotFound:
-			httputil.HandleError(w, "Block not found: "+rpcErr.Err.Error(), code)
-			return
-		case http.StatusInternalServerError:
-			httputil.HandleError(w, "Internal server error: "+rpcErr.Err.Error(), code)
-			return
-		default:
-			httputil.HandleError(w, rpcErr.Err.Error(), code)
-			return
-		}
+		writeBlobsFetchError(w, rpcErr)
+		return
 	}
 
 	if httputil.RespondWithSsz(r) {
@@ -88,6 +76,115 @@ func (s *Server) Blobs(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJson(w, resp)
 }
 
+// GetBlobs is an HTTP handler for Beacon API getBlobs, which returns only the blobs of a block,
+// optionally filtered by the versioned hashes of their KZG commitments. Blobs missing from storage
+// are retrieved from the execution client when possible.
+func (s *Server) GetBlobs(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.GetBlobs")
+	defer span.End()
+
+	hashes, err := parseVersionedHashes(r.URL)
+	if err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	segments := strings.Split(r.URL.Path, "/")
+	blockId := segments[len(segments)-1]
+
+	blk, err := s.Blocker.Block(ctx, []byte(blockId))
+	if !shared.WriteBlockFetchError(w, blk, err) {
+		return
+	}
+	if blk.Version() < version.Deneb {
+		httputil.HandleError(w, "Blobs are not supported before Deneb: block version is "+version.String(blk.Version()), http.StatusBadRequest)
+		return
+	}
+	commitments, err := blk.Block().Body().BlobKzgCommitments()
+	if err != nil {
+		httputil.HandleError(w, "Could not get blob KZG commitments: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	indices := make([]uint64, 0, len(commitments))
+	for i, c := range commitments {
+		if len(hashes) == 0 || hashes[primitives.ConvertKzgCommitmentToVersionedHash(c)] {
+			indices = append(indices, uint64(i))
+		}
+	}
+
+	verifiedBlobs := make([]*blocks.VerifiedROBlob, 0)
+	// An empty list of indices means all blobs to the blocker, so only query it when something matched.
+	if len(indices) > 0 {
+		var rpcErr *core.RpcError
+		verifiedBlobs, rpcErr = s.Blocker.Blobs(ctx, blockId, indices)
+		if rpcErr != nil {
+			writeBlobsFetchError(w, rpcErr)
+			return
+		}
+	}
+
+	if httputil.RespondWithSsz(r) {
+		sszResp := make([]byte, 0, field_params.BlobLength*len(verifiedBlobs))
+		for _, b := range verifiedBlobs {
+			sszResp = append(sszResp, b.Blob...)
+		}
+		httputil.WriteSsz(w, sszResp, "blobs.ssz")
+		return
+	}
+
+	blkRoot, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		httputil.HandleError(w, "Could not hash block: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	isOptimistic, err := s.OptimisticModeFetcher.IsOptimisticForRoot(ctx, blkRoot)
+	if err != nil {
+		httputil.HandleError(w, "Could not check if block is optimistic: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := make([]string, len(verifiedBlobs))
+	for i, b := range verifiedBlobs {
+		data[i] = hexutil.Encode(b.Blob)
+	}
+	httputil.WriteJson(w, &structs.GetBlobsResponse{
+		Data:                data,
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           s.FinalizationFetcher.IsFinalized(ctx, blkRoot),
+	})
+}
+
+func writeBlobsFetchError(w http.ResponseWriter, rpcErr *core.RpcError) {
+	code := core.ErrorReasonToHTTP(rpcErr.Reason)
+	switch code {
+	case http.StatusBadRequest:
+		httputil.HandleError(w, "Invalid block ID: "+rpcErr.Err.Error(), code)
+	case http.StatusNotFound:
+		httputil.HandleError(w, "Block not found: "+rpcErr.Err.Error(), code)
+	case http.StatusInternalServerError:
+		httputil.HandleError(w, "Internal server error: "+rpcErr.Err.Error(), code)
+	default:
+		httputil.HandleError(w, rpcErr.Err.Error(), code)
+	}
+}
+
+// parseVersionedHashes returns the set of versioned hashes given in the query, failing on invalid hashes.
+func parseVersionedHashes(url *url.URL) (map[[32]byte]bool, error) {
+	rawHashes := url.Query()["versioned_hashes"]
+	hashes := make(map[[32]byte]bool, len(rawHashes))
+	invalidHashes := make([]string, 0)
+	for _, raw := range rawHashes {
+		h, err := hexutil.Decode(raw)
+		if err != nil || len(h) != field_params.RootLength {
+			invalidHashes = append(invalidHashes, raw)
+			continue
+		}
+		hashes[[32]byte(h)] = true
+	}
+	if len(invalidHashes) > 0 {
+		return nil, fmt.Errorf("requested versioned hashes %v are invalid", invalidHashes)
+	}
+	return hashes, nil
+}
+
 // parseIndices filters out invalid and duplicate blob indices
 func parseIndices(url *url.URL, s primitives.Slot) ([]uint64, error) {
 	rawIndices := url.Query()["indices"]
@@ -17,11 +17,14 @@ import (
 	mockChain "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
 	testDB "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	mockExecution "github.com/prysmaticlabs/prysm/v5/beacon-chain/execution/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
@@ -413,3 +416,147 @@ func Test_parseIndices(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBlobs(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+
+	db := testDB.SetupDB(t)
+	denebBlock, blobs := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, 123, 4)
+	require.NoError(t, db.SaveBlock(context.Background(), denebBlock))
+	bs := filesystem.NewEphemeralBlobStorage(t)
+	testSidecars, err := verification.BlobSidecarSliceNoop(blobs)
+	require.NoError(t, err)
+	for i := range testSidecars {
+		require.NoError(t, bs.Save(testSidecars[i]))
+	}
+	blockRoot := blobs[0].BlockRoot()
+	versionedHash := func(i int) string {
+		h := primitives.ConvertKzgCommitmentToVersionedHash(blobs[i].KzgCommitment)
+		return hexutil.Encode(h[:])
+	}
+
+	mockChainService := &mockChain.ChainService{
+		FinalizedRoots: map[[32]byte]bool{},
+	}
+	s := &Server{
+		OptimisticModeFetcher: mockChainService,
+		FinalizationFetcher:   mockChainService,
+		TimeFetcher:           mockChainService,
+		Blocker: &lookup.BeaconDbBlocker{
+			ChainInfoFetcher: &mockChain.ChainService{Root: blockRoot[:], Block: denebBlock},
+			GenesisTimeFetcher: &testutil.MockGenesisTimeFetcher{
+				Genesis: time.Now(),
+			},
+			BeaconDB:    db,
+			BlobStorage: bs,
+		},
+	}
+
+	t.Run("all blobs", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/head", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetBlobs(writer, request)
+
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetBlobsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 4, len(resp.Data))
+		for i := range resp.Data {
+			assert.Equal(t, hexutil.Encode(blobs[i].Blob), resp.Data[i])
+		}
+		require.Equal(t, false, resp.ExecutionOptimistic)
+		require.Equal(t, false, resp.Finalized)
+	})
+	t.Run("versioned hashes", func(t *testing.T) {
+		u := "http://foo.example/head?versioned_hashes=" + versionedHash(3) + "&versioned_hashes=" + versionedHash(1)
+		request := httptest.NewRequest("GET", u, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetBlobs(writer, request)
+
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetBlobsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, hexutil.Encode(blobs[1].Blob), resp.Data[0])
+		assert.Equal(t, hexutil.Encode(blobs[3].Blob), resp.Data[1])
+	})
+	t.Run("unknown versioned hash", func(t *testing.T) {
+		u := "http://foo.example/head?versioned_hashes=" + hexutil.Encode(make([]byte, 32))
+		request := httptest.NewRequest("GET", u, nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetBlobs(writer, request)
+
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetBlobsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 0, len(resp.Data))
+	})
+	t.Run("invalid versioned hash", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/head?versioned_hashes=0x1234", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetBlobs(writer, request)
+
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "requested versioned hashes [0x1234] are invalid", e.Message)
+	})
+	t.Run("ssz", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/head?versioned_hashes="+versionedHash(2), nil)
+		request.Header.Add("Accept", "application/octet-stream")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetBlobs(writer, request)
+
+		assert.Equal(t, http.StatusOK, writer.Code)
+		require.Equal(t, fieldparams.BlobLength, len(writer.Body.Bytes()))
+		assert.DeepEqual(t, blobs[2].Blob, writer.Body.Bytes())
+	})
+	t.Run("fallback to execution client", func(t *testing.T) {
+		s := &Server{
+			OptimisticModeFetcher: mockChainService,
+			FinalizationFetcher:   mockChainService,
+			TimeFetcher:           mockChainService,
+			Blocker: &lookup.BeaconDbBlocker{
+				ChainInfoFetcher: &mockChain.ChainService{Root: blockRoot[:], Block: denebBlock},
+				GenesisTimeFetcher: &testutil.MockGenesisTimeFetcher{
+					Genesis: time.Now(),
+				},
+				BeaconDB:               db,
+				BlobStorage:            filesystem.NewEphemeralBlobStorage(t),
+				ExecutionReconstructor: &mockExecution.EngineClient{BlobSidecars: testSidecars[1:2]},
+			},
+		}
+		request := httptest.NewRequest("GET", "http://foo.example/head?versioned_hashes="+versionedHash(1), nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetBlobs(writer, request)
+
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.GetBlobsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, hexutil.Encode(blobs[1].Blob), resp.Data[0])
+	})
+	t.Run("pre-deneb block", func(t *testing.T) {
+		capellaBlock, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockCapella())
+		require.NoError(t, err)
+		s := &Server{Blocker: &testutil.MockBlocker{BlockToReturn: capellaBlock}}
+		request := httptest.NewRequest("GET", "http://foo.example/head", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetBlobs(writer, request)
+
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Blobs are not supported before Deneb", e.Message)
+	})
+}
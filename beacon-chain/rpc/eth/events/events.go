@@ -43,8 +43,12 @@ const (
 	HeadTopic = "head"
 	// BlockTopic represents a new produced block event topic.
 	BlockTopic = "block"
+	// BlockGossipTopic represents a new block received from gossip, before it is imported, event topic.
+	BlockGossipTopic = "block_gossip"
 	// AttestationTopic represents a new submitted attestation event topic.
 	AttestationTopic = "attestation"
+	// SingleAttestationTopic represents a new unaggregated attestation received since Electra event topic.
+	SingleAttestationTopic = "single_attestation"
 	// VoluntaryExitTopic represents a new performed voluntary exit event topic.
 	VoluntaryExitTopic = "voluntary_exit"
 	// FinalizedCheckpointTopic represents a new finalized checkpoint event topic.
@@ -98,6 +102,8 @@ var opsFeedEventTopics = map[feed.EventType]string{
 	operation.BlobSidecarReceived:               BlobSidecarTopic,
 	operation.AttesterSlashingReceived:          AttesterSlashingTopic,
 	operation.ProposerSlashingReceived:          ProposerSlashingTopic,
+	operation.BlockGossipReceived:               BlockGossipTopic,
+	operation.SingleAttReceived:                 SingleAttestationTopic,
 }
 
 var stateFeedEventTopics = map[feed.EventType]string{
@@ -154,6 +160,8 @@ func newTopicRequest(topics []string) (*topicRequest, error) {
 // Consumers should use the eventsource implementation to listen for those events.
 // Servers may send SSE comments beginning with ':' for any purpose,
 // including to keep the event stream connection alive in the presence of proxy servers.
+// Events carry an id, and clients reconnecting with a Last-Event-ID header first receive
+// the recent events of their topics which followed that id.
 func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request) {
 	var err error
 	defer func() {
@@ -172,6 +180,17 @@ func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var lastID *uint64
+	if h := r.Header.Get(lastEventIDHeader); h != "" {
+		var id uint64
+		id, err = strconv.ParseUint(h, 10, 64)
+		if err != nil {
+			httputil.HandleError(w, errors.Wrap(errInvalidLastEventID, err.Error()).Error(), http.StatusBadRequest)
+			return
+		}
+		lastID = &id
+	}
+
 	timeout := s.EventWriteTimeout
 	if timeout == 0 {
 		timeout = time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
@@ -192,7 +211,7 @@ func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request) {
 	es := newEventStreamer(buffSize, ka)
 
 	go es.outboxWriteLoop(ctx, cancel, sw, r.URL.Path)
-	if err := es.recvEventLoop(ctx, cancel, topics, s); err != nil {
+	if err := es.recvEventLoop(ctx, cancel, topics, s, lastID); err != nil {
 		log.WithError(err).Debug("Shutting down StreamEvents handler.")
 	}
 	cleanupStart := time.Now()
@@ -214,36 +233,48 @@ type eventStreamer struct {
 	openUntilExit chan struct{}
 }
 
-func (es *eventStreamer) recvEventLoop(ctx context.Context, cancel context.CancelFunc, req *topicRequest, s *Server) error {
+func (es *eventStreamer) recvEventLoop(ctx context.Context, cancel context.CancelFunc, req *topicRequest, s *Server, lastID *uint64) error {
 	defer close(es.outbox)
 	defer cancel()
-	eventsChan := make(chan *feed.Event, len(es.outbox))
-	if req.needOpsFeed {
-		opsSub := s.OperationNotifier.OperationFeed().Subscribe(eventsChan)
-		defer opsSub.Unsubscribe()
-	}
-	if req.needStateFeed {
-		stateSub := s.StateNotifier.StateFeed().Subscribe(eventsChan)
-		defer stateSub.Unsubscribe()
+	// The connection subscribes to the recorder before the recorder subscribes to the feeds,
+	// so that the first connection does not miss the events following its subscription.
+	recorder := s.eventRecorder()
+	sub, replay := recorder.subscribe(cap(es.outbox), req, lastID)
+	defer recorder.unsubscribe(sub)
+	s.startRecording()
+
+	// Replayed events are queued as the client reads them, as there may be more of them than the outbox can hold.
+	// Meanwhile, new events are buffered by the subscription.
+	for _, e := range replay {
+		lr, err := e.reader(ctx, s, req)
+		if err != nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case es.outbox <- lr:
+		}
 	}
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case event := <-eventsChan:
-			lr, err := s.lazyReaderForEvent(ctx, event, req)
+		case <-sub.slow:
+			// The recorder drops events for connections which do not drain their subscription fast enough.
+			log.WithError(errSlowReader).Warn("Client is unable to keep up with event stream, shutting down.")
+			return errSlowReader
+		case e := <-sub.events:
+			lr, err := e.reader(ctx, s, req)
 			if err != nil {
-				if !errors.Is(err, errNotRequested) {
-					log.WithField("event_type", fmt.Sprintf("%v", event.Data)).WithError(err).Error("StreamEvents API endpoint received an event it was unable to handle.")
-				}
+				log.WithField("event_type", fmt.Sprintf("%v", e.event.Data)).WithError(err).Error("StreamEvents API endpoint received an event it was unable to handle.")
 				continue
 			}
 			// If the client can't keep up, the outbox will eventually completely fill, at which
 			// safeWrite will error, and we'll hit the below return statement, at which point the deferred
-			// Unsuscribe calls will be made and the event feed will stop writing to this channel.
-			// Since the outbox and event stream channels are separately buffered, the event subscription
-			// channel should stay relatively empty, which gives this loop time to unsubscribe
-			// and cleanup before the event stream channel fills and disrupts other readers.
+			// unsubscribe call will be made and the recorder will stop writing to this connection.
+			// Since the outbox and the subscription channels are separately buffered, the subscription
+			// channel should stay relatively empty, which gives this loop time to unsubscribe and cleanup.
 			if err := es.safeWrite(ctx, lr); err != nil {
 				// note: we could hijack the connection and close it here. Does that cause issues? What are the benefits?
 				// A benefit of hijack and close is that it may force an error on the remote end, however just closing the context of the
@@ -427,6 +458,10 @@ func topicForEvent(event *feed.Event) string {
 		return AttesterSlashingTopic
 	case *operation.ProposerSlashingReceivedData:
 		return ProposerSlashingTopic
+	case *operation.BlockGossipReceivedData:
+		return BlockGossipTopic
+	case *operation.SingleAttReceivedData:
+		return SingleAttestationTopic
 	case *ethpb.EventHead:
 		return HeadTopic
 	case *ethpb.EventFinalizedCheckpoint:
@@ -483,6 +518,22 @@ func (s *Server) lazyReaderForEvent(ctx context.Context, event *feed.Event, topi
 		default:
 			return nil, errors.Wrapf(errUnhandledEventData, "Unexpected type %T for the .Attestation field of UnAggregatedAttReceivedData", v.Attestation)
 		}
+	case *operation.SingleAttReceivedData:
+		return func() io.Reader {
+			return jsonMarshalReader(eventName, structs.SingleAttFromConsensus(v.Attestation))
+		}, nil
+	case *operation.BlockGossipReceivedData:
+		blockRoot, err := v.SignedBlock.Block().HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute block root for BlockGossipReceivedData operation feed event")
+		}
+		blk := &structs.BlockGossipEvent{
+			Slot:  fmt.Sprintf("%d", v.SignedBlock.Block().Slot()),
+			Block: hexutil.Encode(blockRoot[:]),
+		}
+		return func() io.Reader {
+			return jsonMarshalReader(eventName, blk)
+		}, nil
 	case *operation.ExitReceivedData:
 		return func() io.Reader {
 			return jsonMarshalReader(eventName, structs.SignedExitFromConsensus(v.Exit))
@@ -496,15 +547,17 @@ func (s *Server) lazyReaderForEvent(ctx context.Context, event *feed.Event, topi
 			return jsonMarshalReader(eventName, structs.SignedBLSChangeFromConsensus(v.Change))
 		}, nil
 	case *operation.BlobSidecarReceivedData:
+		// The event is built right away, so that recorded events don't hold on to the blob.
+		versionedHash := primitives.ConvertKzgCommitmentToVersionedHash(v.Blob.KzgCommitment)
+		ev := &structs.BlobSidecarEvent{
+			BlockRoot:     hexutil.Encode(v.Blob.BlockRootSlice()),
+			Index:         fmt.Sprintf("%d", v.Blob.Index),
+			Slot:          fmt.Sprintf("%d", v.Blob.Slot()),
+			VersionedHash: versionedHash.String(),
+			KzgCommitment: hexutil.Encode(v.Blob.KzgCommitment),
+		}
 		return func() io.Reader {
-			versionedHash := primitives.ConvertKzgCommitmentToVersionedHash(v.Blob.KzgCommitment)
-			return jsonMarshalReader(eventName, &structs.BlobSidecarEvent{
-				BlockRoot:     hexutil.Encode(v.Blob.BlockRootSlice()),
-				Index:         fmt.Sprintf("%d", v.Blob.Index),
-				Slot:          fmt.Sprintf("%d", v.Blob.Slot()),
-				VersionedHash: versionedHash.String(),
-				KzgCommitment: hexutil.Encode(v.Blob.KzgCommitment),
-			})
+			return jsonMarshalReader(eventName, ev)
 		}, nil
 	case *operation.AttesterSlashingReceivedData:
 		switch slashing := v.AttesterSlashing.(type) {
@@ -560,12 +613,12 @@ func (s *Server) lazyReaderForEvent(ctx context.Context, event *feed.Event, topi
 		if err != nil {
 			return nil, errors.Wrap(err, "could not compute block root for BlockProcessedData state feed event")
 		}
+		blk := &structs.BlockEvent{
+			Slot:                fmt.Sprintf("%d", v.Slot),
+			Block:               hexutil.Encode(blockRoot[:]),
+			ExecutionOptimistic: v.Optimistic,
+		}
 		return func() io.Reader {
-			blk := &structs.BlockEvent{
-				Slot:                fmt.Sprintf("%d", v.Slot),
-				Block:               hexutil.Encode(blockRoot[:]),
-				ExecutionOptimistic: v.Optimistic,
-			}
 			return jsonMarshalReader(eventName, blk)
 		}, nil
 	default:
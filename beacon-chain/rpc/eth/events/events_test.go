@@ -7,7 +7,9 @@ import (
 	"math"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -75,7 +77,7 @@ func requireAllEventsReceived(t *testing.T, stn, opn *mockChain.EventFeedWrapper
 				return
 			}
 			require.NoError(t, err)
-			str := string(ev)
+			_, str := splitEventID(string(ev))
 			delete(expected, str)
 			if len(expected) == 0 {
 				return
@@ -98,6 +100,15 @@ func requireAllEventsReceived(t *testing.T, stn, opn *mockChain.EventFeedWrapper
 	}
 }
 
+// splitEventID splits the id line from an event read from the stream.
+func splitEventID(ev string) (string, string) {
+	id, rest, ok := strings.Cut(ev, "\n")
+	if !ok || !strings.HasPrefix(id, "id: ") {
+		return "", ev
+	}
+	return strings.TrimPrefix(id, "id: "), rest
+}
+
 func (tr *topicRequest) testHttpRequest(ctx context.Context, _ *testing.T) *http.Request {
 	tq := make([]string, 0, len(tr.topics))
 	for topic := range tr.topics {
@@ -116,11 +127,15 @@ func operationEventsFixtures(t *testing.T) (*topicRequest, []*feed.Event) {
 		BlobSidecarTopic,
 		AttesterSlashingTopic,
 		ProposerSlashingTopic,
+		BlockGossipTopic,
+		SingleAttestationTopic,
 	})
 	require.NoError(t, err)
 	ro, err := blocks.NewROBlob(util.HydrateBlobSidecar(&eth.BlobSidecar{}))
 	require.NoError(t, err)
 	vblob := blocks.NewVerifiedROBlob(ro)
+	b, err := blocks.NewSignedBeaconBlock(util.HydrateSignedBeaconBlock(&eth.SignedBeaconBlock{}))
+	require.NoError(t, err)
 
 	return topics, []*feed.Event{
 		&feed.Event{
@@ -139,6 +154,23 @@ func operationEventsFixtures(t *testing.T) (*topicRequest, []*feed.Event) {
 				},
 			},
 		},
+		&feed.Event{
+			Type: operation.SingleAttReceived,
+			Data: &operation.SingleAttReceivedData{
+				Attestation: &eth.SingleAttestation{
+					CommitteeId:   1,
+					AttesterIndex: 2,
+					Data:          util.HydrateAttestationData(&eth.AttestationData{}),
+					Signature:     make([]byte, fieldparams.BLSSignatureLength),
+				},
+			},
+		},
+		&feed.Event{
+			Type: operation.BlockGossipReceived,
+			Data: &operation.BlockGossipReceivedData{
+				SignedBlock: b,
+			},
+		},
 		&feed.Event{
 			Type: operation.ExitReceived,
 			Data: &operation.ExitReceivedData{
@@ -544,7 +576,7 @@ func TestStuckReaderScenarios(t *testing.T) {
 
 func wedgedWriterTestCase(t *testing.T, queueDepth func([]*feed.Event) int) {
 	topics, events := operationEventsFixtures(t)
-	require.Equal(t, 8, len(events))
+	require.Equal(t, 10, len(events))
 
 	// set eventFeedDepth to a number lower than the events we intend to send to force the server to drop the reader.
 	stn := mockChain.NewEventFeedWrapper()
@@ -603,3 +635,143 @@ func wedgedWriterTestCase(t *testing.T, queueDepth func([]*feed.Event) int) {
 		t.Fatalf("context canceled / timed out waiting to write all events, err=%v", ctx.Err())
 	}
 }
+
+func TestStartRecording_DoesNotBlockFeeds(t *testing.T) {
+	stn := mockChain.NewEventFeedWrapper()
+	opn := mockChain.NewEventFeedWrapper()
+	s := &Server{
+		StateNotifier:     &mockChain.SimpleNotifier{Feed: stn},
+		OperationNotifier: &mockChain.SimpleNotifier{Feed: opn},
+	}
+	_, events := operationEventsFixtures(t)
+	recorder := s.eventRecorder()
+	sub, _ := recorder.subscribe(1, &topicRequest{topics: map[string]bool{}}, nil)
+	s.startRecording()
+
+	// The recorder is held up, and the events fill its queue instead of blocking the sender. The feed channel and
+	// the queue hold all the events sent but one, which is dropped.
+	recorder.lock.Lock()
+	sent := make(chan struct{})
+	go func() {
+		for i := 0; i < 2*eventRecorderQueueSize+1; i++ {
+			s.OperationNotifier.OperationFeed().Send(events[0])
+		}
+		close(sent)
+	}()
+	select {
+	case <-sent:
+	case <-time.After(10 * time.Second):
+		t.Fatal("feed blocked on the event recorder")
+	}
+	recorder.lock.Unlock()
+
+	// The event overflowing the queue is dropped, which shuts down the connections missing it.
+	select {
+	case <-sub.slow:
+	case <-time.After(10 * time.Second):
+		t.Fatal("connection not notified of the dropped events")
+	}
+}
+
+func TestStreamEvents_Replay(t *testing.T) {
+	stn := mockChain.NewEventFeedWrapper()
+	opn := mockChain.NewEventFeedWrapper()
+	s := &Server{
+		StateNotifier:     &mockChain.SimpleNotifier{Feed: stn},
+		OperationNotifier: &mockChain.SimpleNotifier{Feed: opn},
+		EventWriteTimeout: testEventWriteTimeout,
+	}
+	topics, events := operationEventsFixtures(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+
+	// Handlers are waited for, so that their shutdown does not log into the following tests.
+	var handlers sync.WaitGroup
+	defer func() {
+		cancel()
+		handlers.Wait()
+	}()
+	// stream opens a connection resuming after the given event id, and returns a function reading its events.
+	stream := func(ctx context.Context, lastID string) func() (string, string) {
+		request := topics.testHttpRequest(ctx, t)
+		if lastID != "" {
+			request.Header.Set(lastEventIDHeader, lastID)
+		}
+		w := NewStreamingResponseWriterRecorder(ctx)
+		handlers.Add(1)
+		go func() {
+			defer handlers.Done()
+			s.StreamEvents(w, request)
+		}()
+		sseR := sse.NewEventStreamReader(w.Body(), 1<<24)
+		return func() (string, string) {
+			for {
+				ev, err := sseR.ReadEvent()
+				require.NoError(t, err)
+				// Skip keep-alives.
+				if !strings.HasPrefix(string(ev), ":") {
+					return splitEventID(string(ev))
+				}
+			}
+		}
+	}
+	render := func(ev *feed.Event) string {
+		lr, err := s.lazyReaderForEvent(context.Background(), ev, topics)
+		require.NoError(t, err)
+		b, err := io.ReadAll(lr())
+		require.NoError(t, err)
+		return string(b[0 : len(b)-2])
+	}
+
+	// The first connection receives half of the events live.
+	firstCtx, firstCancel := context.WithCancel(ctx)
+	next := stream(firstCtx, "")
+	require.NoError(t, opn.WaitForSubscription(ctx))
+	half := len(events) / 2
+	ids := make([]uint64, 0, len(events))
+	for _, ev := range events[:half] {
+		s.OperationNotifier.OperationFeed().Send(ev)
+		id, data := next()
+		require.Equal(t, render(ev), data)
+		n, err := strconv.ParseUint(id, 10, 64)
+		require.NoError(t, err)
+		ids = append(ids, n)
+	}
+	firstCancel()
+
+	// The other half is sent while the client is disconnected.
+	for _, ev := range events[half:] {
+		s.OperationNotifier.OperationFeed().Send(ev)
+	}
+
+	// The client reconnects after the first event it received, and receives the following events in order.
+	next = stream(ctx, strconv.FormatUint(ids[0], 10))
+	for i, ev := range events[1:] {
+		id, data := next()
+		require.Equal(t, render(ev), data)
+		n, err := strconv.ParseUint(id, 10, 64)
+		require.NoError(t, err)
+		if i+1 < half {
+			require.Equal(t, ids[i+1], n)
+		} else {
+			require.Equal(t, true, n > ids[len(ids)-1])
+			ids = append(ids, n)
+		}
+	}
+
+	// Live events follow the replayed ones.
+	s.OperationNotifier.OperationFeed().Send(events[0])
+	id, data := next()
+	require.Equal(t, render(events[0]), data)
+	n, err := strconv.ParseUint(id, 10, 64)
+	require.NoError(t, err)
+	require.Equal(t, true, n > ids[len(ids)-1])
+
+	t.Run("invalid last event id", func(t *testing.T) {
+		request := topics.testHttpRequest(ctx, t)
+		request.Header.Set(lastEventIDHeader, "head")
+		w := httptest.NewRecorder()
+		s.StreamEvents(w, request)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.StringContains(t, errInvalidLastEventID.Error(), w.Body.String())
+	})
+}
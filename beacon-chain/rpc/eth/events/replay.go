@@ -0,0 +1,269 @@
+package events
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+)
+
+// DefaultEventReplayDepth is the number of recent events of each topic which are kept to be replayed
+// to clients reconnecting with a Last-Event-ID header.
+const DefaultEventReplayDepth = 128
+
+// eventRecorderQueueSize is the number of feed events buffered for the recorder. It is independent of the
+// connections, and events overflowing it are dropped rather than blocking the feeds.
+const eventRecorderQueueSize = 4 * DefaultEventFeedDepth
+
+// lastEventIDHeader is the header sent by SSE clients on reconnect, holding the id of the last event they received.
+const lastEventIDHeader = "Last-Event-ID"
+
+var errInvalidLastEventID = errors.New("invalid Last-Event-ID header")
+
+// recordedEvent is an event of the beacon node, numbered by the eventRecorder.
+type recordedEvent struct {
+	id    uint64
+	topic string
+	// event is only kept for payload attributes, which are computed for each connection.
+	event *feed.Event
+	// render returns the serialized event, and is shared by all the connections streaming it.
+	// It is nil for payload attributes.
+	render func() []byte
+}
+
+// reader returns the lazyReader writing the event to a client, prefixed with the event id.
+func (e *recordedEvent) reader(ctx context.Context, s *Server, topics *topicRequest) (lazyReader, error) {
+	var lr lazyReader
+	if e.render != nil {
+		lr = func() io.Reader {
+			b := e.render()
+			if b == nil {
+				return nil
+			}
+			return bytes.NewReader(b)
+		}
+	} else {
+		var err error
+		if lr, err = s.lazyReaderForEvent(ctx, e.event, topics); err != nil {
+			return nil, err
+		}
+	}
+	return func() io.Reader {
+		r := lr()
+		if r == nil {
+			return nil
+		}
+		return io.MultiReader(bytes.NewBufferString(fmt.Sprintf("id: %d\n", e.id)), r)
+	}, nil
+}
+
+// eventRing holds the most recent events of a topic.
+type eventRing struct {
+	events []*recordedEvent
+	next   int
+}
+
+func (r *eventRing) add(e *recordedEvent, depth int) {
+	if len(r.events) < depth {
+		r.events = append(r.events, e)
+		return
+	}
+	r.events[r.next] = e
+	r.next = (r.next + 1) % len(r.events)
+}
+
+// since appends the events with an id greater than the given one to dst.
+func (r *eventRing) since(dst []*recordedEvent, id uint64) []*recordedEvent {
+	for i := range r.events {
+		if e := r.events[(r.next+i)%len(r.events)]; e.id > id {
+			dst = append(dst, e)
+		}
+	}
+	return dst
+}
+
+// recorderSub is a connection subscribed to the events of the recorder.
+type recorderSub struct {
+	topics *topicRequest
+	events chan *recordedEvent
+	// slow is closed when the events channel overflowed, and the connection missed events.
+	slow     chan struct{}
+	slowOnce sync.Once
+}
+
+// eventRecorder receives the events of the beacon node feeds on behalf of all the event stream connections.
+// It assigns increasing ids to the events, keeps the most recent events of each topic to replay them
+// to reconnecting clients, and hands the events out to the connections.
+type eventRecorder struct {
+	depth   int
+	lock    sync.Mutex
+	lastID  uint64
+	recent  map[string]*eventRing
+	subs    map[*recorderSub]bool
+	allReqs *topicRequest
+}
+
+func newEventRecorder(depth int) *eventRecorder {
+	all := &topicRequest{topics: make(map[string]bool), needStateFeed: true, needOpsFeed: true}
+	for topic := range topicsForStateFeed {
+		all.topics[topic] = true
+	}
+	for topic := range topicsForOpsFeed {
+		all.topics[topic] = true
+	}
+	return &eventRecorder{
+		depth: depth,
+		// Ids start from the current time, so that they keep increasing across restarts of the node,
+		// and events recorded after a restart are replayed to clients reconnecting with an older id.
+		lastID:  uint64(time.Now().UnixNano()),
+		recent:  make(map[string]*eventRing),
+		subs:    make(map[*recorderSub]bool),
+		allReqs: all,
+	}
+}
+
+// eventRecorder returns the event recorder of the server, starting it on first use.
+func (s *Server) eventRecorder() *eventRecorder {
+	s.recorderOnce.Do(func() {
+		depth := s.EventReplayDepth
+		if depth == 0 {
+			depth = DefaultEventReplayDepth
+		}
+		s.recorder = newEventRecorder(depth)
+	})
+	return s.recorder
+}
+
+// startRecording subscribes the recorder to the feeds of the server, once. The feed events are moved to a queue
+// without waiting for the recorder, so that a busy recorder never holds up the senders of the feeds,
+// some of which are on the gossip path. The events overflowing the queue are dropped.
+func (s *Server) startRecording() {
+	s.recordingOnce.Do(func() {
+		events := make(chan *feed.Event, eventRecorderQueueSize)
+		if s.OperationNotifier != nil {
+			s.OperationNotifier.OperationFeed().Subscribe(events)
+		}
+		if s.StateNotifier != nil {
+			s.StateNotifier.StateFeed().Subscribe(events)
+		}
+		queue := make(chan *feed.Event, eventRecorderQueueSize)
+		go func() {
+			dropped := 0
+			for ev := range events {
+				select {
+				case queue <- ev:
+					if dropped > 0 {
+						log.WithField("dropped_events", dropped).Warn("Event recorder was unable to keep up with the event feeds.")
+						dropped = 0
+					}
+				default:
+					s.eventRecorder().drop()
+					dropped++
+				}
+			}
+		}()
+		go func() {
+			for ev := range queue {
+				s.eventRecorder().record(s, ev)
+			}
+		}()
+	})
+}
+
+// drop is called when the recorder can't keep up with the feeds and an event is dropped. The subscribed connections
+// miss the event, and are shut down like the connections which can't keep up themselves.
+func (r *eventRecorder) drop() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for sub := range r.subs {
+		sub.slowOnce.Do(func() { close(sub.slow) })
+	}
+}
+
+func (r *eventRecorder) record(s *Server, ev *feed.Event) {
+	topic := topicForEvent(ev)
+	if topic == InvalidTopic {
+		return
+	}
+	var render func() []byte
+	if topic != PayloadAttributesTopic {
+		lr, err := s.lazyReaderForEvent(context.Background(), ev, r.allReqs)
+		if err != nil {
+			log.WithField("event_type", fmt.Sprintf("%v", ev.Data)).WithError(err).Error("StreamEvents API endpoint received an event it was unable to handle.")
+			return
+		}
+		render = sync.OnceValue(func() []byte {
+			rd := lr()
+			if rd == nil {
+				return nil
+			}
+			b, err := io.ReadAll(rd)
+			if err != nil {
+				return nil
+			}
+			return b
+		})
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.lastID++
+	e := &recordedEvent{id: r.lastID, topic: topic, render: render}
+	// Payload attributes depend on the time they are computed at, and are not replayed.
+	if render == nil {
+		e.event = ev
+	} else {
+		ring, ok := r.recent[topic]
+		if !ok {
+			ring = &eventRing{}
+			r.recent[topic] = ring
+		}
+		ring.add(e, r.depth)
+	}
+	for sub := range r.subs {
+		if !sub.topics.requested(topic) {
+			continue
+		}
+		select {
+		case sub.events <- e:
+		default:
+			sub.slowOnce.Do(func() { close(sub.slow) })
+		}
+	}
+}
+
+// subscribe subscribes a connection to the recorded events, with a buffer of the given size.
+// When lastID is not nil, the recent events of the requested topics following that id are returned in order,
+// to be sent before the events of the subscription.
+func (r *eventRecorder) subscribe(bufSize int, topics *topicRequest, lastID *uint64) (*recorderSub, []*recordedEvent) {
+	sub := &recorderSub{topics: topics, events: make(chan *recordedEvent, bufSize), slow: make(chan struct{})}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.subs[sub] = true
+	if lastID == nil {
+		return sub, nil
+	}
+	var replay []*recordedEvent
+	for topic, ring := range r.recent {
+		if topics.requested(topic) {
+			replay = ring.since(replay, *lastID)
+		}
+	}
+	slices.SortFunc(replay, func(a, b *recordedEvent) int {
+		return cmp.Compare(a.id, b.id)
+	})
+	return sub, replay
+}
+
+func (r *eventRecorder) unsubscribe(sub *recorderSub) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.subs, sub)
+}
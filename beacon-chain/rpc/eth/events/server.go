@@ -4,6 +4,7 @@
 package events
 
 import (
+	"sync"
 	"time"
 
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
@@ -23,4 +24,8 @@ type Server struct {
 	KeepAliveInterval      time.Duration
 	EventFeedDepth         int
 	EventWriteTimeout      time.Duration
+	EventReplayDepth       int
+	recorderOnce           sync.Once
+	recordingOnce          sync.Once
+	recorder               *eventRecorder
 }
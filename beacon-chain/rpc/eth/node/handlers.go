@@ -1,15 +1,20 @@
 package node
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"runtime"
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/eth/v1"
@@ -38,18 +43,48 @@ func (s *Server) GetSyncStatus(w http.ResponseWriter, r *http.Request) {
 	}
 
 	headSlot := s.HeadFetcher.HeadSlot()
+	currentSlot := s.GenesisTimeFetcher.CurrentSlot()
 	response := &structs.SyncStatusResponse{
 		Data: &structs.SyncStatusResponseData{
 			HeadSlot:     strconv.FormatUint(uint64(headSlot), 10),
-			SyncDistance: strconv.FormatUint(uint64(s.GenesisTimeFetcher.CurrentSlot()-headSlot), 10),
+			SyncDistance: strconv.FormatUint(uint64(currentSlot-headSlot), 10),
 			IsSyncing:    s.SyncChecker.Syncing(),
 			IsOptimistic: isOptimistic,
 			ElOffline:    !s.ExecutionChainInfoFetcher.ExecutionClientConnected(),
 		},
 	}
+	if s.BeaconDB != nil {
+		blobBackfill, err := s.blobBackfillStatus(ctx, currentSlot)
+		if err != nil {
+			httputil.HandleError(w, "Could not read blob backfill status: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		response.Data.BlobBackfill = blobBackfill
+	}
 	httputil.WriteJson(w, response)
 }
 
+// blobBackfillStatus describes the range of blocks whose blobs blob backfill has saved. Blob backfill is syncing
+// until the range reaches the start of the blob retention window.
+func (s *Server) blobBackfillStatus(ctx context.Context, currentSlot primitives.Slot) (*structs.BlobBackfillStatus, error) {
+	status, err := s.BeaconDB.BlobBackfillStatus(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	minSlot, err := sync.BlobRPCMinValidSlot(currentSlot)
+	if err != nil {
+		return nil, err
+	}
+	return &structs.BlobBackfillStatus{
+		LowSlot:   strconv.FormatUint(status.LowSlot, 10),
+		HighSlot:  strconv.FormatUint(status.HighSlot, 10),
+		IsSyncing: primitives.Slot(status.LowSlot) > minSlot,
+	}, nil
+}
+
 // GetIdentity retrieves data about the node's network presence.
 func (s *Server) GetIdentity(w http.ResponseWriter, r *http.Request) {
 	_, span := trace.StartSpan(r.Context(), "node.GetIdentity")
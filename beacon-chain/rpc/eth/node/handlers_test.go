@@ -2,6 +2,7 @@ package node
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -16,13 +17,16 @@ import (
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
 	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	mockp2p "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/testutil"
 	syncmock "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/wrapper"
 	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
 	pb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/runtime/version"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
@@ -70,6 +74,46 @@ func TestSyncStatus(t *testing.T) {
 	assert.Equal(t, false, resp.Data.ElOffline)
 }
 
+func TestSyncStatus_BlobBackfill(t *testing.T) {
+	currentSlot := new(primitives.Slot)
+	*currentSlot = 110
+	state, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, state.SetSlot(100))
+	chainService := &mock.ChainService{Slot: currentSlot, State: state}
+	beaconDB := dbtest.SetupDB(t)
+	s := &Server{
+		BeaconDB:                  beaconDB,
+		HeadFetcher:               chainService,
+		GenesisTimeFetcher:        chainService,
+		OptimisticModeFetcher:     chainService,
+		SyncChecker:               &syncmock.Sync{},
+		ExecutionChainInfoFetcher: &testutil.MockExecutionChainInfoFetcher{},
+	}
+	syncStatus := func() *structs.SyncStatusResponseData {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetSyncStatus(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.SyncStatusResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		return resp.Data
+	}
+
+	// Blob backfill has not run.
+	require.Equal(t, (*structs.BlobBackfillStatus)(nil), syncStatus().BlobBackfill)
+
+	require.NoError(t, beaconDB.SaveBlobBackfillStatus(context.Background(), &dbval.BlobBackfillStatus{LowSlot: 64, HighSlot: 97}))
+	require.DeepEqual(t, &structs.BlobBackfillStatus{LowSlot: "64", HighSlot: "97", IsSyncing: false}, syncStatus().BlobBackfill)
+
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.DenebForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+	require.DeepEqual(t, &structs.BlobBackfillStatus{LowSlot: "64", HighSlot: "97", IsSyncing: true}, syncStatus().BlobBackfill)
+}
+
 func TestGetVersion(t *testing.T) {
 	semVer := version.SemanticVersion()
 	os := runtime.GOOS
@@ -0,0 +1,295 @@
+package rewards
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// maxRewardHistoryEpochs bounds the number of epochs a single reward history request can cover.
+const maxRewardHistoryEpochs = 1024
+
+// RewardHistory is an HTTP handler that returns the attestation, sync committee and proposal rewards and penalties
+// of the requested validators for every epoch in [start_epoch, end_epoch].
+// The state is replayed once across the whole range and the response is streamed as newline-delimited JSON,
+// with one structs.RewardHistoryEpoch written (and flushed) as soon as each epoch is complete.
+func (s *Server) RewardHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "beacon.RewardHistory")
+	defer span.End()
+
+	startEpoch, endEpoch, ok := s.rewardHistoryRange(w, r)
+	if !ok {
+		return
+	}
+	startSlot, err := slots.EpochStart(startEpoch)
+	if err != nil {
+		httputil.HandleError(w, "Could not get start epoch's starting slot: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Start from the state before the first block of the range so that every block in it gets observed.
+	prevSlot := slots.PrevSlot(startSlot)
+	st, err := s.ReplayerBuilder.ReplayerForSlot(prevSlot).ReplayToSlot(ctx, prevSlot)
+	if err != nil {
+		httputil.HandleError(w, "Could not get state for start epoch: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	valIndices, ok := requestedValIndices(w, r, st, nil)
+	if !ok {
+		return
+	}
+	if len(valIndices) == 0 {
+		httputil.HandleError(w, "At least one validator must be requested", http.StatusBadRequest)
+		return
+	}
+	optimistic, err := s.OptimisticModeFetcher.IsOptimistic(ctx)
+	if err != nil {
+		httputil.HandleError(w, "Could not get optimistic mode info: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h := newRewardHistory(st, startEpoch, endEpoch, valIndices)
+	enc := json.NewEncoder(w)
+	flusher, _ := w.(http.Flusher)
+	streaming := false
+	for e := startEpoch; e <= endEpoch; e++ {
+		// Attestation rewards for epoch e are applied in the epoch transition at the end of epoch e+1,
+		// so the state needs to be at the last slot of e+1 before they can be computed.
+		target, err := slots.EpochEnd(e + 1)
+		if err != nil {
+			writeRewardHistoryError(w, enc, streaming, "Could not get next epoch's ending slot: "+err.Error())
+			return
+		}
+		st, err = s.StateAdvancer.AdvanceState(ctx, st, target, h.observeBlock)
+		if err != nil {
+			writeRewardHistoryError(w, enc, streaming, fmt.Sprintf("Could not replay state to slot %d: %s", target, err.Error()))
+			return
+		}
+		data, err := h.epochRewards(ctx, st, e)
+		if err != nil {
+			writeRewardHistoryError(w, enc, streaming, fmt.Sprintf("Could not compute rewards for epoch %d: %s", e, err.Error()))
+			return
+		}
+		if !streaming {
+			w.Header().Set("Content-Type", api.NDJsonMediaType)
+			streaming = true
+		}
+		resp := &structs.RewardHistoryEpoch{
+			Epoch:               strconv.FormatUint(uint64(e), 10),
+			ExecutionOptimistic: optimistic,
+			Finalized:           slots.ToEpoch(target) < s.FinalizationFetcher.FinalizedCheckpt().Epoch,
+			Data:                data,
+		}
+		if err := enc.Encode(resp); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *Server) rewardHistoryRange(w http.ResponseWriter, r *http.Request) (primitives.Epoch, primitives.Epoch, bool) {
+	_, start, ok := shared.UintFromQuery(w, r, "start_epoch", true)
+	if !ok {
+		return 0, 0, false
+	}
+	_, end, ok := shared.UintFromQuery(w, r, "end_epoch", true)
+	if !ok {
+		return 0, 0, false
+	}
+	startEpoch, endEpoch := primitives.Epoch(start), primitives.Epoch(end)
+	if endEpoch < startEpoch {
+		httputil.HandleError(w, "End epoch must not be before start epoch", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	if endEpoch-startEpoch >= maxRewardHistoryEpochs {
+		httputil.HandleError(w, fmt.Sprintf("Requested range exceeds the maximum of %d epochs", maxRewardHistoryEpochs), http.StatusBadRequest)
+		return 0, 0, false
+	}
+	if startEpoch < params.BeaconConfig().AltairForkEpoch {
+		httputil.HandleError(w, "Reward history is not supported for Phase 0", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	currentEpoch := slots.ToEpoch(s.TimeFetcher.CurrentSlot())
+	if endEpoch+1 >= currentEpoch {
+		httputil.HandleError(w,
+			"Rewards for an epoch are available after two epoch transitions to ensure all attestations have a chance of inclusion",
+			http.StatusNotFound)
+		return 0, 0, false
+	}
+	return startEpoch, endEpoch, true
+}
+
+// writeRewardHistoryError reports a failure as a regular error response if nothing has been written yet.
+// Once streaming has started the status code can no longer be changed, so the error becomes the final line.
+func writeRewardHistoryError(w http.ResponseWriter, enc *json.Encoder, streaming bool, msg string) {
+	if !streaming {
+		httputil.HandleError(w, msg, http.StatusInternalServerError)
+		return
+	}
+	_ = enc.Encode(&httputil.DefaultJsonError{Message: msg, Code: http.StatusInternalServerError})
+}
+
+// epochSyncAndProposals holds the block-level rewards collected for the requested validators during one epoch.
+type epochSyncAndProposals struct {
+	sync      []int64
+	proposals []int64
+}
+
+// rewardHistory accumulates per-validator rewards while a state is advanced through a range of epochs.
+type rewardHistory struct {
+	start, end primitives.Epoch
+	indices    []primitives.ValidatorIndex
+	byIndex    map[primitives.ValidatorIndex]int
+	byPubkey   map[[fieldparams.BLSPubkeyLength]byte]int
+	epochs     map[primitives.Epoch]*epochSyncAndProposals
+}
+
+func newRewardHistory(st state.ReadOnlyBeaconState, start, end primitives.Epoch, requested []primitives.ValidatorIndex) *rewardHistory {
+	h := &rewardHistory{
+		start:    start,
+		end:      end,
+		indices:  make([]primitives.ValidatorIndex, 0, len(requested)),
+		byIndex:  make(map[primitives.ValidatorIndex]int, len(requested)),
+		byPubkey: make(map[[fieldparams.BLSPubkeyLength]byte]int, len(requested)),
+		epochs:   make(map[primitives.Epoch]*epochSyncAndProposals),
+	}
+	for _, idx := range requested {
+		if _, ok := h.byIndex[idx]; ok {
+			continue
+		}
+		h.byIndex[idx] = len(h.indices)
+		h.byPubkey[st.PubkeyAtIndex(idx)] = len(h.indices)
+		h.indices = append(h.indices, idx)
+	}
+	return h
+}
+
+func (h *rewardHistory) epoch(e primitives.Epoch) *epochSyncAndProposals {
+	acc, ok := h.epochs[e]
+	if !ok {
+		acc = &epochSyncAndProposals{
+			sync:      make([]int64, len(h.indices)),
+			proposals: make([]int64, len(h.indices)),
+		}
+		h.epochs[e] = acc
+	}
+	return acc
+}
+
+// observeBlock is a stategen.BlockObserver that records the sync committee rewards and penalties of the
+// requested validators, and the block rewards of any of them that proposed the block.
+func (h *rewardHistory) observeBlock(ctx context.Context, preState state.BeaconState, b interfaces.ReadOnlySignedBeaconBlock) error {
+	blk := b.Block()
+	e := slots.ToEpoch(blk.Slot())
+	if e < h.start || e > h.end || b.Version() == version.Phase0 {
+		return nil
+	}
+	acc := h.epoch(e)
+
+	sa, err := blk.Body().SyncAggregate()
+	if err != nil {
+		return errors.Wrap(err, "could not get sync aggregate")
+	}
+	sc, err := preState.CurrentSyncCommittee()
+	if err != nil {
+		return errors.Wrap(err, "could not get current sync committee")
+	}
+	activeBalance, err := helpers.TotalActiveBalance(preState)
+	if err != nil {
+		return errors.Wrap(err, "could not get total active balance")
+	}
+	_, participantReward, err := altair.SyncRewards(activeBalance)
+	if err != nil {
+		return errors.Wrap(err, "could not get sync rewards")
+	}
+	for i := uint64(0); i < sa.SyncCommitteeBits.Len() && i < uint64(len(sc.Pubkeys)); i++ {
+		pos, ok := h.byPubkey[bytesutil.ToBytes48(sc.Pubkeys[i])]
+		if !ok {
+			continue
+		}
+		if sa.SyncCommitteeBits.BitAt(i) {
+			acc.sync[pos] += int64(participantReward) // lint:ignore uintcast -- Rewards are far below max int64.
+		} else {
+			acc.sync[pos] -= int64(participantReward) // lint:ignore uintcast -- Rewards are far below max int64.
+		}
+	}
+
+	pos, ok := h.byIndex[blk.ProposerIndex()]
+	if !ok {
+		return nil
+	}
+	br, httpErr := blockRewardsFromState(ctx, preState.Copy(), blk)
+	if httpErr != nil {
+		return errors.New(httpErr.Message)
+	}
+	total, err := strconv.ParseInt(br.Total, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "could not parse block rewards")
+	}
+	acc.proposals[pos] += total
+	return nil
+}
+
+// epochRewards combines the attestation rewards of epoch e, computed from st at the last slot of e+1,
+// with the block-level rewards collected for e. The collected rewards are released afterwards.
+func (h *rewardHistory) epochRewards(ctx context.Context, st state.BeaconState, e primitives.Epoch) ([]structs.ValidatorEpochRewards, error) {
+	allVals, bal, err := altair.InitializePrecomputeValidators(ctx, st)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not initialize precompute validators")
+	}
+	allVals, bal, err = altair.ProcessEpochParticipation(ctx, st, bal, allVals)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process epoch participation")
+	}
+	vals := make([]*precompute.Validator, len(h.indices))
+	for i, idx := range h.indices {
+		vals[i] = allVals[idx]
+	}
+	deltas, err := altair.AttestationsDelta(st, bal, vals)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get attestations delta")
+	}
+
+	acc := h.epoch(e)
+	delete(h.epochs, e)
+	data := make([]structs.ValidatorEpochRewards, len(h.indices))
+	for i, idx := range h.indices {
+		d := deltas[i]
+		head := int64(d.HeadReward)                              // lint:ignore uintcast -- Rewards are far below max int64.
+		source := int64(d.SourceReward) - int64(d.SourcePenalty) // lint:ignore uintcast -- Rewards are far below max int64.
+		target := int64(d.TargetReward) - int64(d.TargetPenalty) // lint:ignore uintcast -- Rewards are far below max int64.
+		inactivity := -int64(d.InactivityPenalty)                // lint:ignore uintcast -- Penalties are far below max int64.
+		data[i] = structs.ValidatorEpochRewards{
+			ValidatorIndex: strconv.FormatUint(uint64(idx), 10),
+			Head:           strconv.FormatInt(head, 10),
+			Target:         strconv.FormatInt(target, 10),
+			Source:         strconv.FormatInt(source, 10),
+			Inactivity:     strconv.FormatInt(inactivity, 10),
+			SyncCommittee:  strconv.FormatInt(acc.sync[i], 10),
+			Proposals:      strconv.FormatInt(acc.proposals[i], 10),
+			Total:          strconv.FormatInt(head+source+target+inactivity+acc.sync[i]+acc.proposals[i], 10),
+		}
+	}
+	return data, nil
+}
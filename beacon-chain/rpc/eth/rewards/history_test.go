@@ -0,0 +1,218 @@
+package rewards
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	mockstategen "github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen/mock"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls/common"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+// mockStateAdvancer moves the state forward by setting its slot, handing the blocks it knows about to the observer.
+type mockStateAdvancer struct {
+	blocks map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock
+}
+
+func (m *mockStateAdvancer) AdvanceState(ctx context.Context, st state.BeaconState, target primitives.Slot, observe stategen.BlockObserver) (state.BeaconState, error) {
+	for slot := st.Slot() + 1; slot <= target; slot++ {
+		if err := st.SetSlot(slot); err != nil {
+			return nil, err
+		}
+		if b, ok := m.blocks[slot]; ok && observe != nil {
+			if err := observe(ctx, st, b); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return st, nil
+}
+
+func TestRewardHistory(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig()
+	cfg.AltairForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+	helpers.ClearCache()
+
+	const valCount = 64
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+
+	newState := func(t *testing.T) state.BeaconState {
+		st, err := util.NewBeaconStateCapella()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(slotsPerEpoch-1))
+		validators := make([]*eth.Validator, 0, valCount)
+		balances := make([]uint64, 0, valCount)
+		for i := 0; i < valCount; i++ {
+			blsKey, err := bls.RandKey()
+			require.NoError(t, err)
+			validators = append(validators, &eth.Validator{
+				PublicKey:         blsKey.PublicKey().Marshal(),
+				ExitEpoch:         params.BeaconConfig().FarFutureEpoch,
+				WithdrawableEpoch: params.BeaconConfig().FarFutureEpoch,
+				EffectiveBalance:  params.BeaconConfig().MaxEffectiveBalance,
+			})
+			balances = append(balances, params.BeaconConfig().MaxEffectiveBalance)
+		}
+		require.NoError(t, st.SetValidators(validators))
+		require.NoError(t, st.SetBalances(balances))
+		require.NoError(t, st.SetInactivityScores(make([]uint64, valCount)))
+		participation := make([]byte, valCount)
+		for i := range participation {
+			participation[i] = 0b111
+		}
+		require.NoError(t, st.SetCurrentParticipationBits(participation))
+		require.NoError(t, st.SetPreviousParticipationBits(participation))
+		// Every validator sits in the sync committee SyncCommitteeLength/valCount times.
+		scPubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
+		for i := range scPubkeys {
+			scPubkeys[i] = validators[i%valCount].PublicKey
+		}
+		require.NoError(t, st.SetCurrentSyncCommittee(&eth.SyncCommittee{
+			Pubkeys:         scPubkeys,
+			AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
+		}))
+		return st
+	}
+
+	// A block in epoch 1 without any sync committee participation, proposed by validator 0.
+	b := util.HydrateSignedBeaconBlockCapella(util.NewBeaconBlockCapella())
+	b.Block.Slot = slotsPerEpoch + 8
+	b.Block.ProposerIndex = 0
+	b.Block.Body.SyncAggregate = &eth.SyncAggregate{
+		SyncCommitteeBits:      bitfield.NewBitvector512(),
+		SyncCommitteeSignature: common.InfiniteSignature[:],
+	}
+	sbb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+
+	currentSlot := slotsPerEpoch * 4
+	mockChainService := &mock.ChainService{Slot: &currentSlot, FinalizedCheckPoint: &eth.Checkpoint{Epoch: 3}}
+	newServer := func(st state.BeaconState) *Server {
+		return &Server{
+			TimeFetcher:           mockChainService,
+			OptimisticModeFetcher: mockChainService,
+			FinalizationFetcher:   mockChainService,
+			ReplayerBuilder:       mockstategen.NewReplayerBuilder(mockstategen.WithMockState(st)),
+			StateAdvancer: &mockStateAdvancer{blocks: map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock{
+				b.Block.Slot: sbb,
+			}},
+		}
+	}
+	newRequest := func(t *testing.T, query string, ids []string) *http.Request {
+		url := "http://example.com/prysm/v1/beacon/rewards/history?" + query
+		if ids == nil {
+			return httptest.NewRequest(http.MethodPost, url, nil)
+		}
+		raw, err := json.Marshal(ids)
+		require.NoError(t, err)
+		return httptest.NewRequest(http.MethodPost, url, bytes.NewReader(raw))
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		st := newState(t)
+		activeBalance, err := helpers.TotalActiveBalance(st)
+		require.NoError(t, err)
+		_, participantReward, err := altair.SyncRewards(activeBalance)
+		require.NoError(t, err)
+		syncPenalty := -int64(participantReward) * int64(fieldparams.SyncCommitteeLength/valCount)
+
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		newServer(st).RewardHistory(writer, newRequest(t, "start_epoch=1&end_epoch=2", []string{"0", "1", "0"}))
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "application/x-ndjson", writer.Header().Get("Content-Type"))
+
+		epochs := make([]*structs.RewardHistoryEpoch, 0)
+		scanner := bufio.NewScanner(writer.Body)
+		for scanner.Scan() {
+			e := &structs.RewardHistoryEpoch{}
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), e))
+			epochs = append(epochs, e)
+		}
+		require.NoError(t, scanner.Err())
+		require.Equal(t, 2, len(epochs))
+		assert.Equal(t, "1", epochs[0].Epoch)
+		assert.Equal(t, true, epochs[0].Finalized)
+		assert.Equal(t, "2", epochs[1].Epoch)
+		assert.Equal(t, false, epochs[1].Finalized)
+
+		for i, e := range epochs {
+			// Duplicate validators are only reported once.
+			require.Equal(t, 2, len(e.Data))
+			assert.Equal(t, "0", e.Data[0].ValidatorIndex)
+			assert.Equal(t, "1", e.Data[1].ValidatorIndex)
+			for _, r := range e.Data {
+				head, err := strconv.ParseInt(r.Head, 10, 64)
+				require.NoError(t, err)
+				source, err := strconv.ParseInt(r.Source, 10, 64)
+				require.NoError(t, err)
+				target, err := strconv.ParseInt(r.Target, 10, 64)
+				require.NoError(t, err)
+				sync, err := strconv.ParseInt(r.SyncCommittee, 10, 64)
+				require.NoError(t, err)
+				proposals, err := strconv.ParseInt(r.Proposals, 10, 64)
+				require.NoError(t, err)
+				total, err := strconv.ParseInt(r.Total, 10, 64)
+				require.NoError(t, err)
+				assert.Equal(t, true, head > 0 && source > 0 && target > 0)
+				assert.Equal(t, "0", r.Inactivity)
+				if i == 0 {
+					assert.Equal(t, syncPenalty, sync)
+				} else {
+					assert.Equal(t, int64(0), sync)
+				}
+				assert.Equal(t, int64(0), proposals)
+				assert.Equal(t, head+source+target+sync+proposals, total)
+			}
+		}
+	})
+	t.Run("end before start", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		newServer(newState(t)).RewardHistory(writer, newRequest(t, "start_epoch=2&end_epoch=1", []string{"0"}))
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "End epoch must not be before start epoch", e.Message)
+	})
+	t.Run("rewards not yet available", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		newServer(newState(t)).RewardHistory(writer, newRequest(t, "start_epoch=1&end_epoch=3", []string{"0"}))
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("no validators", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		newServer(newState(t)).RewardHistory(writer, newRequest(t, "start_epoch=1&end_epoch=2", nil))
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "At least one validator must be requested", e.Message)
+	})
+}
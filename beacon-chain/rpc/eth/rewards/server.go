@@ -3,6 +3,7 @@ package rewards
 import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/lookup"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
 )
 
 type Server struct {
@@ -13,4 +14,6 @@ type Server struct {
 	Stater                lookup.Stater
 	HeadFetcher           blockchain.HeadFetcher
 	BlockRewardFetcher    BlockRewardsFetcher
+	ReplayerBuilder       stategen.ReplayerBuilder
+	StateAdvancer         stategen.StateAdvancer
 }
@@ -45,7 +45,12 @@ func (rs *BlockRewardService) GetBlockRewardsData(ctx context.Context, blk inter
 	if httpErr != nil {
 		return nil, httpErr
 	}
+	return blockRewardsFromState(ctx, st, blk)
+}
 
+// blockRewardsFromState computes the proposer rewards for blk by running the reward-bearing block processing
+// functions on st, which must be the state at the block's slot before the block is applied. st is modified.
+func blockRewardsFromState(ctx context.Context, st state.BeaconState, blk interfaces.ReadOnlyBeaconBlock) (*structs.BlockRewards, *httputil.DefaultJsonError) {
 	proposerIndex := blk.ProposerIndex()
 	initBalance, err := st.BalanceAtIndex(proposerIndex)
 	if err != nil {
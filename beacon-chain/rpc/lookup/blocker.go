@@ -11,6 +11,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/execution"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/core"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -51,6 +52,8 @@ type BeaconDbBlocker struct {
 	ChainInfoFetcher   blockchain.ChainInfoFetcher
 	GenesisTimeFetcher blockchain.TimeFetcher
 	BlobStorage        *filesystem.BlobStorage
+	// ExecutionReconstructor is optional. When set, blobs missing from BlobStorage are fetched from the execution client.
+	ExecutionReconstructor execution.Reconstructor
 }
 
 // Block returns the beacon block for a given identifier. The identifier can be one of:
@@ -234,27 +237,44 @@ func (p *BeaconDbBlocker) Blobs(ctx context.Context, id string, indices []uint64
 	if len(commitments) == 0 {
 		return make([]*blocks.VerifiedROBlob, 0), nil
 	}
-	if len(indices) == 0 {
-		m, err := p.BlobStorage.Indices(bytesutil.ToBytes32(root), b.Block().Slot())
-		if err != nil {
-			log.WithFields(log.Fields{
-				"blockRoot": hexutil.Encode(root),
-			}).Error(errors.Wrapf(err, "could not retrieve blob indices for root %#x", root))
-			return nil, &core.RpcError{Err: fmt.Errorf("could not retrieve blob indices for root %#x", root), Reason: core.Internal}
+	blockRoot := bytesutil.ToBytes32(root)
+	stored, err := p.BlobStorage.Indices(blockRoot, b.Block().Slot())
+	if err != nil {
+		log.WithFields(log.Fields{
+			"blockRoot": hexutil.Encode(root),
+		}).Error(errors.Wrapf(err, "could not retrieve blob indices for root %#x", root))
+		return nil, &core.RpcError{Err: fmt.Errorf("could not retrieve blob indices for root %#x", root), Reason: core.Internal}
+	}
+	// When no indices are requested, every blob of the block that is in storage or that
+	// can be retrieved from the execution client is returned.
+	all := len(indices) == 0
+	if all {
+		for k, v := range stored {
+			if v && k >= len(commitments) {
+				return nil, &core.RpcError{Err: fmt.Errorf("blob index %d is more than blob kzg commitments :%dd", k, len(commitments)), Reason: core.BadRequest}
+			}
 		}
-		for k, v := range m {
-			if v {
-				if k >= len(commitments) {
-					return nil, &core.RpcError{Err: fmt.Errorf("blob index %d is more than blob kzg commitments :%dd", k, len(commitments)), Reason: core.BadRequest}
-				}
-				indices = append(indices, uint64(k))
+		for i := range commitments {
+			if (i < len(stored) && stored[i]) || p.ExecutionReconstructor != nil {
+				indices = append(indices, uint64(i))
 			}
 		}
 	}
+	reconstructed, err := p.reconstructMissingBlobs(ctx, b, blockRoot, indices, stored, len(commitments))
+	if err != nil {
+		log.WithError(err).WithField("blockRoot", hexutil.Encode(root)).Warn("Could not reconstruct blobs from the execution client")
+	}
 	// returns empty slice if there are no indices
-	blobs := make([]*blocks.VerifiedROBlob, len(indices))
-	for i, index := range indices {
-		vblob, err := p.BlobStorage.Get(bytesutil.ToBytes32(root), index)
+	blobs := make([]*blocks.VerifiedROBlob, 0, len(indices))
+	for _, index := range indices {
+		if vblob, ok := reconstructed[index]; ok {
+			blobs = append(blobs, &vblob)
+			continue
+		}
+		if all && (index >= uint64(len(stored)) || !stored[index]) {
+			continue
+		}
+		vblob, err := p.BlobStorage.Get(blockRoot, index)
 		if err != nil {
 			log.WithFields(log.Fields{
 				"blockRoot": hexutil.Encode(root),
@@ -262,7 +282,44 @@ func (p *BeaconDbBlocker) Blobs(ctx context.Context, id string, indices []uint64
 			}).Error(errors.Wrapf(err, "could not retrieve blob for block root %#x at index %d", root, index))
 			return nil, &core.RpcError{Err: fmt.Errorf("could not retrieve blob for block root %#x at index %d", root, index), Reason: core.Internal}
 		}
-		blobs[i] = &vblob
+		blobs = append(blobs, &vblob)
 	}
 	return blobs, nil
 }
+
+// reconstructMissingBlobs fetches the requested blobs that are missing from blob storage from the execution client,
+// keyed by blob index. Blobs the execution client does not have are left out of the result.
+func (p *BeaconDbBlocker) reconstructMissingBlobs(ctx context.Context, b interfaces.ReadOnlySignedBeaconBlock, root [32]byte, indices []uint64, stored []bool, commitments int) (map[uint64]blocks.VerifiedROBlob, error) {
+	if p.ExecutionReconstructor == nil || len(indices) == 0 {
+		return nil, nil
+	}
+	// Mark everything as existing except for the requested blobs that are not in storage,
+	// so that only those are requested from the execution client.
+	exists := make([]bool, commitments)
+	for i := range exists {
+		exists[i] = true
+	}
+	missing := false
+	for _, index := range indices {
+		if index >= uint64(commitments) {
+			continue
+		}
+		if index < uint64(len(stored)) && stored[index] {
+			continue
+		}
+		exists[index] = false
+		missing = true
+	}
+	if !missing {
+		return nil, nil
+	}
+	vblobs, err := p.ExecutionReconstructor.ReconstructBlobSidecars(ctx, b, root, exists)
+	if err != nil {
+		return nil, err
+	}
+	reconstructed := make(map[uint64]blocks.VerifiedROBlob, len(vblobs))
+	for _, vblob := range vblobs {
+		reconstructed[vblob.Index] = vblob
+	}
+	return reconstructed, nil
+}
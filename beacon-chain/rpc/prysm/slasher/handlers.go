@@ -0,0 +1,245 @@
+package slasher
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/eth/shared"
+	beaconslasher "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// ListSlashings retrieves the attester and proposer slashings detected by the slasher
+// in the requested epoch range, which defaults to the whole history kept by the slasher.
+func (s *Server) ListSlashings(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "slasher.ListSlashings")
+	defer span.End()
+
+	startEpoch, endEpoch, ok := s.epochRange(w, r)
+	if !ok {
+		return
+	}
+
+	attSlashings, err := s.Slasher.AttesterSlashings(ctx, startEpoch, endEpoch)
+	if err != nil {
+		httputil.HandleError(w, "Could not get attester slashings: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	attSlashingStructs, err := attesterSlashingsToStructs(attSlashings)
+	if err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	propSlashings, err := s.Slasher.ProposerSlashings(ctx, startEpoch, endEpoch)
+	if err != nil {
+		httputil.HandleError(w, "Could not get proposer slashings: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	httputil.WriteJson(w, &structs.GetSlasherSlashingsResponse{
+		AttesterSlashings: attSlashingStructs,
+		ProposerSlashings: structs.ProposerSlashingsFromConsensus(propSlashings),
+	})
+}
+
+// ListAttestations retrieves the attestations of a validator stored by the slasher with a target epoch
+// in the requested epoch range, which defaults to the whole history kept by the slasher.
+func (s *Server) ListAttestations(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "slasher.ListAttestations")
+	defer span.End()
+
+	_, validatorIdx, ok := shared.UintFromRoute(w, r, "validator_index")
+	if !ok {
+		return
+	}
+	startEpoch, endEpoch, ok := s.epochRange(w, r)
+	if !ok {
+		return
+	}
+
+	records, err := s.Slasher.AttestationRecords(ctx, primitives.ValidatorIndex(validatorIdx), startEpoch, endEpoch)
+	if err != nil {
+		httputil.HandleError(w, "Could not get attestation records: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := make([]*structs.SlasherAttestation, len(records))
+	for i, record := range records {
+		data[i] = &structs.SlasherAttestation{
+			DataRoot:    hexutil.Encode(record.DataRoot[:]),
+			Attestation: structs.IndexedAttFromConsensus(record.IndexedAttestation),
+		}
+	}
+	httputil.WriteJson(w, &structs.GetSlasherAttestationsResponse{Data: data})
+}
+
+// ListProposals retrieves the block proposals of a validator stored by the slasher with a slot
+// in the requested epoch range, which defaults to the whole history kept by the slasher.
+func (s *Server) ListProposals(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "slasher.ListProposals")
+	defer span.End()
+
+	_, validatorIdx, ok := shared.UintFromRoute(w, r, "validator_index")
+	if !ok {
+		return
+	}
+	startEpoch, endEpoch, ok := s.epochRange(w, r)
+	if !ok {
+		return
+	}
+
+	records, err := s.Slasher.BlockProposals(ctx, primitives.ValidatorIndex(validatorIdx), startEpoch, endEpoch)
+	if err != nil {
+		httputil.HandleError(w, "Could not get proposal records: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := make([]*structs.SlasherProposal, len(records))
+	for i, record := range records {
+		data[i] = &structs.SlasherProposal{
+			HeaderRoot: hexutil.Encode(record.HeaderRoot[:]),
+			Header:     structs.SignedBeaconBlockHeaderFromConsensus(record.SignedBeaconBlockHeader),
+		}
+	}
+	httputil.WriteJson(w, &structs.GetSlasherProposalsResponse{Data: data})
+}
+
+// CheckSlashableAttestation checks whether the submitted indexed attestation would be slashable
+// given the attestations and spans currently stored by the slasher, and returns the corresponding
+// attester slashings. The attestation is not recorded, and its signature is not verified.
+// The Electra indexed attestation has the same JSON shape as the earlier one, and is told apart by the
+// Eth-Consensus-Version header, or by the fork of its target epoch when the header is missing.
+func (s *Server) CheckSlashableAttestation(w http.ResponseWriter, r *http.Request) {
+	ctx, span := trace.StartSpan(r.Context(), "slasher.CheckSlashableAttestation")
+	defer span.End()
+
+	var req structs.IndexedAttestation
+	err := json.NewDecoder(r.Body).Decode(&req)
+	switch {
+	case errors.Is(err, io.EOF):
+		httputil.HandleError(w, "No data submitted", http.StatusBadRequest)
+		return
+	case err != nil:
+		httputil.HandleError(w, "Could not decode request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Data == nil || req.Data.Source == nil || req.Data.Target == nil {
+		httputil.HandleError(w, "Attestation data is required", http.StatusBadRequest)
+		return
+	}
+	phase0Att, err := req.ToConsensus()
+	if err != nil {
+		httputil.HandleError(w, "Could not convert request attestation to consensus attestation: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	v := slots.ToForkVersion(slots.UnsafeEpochStart(phase0Att.Data.Target.Epoch))
+	if versionHeader := r.Header.Get(api.VersionHeader); versionHeader != "" {
+		v, err = version.FromString(versionHeader)
+		if err != nil {
+			httputil.HandleError(w, "Invalid version: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	var att ethpb.IndexedAtt = phase0Att
+	if v >= version.Electra {
+		att = &ethpb.IndexedAttestationElectra{
+			AttestingIndices: phase0Att.AttestingIndices,
+			Data:             phase0Att.Data,
+			Signature:        phase0Att.Signature,
+		}
+	}
+	if err := attestation.IsValidAttestationIndices(ctx, att); err != nil {
+		httputil.HandleError(w, "Invalid attestation: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	slashings, err := s.Slasher.IsSlashableAttestation(ctx, att)
+	if errors.Is(err, beaconslasher.ErrInvalidAttestation) {
+		httputil.HandleError(w, "Invalid attestation: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		httputil.HandleError(w, "Could not check attestation: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	slashingStructs, err := attesterSlashingsToStructs(slashings)
+	if err != nil {
+		httputil.HandleError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	httputil.WriteJson(w, &structs.CheckSlashableAttestationResponse{
+		Slashable:         len(slashings) > 0,
+		AttesterSlashings: slashingStructs,
+	})
+}
+
+// epochRange parses the optional start_epoch and end_epoch query parameters. The end epoch defaults
+// to the current epoch and the start epoch to the oldest epoch of the history kept by the slasher.
+func (s *Server) epochRange(w http.ResponseWriter, r *http.Request) (primitives.Epoch, primitives.Epoch, bool) {
+	historyLength := s.Slasher.HistoryLength()
+
+	rawEnd, end, ok := shared.UintFromQuery(w, r, "end_epoch", false)
+	if !ok {
+		return 0, 0, false
+	}
+	endEpoch := primitives.Epoch(end)
+	if rawEnd == "" {
+		endEpoch = slots.ToEpoch(s.GenesisTimeFetcher.CurrentSlot())
+	}
+
+	rawStart, start, ok := shared.UintFromQuery(w, r, "start_epoch", false)
+	if !ok {
+		return 0, 0, false
+	}
+	startEpoch := primitives.Epoch(start)
+	if rawStart == "" {
+		startEpoch = 0
+		if endEpoch >= historyLength {
+			startEpoch = endEpoch - historyLength + 1
+		}
+	}
+
+	if startEpoch > endEpoch {
+		httputil.HandleError(w, fmt.Sprintf("Start epoch %d is greater than end epoch %d", startEpoch, endEpoch), http.StatusBadRequest)
+		return 0, 0, false
+	}
+	if endEpoch-startEpoch >= historyLength {
+		httputil.HandleError(w, fmt.Sprintf("Epoch range cannot exceed the slasher history length of %d epochs", historyLength), http.StatusBadRequest)
+		return 0, 0, false
+	}
+	return startEpoch, endEpoch, true
+}
+
+func attesterSlashingsToStructs(slashings []ethpb.AttSlashing) ([]*structs.SlasherAttesterSlashing, error) {
+	result := make([]*structs.SlasherAttesterSlashing, len(slashings))
+	for i, slashing := range slashings {
+		var slashingStruct interface{}
+		switch s := slashing.(type) {
+		case *ethpb.AttesterSlashing:
+			slashingStruct = structs.AttesterSlashingFromConsensus(s)
+		case *ethpb.AttesterSlashingElectra:
+			slashingStruct = structs.AttesterSlashingElectraFromConsensus(s)
+		default:
+			return nil, fmt.Errorf("unsupported attester slashing type %T", slashing)
+		}
+		data, err := json.Marshal(slashingStruct)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal slashing")
+		}
+		result[i] = &structs.SlasherAttesterSlashing{
+			Version: version.String(slashing.Version()),
+			Data:    data,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,348 @@
+package slasher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/api"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	beaconslasher "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type mockSlasher struct {
+	attesterSlashings  []ethpb.AttSlashing
+	proposerSlashings  []*ethpb.ProposerSlashing
+	attestations       []*slashertypes.IndexedAttestationWrapper
+	proposals          []*slashertypes.SignedBlockHeaderWrapper
+	slashable          []ethpb.AttSlashing
+	slashableErr       error
+	validatorIdx       primitives.ValidatorIndex
+	startEpoch         primitives.Epoch
+	endEpoch           primitives.Epoch
+	checkedAttestation ethpb.IndexedAtt
+}
+
+func (m *mockSlasher) HistoryLength() primitives.Epoch {
+	return 4096
+}
+
+func (m *mockSlasher) AttesterSlashings(_ context.Context, startEpoch, endEpoch primitives.Epoch) ([]ethpb.AttSlashing, error) {
+	m.startEpoch, m.endEpoch = startEpoch, endEpoch
+	return m.attesterSlashings, nil
+}
+
+func (m *mockSlasher) ProposerSlashings(_ context.Context, startEpoch, endEpoch primitives.Epoch) ([]*ethpb.ProposerSlashing, error) {
+	m.startEpoch, m.endEpoch = startEpoch, endEpoch
+	return m.proposerSlashings, nil
+}
+
+func (m *mockSlasher) AttestationRecords(
+	_ context.Context, validatorIdx primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch,
+) ([]*slashertypes.IndexedAttestationWrapper, error) {
+	m.validatorIdx, m.startEpoch, m.endEpoch = validatorIdx, startEpoch, endEpoch
+	return m.attestations, nil
+}
+
+func (m *mockSlasher) BlockProposals(
+	_ context.Context, validatorIdx primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch,
+) ([]*slashertypes.SignedBlockHeaderWrapper, error) {
+	m.validatorIdx, m.startEpoch, m.endEpoch = validatorIdx, startEpoch, endEpoch
+	return m.proposals, nil
+}
+
+func (m *mockSlasher) IsSlashableAttestation(_ context.Context, att ethpb.IndexedAtt) ([]ethpb.AttSlashing, error) {
+	m.checkedAttestation = att
+	return m.slashable, m.slashableErr
+}
+
+func TestListSlashings(t *testing.T) {
+	currentSlot := primitives.Slot(10_000 * 32)
+	attSlashing := &ethpb.AttesterSlashing{
+		Attestation_1: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1}}),
+		Attestation_2: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1}, Data: &ethpb.AttestationData{Slot: 1}}),
+	}
+	electraAtt1 := util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{2}})
+	electraAtt2 := util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{2}, Data: &ethpb.AttestationData{Slot: 1}})
+	attSlashingElectra := &ethpb.AttesterSlashingElectra{
+		Attestation_1: &ethpb.IndexedAttestationElectra{
+			AttestingIndices: electraAtt1.AttestingIndices,
+			Data:             electraAtt1.Data,
+			Signature:        electraAtt1.Signature,
+		},
+		Attestation_2: &ethpb.IndexedAttestationElectra{
+			AttestingIndices: electraAtt2.AttestingIndices,
+			Data:             electraAtt2.Data,
+			Signature:        electraAtt2.Signature,
+		},
+	}
+	propSlashing := &ethpb.ProposerSlashing{
+		Header_1: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+		Header_2: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{Slot: 1}}),
+	}
+	slasher := &mockSlasher{
+		attesterSlashings: []ethpb.AttSlashing{attSlashing, attSlashingElectra},
+		proposerSlashings: []*ethpb.ProposerSlashing{propSlashing},
+	}
+	s := &Server{
+		Slasher:            slasher,
+		GenesisTimeFetcher: &mock.ChainService{Slot: &currentSlot},
+	}
+
+	t.Run("default range", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/slashings", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.ListSlashings(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, primitives.Epoch(10_000-4096+1), slasher.startEpoch)
+		assert.Equal(t, primitives.Epoch(10_000), slasher.endEpoch)
+
+		resp := &structs.GetSlasherSlashingsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.AttesterSlashings))
+		assert.Equal(t, "phase0", resp.AttesterSlashings[0].Version)
+		phase0 := &structs.AttesterSlashing{}
+		require.NoError(t, json.Unmarshal(resp.AttesterSlashings[0].Data, phase0))
+		assert.DeepEqual(t, []string{"1"}, phase0.Attestation1.AttestingIndices)
+		assert.Equal(t, "electra", resp.AttesterSlashings[1].Version)
+		electra := &structs.AttesterSlashingElectra{}
+		require.NoError(t, json.Unmarshal(resp.AttesterSlashings[1].Data, electra))
+		assert.DeepEqual(t, []string{"2"}, electra.Attestation1.AttestingIndices)
+		require.Equal(t, 1, len(resp.ProposerSlashings))
+		assert.Equal(t, "1", resp.ProposerSlashings[0].SignedHeader2.Message.Slot)
+	})
+	t.Run("requested range", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/slashings?start_epoch=5&end_epoch=7", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.ListSlashings(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, primitives.Epoch(5), slasher.startEpoch)
+		assert.Equal(t, primitives.Epoch(7), slasher.endEpoch)
+	})
+	t.Run("invalid range", func(t *testing.T) {
+		for _, query := range []string{"start_epoch=8&end_epoch=7", "start_epoch=0&end_epoch=4096", "start_epoch=foo"} {
+			request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/slashings?"+query, nil)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+
+			s.ListSlashings(writer, request)
+			require.Equal(t, http.StatusBadRequest, writer.Code)
+			e := &httputil.DefaultJsonError{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+			assert.Equal(t, http.StatusBadRequest, e.Code)
+		}
+	})
+}
+
+func TestListAttestations(t *testing.T) {
+	currentSlot := primitives.Slot(64)
+	att := util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{3, 7}})
+	slasher := &mockSlasher{
+		attestations: []*slashertypes.IndexedAttestationWrapper{{IndexedAttestation: att, DataRoot: [32]byte{'a'}}},
+	}
+	s := &Server{
+		Slasher:            slasher,
+		GenesisTimeFetcher: &mock.ChainService{Slot: &currentSlot},
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/validators/7/attestations", nil)
+	request.SetPathValue("validator_index", "7")
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.ListAttestations(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, primitives.ValidatorIndex(7), slasher.validatorIdx)
+	assert.Equal(t, primitives.Epoch(0), slasher.startEpoch)
+	assert.Equal(t, primitives.Epoch(2), slasher.endEpoch)
+
+	resp := &structs.GetSlasherAttestationsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Data))
+	assert.Equal(t, "0x6100000000000000000000000000000000000000000000000000000000000000", resp.Data[0].DataRoot)
+	assert.DeepEqual(t, []string{"3", "7"}, resp.Data[0].Attestation.AttestingIndices)
+
+	request = httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/validators/foo/attestations", nil)
+	request.SetPathValue("validator_index", "foo")
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.ListAttestations(writer, request)
+	require.Equal(t, http.StatusBadRequest, writer.Code)
+}
+
+func TestListProposals(t *testing.T) {
+	currentSlot := primitives.Slot(64)
+	header := util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{Slot: 33, ProposerIndex: 4}})
+	slasher := &mockSlasher{
+		proposals: []*slashertypes.SignedBlockHeaderWrapper{{SignedBeaconBlockHeader: header, HeaderRoot: [32]byte{'b'}}},
+	}
+	s := &Server{
+		Slasher:            slasher,
+		GenesisTimeFetcher: &mock.ChainService{Slot: &currentSlot},
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://example.com/prysm/v1/slasher/validators/4/proposals?start_epoch=1&end_epoch=1", nil)
+	request.SetPathValue("validator_index", "4")
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+
+	s.ListProposals(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, primitives.ValidatorIndex(4), slasher.validatorIdx)
+	assert.Equal(t, primitives.Epoch(1), slasher.startEpoch)
+	assert.Equal(t, primitives.Epoch(1), slasher.endEpoch)
+
+	resp := &structs.GetSlasherProposalsResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Data))
+	assert.Equal(t, "0x6200000000000000000000000000000000000000000000000000000000000000", resp.Data[0].HeaderRoot)
+	assert.Equal(t, "33", resp.Data[0].Header.Message.Slot)
+	assert.Equal(t, "4", resp.Data[0].Header.Message.ProposerIndex)
+}
+
+func TestCheckSlashableAttestation(t *testing.T) {
+	att := util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{1},
+		Data: &ethpb.AttestationData{
+			Source: &ethpb.Checkpoint{Epoch: 1},
+			Target: &ethpb.Checkpoint{Epoch: 2},
+		},
+	})
+	body, err := json.Marshal(structs.IndexedAttFromConsensus(att))
+	require.NoError(t, err)
+
+	t.Run("slashable", func(t *testing.T) {
+		slashing := &ethpb.AttesterSlashing{
+			Attestation_1: att,
+			Attestation_2: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1}}),
+		}
+		slasher := &mockSlasher{slashable: []ethpb.AttSlashing{slashing}}
+		s := &Server{Slasher: slasher}
+
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/attestations/slashable", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.CheckSlashableAttestation(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.DeepEqual(t, att, slasher.checkedAttestation)
+		resp := &structs.CheckSlashableAttestationResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, true, resp.Slashable)
+		require.Equal(t, 1, len(resp.AttesterSlashings))
+		assert.Equal(t, "phase0", resp.AttesterSlashings[0].Version)
+	})
+	t.Run("not slashable", func(t *testing.T) {
+		s := &Server{Slasher: &mockSlasher{}}
+
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/attestations/slashable", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.CheckSlashableAttestation(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &structs.CheckSlashableAttestationResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, false, resp.Slashable)
+		assert.Equal(t, 0, len(resp.AttesterSlashings))
+	})
+	t.Run("invalid attestation", func(t *testing.T) {
+		s := &Server{Slasher: &mockSlasher{slashableErr: beaconslasher.ErrInvalidAttestation}}
+
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/attestations/slashable", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.CheckSlashableAttestation(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "Invalid attestation", e.Message)
+	})
+	t.Run("electra", func(t *testing.T) {
+		slasher := &mockSlasher{}
+		s := &Server{Slasher: slasher}
+
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/attestations/slashable", bytes.NewReader(body))
+		request.Header.Set(api.VersionHeader, version.String(version.Electra))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.CheckSlashableAttestation(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.DeepEqual(t, &ethpb.IndexedAttestationElectra{
+			AttestingIndices: att.AttestingIndices,
+			Data:             att.Data,
+			Signature:        att.Signature,
+		}, slasher.checkedAttestation)
+	})
+	t.Run("invalid attesting indices", func(t *testing.T) {
+		tooMany := make([]uint64, params.BeaconConfig().MaxValidatorsPerCommittee+1)
+		for i := range tooMany {
+			tooMany[i] = uint64(i)
+		}
+		tests := []struct {
+			name    string
+			indices []uint64
+			want    string
+		}{
+			{name: "unsorted", indices: []uint64{2, 1}, want: "attesting indices is not uniquely sorted"},
+			{name: "duplicate", indices: []uint64{1, 1}, want: "attesting indices is not uniquely sorted"},
+			{name: "too many", indices: tooMany, want: "validator indices count exceeds MAX_VALIDATORS_PER_COMMITTEE"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				slasher := &mockSlasher{}
+				s := &Server{Slasher: slasher}
+				invalid := util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{
+					AttestingIndices: tt.indices,
+					Data:             att.Data,
+				})
+				body, err := json.Marshal(structs.IndexedAttFromConsensus(invalid))
+				require.NoError(t, err)
+
+				request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/attestations/slashable", bytes.NewReader(body))
+				writer := httptest.NewRecorder()
+				writer.Body = &bytes.Buffer{}
+
+				s.CheckSlashableAttestation(writer, request)
+				require.Equal(t, http.StatusBadRequest, writer.Code)
+				e := &httputil.DefaultJsonError{}
+				require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+				assert.StringContains(t, tt.want, e.Message)
+				assert.Equal(t, nil, slasher.checkedAttestation)
+			})
+		}
+	})
+	t.Run("no body", func(t *testing.T) {
+		s := &Server{Slasher: &mockSlasher{}}
+
+		request := httptest.NewRequest(http.MethodPost, "http://example.com/prysm/v1/slasher/attestations/slashable", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.CheckSlashableAttestation(writer, request)
+		require.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &httputil.DefaultJsonError{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "No data submitted", e.Message)
+	})
+}
@@ -0,0 +1,30 @@
+package slasher
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+// Slasher gives read-only access to the slashings, records and spans of the slasher service.
+type Slasher interface {
+	HistoryLength() primitives.Epoch
+	AttesterSlashings(ctx context.Context, startEpoch, endEpoch primitives.Epoch) ([]ethpb.AttSlashing, error)
+	ProposerSlashings(ctx context.Context, startEpoch, endEpoch primitives.Epoch) ([]*ethpb.ProposerSlashing, error)
+	AttestationRecords(
+		ctx context.Context, validatorIdx primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch,
+	) ([]*slashertypes.IndexedAttestationWrapper, error)
+	BlockProposals(
+		ctx context.Context, validatorIdx primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch,
+	) ([]*slashertypes.SignedBlockHeaderWrapper, error)
+	IsSlashableAttestation(ctx context.Context, att ethpb.IndexedAtt) ([]ethpb.AttSlashing, error)
+}
+
+// Server defines a server implementation of the slasher endpoints of the Prysm beacon node API.
+type Server struct {
+	Slasher            Slasher
+	GenesisTimeFetcher blockchain.TimeFetcher
+}
@@ -197,16 +197,6 @@ func (vs *Server) getPayloadHeaderFromBuilder(
 		return nil, err
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, blockBuilderTimeout)
-	defer cancel()
-
-	signedBid, err := vs.BlockBuilder.GetHeader(ctx, slot, bytesutil.ToBytes32(h.BlockHash()), pk)
-	if err != nil {
-		return nil, err
-	}
-	if signedBid == nil || signedBid.IsNil() {
-		return nil, errors.New("builder returned nil bid")
-	}
 	fork, err := forks.Fork(slots.ToEpoch(slot))
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get fork information")
@@ -215,76 +205,112 @@ func (vs *Server) getPayloadHeaderFromBuilder(
 	if !ok {
 		return nil, errors.New("unable to find current fork in schedule")
 	}
-	if !strings.EqualFold(version.String(signedBid.Version()), forkName) {
-		return nil, fmt.Errorf("builder bid response version: %d is different from head block version: %d for epoch %d", signedBid.Version(), b.Version(), slots.ToEpoch(slot))
-	}
 
-	bid, err := signedBid.Message()
+	var gasLimit uint64
+	reg, err := vs.BlockBuilder.RegistrationByValidatorID(ctx, idx)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not get bid")
-	}
-	if bid == nil || bid.IsNil() {
-		return nil, errors.New("builder returned nil bid")
-	}
-
-	v := bid.Value()
-	if big.NewInt(0).Cmp(v) == 0 {
-		return nil, errors.New("builder returned header with 0 bid amount")
+		log.WithError(err).Warn("Proposer: failed to get registration by validator ID, could not check gas limit")
+	} else {
+		gasLimit = expectedGasLimit(parentGasLimit, reg.GasLimit)
 	}
 
-	header, err := bid.Header()
-	if err != nil {
-		return nil, errors.Wrap(err, "could not get bid header")
-	}
-	txRoot, err := header.TransactionsRoot()
+	t, err := slots.ToTime(uint64(vs.TimeFetcher.GenesisTime().Unix()), slot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not get transaction root")
-	}
-	if bytesutil.ToBytes32(txRoot) == emptyTransactionsRoot {
-		return nil, errors.New("builder returned header with an empty tx root")
+		return nil, err
 	}
 
-	if !bytes.Equal(header.ParentHash(), h.BlockHash()) {
-		return nil, fmt.Errorf("incorrect parent hash %#x != %#x", header.ParentHash(), h.BlockHash())
-	}
+	// Every relay's bid goes through these checks before the bids are compared, so that an invalid bid can't win
+	// the auction over a valid one.
+	validate := func(signedBid builder.SignedBid) error {
+		if signedBid == nil || signedBid.IsNil() {
+			return errors.New("builder returned nil bid")
+		}
+		if !strings.EqualFold(version.String(signedBid.Version()), forkName) {
+			return fmt.Errorf("builder bid response version: %d is different from head block version: %d for epoch %d", signedBid.Version(), b.Version(), slots.ToEpoch(slot))
+		}
 
-	reg, err := vs.BlockBuilder.RegistrationByValidatorID(ctx, idx)
-	if err != nil {
-		log.WithError(err).Warn("Proposer: failed to get registration by validator ID, could not check gas limit")
-	} else {
-		gasLimit := expectedGasLimit(parentGasLimit, reg.GasLimit)
-		if gasLimit != header.GasLimit() {
-			return nil, fmt.Errorf("incorrect header gas limit %d != %d", gasLimit, header.GasLimit())
+		bid, err := signedBid.Message()
+		if err != nil {
+			return errors.Wrap(err, "could not get bid")
+		}
+		if bid == nil || bid.IsNil() {
+			return errors.New("builder returned nil bid")
+		}
+
+		if big.NewInt(0).Cmp(bid.Value()) == 0 {
+			return errors.New("builder returned header with 0 bid amount")
 		}
+
+		header, err := bid.Header()
+		if err != nil {
+			return errors.Wrap(err, "could not get bid header")
+		}
+		txRoot, err := header.TransactionsRoot()
+		if err != nil {
+			return errors.Wrap(err, "could not get transaction root")
+		}
+		if bytesutil.ToBytes32(txRoot) == emptyTransactionsRoot {
+			return errors.New("builder returned header with an empty tx root")
+		}
+
+		if !bytes.Equal(header.ParentHash(), h.BlockHash()) {
+			return fmt.Errorf("incorrect parent hash %#x != %#x", header.ParentHash(), h.BlockHash())
+		}
+
+		if reg != nil && gasLimit != header.GasLimit() {
+			return fmt.Errorf("incorrect header gas limit %d != %d", gasLimit, header.GasLimit())
+		}
+
+		if header.Timestamp() != uint64(t.Unix()) {
+			return fmt.Errorf("incorrect timestamp %d != %d", header.Timestamp(), uint64(t.Unix()))
+		}
+
+		if err := validateBuilderSignature(signedBid); err != nil {
+			return errors.Wrap(err, "could not validate builder signature")
+		}
+
+		if bid.Version() >= version.Deneb {
+			kzgCommitments, err := bid.BlobKzgCommitments()
+			if err != nil {
+				return errors.Wrap(err, "could not get blob kzg commitments")
+			}
+			if len(kzgCommitments) > params.BeaconConfig().MaxBlobsPerBlock(slot) {
+				return fmt.Errorf("builder returned too many kzg commitments: %d", len(kzgCommitments))
+			}
+			for _, c := range kzgCommitments {
+				if len(c) != fieldparams.BLSPubkeyLength {
+					return fmt.Errorf("builder returned invalid kzg commitment length: %d", len(c))
+				}
+			}
+		}
+		return nil
 	}
 
-	t, err := slots.ToTime(uint64(vs.TimeFetcher.GenesisTime().Unix()), slot)
+	ctx, cancel := context.WithTimeout(ctx, blockBuilderTimeout)
+	defer cancel()
+
+	signedBid, err := vs.BlockBuilder.GetHeader(ctx, slot, bytesutil.ToBytes32(h.BlockHash()), pk, validate)
 	if err != nil {
 		return nil, err
 	}
-	if header.Timestamp() != uint64(t.Unix()) {
-		return nil, fmt.Errorf("incorrect timestamp %d != %d", header.Timestamp(), uint64(t.Unix()))
+	if signedBid == nil || signedBid.IsNil() {
+		return nil, errors.New("builder returned nil bid")
 	}
-
-	if err := validateBuilderSignature(signedBid); err != nil {
-		return nil, errors.Wrap(err, "could not validate builder signature")
+	bid, err := signedBid.Message()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get bid")
+	}
+	v := bid.Value()
+	header, err := bid.Header()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get bid header")
 	}
-
-	maxBlobsPerBlock := params.BeaconConfig().MaxBlobsPerBlock(slot)
 	var kzgCommitments [][]byte
 	if bid.Version() >= version.Deneb {
 		kzgCommitments, err = bid.BlobKzgCommitments()
 		if err != nil {
 			return nil, errors.Wrap(err, "could not get blob kzg commitments")
 		}
-		if len(kzgCommitments) > maxBlobsPerBlock {
-			return nil, fmt.Errorf("builder returned too many kzg commitments: %d", len(kzgCommitments))
-		}
-		for _, c := range kzgCommitments {
-			if len(c) != fieldparams.BLSPubkeyLength {
-				return nil, fmt.Errorf("builder returned invalid kzg commitment length: %d", len(c))
-			}
-		}
 	}
 
 	l := log.WithFields(logrus.Fields{
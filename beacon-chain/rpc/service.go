@@ -37,6 +37,7 @@ import (
 	debugv1alpha1 "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/v1alpha1/debug"
 	nodev1alpha1 "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/v1alpha1/node"
 	validatorv1alpha1 "github.com/prysmaticlabs/prysm/v5/beacon-chain/rpc/prysm/v1alpha1/validator"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
 	chainSync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
@@ -99,6 +100,7 @@ type Config struct {
 	AttestationsPool          attestations.Pool
 	ExitPool                  voluntaryexits.PoolManager
 	SlashingsPool             slashings.PoolManager
+	SlasherService            *slasher.Service
 	SyncCommitteeObjectPool   synccommittee.Pool
 	BLSChangesPool            blstoexec.PoolManager
 	SyncService               chainSync.Checker
@@ -180,8 +182,11 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 	if s.cfg.StateGen != nil {
 		stateCache = s.cfg.StateGen.CombinedCache()
 	}
-	withCache := stategen.WithCache(stateCache)
-	ch := stategen.NewCanonicalHistory(s.cfg.BeaconDB, s.cfg.ChainInfoFetcher, s.cfg.ChainInfoFetcher, withCache)
+	chOpts := []stategen.CanonicalHistoryOption{stategen.WithCache(stateCache)}
+	if s.cfg.BeaconDB != nil && s.cfg.BeaconDB.StateDiffInterval() > 0 {
+		chOpts = append(chOpts, stategen.WithHierarchicalStates(s.cfg.BeaconDB))
+	}
+	ch := stategen.NewCanonicalHistory(s.cfg.BeaconDB, s.cfg.ChainInfoFetcher, s.cfg.ChainInfoFetcher, chOpts...)
 	stater := &lookup.BeaconDbStater{
 		BeaconDB:           s.cfg.BeaconDB,
 		ChainInfoFetcher:   s.cfg.ChainInfoFetcher,
@@ -190,10 +195,11 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		ReplayerBuilder:    ch,
 	}
 	blocker := &lookup.BeaconDbBlocker{
-		BeaconDB:           s.cfg.BeaconDB,
-		ChainInfoFetcher:   s.cfg.ChainInfoFetcher,
-		GenesisTimeFetcher: s.cfg.GenesisTimeFetcher,
-		BlobStorage:        s.cfg.BlobStorage,
+		BeaconDB:               s.cfg.BeaconDB,
+		ChainInfoFetcher:       s.cfg.ChainInfoFetcher,
+		GenesisTimeFetcher:     s.cfg.GenesisTimeFetcher,
+		BlobStorage:            s.cfg.BlobStorage,
+		ExecutionReconstructor: s.cfg.ExecutionReconstructor,
 	}
 	rewardFetcher := &rewards.BlockRewardService{Replayer: ch, DB: s.cfg.BeaconDB}
 	coreService := &core.Service{
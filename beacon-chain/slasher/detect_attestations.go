@@ -242,37 +242,9 @@ func (s *Service) checkDoubleVotes(
 	for _, doubleVote := range doubleVotes {
 		doubleVotesTotal.Inc()
 
-		wrapper_1 := doubleVote.Wrapper_1
-		wrapper_2 := doubleVote.Wrapper_2
-
-		att_1, ok := wrapper_1.IndexedAttestation.(*ethpb.IndexedAttestation)
-		if !ok {
-			return nil, fmt.Errorf(
-				"first attestation has wrong type (expected %T, got %T)",
-				&ethpb.IndexedAttestation{},
-				wrapper_1.IndexedAttestation,
-			)
-		}
-		att_2, ok := wrapper_2.IndexedAttestation.(*ethpb.IndexedAttestation)
-		if !ok {
-			return nil, fmt.Errorf(
-				"second attestation has wrong type (expected %T, got %T)",
-				&ethpb.IndexedAttestation{},
-				wrapper_2.IndexedAttestation,
-			)
-		}
-
-		slashing := &ethpb.AttesterSlashing{
-			Attestation_1: att_1,
-			Attestation_2: att_2,
-		}
-
-		// Ensure the attestation with the lower data root is the first attestation.
-		if bytes.Compare(wrapper_1.DataRoot[:], wrapper_2.DataRoot[:]) > 0 {
-			slashing = &ethpb.AttesterSlashing{
-				Attestation_1: att_2,
-				Attestation_2: att_1,
-			}
+		slashing, err := attesterSlashingForDoubleVote(doubleVote)
+		if err != nil {
+			return nil, err
 		}
 
 		root, err := slashing.HashTreeRoot()
@@ -286,6 +258,42 @@ func (s *Service) checkDoubleVotes(
 	return slashings, nil
 }
 
+// attesterSlashingForDoubleVote builds the attester slashing corresponding to a double vote found in the database.
+func attesterSlashingForDoubleVote(doubleVote *slashertypes.AttesterDoubleVote) (*ethpb.AttesterSlashing, error) {
+	wrapper_1 := doubleVote.Wrapper_1
+	wrapper_2 := doubleVote.Wrapper_2
+
+	att_1, ok := wrapper_1.IndexedAttestation.(*ethpb.IndexedAttestation)
+	if !ok {
+		return nil, fmt.Errorf(
+			"first attestation has wrong type (expected %T, got %T)",
+			&ethpb.IndexedAttestation{},
+			wrapper_1.IndexedAttestation,
+		)
+	}
+	att_2, ok := wrapper_2.IndexedAttestation.(*ethpb.IndexedAttestation)
+	if !ok {
+		return nil, fmt.Errorf(
+			"second attestation has wrong type (expected %T, got %T)",
+			&ethpb.IndexedAttestation{},
+			wrapper_2.IndexedAttestation,
+		)
+	}
+
+	// Ensure the attestation with the lower data root is the first attestation.
+	if bytes.Compare(wrapper_1.DataRoot[:], wrapper_2.DataRoot[:]) > 0 {
+		return &ethpb.AttesterSlashing{
+			Attestation_1: att_2,
+			Attestation_2: att_1,
+		}, nil
+	}
+
+	return &ethpb.AttesterSlashing{
+		Attestation_1: att_1,
+		Attestation_2: att_2,
+	}, nil
+}
+
 // updatedChunkByChunkIndex loads the chunks from the database for validators corresponding to
 // the `validatorChunkIndex`.
 // It then updates the chunks with the neutral element for corresponding validators from
@@ -561,6 +569,11 @@ func (s *Service) applyAttestationForValidator(
 		)
 	}
 	if slashing != nil {
+		if chunkKind == slashertypes.MinSpan {
+			surroundingVotesTotal.Inc()
+		} else {
+			surroundedVotesTotal.Inc()
+		}
 		return slashing, nil
 	}
 
@@ -8,10 +8,11 @@ import (
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"golang.org/x/exp/maps"
 )
 
-// Verifies attester slashings, logs them, and submits them to the slashing operations pool
-// in the beacon node if they pass validation.
+// Verifies attester slashings, logs them, saves them as detected slashings and submits them
+// to the slashing operations pool in the beacon node if they pass validation.
 func (s *Service) processAttesterSlashings(
 	ctx context.Context, slashings map[[fieldparams.RootLength]byte]ethpb.AttSlashing,
 ) (map[[fieldparams.RootLength]byte]ethpb.AttSlashing, error) {
@@ -22,6 +23,12 @@ func (s *Service) processAttesterSlashings(
 		return processedSlashings, nil
 	}
 
+	if s.serviceCfg.RemoteChain != nil {
+		// The attestations received by a standalone slasher are verified before they are fed to it.
+		s.saveAttesterSlashings(ctx, maps.Values(slashings))
+		return s.submitAttesterSlashings(ctx, slashings), nil
+	}
+
 	// Get the head state.
 	beaconState, err := s.serviceCfg.HeadStateFetcher.HeadState(ctx)
 	if err != nil {
@@ -56,23 +63,31 @@ func (s *Service) processAttesterSlashings(
 		processedSlashings[root] = slashing
 	}
 
+	s.saveAttesterSlashings(ctx, maps.Values(processedSlashings))
 	return processedSlashings, nil
 }
 
-// Verifies proposer slashings, logs them, and submits them to the slashing operations pool
-// in the beacon node if they pass validation.
+// Verifies proposer slashings, logs them, saves them as detected slashings and submits them
+// to the slashing operations pool in the beacon node if they pass validation.
 func (s *Service) processProposerSlashings(ctx context.Context, slashings []*ethpb.ProposerSlashing) error {
 	// If no slashings, return early.
 	if len(slashings) == 0 {
 		return nil
 	}
 
+	if s.serviceCfg.RemoteChain != nil {
+		s.saveProposerSlashings(ctx, slashings)
+		s.submitProposerSlashings(ctx, slashings)
+		return nil
+	}
+
 	// Get the head state.
 	beaconState, err := s.serviceCfg.HeadStateFetcher.HeadState(ctx)
 	if err != nil {
 		return err
 	}
 
+	verifiedSlashings := make([]*ethpb.ProposerSlashing, 0, len(slashings))
 	for _, slashing := range slashings {
 		// Verify the signature of the first block.
 		if err := s.verifyBlockSignature(ctx, slashing.Header_1); err != nil {
@@ -97,11 +112,65 @@ func (s *Service) processProposerSlashings(ctx context.Context, slashings []*eth
 		if err := s.serviceCfg.SlashingPoolInserter.InsertProposerSlashing(ctx, beaconState, slashing); err != nil {
 			log.WithError(err).Error("Could not insert proposer slashing into operations pool")
 		}
+
+		verifiedSlashings = append(verifiedSlashings, slashing)
 	}
 
+	s.saveProposerSlashings(ctx, verifiedSlashings)
 	return nil
 }
 
+// Saves detected attester slashings so they can be queried later on. Failing to save them
+// does not prevent them from being submitted, so the error is only logged.
+func (s *Service) saveAttesterSlashings(ctx context.Context, slashings []ethpb.AttSlashing) {
+	if len(slashings) == 0 {
+		return
+	}
+	if err := s.serviceCfg.Database.SaveAttesterSlashings(ctx, slashings); err != nil {
+		log.WithError(err).Error("Could not save attester slashings")
+	}
+}
+
+// Saves detected proposer slashings so they can be queried later on. Failing to save them
+// does not prevent them from being submitted, so the error is only logged.
+func (s *Service) saveProposerSlashings(ctx context.Context, slashings []*ethpb.ProposerSlashing) {
+	if len(slashings) == 0 {
+		return
+	}
+	if err := s.serviceCfg.Database.SaveProposerSlashings(ctx, slashings); err != nil {
+		log.WithError(err).Error("Could not save proposer slashings")
+	}
+}
+
+// Logs attester slashings and submits them to the beacon nodes followed by a standalone slasher.
+// The signatures of the attestations received by a standalone slasher are verified before they
+// are fed to it, and the slashings are verified again by the beacon nodes.
+func (s *Service) submitAttesterSlashings(
+	ctx context.Context, slashings map[[fieldparams.RootLength]byte]ethpb.AttSlashing,
+) map[[fieldparams.RootLength]byte]ethpb.AttSlashing {
+	processedSlashings := map[[fieldparams.RootLength]byte]ethpb.AttSlashing{}
+	for root, slashing := range slashings {
+		logAttesterSlashing(slashing)
+		if err := s.serviceCfg.RemoteChain.SubmitAttesterSlashing(ctx, slashing); err != nil {
+			log.WithError(err).Error("Could not submit attester slashing to beacon node")
+			continue
+		}
+		processedSlashings[root] = slashing
+	}
+	return processedSlashings
+}
+
+// Logs proposer slashings and submits them to the beacon nodes followed by a standalone slasher.
+// The block headers received by a standalone slasher are those of blocks imported by the beacon nodes.
+func (s *Service) submitProposerSlashings(ctx context.Context, slashings []*ethpb.ProposerSlashing) {
+	for _, slashing := range slashings {
+		logProposerSlashing(slashing)
+		if err := s.serviceCfg.RemoteChain.SubmitProposerSlashing(ctx, slashing); err != nil {
+			log.WithError(err).Error("Could not submit proposer slashing to beacon node")
+		}
+	}
+}
+
 func (s *Service) verifyBlockSignature(ctx context.Context, header *ethpb.SignedBeaconBlockHeader) error {
 	parentState, err := s.serviceCfg.StateGen.StateByRoot(ctx, bytesutil.ToBytes32(header.Header.ParentRoot))
 	if err != nil {
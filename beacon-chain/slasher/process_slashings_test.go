@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/pkg/errors"
 	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
 	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
@@ -12,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
@@ -267,3 +269,76 @@ func TestService_processProposerSlashings(t *testing.T) {
 		require.LogsDoNotContain(tt, hook, "Invalid signature")
 	})
 }
+
+type mockRemoteChain struct {
+	headSlot          primitives.Slot
+	numValidators     int
+	attesterSlashings []ethpb.AttSlashing
+	proposerSlashings []*ethpb.ProposerSlashing
+	submitErr         error
+}
+
+func (m *mockRemoteChain) HeadSlot() primitives.Slot {
+	return m.headSlot
+}
+
+func (m *mockRemoteChain) NumValidators(_ context.Context) (int, error) {
+	return m.numValidators, nil
+}
+
+func (m *mockRemoteChain) SubmitAttesterSlashing(_ context.Context, slashing ethpb.AttSlashing) error {
+	if m.submitErr != nil {
+		return m.submitErr
+	}
+	m.attesterSlashings = append(m.attesterSlashings, slashing)
+	return nil
+}
+
+func (m *mockRemoteChain) SubmitProposerSlashing(_ context.Context, slashing *ethpb.ProposerSlashing) error {
+	if m.submitErr != nil {
+		return m.submitErr
+	}
+	m.proposerSlashings = append(m.proposerSlashings, slashing)
+	return nil
+}
+
+func TestService_processSlashings_RemoteChain(t *testing.T) {
+	ctx := context.Background()
+	remote := &mockRemoteChain{}
+	s := &Service{
+		serviceCfg: &ServiceConfig{
+			Database:    dbtest.SetupSlasherDB(t),
+			RemoteChain: remote,
+		},
+	}
+
+	attSlashing := &ethpb.AttesterSlashing{
+		Attestation_1: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{0}}),
+		Attestation_2: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{0}}),
+	}
+	root, err := attSlashing.HashTreeRoot()
+	require.NoError(t, err)
+	proposerSlashing := &ethpb.ProposerSlashing{
+		Header_1: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+		Header_2: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+	}
+
+	t.Run("submitted", func(t *testing.T) {
+		processed, err := s.processAttesterSlashings(ctx, map[[fieldparams.RootLength]byte]ethpb.AttSlashing{root: attSlashing})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(processed))
+		require.Equal(t, 1, len(remote.attesterSlashings))
+		require.NoError(t, s.processProposerSlashings(ctx, []*ethpb.ProposerSlashing{proposerSlashing}))
+		require.Equal(t, 1, len(remote.proposerSlashings))
+	})
+	t.Run("submission failed", func(t *testing.T) {
+		hook := logTest.NewGlobal()
+		remote.submitErr = errors.New("unavailable")
+		processed, err := s.processAttesterSlashings(ctx, map[[fieldparams.RootLength]byte]ethpb.AttSlashing{root: attSlashing})
+		require.NoError(t, err)
+		require.Equal(t, 0, len(processed))
+		require.LogsContain(t, hook, "Could not submit attester slashing to beacon node")
+		require.NoError(t, s.processProposerSlashings(ctx, []*ethpb.ProposerSlashing{proposerSlashing}))
+		require.LogsContain(t, hook, "Could not submit proposer slashing to beacon node")
+	})
+}
@@ -0,0 +1,159 @@
+package slasher
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+// ErrInvalidAttestation is returned when checking the slashability of an attestation
+// which could never be processed by the slasher.
+var ErrInvalidAttestation = errors.New("invalid attestation")
+
+// HistoryLength returns the number of epochs of history kept by the slasher.
+// Older records, spans and detected slashings are pruned.
+func (s *Service) HistoryLength() primitives.Epoch {
+	return s.params.HistoryLength()
+}
+
+// AttesterSlashings returns the attester slashings detected by the slasher
+// with a target epoch in the inclusive range [startEpoch, endEpoch].
+func (s *Service) AttesterSlashings(
+	ctx context.Context, startEpoch, endEpoch primitives.Epoch,
+) ([]ethpb.AttSlashing, error) {
+	return s.serviceCfg.Database.AttesterSlashings(ctx, startEpoch, endEpoch)
+}
+
+// ProposerSlashings returns the proposer slashings detected by the slasher
+// for a slot in the inclusive epoch range [startEpoch, endEpoch].
+func (s *Service) ProposerSlashings(
+	ctx context.Context, startEpoch, endEpoch primitives.Epoch,
+) ([]*ethpb.ProposerSlashing, error) {
+	startSlot, endSlot, err := slotRange(startEpoch, endEpoch)
+	if err != nil {
+		return nil, err
+	}
+	return s.serviceCfg.Database.ProposerSlashings(ctx, startSlot, endSlot)
+}
+
+// AttestationRecords returns the attestations of a validator stored by the slasher
+// with a target epoch in the inclusive range [startEpoch, endEpoch].
+func (s *Service) AttestationRecords(
+	ctx context.Context, validatorIdx primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch,
+) ([]*slashertypes.IndexedAttestationWrapper, error) {
+	return s.serviceCfg.Database.AttestationRecordsForValidator(ctx, validatorIdx, startEpoch, endEpoch)
+}
+
+// BlockProposals returns the block proposals of a validator stored by the slasher
+// for a slot in the inclusive epoch range [startEpoch, endEpoch].
+func (s *Service) BlockProposals(
+	ctx context.Context, validatorIdx primitives.ValidatorIndex, startEpoch, endEpoch primitives.Epoch,
+) ([]*slashertypes.SignedBlockHeaderWrapper, error) {
+	startSlot, endSlot, err := slotRange(startEpoch, endEpoch)
+	if err != nil {
+		return nil, err
+	}
+	return s.serviceCfg.Database.BlockProposalsForValidator(ctx, validatorIdx, startSlot, endSlot)
+}
+
+// IsSlashableAttestation returns the attester slashings the given attestation would lead to,
+// against the attestations and min/max spans already stored by the slasher.
+// The attestation is neither recorded nor applied to the spans, and its signature is not verified.
+func (s *Service) IsSlashableAttestation(ctx context.Context, att ethpb.IndexedAtt) ([]ethpb.AttSlashing, error) {
+	ctx, span := trace.StartSpan(ctx, "Slasher.IsSlashableAttestation")
+	defer span.End()
+
+	if !validateAttestationIntegrity(att) {
+		return nil, errors.Wrap(ErrInvalidAttestation, "source epoch must be less than target epoch")
+	}
+	if err := attestation.IsValidAttestationIndices(ctx, att); err != nil {
+		return nil, errors.Wrap(ErrInvalidAttestation, err.Error())
+	}
+	dataRoot, err := att.GetData().HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get hash tree root of attestation")
+	}
+	attWrapper := &slashertypes.IndexedAttestationWrapper{
+		IndexedAttestation: att,
+		DataRoot:           dataRoot,
+	}
+
+	slashingsByRoot := make(map[[fieldparams.RootLength]byte]ethpb.AttSlashing)
+	slashings := make([]ethpb.AttSlashing, 0)
+	addSlashing := func(slashing ethpb.AttSlashing) error {
+		root, err := slashing.HashTreeRoot()
+		if err != nil {
+			return errors.Wrap(err, "could not hash tree root for attester slashing")
+		}
+		if _, ok := slashingsByRoot[root]; !ok {
+			slashingsByRoot[root] = slashing
+			slashings = append(slashings, slashing)
+		}
+		return nil
+	}
+
+	// Check for double votes against the attestations stored in the database.
+	doubleVotes, err := s.serviceCfg.Database.CheckAttesterDoubleVotes(
+		ctx, []*slashertypes.IndexedAttestationWrapper{attWrapper},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve potential double votes from disk")
+	}
+	for _, doubleVote := range doubleVotes {
+		slashing, err := attesterSlashingForDoubleVote(doubleVote)
+		if err != nil {
+			return nil, err
+		}
+		if err := addSlashing(slashing); err != nil {
+			return nil, err
+		}
+	}
+
+	// Check for surround votes against the min/max spans stored in the database.
+	// Chunks are only read here, the spans are updated by the attestation processing routine.
+	chunkIndex := s.params.chunkIndex(att.GetData().Source.Epoch)
+	for _, idx := range att.GetAttestingIndices() {
+		validatorIdx := primitives.ValidatorIndex(idx)
+		validatorChunkIndex := s.params.validatorChunkIndex(validatorIdx)
+		for _, kind := range []slashertypes.ChunkKind{slashertypes.MinSpan, slashertypes.MaxSpan} {
+			chunk, err := s.getChunkFromDatabase(ctx, kind, validatorChunkIndex, chunkIndex)
+			if err != nil {
+				return nil, err
+			}
+			slashing, err := chunk.CheckSlashable(ctx, s.serviceCfg.Database, validatorIdx, attWrapper)
+			if err != nil {
+				return nil, errors.Wrapf(
+					err, "could not check if attestation for validator index %d is slashable", validatorIdx,
+				)
+			}
+			if slashing == nil {
+				continue
+			}
+			if err := addSlashing(slashing); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return slashings, nil
+}
+
+// slotRange returns the first slot of startEpoch and the last slot of endEpoch.
+func slotRange(startEpoch, endEpoch primitives.Epoch) (primitives.Slot, primitives.Slot, error) {
+	startSlot, err := slots.EpochStart(startEpoch)
+	if err != nil {
+		return 0, 0, err
+	}
+	endSlot, err := slots.EpochEnd(endEpoch)
+	if err != nil {
+		return 0, 0, err
+	}
+	return startSlot, endSlot, nil
+}
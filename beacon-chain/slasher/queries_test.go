@@ -0,0 +1,118 @@
+package slasher
+
+import (
+	"context"
+	"testing"
+
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	slashertypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestService_Queries(t *testing.T) {
+	ctx := context.Background()
+	remote := &mockRemoteChain{}
+	s, err := New(ctx, &ServiceConfig{
+		Database:    dbtest.SetupSlasherDB(t),
+		RemoteChain: remote,
+	})
+	require.NoError(t, err)
+	currentSlot, err := slots.EpochStart(5)
+	require.NoError(t, err)
+
+	// Validators 1 and 2 attest with source 1 and target 4, then validator 1 double votes.
+	att := createAttestationWrapperEmptySig(t, 1, 4, []uint64{1, 2}, []byte{1})
+	s.processAttestations(ctx, []*slashertypes.IndexedAttestationWrapper{att}, currentSlot)
+	doubleVote := createAttestationWrapperEmptySig(t, 1, 4, []uint64{1}, []byte{2})
+	s.processAttestations(ctx, []*slashertypes.IndexedAttestationWrapper{doubleVote}, currentSlot)
+	require.Equal(t, 1, len(remote.attesterSlashings))
+
+	attesterSlashings, err := s.AttesterSlashings(ctx, 0, 5)
+	require.NoError(t, err)
+	require.DeepEqual(t, remote.attesterSlashings, attesterSlashings)
+	attesterSlashings, err = s.AttesterSlashings(ctx, 0, 3)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(attesterSlashings))
+
+	records, err := s.AttestationRecords(ctx, 2, 0, 5)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*slashertypes.IndexedAttestationWrapper{att}, records)
+
+	proposal := createProposalWrapper(t, currentSlot, 3, []byte{1})
+	require.NoError(t, s.serviceCfg.Database.SaveBlockProposals(ctx, []*slashertypes.SignedBlockHeaderWrapper{proposal}))
+	proposerSlashing := &ethpb.ProposerSlashing{
+		Header_1: proposal.SignedBeaconBlockHeader,
+		Header_2: createProposalWrapper(t, currentSlot, 3, []byte{2}).SignedBeaconBlockHeader,
+	}
+	require.NoError(t, s.processProposerSlashings(ctx, []*ethpb.ProposerSlashing{proposerSlashing}))
+
+	proposerSlashings, err := s.ProposerSlashings(ctx, 5, 5)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*ethpb.ProposerSlashing{proposerSlashing}, proposerSlashings)
+	proposerSlashings, err = s.ProposerSlashings(ctx, 0, 4)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(proposerSlashings))
+
+	proposals, err := s.BlockProposals(ctx, 3, 5, 5)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*slashertypes.SignedBlockHeaderWrapper{proposal}, proposals)
+}
+
+func TestService_IsSlashableAttestation(t *testing.T) {
+	ctx := context.Background()
+	s, err := New(ctx, &ServiceConfig{
+		Database:    dbtest.SetupSlasherDB(t),
+		RemoteChain: &mockRemoteChain{},
+	})
+	require.NoError(t, err)
+	currentSlot, err := slots.EpochStart(5)
+	require.NoError(t, err)
+
+	att := createAttestationWrapperEmptySig(t, 1, 4, []uint64{1, 2}, []byte{1})
+	s.processAttestations(ctx, []*slashertypes.IndexedAttestationWrapper{att}, currentSlot)
+
+	tests := []struct {
+		name           string
+		source, target primitives.Epoch
+		indices        []uint64
+		blockRoot      []byte
+		want           int
+	}{
+		{name: "same attestation", source: 1, target: 4, indices: []uint64{1, 2}, blockRoot: []byte{1}, want: 0},
+		{name: "unrelated validator", source: 0, target: 5, indices: []uint64{3}, blockRoot: []byte{1}, want: 0},
+		{name: "double vote", source: 1, target: 4, indices: []uint64{1, 2}, blockRoot: []byte{2}, want: 1},
+		{name: "surrounding vote", source: 0, target: 5, indices: []uint64{1}, blockRoot: []byte{1}, want: 1},
+		{name: "surrounded vote", source: 2, target: 3, indices: []uint64{2}, blockRoot: []byte{1}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incoming := createAttestationWrapperEmptySig(t, tt.source, tt.target, tt.indices, tt.blockRoot)
+			slashings, err := s.IsSlashableAttestation(ctx, incoming.IndexedAttestation)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, len(slashings))
+			for _, slashing := range slashings {
+				require.Equal(t, true, slashing.FirstAttestation().GetData().Target.Epoch == 4 || slashing.SecondAttestation().GetData().Target.Epoch == 4)
+			}
+		})
+	}
+
+	// Checking attestations neither records them nor detects slashings.
+	records, err := s.AttestationRecords(ctx, 1, 0, 5)
+	require.NoError(t, err)
+	require.DeepEqual(t, []*slashertypes.IndexedAttestationWrapper{att}, records)
+	attesterSlashings, err := s.AttesterSlashings(ctx, 0, 5)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(attesterSlashings))
+
+	invalid := createAttestationWrapperEmptySig(t, 4, 3, []uint64{1}, []byte{1})
+	_, err = s.IsSlashableAttestation(ctx, invalid.IndexedAttestation)
+	require.ErrorIs(t, err, ErrInvalidAttestation)
+
+	unsorted := createAttestationWrapperEmptySig(t, 3, 4, []uint64{2, 1}, []byte{1})
+	_, err = s.IsSlashableAttestation(ctx, unsorted.IndexedAttestation)
+	require.ErrorIs(t, err, ErrInvalidAttestation)
+	require.ErrorContains(t, "attesting indices is not uniquely sorted", err)
+}
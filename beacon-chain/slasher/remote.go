@@ -0,0 +1,18 @@
+package slasher
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+)
+
+// RemoteChain is the chain of the beacon nodes followed by a slasher running as a standalone process.
+// Slashings detected by such a slasher are submitted to the beacon nodes, which verify them
+// before inserting them into their operations pool.
+type RemoteChain interface {
+	HeadSlot() primitives.Slot
+	NumValidators(ctx context.Context) (int, error)
+	SubmitAttesterSlashing(ctx context.Context, slashing ethpb.AttSlashing) error
+	SubmitProposerSlashing(ctx context.Context, slashing *ethpb.ProposerSlashing) error
+}
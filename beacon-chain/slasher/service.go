@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/async/event"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain"
 	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
@@ -42,6 +43,9 @@ type ServiceConfig struct {
 	HeadStateFetcher        blockchain.HeadFetcher
 	SyncChecker             beaconChainSync.Checker
 	ClockWaiter             startup.ClockWaiter
+	// RemoteChain is set when the slasher runs outside of the beacon node. It then replaces the
+	// head state fetcher, the state fetchers and the slashing pool inserter.
+	RemoteChain RemoteChain
 }
 
 // Service defining a slasher implementation as part of
@@ -92,12 +96,11 @@ func (s *Service) run() {
 	log.Info("Completed chain sync, starting slashing detection")
 
 	// Get the latest epoch written for each validator from disk on startup.
-	headState, err := s.serviceCfg.HeadStateFetcher.HeadState(s.ctx)
+	numVals, err := s.numValidators(s.ctx)
 	if err != nil {
-		log.WithError(err).Error("Failed to fetch head state")
+		log.WithError(err).Error("Failed to fetch number of validators")
 		return
 	}
-	numVals := headState.NumValidators()
 	validatorIndices := make([]primitives.ValidatorIndex, numVals)
 	for i := 0; i < numVals; i++ {
 		validatorIndices[i] = primitives.ValidatorIndex(i)
@@ -122,7 +125,7 @@ func (s *Service) run() {
 	beaconBlockHeadersChan := make(chan *ethpb.SignedBeaconBlockHeader, 1)
 
 	// This section can be totally removed once Electra is on mainnet.
-	headSlot := s.serviceCfg.HeadStateFetcher.HeadSlot()
+	headSlot := s.headSlot()
 	headEpoch := slots.ToEpoch(headSlot)
 
 	maxPruningEpoch := primitives.Epoch(0)
@@ -198,6 +201,26 @@ func (*Service) Status() error {
 	return nil
 }
 
+// headSlot returns the slot of the head of the chain followed by the slasher.
+func (s *Service) headSlot() primitives.Slot {
+	if s.serviceCfg.RemoteChain != nil {
+		return s.serviceCfg.RemoteChain.HeadSlot()
+	}
+	return s.serviceCfg.HeadStateFetcher.HeadSlot()
+}
+
+// numValidators returns the number of validators in the head state of the chain followed by the slasher.
+func (s *Service) numValidators(ctx context.Context) (int, error) {
+	if s.serviceCfg.RemoteChain != nil {
+		return s.serviceCfg.RemoteChain.NumValidators(ctx)
+	}
+	headState, err := s.serviceCfg.HeadStateFetcher.HeadState(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get head state")
+	}
+	return headState.NumValidators(), nil
+}
+
 func (s *Service) waitForChainInitialization() {
 	clock, err := s.serviceCfg.ClockWaiter.WaitForClock(s.ctx)
 	if err != nil {
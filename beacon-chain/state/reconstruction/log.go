@@ -0,0 +1,5 @@
+package reconstruction
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "state-reconstruction")
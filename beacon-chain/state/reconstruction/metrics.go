@@ -0,0 +1,27 @@
+package reconstruction
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	archivedStateSlot = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "backfill_archived_state_slot",
+			Help: "Slot of the last archived state regenerated after backfilling to genesis.",
+		},
+	)
+	archivedStateTargetSlot = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "backfill_archived_state_target_slot",
+			Help: "Slot of the checkpoint sync origin, up to which archived states are regenerated.",
+		},
+	)
+	replayedBlocks = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "backfill_archived_state_replayed_blocks",
+			Help: "Number of blocks replayed to regenerate archived states.",
+		},
+	)
+)
@@ -0,0 +1,296 @@
+// Package reconstruction implements a background service which regenerates the archived states of a checkpoint
+// synced node, once its backfilled block history reaches genesis, so that historical states can be served for
+// slots before the checkpoint sync origin.
+package reconstruction
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	"github.com/prysmaticlabs/prysm/v5/runtime"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	errGenesisSync       = errors.New("node synced from genesis")
+	errIncompleteHistory = errors.New("block history in the db does not reach genesis")
+)
+
+// Database describes the db methods needed to reconstruct historical states.
+type Database interface {
+	stategen.FinalizedHistoryAccessor
+	BackfillStatus(ctx context.Context) (*dbval.BackfillStatus, error)
+	OriginCheckpointBlockRoot(ctx context.Context) ([32]byte, error)
+	HasState(ctx context.Context, blockRoot [32]byte) bool
+	SaveState(ctx context.Context, st state.ReadOnlyBeaconState, blockRoot [32]byte) error
+}
+
+// Service regenerates the archived states that stategen would have saved if the node had synced from genesis,
+// keyed like stategen does it, by the root of the highest block at or below each archived point, whose post-state is
+// saved.
+// Backfill downloads blocks in descending order, while states can only be computed in ascending order from the
+// genesis state, so reconstruction waits until the backfilled blocks are connected to the genesis block.
+type Service struct {
+	ctx             context.Context
+	cancel          context.CancelFunc
+	enabled         bool
+	toGenesis       bool
+	db              Database
+	interval        primitives.Slot
+	blocksPerSecond uint64
+	pollInterval    time.Duration
+	initSyncWaiter  func() error
+	next            time.Time
+}
+
+var _ runtime.Service = (*Service)(nil)
+
+// Option is a functional option for the reconstruction Service.
+type Option func(*Service) error
+
+// WithEnabled determines whether the service reconstructs historical states. It is disabled by default.
+func WithEnabled(enabled bool) Option {
+	return func(s *Service) error {
+		s.enabled = enabled
+		return nil
+	}
+}
+
+// WithBackfillToGenesis tells the service that backfill is downloading the block history down to genesis, so that
+// reconstruction can wait for it. Otherwise, the block history must already reach genesis when the service is created.
+func WithBackfillToGenesis(enabled bool) Option {
+	return func(s *Service) error {
+		s.toGenesis = enabled
+		return nil
+	}
+}
+
+// WithDatabase sets the db the states are read from and saved to.
+func WithDatabase(d Database) Option {
+	return func(s *Service) error {
+		s.db = d
+		return nil
+	}
+}
+
+// WithBlocksPerSecond limits the number of blocks replayed per second, so that reconstruction doesn't starve
+// the processing of new blocks. A value of 0 disables the limit.
+func WithBlocksPerSecond(n uint64) Option {
+	return func(s *Service) error {
+		s.blocksPerSecond = n
+		return nil
+	}
+}
+
+// WithInitSyncWaiter sets a function that blocks until initial sync is complete, so that reconstruction
+// only starts once the node is following the head of the chain.
+func WithInitSyncWaiter(w func() error) Option {
+	return func(s *Service) error {
+		s.initSyncWaiter = w
+		return nil
+	}
+}
+
+// NewService initializes the reconstruction Service. The service won't do anything until Start() is called.
+func NewService(ctx context.Context, opts ...Option) (*Service, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	cfg := params.BeaconConfig()
+	s := &Service{
+		ctx:          ctx,
+		cancel:       cancel,
+		interval:     cfg.SlotsPerArchivedPoint,
+		pollInterval: time.Duration(uint64(cfg.SlotsPerEpoch)*cfg.SecondsPerSlot) * time.Second,
+	}
+	for _, o := range opts {
+		if err := o(s); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	if s.enabled && s.db == nil {
+		cancel()
+		return nil, errors.New("historical state reconstruction requires a database")
+	}
+	if s.interval == 0 {
+		cancel()
+		return nil, errors.New("slots per archived point must be greater than 0")
+	}
+	if s.enabled && !s.toGenesis {
+		// Nothing will fill the missing blocks, so don't wait for them forever.
+		status, err := s.connectedStatus(ctx)
+		if err != nil && !errors.Is(err, errGenesisSync) {
+			cancel()
+			return nil, err
+		}
+		if err == nil && status == nil {
+			cancel()
+			return nil, errors.Wrap(errIncompleteHistory, "historical state reconstruction needs the full block history, "+
+				"backfill it to genesis or import it from era files")
+		}
+	}
+	return s, nil
+}
+
+// Start waits for the backfilled blocks to reach genesis and then reconstructs the missing archived states.
+func (s *Service) Start() {
+	if !s.enabled {
+		log.Debug("Historical state reconstruction not enabled")
+		return
+	}
+	if s.initSyncWaiter != nil {
+		if err := s.initSyncWaiter(); err != nil {
+			log.WithError(err).Error("Error waiting for init-sync to complete")
+			return
+		}
+	}
+	for {
+		status, err := s.connectedStatus(s.ctx)
+		switch {
+		case errors.Is(err, errGenesisSync):
+			log.Info("Node synced from genesis, there are no historical states to reconstruct")
+			return
+		case err != nil:
+			log.WithError(err).Error("Could not read backfill status")
+		case status == nil:
+			log.Debug("Waiting for backfill to reach genesis before reconstructing historical states")
+		default:
+			err := s.reconstruct(s.ctx, status)
+			if err == nil {
+				log.Info("Historical state reconstruction is complete")
+				return
+			}
+			if s.ctx.Err() != nil {
+				return
+			}
+			log.WithError(err).Error("Historical state reconstruction failed, retrying")
+		}
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
+
+// Stop cancels reconstruction. States saved so far are kept, and reconstruction resumes from them on the next start.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the reconstruction service.
+func (*Service) Status() error {
+	return nil
+}
+
+// connectedStatus returns the backfill status once the backfilled blocks are connected to the genesis block,
+// and nil while they are not.
+func (s *Service) connectedStatus(ctx context.Context) (*dbval.BackfillStatus, error) {
+	status, err := s.db.BackfillStatus(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		if _, err := s.db.OriginCheckpointBlockRoot(ctx); errors.Is(err, db.ErrNotFoundOriginBlockRoot) {
+			return nil, errGenesisSync
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	gr, err := s.db.GenesisBlockRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read genesis block root")
+	}
+	// Blocks imported from era files include the genesis block itself.
+	if status.LowSlot != 0 && bytesutil.ToBytes32(status.LowParentRoot) != gr {
+		return nil, nil
+	}
+	return status, nil
+}
+
+// reconstruct saves the missing archived states in [interval, status.OriginSlot). States saved by a previous run are
+// kept, and reconstruction continues from the highest of them, so that it can be resumed after a restart.
+func (s *Service) reconstruct(ctx context.Context, status *dbval.BackfillStatus) error {
+	gr, err := s.db.GenesisBlockRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not read genesis block root")
+	}
+	origin := primitives.Slot(status.OriginSlot)
+	archivedStateTargetSlot.Set(float64(origin))
+	history := stategen.NewFinalizedHistory(s.db, origin)
+	var st state.BeaconState
+	prev := gr
+	for slot := s.interval; slot < origin; slot += s.interval {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		root, err := history.BlockRootForSlot(ctx, slot)
+		if err != nil {
+			return errors.Wrapf(err, "could not find canonical block root for slot %d", slot)
+		}
+		if s.db.HasState(ctx, root) {
+			prev, st = root, nil
+			continue
+		}
+		b, err := s.db.Block(ctx, root)
+		if err != nil {
+			return errors.Wrapf(err, "could not read block %#x", root)
+		}
+		if st == nil {
+			st, err = s.db.StateOrError(ctx, prev)
+			if err != nil {
+				return errors.Wrapf(err, "could not load state for block root %#x", prev)
+			}
+		}
+		// The state saved under the block root is the post-state of the block, even when the archived point is a
+		// skipped slot, like stategen does when it migrates states to cold storage.
+		st, err = history.AdvanceState(ctx, st, b.Block().Slot(), s.pace)
+		if err != nil {
+			return errors.Wrapf(err, "could not advance state to slot %d", b.Block().Slot())
+		}
+		if err := s.db.SaveState(ctx, st.Copy(), root); err != nil {
+			return errors.Wrapf(err, "could not save archived state for slot %d", slot)
+		}
+		prev = root
+		archivedStateSlot.Set(float64(slot))
+		log.WithFields(logrus.Fields{
+			"slot":       slot,
+			"originSlot": origin,
+			"progress":   fmt.Sprintf("%.2f%%", 100*float64(slot)/float64(origin)),
+		}).Info("Reconstructed archived state")
+	}
+	return nil
+}
+
+// pace is called before every replayed block, and sleeps as needed to keep replay under blocksPerSecond.
+func (s *Service) pace(ctx context.Context, _ state.BeaconState, _ interfaces.ReadOnlySignedBeaconBlock) error {
+	replayedBlocks.Inc()
+	if s.blocksPerSecond == 0 {
+		return nil
+	}
+	now := time.Now()
+	// Don't let the time spent waiting for backfill or replaying slowly turn into a burst of blocks.
+	if s.next.Before(now) {
+		s.next = now
+	}
+	wait := s.next.Sub(now)
+	s.next = s.next.Add(time.Second / time.Duration(s.blocksPerSecond))
+	if wait == 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
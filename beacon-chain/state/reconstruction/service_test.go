@@ -0,0 +1,143 @@
+package reconstruction
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestReconstruct(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.SlotsPerArchivedPoint = 8
+	params.OverrideBeaconConfig(cfg)
+	ctx := context.Background()
+	d, err := kv.NewKVStore(ctx, t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, d.Close())
+	})
+	st, keys := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, d.SaveGenesisData(ctx, st))
+	genesisRoot, err := d.GenesisBlockRoot(ctx)
+	require.NoError(t, err)
+
+	// Blocks every 4 slots up to the origin at slot 32, skipping slot 16 so that one archived point has no block.
+	// The state of that archived point is the post-state of the block at slot 12.
+	origin := params.BeaconConfig().SlotsPerEpoch
+	roots := map[primitives.Slot][32]byte{}
+	want := map[primitives.Slot][32]byte{}
+	stateSlots := map[primitives.Slot]primitives.Slot{}
+	conf := util.DefaultBlockGenConfig()
+	conf.NumAttestations = 0
+	for slot := primitives.Slot(4); slot <= origin; slot += 4 {
+		if slot == 16 {
+			roots[slot], want[slot], stateSlots[slot] = roots[slot-4], want[slot-4], slot-4
+			continue
+		}
+		pb, err := util.GenerateFullBlock(st, keys, conf, slot)
+		require.NoError(t, err)
+		b, err := blocks.NewSignedBeaconBlock(pb)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, b)
+		require.NoError(t, err)
+		require.NoError(t, d.SaveBlock(ctx, b))
+		roots[slot], err = b.Block().HashTreeRoot()
+		require.NoError(t, err)
+		want[slot], err = st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		stateSlots[slot] = slot
+	}
+	originRoot, lowRoot := roots[origin], roots[4]
+	require.NoError(t, d.SaveState(ctx, st, originRoot))
+	require.NoError(t, d.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: originRoot[:]}))
+
+	requireReconstructed := func(t *testing.T, slots ...primitives.Slot) {
+		for _, slot := range slots {
+			s, err := d.State(ctx, roots[slot])
+			require.NoError(t, err)
+			require.Equal(t, stateSlots[slot], s.Slot())
+			htr, err := s.HashTreeRoot(ctx)
+			require.NoError(t, err)
+			require.Equal(t, want[slot], htr, "wrong archived state at slot %d", slot)
+		}
+	}
+	s, err := NewService(ctx, WithEnabled(true), WithDatabase(d), WithBlocksPerSecond(1000))
+	require.NoError(t, err)
+	s.pollInterval = time.Millisecond
+
+	t.Run("genesis sync", func(t *testing.T) {
+		_, err := s.connectedStatus(ctx)
+		require.ErrorIs(t, err, errGenesisSync)
+	})
+	require.NoError(t, d.SaveOriginCheckpointBlockRoot(ctx, originRoot))
+	t.Run("no backfill status", func(t *testing.T) {
+		status, err := s.connectedStatus(ctx)
+		require.NoError(t, err)
+		require.IsNil(t, status)
+	})
+	t.Run("not connected to genesis", func(t *testing.T) {
+		require.NoError(t, d.SaveBackfillStatus(ctx, &dbval.BackfillStatus{
+			LowSlot:       8,
+			LowParentRoot: lowRoot[:],
+			OriginSlot:    uint64(origin),
+			OriginRoot:    originRoot[:],
+		}))
+		status, err := s.connectedStatus(ctx)
+		require.NoError(t, err)
+		require.IsNil(t, status)
+		// Without backfill to genesis, the missing blocks would never arrive.
+		_, err = NewService(ctx, WithEnabled(true), WithDatabase(d))
+		require.ErrorIs(t, err, errIncompleteHistory)
+		_, err = NewService(ctx, WithEnabled(true), WithDatabase(d), WithBackfillToGenesis(true))
+		require.NoError(t, err)
+	})
+	status := &dbval.BackfillStatus{
+		LowSlot:       4,
+		LowRoot:       lowRoot[:],
+		LowParentRoot: genesisRoot[:],
+		OriginSlot:    uint64(origin),
+		OriginRoot:    originRoot[:],
+	}
+	require.NoError(t, d.SaveBackfillStatus(ctx, status))
+	t.Run("connected to genesis", func(t *testing.T) {
+		_, err := NewService(ctx, WithEnabled(true), WithDatabase(d))
+		require.NoError(t, err)
+	})
+	t.Run("reconstruct", func(t *testing.T) {
+		// Start returns once all archived states are saved.
+		s.Start()
+		requireReconstructed(t, 8, 16, 24)
+	})
+	t.Run("resume", func(t *testing.T) {
+		require.NoError(t, d.DeleteState(ctx, roots[24]))
+		require.Equal(t, false, d.HasState(ctx, roots[24]))
+		require.NoError(t, s.reconstruct(ctx, status))
+		requireReconstructed(t, 8, 16, 24)
+	})
+}
+
+func TestPace(t *testing.T) {
+	ctx := context.Background()
+	s := &Service{blocksPerSecond: 100}
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, s.pace(ctx, nil, nil))
+	}
+	require.Equal(t, true, time.Since(start) >= 40*time.Millisecond)
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	s.next = time.Now().Add(time.Hour)
+	require.ErrorIs(t, s.pace(cctx, nil, nil), context.Canceled)
+}
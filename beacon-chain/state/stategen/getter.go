@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/time"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
@@ -217,6 +218,26 @@ func (s *State) loadStateByRoot(ctx context.Context, blockRoot [32]byte) (state.
 	}
 	targetSlot := summary.Slot
 
+	// When the DB stores hierarchical state diffs, finalized states are rebuilt from the closest hierarchical
+	// state below them, since the states of the cold section are not saved by block root in that mode.
+	if s.stateDiffInterval > 0 && s.beaconDB.IsFinalizedBlock(ctx, blockRoot) {
+		startState, err := s.beaconDB.HierarchicalStateForSlot(ctx, targetSlot)
+		switch {
+		case err == nil:
+			if startState.Slot() == targetSlot {
+				return startState, nil
+			}
+			blks, err := s.loadBlocks(ctx, startState.Slot()+1, targetSlot, bytesutil.ToBytes32(summary.Root))
+			if err != nil {
+				return nil, errors.Wrap(err, "could not load blocks for cold state using root")
+			}
+			replayBlockCount.Observe(float64(len(blks)))
+			return s.replayBlocks(ctx, startState, blks, targetSlot)
+		case !errors.Is(err, db.ErrNotFoundState):
+			return nil, errors.Wrap(err, "could not get hierarchical state")
+		}
+	}
+
 	// Since the requested state is not in caches or DB, start replaying using the last
 	// available ancestor state which is retrieved using input block's root.
 	startState, err := s.latestAncestor(ctx, blockRoot)
@@ -1,6 +1,7 @@
 package stategen
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
@@ -20,6 +21,14 @@ func WithCache(c CachedGetter) CanonicalHistoryOption {
 	}
 }
 
+// WithHierarchicalStates makes the CanonicalHistory start replaying from the hierarchical state below the target
+// slot when there is one, instead of walking back the chain to a state saved by block root.
+func WithHierarchicalStates(g HierarchicalStateGetter) CanonicalHistoryOption {
+	return func(h *CanonicalHistory) {
+		h.hs = g
+	}
+}
+
 type CanonicalHistoryOption func(*CanonicalHistory)
 
 func NewCanonicalHistory(h HistoryAccessor, cc CanonicalChecker, cs CurrentSlotter, opts ...CanonicalHistoryOption) *CanonicalHistory {
@@ -39,6 +48,7 @@ type CanonicalHistory struct {
 	cc    CanonicalChecker
 	cs    CurrentSlotter
 	cache CachedGetter
+	hs    HierarchicalStateGetter
 }
 
 func (c *CanonicalHistory) ReplayerForSlot(target primitives.Slot) Replayer {
@@ -49,6 +59,9 @@ func (c *CanonicalHistory) BlockRootForSlot(ctx context.Context, target primitiv
 	if currentSlot := c.cs.CurrentSlot(); target > currentSlot {
 		return [32]byte{}, errors.Wrap(ErrFutureSlotRequested, fmt.Sprintf("requested=%d, current=%d", target, currentSlot))
 	}
+	if !c.h.AvailableBlock(target) {
+		return [32]byte{}, errors.Wrapf(ErrNoDataForSlot, "slot %d has been pruned from the db", target)
+	}
 
 	slotAbove := target + 1
 	// don't bother searching for candidate roots when we know the target slot is genesis
@@ -110,6 +123,15 @@ func (c *CanonicalHistory) chainForSlot(ctx context.Context, target primitives.S
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "no canonical block root found below slot=%d", target)
 	}
+	if c.hs != nil {
+		s, descendants, err := c.hierarchicalChain(ctx, target)
+		if err == nil {
+			return s, descendants, nil
+		}
+		if !errors.Is(err, db.ErrNotFoundState) {
+			return nil, nil, errors.Wrap(err, "failed to replay from hierarchical state")
+		}
+	}
 	b, err := c.h.Block(ctx, r)
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "unable to retrieve canonical block for slot, root=%#x", r)
@@ -122,6 +144,116 @@ func (c *CanonicalHistory) chainForSlot(ctx context.Context, target primitives.S
 	return s, descendants, nil
 }
 
+// hierarchicalChain returns the hierarchical state below target and the canonical blocks that descend from it.
+func (c *CanonicalHistory) hierarchicalChain(ctx context.Context, target primitives.Slot) (state.BeaconState, []interfaces.ReadOnlySignedBeaconBlock, error) {
+	st, err := c.hs.HierarchicalStateForSlot(ctx, target)
+	if err != nil {
+		return nil, nil, err
+	}
+	descendants, err := c.descendantsOfState(ctx, st, target)
+	if err != nil {
+		return nil, nil, err
+	}
+	return st, descendants, nil
+}
+
+var _ StateAdvancer = &CanonicalHistory{}
+
+// AdvanceState applies the canonical blocks with slots in (st.Slot(), target] to st and then runs process_slots
+// up to target. Unlike ReplayerForSlot, it does not look for a saved state to start from, so repeatedly advancing
+// the same state only ever processes each block once. observe, when non-nil, is called with the pre-state of every
+// block before that block is applied.
+func (c *CanonicalHistory) AdvanceState(ctx context.Context, st state.BeaconState, target primitives.Slot, observe BlockObserver) (state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "canonicalChainer.AdvanceState")
+	defer span.End()
+	if st == nil || st.IsNil() {
+		return nil, errUnknownState
+	}
+	if target < st.Slot() {
+		return nil, errors.Wrapf(ErrReplayTargetSlotExceeded, "slot desired=%d, state.slot=%d", target, st.Slot())
+	}
+	if target == st.Slot() {
+		return st, nil
+	}
+	descendants, err := c.descendantsOfState(ctx, st, target)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range descendants {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if observe != nil {
+			st, err = ReplayProcessSlots(ctx, st, b.Block().Slot())
+			if err != nil {
+				return nil, errors.Wrap(err, "could not process slot")
+			}
+			if err := observe(ctx, st, b); err != nil {
+				return nil, err
+			}
+		}
+		st, err = executeStateTransitionStateGen(ctx, st, b)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if target > st.Slot() {
+		st, err = ReplayProcessSlots(ctx, st, target)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not process slots up to %d", target)
+		}
+	}
+	return st, nil
+}
+
+// descendantsOfState returns, in ascending order, the canonical blocks with slots in (st.Slot(), target].
+// It walks back from the canonical block for target and checks that the block it stops at is the one
+// st's latest block header describes, so that a state from an orphaned branch is never advanced.
+func (c *CanonicalHistory) descendantsOfState(ctx context.Context, st state.ReadOnlyBeaconState, target primitives.Slot) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	r, err := c.BlockRootForSlot(ctx, target)
+	if err != nil {
+		return nil, errors.Wrapf(err, "no canonical block root found below slot=%d", target)
+	}
+	chain := make([]interfaces.ReadOnlySignedBeaconBlock, 0)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, errors.Wrap(err, "context canceled while finding descendants of state")
+		}
+		b, err := c.h.Block(ctx, r)
+		if err != nil {
+			return nil, errors.Wrapf(err, "db error when retrieving block by root=%#x", r)
+		}
+		if blocks.BeaconBlockIsNil(b) != nil {
+			return nil, errors.Wrapf(db.ErrNotFound, "unable to retrieve block by root=%#x", r)
+		}
+		if b.Block().Slot() <= st.Slot() {
+			if err := checkLatestHeader(st, b); err != nil {
+				return nil, err
+			}
+			reverseChain(chain)
+			return chain, nil
+		}
+		chain = append(chain, b)
+		r = b.Block().ParentRoot()
+	}
+}
+
+func checkLatestHeader(st state.ReadOnlyBeaconState, b interfaces.ReadOnlySignedBeaconBlock) error {
+	header := st.LatestBlockHeader()
+	if header == nil {
+		return errors.New("nil latest block header in state")
+	}
+	bodyRoot, err := b.Block().Body().HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute block body root")
+	}
+	parentRoot := b.Block().ParentRoot()
+	if header.Slot != b.Block().Slot() || !bytes.Equal(header.ParentRoot, parentRoot[:]) || !bytes.Equal(header.BodyRoot, bodyRoot[:]) {
+		return errors.Wrapf(ErrStateNotCanonical, "latest block header at slot %d does not match canonical block at slot %d", header.Slot, b.Block().Slot())
+	}
+	return nil
+}
+
 func (c *CanonicalHistory) getState(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error) {
 	if c.cache != nil {
 		st, err := c.cache.ByBlockRoot(blockRoot)
@@ -192,3 +324,30 @@ func reverseChain(c []interfaces.ReadOnlySignedBeaconBlock) {
 		c[i], c[last-i] = c[last-i], c[i]
 	}
 }
+
+// FinalizedHistoryAccessor is a HistoryAccessor that can also tell whether a block is in the finalized block index.
+type FinalizedHistoryAccessor interface {
+	HistoryAccessor
+	IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool
+}
+
+// NewFinalizedHistory returns a CanonicalHistory that only considers finalized blocks canonical, and that treats
+// the given slot as the current slot. It can replay finalized states up to that slot without a fork choice store,
+// for instance when exporting or reconstructing historical states.
+func NewFinalizedHistory(h FinalizedHistoryAccessor, slot primitives.Slot, opts ...CanonicalHistoryOption) *CanonicalHistory {
+	return NewCanonicalHistory(h, finalizedChecker{h: h}, fixedSlot(slot), opts...)
+}
+
+type finalizedChecker struct {
+	h FinalizedHistoryAccessor
+}
+
+func (c finalizedChecker) IsCanonical(ctx context.Context, root [32]byte) (bool, error) {
+	return c.h.IsFinalizedBlock(ctx, root), nil
+}
+
+type fixedSlot primitives.Slot
+
+func (s fixedSlot) CurrentSlot() primitives.Slot {
+	return primitives.Slot(s)
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/mock"
@@ -22,6 +23,15 @@ func TestBlockForSlotFuture(t *testing.T) {
 	require.ErrorIs(t, err, ErrFutureSlotRequested)
 }
 
+func TestBlockForSlotPruned(t *testing.T) {
+	ch := &CanonicalHistory{
+		h:  &mockHistory{prunedBefore: 64},
+		cs: &mockCurrentSlotter{Slot: 100},
+	}
+	_, err := ch.BlockRootForSlot(context.Background(), 63)
+	require.ErrorIs(t, err, ErrNoDataForSlot)
+}
+
 func TestChainForSlotFuture(t *testing.T) {
 	ch := &CanonicalHistory{
 		cs: &mockCurrentSlotter{Slot: 0},
@@ -445,6 +455,84 @@ func TestChainForSlot(t *testing.T) {
 	}
 }
 
+type mockHierarchicalStates func(ctx context.Context, slot primitives.Slot) (state.BeaconState, error)
+
+func (m mockHierarchicalStates) HierarchicalStateForSlot(ctx context.Context, slot primitives.Slot) (state.BeaconState, error) {
+	return m(ctx, slot)
+}
+
+func TestChainForSlot_HierarchicalStates(t *testing.T) {
+	ctx := context.Background()
+	var zero, one, two, three primitives.Slot = 50, 51, 150, 151
+	specs := []mockHistorySpec{
+		{slot: zero, canonicalBlock: true, savedState: true},
+		{slot: one, canonicalBlock: true},
+		{slot: two},
+		{slot: three, canonicalBlock: true},
+	}
+	hist := newMockHistory(t, specs, three+10)
+	var anchor primitives.Slot = 64
+	anchorState, err := ReplayProcessSlots(ctx, hist.hiddenStates[hist.slotMap[one]].Copy(), anchor)
+	require.NoError(t, err)
+	anchorStateRoot, err := anchorState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	savedStateRoot, err := hist.states[hist.slotMap[zero]].HashTreeRoot(ctx)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name       string
+		hs         mockHierarchicalStates
+		stateRoot  [32]byte
+		blockRoots [][32]byte
+		err        error
+	}{
+		{
+			name: "replays from hierarchical state",
+			hs: func(_ context.Context, slot primitives.Slot) (state.BeaconState, error) {
+				require.Equal(t, three, slot)
+				return anchorState.Copy(), nil
+			},
+			stateRoot:  anchorStateRoot,
+			blockRoots: [][32]byte{hist.slotMap[two], hist.slotMap[three]},
+		},
+		{
+			name: "falls back to saved state when not found",
+			hs: func(context.Context, primitives.Slot) (state.BeaconState, error) {
+				return nil, db.ErrNotFoundState
+			},
+			stateRoot:  savedStateRoot,
+			blockRoots: [][32]byte{hist.slotMap[one], hist.slotMap[two], hist.slotMap[three]},
+		},
+		{
+			name: "other errors",
+			hs: func(context.Context, primitives.Slot) (state.BeaconState, error) {
+				return nil, errors.New("corrupt")
+			},
+			err: errors.New("corrupt"),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ch := NewCanonicalHistory(hist, hist, hist, WithHierarchicalStates(c.hs))
+			st, blocks, err := ch.chainForSlot(ctx, three)
+			if c.err != nil {
+				require.ErrorContains(t, c.err.Error(), err)
+				return
+			}
+			require.NoError(t, err)
+			actualStRoot, err := st.HashTreeRoot(ctx)
+			require.NoError(t, err)
+			require.Equal(t, c.stateRoot, actualStRoot)
+			require.Equal(t, len(c.blockRoots), len(blocks))
+			for i, b := range blocks {
+				root, err := b.Block().HashTreeRoot()
+				require.NoError(t, err)
+				require.Equal(t, c.blockRoots[i], root)
+			}
+		})
+	}
+}
+
 func TestAncestorChainOrdering(t *testing.T) {
 	ctx := context.Background()
 	var zero, one, two, three, four, five primitives.Slot = 50, 51, 150, 151, 152, 200
@@ -579,3 +667,87 @@ func mockBlocks(n int, iter func(int, chan uint32)) []interfaces.ReadOnlySignedB
 	}
 	return mb
 }
+
+func TestAdvanceState(t *testing.T) {
+	ctx := context.Background()
+	var zero, one, two, three primitives.Slot = 50, 51, 150, 151
+	specs := []mockHistorySpec{
+		{slot: zero, canonicalBlock: true, savedState: true},
+		{slot: one, canonicalBlock: true},
+		{slot: two},
+		{slot: three, canonicalBlock: true},
+	}
+	hist := newMockHistory(t, specs, three+10)
+	ch := NewCanonicalHistory(hist, hist, hist)
+
+	t.Run("applies descendants once and observes pre-states", func(t *testing.T) {
+		st, err := ch.ReplayerForSlot(one).ReplayBlocks(ctx)
+		require.NoError(t, err)
+		observed := make([][32]byte, 0)
+		st, err = ch.AdvanceState(ctx, st, three, func(_ context.Context, pre state.BeaconState, b interfaces.ReadOnlySignedBeaconBlock) error {
+			require.Equal(t, b.Block().Slot(), pre.Slot())
+			root, err := b.Block().HashTreeRoot()
+			require.NoError(t, err)
+			observed = append(observed, root)
+			return nil
+		})
+		require.NoError(t, err)
+		require.DeepEqual(t, [][32]byte{hist.slotMap[two], hist.slotMap[three]}, observed)
+		expectedHTR, err := hist.hiddenStates[hist.slotMap[three]].HashTreeRoot(ctx)
+		require.NoError(t, err)
+		actualHTR, err := st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		require.Equal(t, expectedHTR, actualHTR)
+
+		st, err = ch.AdvanceState(ctx, st, three+5, nil)
+		require.NoError(t, err)
+		require.Equal(t, three+5, st.Slot())
+	})
+	t.Run("target before state slot", func(t *testing.T) {
+		st, err := ch.ReplayerForSlot(one).ReplayBlocks(ctx)
+		require.NoError(t, err)
+		_, err = ch.AdvanceState(ctx, st, zero, nil)
+		require.ErrorIs(t, err, ErrReplayTargetSlotExceeded)
+	})
+	t.Run("state not on canonical chain", func(t *testing.T) {
+		st, err := ch.ReplayerForSlot(zero).ReplayBlocks(ctx)
+		require.NoError(t, err)
+		// Skip block one so the state's latest header no longer matches the canonical block at that slot.
+		st, err = ReplayProcessSlots(ctx, st, one)
+		require.NoError(t, err)
+		_, err = ch.AdvanceState(ctx, st, three, nil)
+		require.ErrorIs(t, err, ErrStateNotCanonical)
+	})
+}
+
+type mockFinalizedHistory struct {
+	*mockHistory
+}
+
+func (m mockFinalizedHistory) IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool {
+	canon, err := m.IsCanonical(ctx, blockRoot)
+	return err == nil && canon
+}
+
+func TestNewFinalizedHistory(t *testing.T) {
+	ctx := context.Background()
+	var begin, middle, end primitives.Slot = 100, 150, 155
+	specs := []mockHistorySpec{
+		{slot: begin, canonicalBlock: true},
+		{slot: middle},
+		{slot: end, canonicalBlock: true},
+	}
+	hist := newMockHistory(t, specs, end+10)
+	ch := NewFinalizedHistory(mockFinalizedHistory{hist}, end)
+
+	root, err := ch.BlockRootForSlot(ctx, end)
+	require.NoError(t, err)
+	require.Equal(t, hist.slotMap[end], root)
+	// The block at middle is not finalized, so the finalized block below it is used.
+	root, err = ch.BlockRootForSlot(ctx, middle)
+	require.NoError(t, err)
+	require.Equal(t, hist.slotMap[begin], root)
+	// Slots past the given slot can't be replayed, even though they are before the mock's current slot.
+	_, err = ch.BlockRootForSlot(ctx, end+1)
+	require.ErrorIs(t, err, ErrFutureSlotRequested)
+}
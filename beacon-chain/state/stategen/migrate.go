@@ -5,7 +5,9 @@ import (
 	"encoding/hex"
 	"fmt"
 
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
 	"github.com/sirupsen/logrus"
@@ -44,6 +46,16 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 			return ctx.Err()
 		}
 
+		// When the db stores hierarchical state diffs, they replace the states saved on archived points.
+		if s.stateDiffInterval > 0 {
+			if slot%s.stateDiffInterval == 0 {
+				if err := s.saveHierarchicalState(ctx, slot); err != nil {
+					return errors.Wrapf(err, "could not save hierarchical state for slot %d", slot)
+				}
+			}
+			continue
+		}
+
 		if slot%s.slotsPerArchivedPoint == 0 && slot != 0 {
 			cached, exists, err := s.epochBoundaryStateCache.getBySlot(slot)
 			if err != nil {
@@ -120,3 +132,39 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 
 	return nil
 }
+
+// saveHierarchicalState saves the finalized state at the given slot to the hierarchical state storage of the DB.
+// Unlike the states saved on archived points, which are keyed by block root, the saved state is processed up to
+// the slot when the slot is skipped, so that the state of any slot can be rebuilt from it.
+func (s *State) saveHierarchicalState(ctx context.Context, slot primitives.Slot) error {
+	cached, exists, err := s.epochBoundaryStateCache.getBySlot(slot)
+	if err != nil {
+		return fmt.Errorf("could not get epoch boundary state for slot %d", slot)
+	}
+	var st state.BeaconState
+	if exists {
+		st = cached.state
+	} else {
+		_, roots, err := s.beaconDB.HighestRootsBelowSlot(ctx, slot+1)
+		if err != nil {
+			return err
+		}
+		// Given the block has been finalized, the db should not have more than one block in a given slot.
+		if len(roots) != 1 {
+			return errUnknownBlock
+		}
+		st, err = s.StateByRoot(ctx, roots[0])
+		if err != nil {
+			return err
+		}
+		st, err = ReplayProcessSlots(ctx, st.Copy(), slot)
+		if err != nil {
+			return err
+		}
+	}
+	if err := s.beaconDB.SaveHierarchicalState(ctx, st); err != nil {
+		return err
+	}
+	log.WithField("slot", slot).Debug("Saved hierarchical state in DB")
+	return nil
+}
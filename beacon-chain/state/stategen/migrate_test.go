@@ -5,8 +5,11 @@ import (
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
 	testDB "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
 	consensusblocks "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
@@ -225,3 +228,62 @@ func TestMigrateToCold_ParallelCalls(t *testing.T) {
 	assert.DeepEqual(t, [][32]byte{r7}, service.saveHotStateDB.blockRootsOfSavedStates, "Did not remove all saved hot state roots")
 	require.LogsContain(t, hook, "Saved state in DB")
 }
+
+func TestMigrateToCold_HierarchicalStates(t *testing.T) {
+	ctx := context.Background()
+	// Full states every 4 slots, diffs every 2 slots.
+	beaconDB, err := kv.NewKVStore(ctx, t.TempDir(), kv.WithStateDiffExponents([]uint64{2, 1}))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, beaconDB.Close())
+	})
+	service := New(beaconDB, doublylinkedtree.New())
+	require.Equal(t, primitives.Slot(2), service.stateDiffInterval)
+
+	genesisState, pks := util.DeterministicGenesisState(t, 32)
+	genesisStateRoot, err := genesisState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis := blocks.NewGenesisBlock(genesisStateRoot[:])
+	util.SaveBlock(t, ctx, beaconDB, genesis)
+	gRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, gRoot))
+	require.NoError(t, service.epochBoundaryStateCache.put(gRoot, genesisState))
+
+	// The chain has blocks at slots 1, 3 and 5, the states are only in the hot state cache.
+	st := genesisState.Copy()
+	states := make(map[primitives.Slot]state.BeaconState)
+	roots := make(map[primitives.Slot][32]byte)
+	for _, slot := range []primitives.Slot{1, 3, 5} {
+		b, err := util.GenerateFullBlock(st, pks, util.DefaultBlockGenConfig(), slot)
+		require.NoError(t, err)
+		wsb, err := consensusblocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		require.NoError(t, err)
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		util.SaveBlock(t, ctx, beaconDB, b)
+		require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: root[:]}))
+		service.hotStateCache.put(root, st.Copy())
+		states[slot], roots[slot] = st.Copy(), root
+	}
+	fRoot := roots[5]
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Root: fRoot[:]}))
+
+	require.NoError(t, service.MigrateToCold(ctx, fRoot))
+	require.Equal(t, false, beaconDB.HasState(ctx, roots[3]))
+	want, err := ReplayProcessSlots(ctx, states[3].Copy(), 4)
+	require.NoError(t, err)
+	got, err := beaconDB.HierarchicalStateForSlot(ctx, 4)
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, want.ToProtoUnsafe(), got.ToProtoUnsafe())
+
+	// Without any state saved by block root, cold states are rebuilt from the hierarchical states.
+	service = New(beaconDB, doublylinkedtree.New())
+	for _, slot := range []primitives.Slot{1, 3} {
+		got, err := service.StateByRoot(ctx, roots[slot])
+		require.NoError(t, err)
+		assert.DeepSSZEqual(t, states[slot].ToProtoUnsafe(), got.ToProtoUnsafe())
+	}
+}
@@ -89,6 +89,7 @@ type mockHistory struct {
 	states                         map[[32]byte]state.BeaconState
 	hiddenStates                   map[[32]byte]state.BeaconState
 	current                        primitives.Slot
+	prunedBefore                   primitives.Slot
 	overrideHighestSlotBlocksBelow func(context.Context, primitives.Slot) (primitives.Slot, [][32]byte, error)
 }
 
@@ -158,6 +159,10 @@ func (m *mockHistory) IsCanonical(_ context.Context, blockRoot [32]byte) (bool,
 	return ok && canon, nil
 }
 
+func (m *mockHistory) AvailableBlock(slot primitives.Slot) bool {
+	return slot == 0 || slot >= m.prunedBefore
+}
+
 func (m *mockHistory) CurrentSlot() primitives.Slot {
 	return m.current
 }
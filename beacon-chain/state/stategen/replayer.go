@@ -17,6 +17,7 @@ var ErrFutureSlotRequested = errors.New("cannot replay to future slots")
 var ErrNoCanonicalBlockForSlot = errors.New("none of the blocks found in the db slot index are canonical")
 var ErrNoBlocksBelowSlot = errors.New("no blocks found in db below slot")
 var ErrReplayTargetSlotExceeded = errors.New("desired replay slot is less than state's slot")
+var ErrStateNotCanonical = errors.New("state is not built on the canonical chain")
 
 type retrievalMethod int
 
@@ -30,6 +31,12 @@ type HistoryAccessor interface {
 	GenesisBlockRoot(ctx context.Context) ([32]byte, error)
 	Block(ctx context.Context, blockRoot [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error)
 	StateOrError(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
+	AvailableBlock(slot primitives.Slot) bool
+}
+
+// HierarchicalStateGetter gives access to the hierarchical states of the db, see kv.SaveHierarchicalState.
+type HierarchicalStateGetter interface {
+	HierarchicalStateForSlot(ctx context.Context, slot primitives.Slot) (state.BeaconState, error)
 }
 
 // CanonicalChecker determines whether the given block root is canonical.
@@ -177,3 +184,17 @@ type ReplayerBuilder interface {
 	// slots via process_slots.
 	ReplayerForSlot(target primitives.Slot) Replayer
 }
+
+// BlockObserver is called by a StateAdvancer with the state immediately before a canonical block is applied,
+// after process_slots has advanced it to the block's slot. The state is used for the rest of the replay,
+// so observers that need to mutate it must work on a copy.
+type BlockObserver func(ctx context.Context, preState state.BeaconState, b interfaces.ReadOnlySignedBeaconBlock) error
+
+// StateAdvancer moves a state the caller already holds forward along the canonical chain.
+// This allows callers walking a range of slots to apply each block once, instead of
+// building a new Replayer (and replaying from the nearest saved state) for every slot they are interested in.
+type StateAdvancer interface {
+	// AdvanceState applies the canonical blocks with slots in (st.Slot(), target] to st and then runs
+	// process_slots up to target. observe may be nil.
+	AdvanceState(ctx context.Context, st state.BeaconState, target primitives.Slot, observe BlockObserver) (state.BeaconState, error)
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/das"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
-	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	eth "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/sirupsen/logrus"
@@ -75,6 +74,8 @@ type batch struct {
 	blockPid       peer.ID
 	blobPid        peer.ID
 	bs             *blobSync
+	// blobsIncomplete is set when not all of the blobs of the batch could be downloaded.
+	blobsIncomplete bool
 }
 
 func (b batch) logFields() logrus.Fields {
@@ -145,12 +146,13 @@ func (b batch) withResults(results verifiedROBlocks, bs *blobSync) batch {
 	return b.withState(batchImportable)
 }
 
+// postBlobSync moves the batch to the importable state once its blobs have been downloaded. Blobs that could not be
+// downloaded don't hold up the import of the blocks, they are left to the blob backfill process instead.
 func (b batch) postBlobSync() batch {
 	if b.blobsNeeded() > 0 {
-		log.WithFields(b.logFields()).WithField("blobsMissing", b.blobsNeeded()).Error("Batch still missing blobs after downloading from peer")
-		b.bs = nil
-		b.results = []blocks.ROBlock{}
-		return b.withState(batchErrRetryable)
+		log.WithFields(b.logFields()).WithField("blobsMissing", b.blobsNeeded()).
+			Warn("Batch still missing blobs after downloading from peer, importing blocks without them")
+		b.blobsIncomplete = true
 	}
 	return b.withState(batchImportable)
 }
@@ -196,6 +198,9 @@ func (b batch) blobResponseValidator() sync.BlobResponseValidation {
 }
 
 func (b batch) availabilityStore() das.AvailabilityStore {
+	if b.blobsIncomplete {
+		return &partialAvailabilityStore{AvailabilityStore: b.bs.store}
+	}
 	return b.bs.store
 }
 
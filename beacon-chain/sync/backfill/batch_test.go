@@ -6,8 +6,14 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestSortBatchDesc(t *testing.T) {
@@ -41,3 +47,66 @@ func TestWaitUntilReady(t *testing.T) {
 	require.Equal(t, 1, b.retries)
 	batchBlockUntil = wur
 }
+
+func TestPostBlobSync_MissingBlobs(t *testing.T) {
+	current := primitives.Slot(128)
+	blks, blobs := testBlobGen(t, 63, 2)
+	cfg := &blobSyncConfig{
+		retentionStart: 0,
+		nbv: func(b blocks.ROBlob, _ []verification.Requirement) verification.BlobVerifier {
+			return &verification.MockBlobVerifier{CbVerifiedROBlob: func() (blocks.VerifiedROBlob, error) {
+				return blocks.NewVerifiedROBlob(b), nil
+			}}
+		},
+		store: filesystem.NewEphemeralBlobStorage(t),
+	}
+	bs, err := newBlobSync(current, blks, cfg)
+	require.NoError(t, err)
+	b := batch{}.withResults(blks, bs)
+	require.Equal(t, batchBlobSync, b.state)
+	// Only the blobs of the first block are received.
+	for i := range blobs[0] {
+		require.NoError(t, bs.validateNext(blobs[0][i]))
+	}
+	b = b.postBlobSync()
+	require.Equal(t, batchImportable, b.state)
+	require.Equal(t, true, b.blobsIncomplete)
+	require.Equal(t, 2, len(b.results))
+	for i := range blks {
+		require.NoError(t, b.availabilityStore().IsDataAvailable(context.Background(), current, blks[i]))
+	}
+	// The blobs that were received are saved.
+	idx, err := cfg.store.Indices(blks[0].Root(), blks[0].Block().Slot())
+	require.NoError(t, err)
+	require.DeepEqual(t, []bool{true, true, true}, idx[:3])
+	idx, err = cfg.store.Indices(blks[1].Root(), blks[1].Block().Slot())
+	require.NoError(t, err)
+	require.DeepEqual(t, []bool{false, false, false}, idx[:3])
+}
+
+func TestPartialAvailabilityStore_InvalidBlobs(t *testing.T) {
+	current := primitives.Slot(128)
+	blks, blobs := testBlobGen(t, 63, 2)
+	cfg := &blobSyncConfig{
+		retentionStart: 0,
+		nbv: func(b blocks.ROBlob, _ []verification.Requirement) verification.BlobVerifier {
+			return &verification.MockBlobVerifier{CbVerifiedROBlob: func() (blocks.VerifiedROBlob, error) {
+				return blocks.NewVerifiedROBlob(b), nil
+			}}
+		},
+		store: filesystem.NewEphemeralBlobStorage(t),
+	}
+	bs, err := newBlobSync(current, blks, cfg)
+	require.NoError(t, err)
+	b := batch{}.withResults(blks, bs).postBlobSync()
+	require.Equal(t, true, b.blobsIncomplete)
+
+	// A sidecar that does not match the commitment of its block is not a missing blob.
+	pb := proto.Clone(blobs[1][0].BlobSidecar).(*ethpb.BlobSidecar)
+	pb.KzgCommitment = bytesutil.PadTo([]byte("bad commitment"), 48)
+	bad, err := blocks.NewROBlobWithRoot(pb, blks[1].Root())
+	require.NoError(t, err)
+	require.NoError(t, bs.store.Persist(current, bad))
+	require.ErrorContains(t, "did not match block commitment", b.availabilityStore().IsDataAvailable(context.Background(), current, blks[1]))
+	require.NoError(t, b.availabilityStore().IsDataAvailable(context.Background(), current, blks[0]))
+}
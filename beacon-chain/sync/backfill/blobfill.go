@@ -0,0 +1,309 @@
+package backfill
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var errBlobsStillMissing = errors.New("blobs are still missing after downloading from peer")
+
+// BlobBackfillDB describes the set of DB methods that the blob backfill process needs.
+type BlobBackfillDB interface {
+	BlobBackfillStatus(context.Context) (*dbval.BlobBackfillStatus, error)
+	SaveBlobBackfillStatus(context.Context, *dbval.BlobBackfillStatus) error
+	FinalizedCheckpoint(context.Context) (*ethpb.Checkpoint, error)
+	Blocks(ctx context.Context, f *filters.QueryFilter) ([]interfaces.ReadOnlySignedBeaconBlock, [][32]byte, error)
+	IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool
+}
+
+// blobFiller downloads the blobs missing from the blob storage for the finalized blocks in the db, independently of
+// the blocks themselves. It keeps track of the range of blocks whose blobs are known to be on disk with a
+// BlobBackfillStatus value in the db, so that it can resume after a restart. The range grows upwards as blocks are
+// finalized, and downwards as far as the blob retention window and the blocks in the db allow.
+type blobFiller struct {
+	db        BlobBackfillDB
+	blocks    *Store
+	blobStore *filesystem.BlobStorage
+	clock     *startup.Clock
+	p2p       p2p.P2P
+	pa        PeerAssigner
+	cm        sync.ContextByteVersions
+	nbv       verification.NewBlobVerifier
+	batchSize primitives.Slot
+	status    *dbval.BlobBackfillStatus
+}
+
+// run fills the missing blobs one batch of slots at a time until the context is canceled.
+func (f *blobFiller) run(ctx context.Context) {
+	if err := f.loadStatus(ctx); err != nil {
+		log.WithError(err).Error("Could not initialize blob backfill")
+		return
+	}
+	for {
+		filled, err := f.fillNext(ctx)
+		if err != nil {
+			log.WithError(err).Debug("Blob backfill batch failed")
+		}
+		delay := time.Duration(0)
+		switch {
+		case err != nil:
+			delay = retryDelay
+		case !filled:
+			// Wait for finality or block backfill to make more blocks available.
+			delay = time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// loadStatus reads the blob backfill status from the db. If the blob storage no longer holds the blobs at either end
+// of the recorded range, for instance because the blob directory was wiped, the status is reset.
+func (f *blobFiller) loadStatus(ctx context.Context) error {
+	top, err := f.topSlot(ctx)
+	if err != nil {
+		return err
+	}
+	status, err := f.db.BlobBackfillStatus(ctx)
+	if err != nil {
+		if !errors.Is(err, db.ErrNotFound) {
+			return errors.Wrap(err, "could not read blob backfill status")
+		}
+		return f.saveStatus(ctx, &dbval.BlobBackfillStatus{LowSlot: uint64(top), HighSlot: uint64(top)})
+	}
+	f.status = status
+	low, high := primitives.Slot(status.LowSlot), primitives.Slot(status.HighSlot)
+	if bottom := f.bottomSlot(); low < bottom {
+		low = bottom
+	}
+	ends := [][2]primitives.Slot{{low, min(low+f.batchSize, high)}, {max(low, high-min(high, f.batchSize)), high}}
+	for _, e := range ends {
+		if e[0] >= e[1] {
+			continue
+		}
+		blks, err := f.canonicalBlocks(ctx, e[0], e[1])
+		if err != nil {
+			return err
+		}
+		missing, err := f.missingBlobs(ctx, blks)
+		if err != nil {
+			return err
+		}
+		if len(missing) > 0 {
+			log.WithFields(logrus.Fields{
+				"lowSlot":  status.LowSlot,
+				"highSlot": status.HighSlot,
+				"missing":  len(missing),
+			}).Warn("Blob storage is missing blobs that blob backfill previously saved, restarting blob backfill")
+			return f.saveStatus(ctx, &dbval.BlobBackfillStatus{LowSlot: uint64(top), HighSlot: uint64(top)})
+		}
+	}
+	return nil
+}
+
+// fillNext fills the blobs of the next batch of slots, extending the covered range upwards to the latest finalized
+// block first, then downwards to the oldest block that needs blobs. It returns false when there was nothing to fill.
+func (f *blobFiller) fillNext(ctx context.Context) (bool, error) {
+	top, err := f.topSlot(ctx)
+	if err != nil {
+		return false, err
+	}
+	bottom := f.bottomSlot()
+	if bottom >= top {
+		// No finalized block needs blobs yet.
+		return false, nil
+	}
+	if primitives.Slot(f.status.HighSlot) < bottom {
+		// None of the blocks below the bottom slot need blobs, so the range can start over from there.
+		if err := f.saveStatus(ctx, &dbval.BlobBackfillStatus{LowSlot: uint64(bottom), HighSlot: uint64(bottom)}); err != nil {
+			return false, err
+		}
+	}
+	status := &dbval.BlobBackfillStatus{LowSlot: f.status.LowSlot, HighSlot: f.status.HighSlot}
+	var begin, end primitives.Slot
+	switch {
+	case primitives.Slot(status.HighSlot) < top:
+		begin = primitives.Slot(status.HighSlot)
+		end = min(begin+f.batchSize, top)
+		status.HighSlot = uint64(end)
+	case primitives.Slot(status.LowSlot) > bottom:
+		end = primitives.Slot(status.LowSlot)
+		begin = max(bottom, end-min(end, f.batchSize))
+		status.LowSlot = uint64(begin)
+	default:
+		return false, nil
+	}
+	if err := f.fill(ctx, begin, end); err != nil {
+		return false, errors.Wrapf(err, "could not fill blobs for slots [%d, %d)", begin, end)
+	}
+	return true, f.saveStatus(ctx, status)
+}
+
+// fill downloads and saves the missing blobs of the canonical blocks in the [begin, end) slot range.
+func (f *blobFiller) fill(ctx context.Context, begin, end primitives.Slot) error {
+	blks, err := f.canonicalBlocks(ctx, begin, end)
+	if err != nil {
+		return err
+	}
+	missing, err := f.missingBlobs(ctx, blks)
+	if err != nil || len(missing) == 0 {
+		return err
+	}
+	// BlobSidecarsByRange responses include the blobs of every block in the range, so the request covers all the
+	// blocks from the first to the last one missing blobs.
+	first, last := missing[0].Block().Slot(), missing[len(missing)-1].Block().Slot()
+	vbs := make(verifiedROBlocks, 0, len(blks))
+	for _, b := range blks {
+		if b.Block().Slot() >= first && b.Block().Slot() <= last {
+			vbs = append(vbs, b)
+		}
+	}
+	current := f.clock.CurrentSlot()
+	retentionStart, err := sync.BlobRPCMinValidSlot(current)
+	if err != nil {
+		return errors.Wrap(err, "configuration issue, could not compute minimum blob retention slot")
+	}
+	bs, err := newBlobSync(current, vbs, &blobSyncConfig{retentionStart: retentionStart, nbv: f.nbv, store: f.blobStore})
+	if err != nil {
+		return err
+	}
+	pids, err := f.pa.Assign(map[peer.ID]bool{}, 1)
+	if err != nil {
+		return errors.Wrap(err, "could not assign a peer")
+	}
+	req := &ethpb.BlobSidecarsByRangeRequest{StartSlot: first, Count: uint64(last - first + 1)}
+	if _, err := sync.SendBlobsByRangeRequest(ctx, f.clock, f.p2p, pids[0], f.cm, req, bs.validateNext, blobValidationMetrics); err != nil {
+		return errors.Wrapf(err, "blob request to peer %s failed", pids[0])
+	}
+	if bs.blobsNeeded() > 0 {
+		return errors.Wrapf(errBlobsStillMissing, "%d blobs not received from peer %s", bs.blobsNeeded(), pids[0])
+	}
+	for _, b := range vbs {
+		if err := bs.store.IsDataAvailable(ctx, current, b); err != nil {
+			return err
+		}
+	}
+	nFilled := 0
+	for _, b := range missing {
+		c, err := b.Block().Body().BlobKzgCommitments()
+		if err != nil {
+			return err
+		}
+		nFilled += len(c)
+	}
+	blobBackfillBlobsFilled.Add(float64(nFilled))
+	log.WithFields(logrus.Fields{
+		"begin":  begin,
+		"end":    end,
+		"blocks": len(missing),
+	}).Debug("Blob backfill saved missing blobs")
+	return nil
+}
+
+// canonicalBlocks returns the finalized blocks with blob commitments in the [begin, end) slot range, in slot order.
+func (f *blobFiller) canonicalBlocks(ctx context.Context, begin, end primitives.Slot) (verifiedROBlocks, error) {
+	blks, roots, err := f.db.Blocks(ctx, filters.NewFilter().SetStartSlot(begin).SetEndSlot(end-1))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read blocks for slots [%d, %d)", begin, end)
+	}
+	result := make(verifiedROBlocks, 0, len(blks))
+	for i := range blks {
+		if blks[i].Version() < version.Deneb || !f.db.IsFinalizedBlock(ctx, roots[i]) {
+			continue
+		}
+		c, err := blks[i].Block().Body().BlobKzgCommitments()
+		if err != nil {
+			return nil, err
+		}
+		if len(c) == 0 {
+			continue
+		}
+		rb, err := blocks.NewROBlockWithRoot(blks[i], roots[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, rb)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Block().Slot() < result[j].Block().Slot()
+	})
+	return result, nil
+}
+
+// missingBlobs returns the blocks that the blob storage doesn't have all the blobs of.
+func (f *blobFiller) missingBlobs(ctx context.Context, blks verifiedROBlocks) (verifiedROBlocks, error) {
+	sumz, err := f.blobStore.WaitForSummarizer(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "blob storage summary is not available")
+	}
+	var missing verifiedROBlocks
+	for _, b := range blks {
+		c, err := b.Block().Body().BlobKzgCommitments()
+		if err != nil {
+			return nil, err
+		}
+		if !sumz.Summary(b.Root()).AllAvailable(len(c)) {
+			missing = append(missing, b)
+		}
+	}
+	return missing, nil
+}
+
+// topSlot is the end of the range of slots that blob backfill covers, exclusive: the slot after the latest finalized
+// checkpoint.
+func (f *blobFiller) topSlot(ctx context.Context) (primitives.Slot, error) {
+	cp, err := f.db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not read finalized checkpoint")
+	}
+	start, err := slots.EpochStart(cp.Epoch)
+	if err != nil {
+		return 0, err
+	}
+	return start + 1, nil
+}
+
+// bottomSlot is the lowest slot that blob backfill covers, which is the start of the blob retention window, unless
+// block backfill has not reached it yet.
+func (f *blobFiller) bottomSlot() primitives.Slot {
+	bottom, err := sync.BlobRPCMinValidSlot(f.clock.CurrentSlot())
+	if err != nil {
+		log.WithError(err).Error("Configuration issue, could not compute minimum blob retention slot")
+	}
+	if f.blocks.isGenesisSync() {
+		return bottom
+	}
+	return max(bottom, primitives.Slot(f.blocks.status().LowSlot))
+}
+
+func (f *blobFiller) saveStatus(ctx context.Context, status *dbval.BlobBackfillStatus) error {
+	if err := f.db.SaveBlobBackfillStatus(ctx, status); err != nil {
+		return errors.Wrap(err, "could not save blob backfill status")
+	}
+	f.status = status
+	blobBackfillLowSlot.Set(float64(status.LowSlot))
+	return nil
+}
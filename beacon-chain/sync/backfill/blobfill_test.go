@@ -0,0 +1,131 @@
+package backfill
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/proto/dbval"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type mockBlobBackfillDB struct {
+	status    *dbval.BlobBackfillStatus
+	finalized primitives.Epoch
+	blocks    []blocks.ROBlock
+}
+
+var _ BlobBackfillDB = &mockBlobBackfillDB{}
+
+func (d *mockBlobBackfillDB) BlobBackfillStatus(_ context.Context) (*dbval.BlobBackfillStatus, error) {
+	if d.status == nil {
+		return nil, db.ErrNotFound
+	}
+	return d.status, nil
+}
+
+func (d *mockBlobBackfillDB) SaveBlobBackfillStatus(_ context.Context, status *dbval.BlobBackfillStatus) error {
+	d.status = status
+	return nil
+}
+
+func (d *mockBlobBackfillDB) FinalizedCheckpoint(_ context.Context) (*ethpb.Checkpoint, error) {
+	return &ethpb.Checkpoint{Epoch: d.finalized}, nil
+}
+
+func (d *mockBlobBackfillDB) Blocks(_ context.Context, f *filters.QueryFilter) ([]interfaces.ReadOnlySignedBeaconBlock, [][32]byte, error) {
+	start := f.Filters()[filters.StartSlot].(primitives.Slot)
+	end := f.Filters()[filters.EndSlot].(primitives.Slot)
+	var blks []interfaces.ReadOnlySignedBeaconBlock
+	var roots [][32]byte
+	for _, b := range d.blocks {
+		if b.Block().Slot() >= start && b.Block().Slot() <= end {
+			blks = append(blks, b)
+			roots = append(roots, b.Root())
+		}
+	}
+	return blks, roots, nil
+}
+
+func (d *mockBlobBackfillDB) IsFinalizedBlock(_ context.Context, _ [32]byte) bool {
+	return true
+}
+
+func TestBlobFiller(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch, cfg.BellatrixForkEpoch, cfg.CapellaForkEpoch, cfg.DenebForkEpoch = 0, 0, 0, 0
+	params.OverrideBeaconConfig(cfg)
+	ctx := context.Background()
+
+	d := &mockBlobBackfillDB{finalized: 2}
+	blobStore := filesystem.NewEphemeralBlobStorage(t)
+	for slot := primitives.Slot(1); slot < 96; slot++ {
+		b, blobs := util.GenerateTestDenebBlockWithSidecar(t, [32]byte{}, slot, 1)
+		d.blocks = append(d.blocks, b)
+		require.NoError(t, blobStore.Save(verification.FakeVerifyForTest(t, blobs[0])))
+	}
+	slotDuration := time.Duration(cfg.SecondsPerSlot) * time.Second
+	clock := startup.NewClock(time.Now().Add(-200*slotDuration), [32]byte{})
+	newFiller := func(blobStore *filesystem.BlobStorage, blockStatus *Store) *blobFiller {
+		return &blobFiller{
+			db:        d,
+			blocks:    blockStatus,
+			blobStore: blobStore,
+			clock:     clock,
+			pa:        &mockAssigner{err: errors.New("no peers")},
+			nbv:       testNewBlobVerifier(),
+			batchSize: 16,
+		}
+	}
+	fillAll := func(f *blobFiller) {
+		for {
+			filled, err := f.fillNext(ctx)
+			require.NoError(t, err)
+			if !filled {
+				return
+			}
+		}
+	}
+
+	// The range starts at the finalized checkpoint, and is filled down to the bottom of the retention window.
+	f := newFiller(blobStore, &Store{genesisSync: true})
+	require.NoError(t, f.loadStatus(ctx))
+	require.DeepEqual(t, &dbval.BlobBackfillStatus{LowSlot: 65, HighSlot: 65}, d.status)
+	fillAll(f)
+	require.DeepEqual(t, &dbval.BlobBackfillStatus{LowSlot: 0, HighSlot: 65}, d.status)
+
+	// The range follows finality after a restart.
+	d.finalized = 3
+	f = newFiller(blobStore, &Store{genesisSync: true})
+	require.NoError(t, f.loadStatus(ctx))
+	require.DeepEqual(t, &dbval.BlobBackfillStatus{LowSlot: 0, HighSlot: 65}, d.status)
+	fillAll(f)
+	require.DeepEqual(t, &dbval.BlobBackfillStatus{LowSlot: 0, HighSlot: 97}, d.status)
+
+	// A wiped blob storage restarts blob backfill, and the range doesn't move while blobs are missing.
+	f = newFiller(filesystem.NewEphemeralBlobStorage(t), &Store{genesisSync: true})
+	require.NoError(t, f.loadStatus(ctx))
+	require.DeepEqual(t, &dbval.BlobBackfillStatus{LowSlot: 97, HighSlot: 97}, d.status)
+	_, err := f.fillNext(ctx)
+	require.ErrorContains(t, "no peers", err)
+	require.DeepEqual(t, &dbval.BlobBackfillStatus{LowSlot: 97, HighSlot: 97}, d.status)
+
+	// Blob backfill doesn't go lower than block backfill.
+	f = newFiller(blobStore, &Store{bs: &dbval.BackfillStatus{LowSlot: 40}})
+	require.NoError(t, f.loadStatus(ctx))
+	fillAll(f)
+	require.DeepEqual(t, &dbval.BlobBackfillStatus{LowSlot: 40, HighSlot: 97}, d.status)
+}
@@ -3,6 +3,7 @@ package backfill
 import (
 	"bytes"
 	"context"
+	"fmt"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/das"
@@ -143,3 +144,21 @@ func (bbv *blobBatchVerifier) VerifiedROBlobs(_ context.Context, blk blocks.ROBl
 }
 
 var _ das.BlobBatchVerifier = &blobBatchVerifier{}
+
+// partialAvailabilityStore is the AvailabilityStore of a batch that is missing some of its blobs. The blobs that were
+// downloaded are saved, but blocks missing blobs pass the availability check, so that they can be imported. The
+// missing blobs are left to the blob backfill process. Blobs that were downloaded but are invalid still fail the
+// check.
+type partialAvailabilityStore struct {
+	das.AvailabilityStore
+}
+
+func (s *partialAvailabilityStore) IsDataAvailable(ctx context.Context, current primitives.Slot, b blocks.ROBlock) error {
+	err := s.AvailabilityStore.IsDataAvailable(ctx, current, b)
+	if errors.Is(err, das.ErrMissingSidecar) {
+		log.WithError(err).WithField("root", fmt.Sprintf("%#x", b.Root())).WithField("slot", b.Block().Slot()).
+			Debug("Importing backfill block without all of its blobs")
+		return nil
+	}
+	return err
+}
@@ -27,6 +27,24 @@ var (
 			Help: "Backfill remaining batches.",
 		},
 	)
+	backfillLowSlot = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "backfill_low_slot",
+			Help: "Slot of the lowest block imported by backfill.",
+		},
+	)
+	blobBackfillLowSlot = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "backfill_blobs_low_slot",
+			Help: "Lowest slot of the range of finalized blocks whose blobs are known to be on disk.",
+		},
+	)
+	blobBackfillBlobsFilled = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "backfill_blobs_filled",
+			Help: "Number of blobs downloaded and saved by blob backfill.",
+		},
+	)
 	backfillBatchesImported = promauto.NewCounter(
 		prometheus.CounterOpts{
 			Name: "backfill_batches_imported",
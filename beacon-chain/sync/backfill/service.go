@@ -39,6 +39,7 @@ type Service struct {
 	batchImporter   batchImporter
 	blobStore       *filesystem.BlobStorage
 	initSyncWaiter  func() error
+	blobDB          BlobBackfillDB
 }
 
 var _ runtime.Service = (*Service)(nil)
@@ -136,6 +137,28 @@ func WithMinimumSlot(s primitives.Slot) ServiceOption {
 	}
 }
 
+// WithBackfillToGenesis makes backfill download blocks all the way back to genesis, instead of stopping at
+// current - MIN_EPOCHS_FOR_BLOCK_REQUESTS, so that the states before the checkpoint sync origin can be reconstructed.
+func WithBackfillToGenesis() ServiceOption {
+	return func(s *Service) error {
+		s.ms = func(primitives.Slot) primitives.Slot {
+			// Slot 0 is the genesis block, which is already in the db and can't be verified like other blocks.
+			return 1
+		}
+		return nil
+	}
+}
+
+// WithBlobBackfill enables the backfill of blobs missing from the blob storage for the blocks in the db, which is
+// tracked independently of block backfill, so that blobs can be filled again after the blob storage was wiped or the
+// blob retention period was extended.
+func WithBlobBackfill(d BlobBackfillDB) ServiceOption {
+	return func(s *Service) error {
+		s.blobDB = d
+		return nil
+	}
+}
+
 // NewService initializes the backfill Service. Like all implementations of the Service interface,
 // the service won't begin its runloop until Start() is called.
 func NewService(ctx context.Context, su *Store, bStore *filesystem.BlobStorage, cw startup.ClockWaiter, p p2p.P2P, pa PeerAssigner, opts ...ServiceOption) (*Service, error) {
@@ -220,10 +243,12 @@ func (s *Service) importBatches(ctx context.Context) {
 	}
 
 	nt := s.batchSeq.numTodo()
+	low := s.store.status().LowSlot
 	log.WithField("imported", imported).WithField("importable", len(importable)).
-		WithField("batchesRemaining", nt).
+		WithField("batchesRemaining", nt).WithField("lowestBackfilledSlot", low).
 		Info("Backfill batches processed")
 
+	backfillLowSlot.Set(float64(low))
 	backfillRemainingBatches.Set(float64(nt))
 }
 
@@ -271,6 +296,10 @@ func (s *Service) Start() {
 		return
 	}
 
+	if s.blobDB != nil {
+		go s.runBlobFiller()
+	}
+
 	if s.store.isGenesisSync() {
 		log.Info("Backfill short-circuit; node synced from genesis")
 		return
@@ -319,6 +348,36 @@ func (s *Service) Start() {
 	}
 }
 
+// runBlobFiller runs blob backfill until the service context is canceled. Unlike block backfill, it also runs on
+// nodes synced from genesis, and keeps running once the blob retention window is filled, to follow finality.
+func (s *Service) runBlobFiller() {
+	if s.initSyncWaiter != nil {
+		if err := s.initSyncWaiter(); err != nil {
+			log.WithError(err).Error("Error waiting for init-sync to complete")
+			return
+		}
+	}
+	vr := s.clock.GenesisValidatorsRoot()
+	cm, err := sync.ContextByteVersionsForValRoot(vr)
+	if err != nil {
+		log.WithError(err).Errorf("Unable to initialize context version map using genesis validator root %#x", vr)
+		return
+	}
+	f := &blobFiller{
+		db:        s.blobDB,
+		blocks:    s.store,
+		blobStore: s.blobStore,
+		clock:     s.clock,
+		p2p:       s.p2p,
+		pa:        s.pa,
+		cm:        cm,
+		nbv:       s.newBlobVerifier,
+		batchSize: primitives.Slot(s.batchSize),
+	}
+	log.Info("Starting blob backfill")
+	f.run(s.ctx)
+}
+
 func (s *Service) initBatches() error {
 	batches, err := s.batchSeq.sequence()
 	if err != nil {
@@ -132,3 +132,11 @@ func TestBackfillMinSlotDefault(t *testing.T) {
 		require.Equal(t, specMin, s.ms(current))
 	})
 }
+
+func TestBackfillToGenesis(t *testing.T) {
+	oe := helpers.MinEpochsForBlockRequests()
+	current := primitives.Slot((oe + 100).Mul(uint64(params.BeaconConfig().SlotsPerEpoch)))
+	s := &Service{ms: minimumBackfillSlot}
+	require.NoError(t, WithBackfillToGenesis()(s))
+	require.Equal(t, primitives.Slot(1), s.ms(current))
+}
@@ -48,7 +48,11 @@ type Store struct {
 // AvailableBlock determines if the given slot is covered by the current chain history.
 // If the slot is <= backfill low slot, or >= backfill high slot, the result is true.
 // If the slot is between the backfill low and high slots, the result is false.
+// Slots that have been removed from the db by history pruning are never available.
 func (s *Store) AvailableBlock(sl primitives.Slot) bool {
+	if !s.store.AvailableBlock(sl) {
+		return false
+	}
 	s.RLock()
 	defer s.RUnlock()
 	// short circuit if the node was synced from genesis
@@ -178,4 +182,5 @@ type BeaconDB interface {
 	Block(context.Context, [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error)
 	SaveROBlocks(ctx context.Context, blks []blocks.ROBlock, cache bool) error
 	StateOrError(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
+	AvailableBlock(primitives.Slot) bool
 }
@@ -30,6 +30,7 @@ type mockBackfillDB struct {
 	err                       error
 	states                    map[[32]byte]state.BeaconState
 	blocks                    map[[32]byte]blocks.ROBlock
+	prunedBefore              primitives.Slot
 }
 
 var _ BeaconDB = &mockBackfillDB{}
@@ -89,6 +90,10 @@ func (d *mockBackfillDB) BackfillFinalizedIndex(ctx context.Context, blocks []bl
 	return nil
 }
 
+func (d *mockBackfillDB) AvailableBlock(sl primitives.Slot) bool {
+	return sl == 0 || sl >= d.prunedBefore
+}
+
 func TestSlotCovered(t *testing.T) {
 	cases := []struct {
 		name   string
@@ -98,28 +103,46 @@ func TestSlotCovered(t *testing.T) {
 	}{
 		{
 			name:   "genesis true",
-			status: &Store{bs: &dbval.BackfillStatus{LowSlot: 10}},
+			status: &Store{bs: &dbval.BackfillStatus{LowSlot: 10}, store: &mockBackfillDB{}},
 			slot:   0,
 			result: true,
 		},
 		{
 			name:   "above end true",
-			status: &Store{bs: &dbval.BackfillStatus{LowSlot: 1}},
+			status: &Store{bs: &dbval.BackfillStatus{LowSlot: 1}, store: &mockBackfillDB{}},
 			slot:   2,
 			result: true,
 		},
 		{
 			name:   "equal end true",
-			status: &Store{bs: &dbval.BackfillStatus{LowSlot: 1}},
+			status: &Store{bs: &dbval.BackfillStatus{LowSlot: 1}, store: &mockBackfillDB{}},
 			slot:   1,
 			result: true,
 		},
 		{
 			name:   "genesisSync always true",
-			status: &Store{genesisSync: true},
+			status: &Store{genesisSync: true, store: &mockBackfillDB{}},
 			slot:   100,
 			result: true,
 		},
+		{
+			name:   "pruned false",
+			status: &Store{genesisSync: true, store: &mockBackfillDB{prunedBefore: 64}},
+			slot:   63,
+			result: false,
+		},
+		{
+			name:   "above pruned true",
+			status: &Store{bs: &dbval.BackfillStatus{LowSlot: 1}, store: &mockBackfillDB{prunedBefore: 64}},
+			slot:   64,
+			result: true,
+		},
+		{
+			name:   "genesis never pruned",
+			status: &Store{genesisSync: true, store: &mockBackfillDB{prunedBefore: 64}},
+			slot:   0,
+			result: true,
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
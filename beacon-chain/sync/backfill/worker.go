@@ -87,8 +87,10 @@ func (w *p2pWorker) handleBlobs(ctx context.Context, b batch) batch {
 	// adds each of them to a batch AvailabilityStore once it is checked.
 	blobs, err := sync.SendBlobsByRangeRequest(ctx, w.c, w.p2p, b.blobPid, w.cm, b.blobRequest(), b.blobResponseValidator(), blobValidationMetrics)
 	if err != nil {
-		b.bs = nil
-		return b.withRetryableError(err)
+		// The blocks of the batch are imported without the blobs that were not received, and the blob backfill
+		// process will request them again.
+		log.WithError(err).WithFields(b.logFields()).Debug("Batch blob request failed")
+		return b.postBlobSync()
 	}
 	dlt := time.Now()
 	backfillBatchTimeDownloadingBlobs.Observe(float64(dlt.Sub(start).Milliseconds()))
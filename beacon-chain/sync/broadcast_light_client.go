@@ -0,0 +1,134 @@
+package sync
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"google.golang.org/protobuf/proto"
+)
+
+// lightClientStore keeps the latest light client updates forwarded by this node. They are served over req/resp,
+// and the updates received over gossip are only forwarded if they match them.
+type lightClientStore struct {
+	sync.RWMutex
+	finalityUpdate   interfaces.LightClientFinalityUpdate
+	optimisticUpdate interfaces.LightClientOptimisticUpdate
+}
+
+func (l *lightClientStore) latestFinalityUpdate() interfaces.LightClientFinalityUpdate {
+	l.RLock()
+	defer l.RUnlock()
+	return l.finalityUpdate
+}
+
+func (l *lightClientStore) latestOptimisticUpdate() interfaces.LightClientOptimisticUpdate {
+	l.RLock()
+	defer l.RUnlock()
+	return l.optimisticUpdate
+}
+
+// setFinalityUpdate saves the update and returns true if it should be forwarded, that is if its finalized header is
+// newer than the one of the latest update, or if it is as new and the update is the first one for that header with
+// a supermajority of the sync committee participating.
+func (l *lightClientStore) setFinalityUpdate(update interfaces.LightClientFinalityUpdate) bool {
+	l.Lock()
+	defer l.Unlock()
+	if l.finalityUpdate != nil {
+		slot, latestSlot := update.FinalizedHeader().Beacon().Slot, l.finalityUpdate.FinalizedHeader().Beacon().Slot
+		if slot < latestSlot {
+			return false
+		}
+		if slot == latestSlot && (!hasSupermajority(update.SyncAggregate()) || hasSupermajority(l.finalityUpdate.SyncAggregate())) {
+			return false
+		}
+	}
+	l.finalityUpdate = update
+	return true
+}
+
+// setOptimisticUpdate saves the update and returns true if it should be forwarded, that is if its attested header
+// is newer than the one of the latest update.
+func (l *lightClientStore) setOptimisticUpdate(update interfaces.LightClientOptimisticUpdate) bool {
+	l.Lock()
+	defer l.Unlock()
+	if l.optimisticUpdate != nil && update.AttestedHeader().Beacon().Slot <= l.optimisticUpdate.AttestedHeader().Beacon().Slot {
+		return false
+	}
+	l.optimisticUpdate = update
+	return true
+}
+
+func hasSupermajority(aggregate *ethpb.SyncAggregate) bool {
+	if aggregate == nil {
+		return false
+	}
+	bits := aggregate.SyncCommitteeBits
+	return bits.Count()*3 > bits.Len()*2
+}
+
+// lightClientUpdatesRoutine listens to the light client updates computed by the blockchain service for each new
+// block, and broadcasts the ones that are better than the previously forwarded ones.
+func (s *Service) lightClientUpdatesRoutine() {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.cfg.stateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Could not subscribe to state events")
+			return
+		case ev := <-stateChannel:
+			switch ev.Type {
+			case statefeed.LightClientFinalityUpdate:
+				update, ok := ev.Data.(interfaces.LightClientFinalityUpdate)
+				if !ok {
+					log.Errorf("Received light client finality update event of type %T", ev.Data)
+					continue
+				}
+				if s.lcStore.setFinalityUpdate(update) {
+					go s.broadcastLightClientUpdate(update.SignatureSlot(), update.Proto())
+				}
+			case statefeed.LightClientOptimisticUpdate:
+				update, ok := ev.Data.(interfaces.LightClientOptimisticUpdate)
+				if !ok {
+					log.Errorf("Received light client optimistic update event of type %T", ev.Data)
+					continue
+				}
+				if s.lcStore.setOptimisticUpdate(update) {
+					go s.broadcastLightClientUpdate(update.SignatureSlot(), update.Proto())
+				}
+			}
+		}
+	}
+}
+
+// broadcastLightClientUpdate broadcasts the update once a third of its signature slot has passed, as peers ignore
+// updates received before the block at the signature slot had time to propagate.
+func (s *Service) broadcastLightClientUpdate(signatureSlot primitives.Slot, update proto.Message) {
+	if s.cfg.initialSync.Syncing() {
+		return
+	}
+	select {
+	case <-s.ctx.Done():
+		return
+	case <-time.After(time.Until(lightClientUpdateForwardTime(s.cfg.clock.SlotStart(signatureSlot)))):
+	}
+	if err := s.cfg.p2p.Broadcast(s.ctx, update); err != nil {
+		log.WithError(err).Debug("Could not broadcast light client update")
+	}
+}
+
+// lightClientUpdateForwardTime returns the time, a third of a slot after the given slot start, from which a light
+// client update signed at that slot can be forwarded.
+func lightClientUpdateForwardTime(slotStart time.Time) time.Time {
+	cfg := params.BeaconConfig()
+	return slotStart.Add(time.Duration(cfg.SecondsPerSlot) * time.Second / time.Duration(cfg.IntervalsPerSlot))
+}
@@ -2,28 +2,68 @@ package checkpoint
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/api/client"
 	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
 )
 
+var errNoQuorum = errors.New("checkpoint sync providers did not agree on a finalized checkpoint")
+
 // APIInitializer manages initializing the beacon node using checkpoint sync, retrieving the checkpoint state and root
-// from the remote beacon node api.
+// from the remote beacon node api. When several beacon nodes are given, a quorum of them must agree on the
+// finalized checkpoint before its state is downloaded.
 type APIInitializer struct {
-	c *beacon.Client
+	providers []*provider
+	quorum    int
+}
+
+type provider struct {
+	host string
+	c    *beacon.Client
+}
+
+// APIInitializerOpt is a functional option for the APIInitializer.
+type APIInitializerOpt func(*APIInitializer)
+
+// WithQuorum sets how many providers must agree on the finalized checkpoint. It defaults to a majority of them.
+func WithQuorum(quorum int) APIInitializerOpt {
+	return func(dl *APIInitializer) {
+		dl.quorum = quorum
+	}
 }
 
 // NewAPIInitializer creates an APIInitializer, handling the set up of a beacon node api client
-// using the provided host string.
-func NewAPIInitializer(beaconNodeHost string) (*APIInitializer, error) {
-	c, err := beacon.NewClient(beaconNodeHost, client.WithMaxBodySize(client.MaxBodySizeState))
-	if err != nil {
-		return nil, errors.Wrapf(err, "unable to parse beacon node url or hostname - %s", beaconNodeHost)
+// for each of the provided host strings.
+func NewAPIInitializer(beaconNodeHosts []string, opts ...APIInitializerOpt) (*APIInitializer, error) {
+	if len(beaconNodeHosts) == 0 {
+		return nil, errors.New("at least one checkpoint sync provider is required")
 	}
-	return &APIInitializer{c: c}, nil
+	dl := &APIInitializer{quorum: len(beaconNodeHosts)/2 + 1}
+	for _, host := range beaconNodeHosts {
+		c, err := beacon.NewClient(host, client.WithMaxBodySize(client.MaxBodySizeState))
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to parse beacon node url or hostname - %s", host)
+		}
+		dl.providers = append(dl.providers, &provider{host: host, c: c})
+	}
+	for _, o := range opts {
+		o(dl)
+	}
+	if dl.quorum < 1 || dl.quorum > len(dl.providers) {
+		return nil, fmt.Errorf("checkpoint sync quorum must be between 1 and the number of providers (%d), got %d", len(dl.providers), dl.quorum)
+	}
+	return dl, nil
 }
 
 // Initialize downloads origin state and block for checkpoint sync and initializes database records to
@@ -37,9 +77,105 @@ func (dl *APIInitializer) Initialize(ctx context.Context, d db.Database) error {
 	if err != nil && !errors.Is(err, db.ErrNotFound) {
 		return errors.Wrap(err, "error while checking database for origin root")
 	}
-	od, err := beacon.DownloadFinalizedData(ctx, dl.c)
+	od, err := dl.download(ctx)
 	if err != nil {
 		return errors.Wrap(err, "Error retrieving checkpoint origin state and block")
 	}
 	return d.SaveOrigin(ctx, od.StateBytes(), od.BlockBytes())
 }
+
+func (dl *APIInitializer) download(ctx context.Context) (*beacon.OriginData, error) {
+	if len(dl.providers) == 1 {
+		return beacon.DownloadFinalizedData(ctx, dl.providers[0].c)
+	}
+	cp, agreeing, err := dl.finalizedCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slot, err := slots.EpochStart(cp.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	// Finality may have moved on since the providers were asked, so the state is requested by the slot of the
+	// checkpoint rather than as the finalized state.
+	for _, p := range agreeing {
+		od, err := beacon.DownloadOriginData(ctx, p.c, beacon.IdFromSlot(slot))
+		if err != nil {
+			log.WithError(err).WithField("provider", p.host).Warn("Could not download checkpoint state and block")
+			continue
+		}
+		if od.BlockRoot() != bytesutil.ToBytes32(cp.Root) {
+			log.WithFields(logrus.Fields{
+				"provider":  p.host,
+				"expected":  fmt.Sprintf("%#x", cp.Root),
+				"blockRoot": fmt.Sprintf("%#x", od.BlockRoot()),
+			}).Warn("Checkpoint state downloaded from provider does not match the agreed checkpoint")
+			continue
+		}
+		log.WithField("provider", p.host).Info("Downloaded checkpoint state and block")
+		return od, nil
+	}
+	return nil, fmt.Errorf("could not download state for checkpoint %d/%#x from any agreeing provider", cp.Epoch, cp.Root)
+}
+
+// finalizedCheckpoint asks every provider for its finalized checkpoint, and returns the checkpoint agreed upon by
+// the most providers, as long as they meet the quorum, along with those providers.
+func (dl *APIInitializer) finalizedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, []*provider, error) {
+	checkpoints := make([]*ethpb.Checkpoint, len(dl.providers))
+	errs := make([]error, len(dl.providers))
+	var wg sync.WaitGroup
+	for i, p := range dl.providers {
+		wg.Add(1)
+		go func(i int, p *provider) {
+			defer wg.Done()
+			checkpoints[i], errs[i] = p.c.GetFinalizedCheckpoint(ctx, beacon.IdHead)
+		}(i, p)
+	}
+	wg.Wait()
+
+	type vote struct {
+		epoch primitives.Epoch
+		root  [32]byte
+	}
+	votes := make(map[vote][]*provider)
+	var best vote
+	for i, cp := range checkpoints {
+		if errs[i] != nil {
+			continue
+		}
+		v := vote{epoch: cp.Epoch, root: bytesutil.ToBytes32(cp.Root)}
+		votes[v] = append(votes[v], dl.providers[i])
+		if n := len(votes[v]); n > len(votes[best]) || (n == len(votes[best]) && v.epoch > best.epoch) {
+			best = v
+		}
+	}
+
+	var disagreeing []string
+	for i, p := range dl.providers {
+		switch {
+		case errs[i] != nil:
+			log.WithError(errs[i]).WithField("provider", p.host).Warn("Could not get finalized checkpoint from provider")
+			disagreeing = append(disagreeing, p.host)
+		case checkpoints[i].Epoch != best.epoch || bytesutil.ToBytes32(checkpoints[i].Root) != best.root:
+			log.WithFields(logrus.Fields{
+				"provider": p.host,
+				"epoch":    checkpoints[i].Epoch,
+				"root":     fmt.Sprintf("%#x", checkpoints[i].Root),
+			}).Warn("Provider disagrees with the finalized checkpoint of the other providers")
+			disagreeing = append(disagreeing, p.host)
+		}
+	}
+
+	agreeing := votes[best]
+	if len(agreeing) < dl.quorum {
+		return nil, nil, errors.Wrapf(errNoQuorum, "%d of %d providers agree on checkpoint %d/%#x, quorum is %d, disagreeing providers: %s",
+			len(agreeing), len(dl.providers), best.epoch, best.root, dl.quorum, strings.Join(disagreeing, ", "))
+	}
+	log.WithFields(logrus.Fields{
+		"epoch":     best.epoch,
+		"root":      fmt.Sprintf("%#x", best.root),
+		"agreeing":  len(agreeing),
+		"providers": len(dl.providers),
+	}).Info("Checkpoint sync providers agreed on finalized checkpoint")
+	return &ethpb.Checkpoint{Epoch: best.epoch, Root: best.root[:]}, agreeing, nil
+}
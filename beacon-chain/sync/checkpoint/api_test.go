@@ -0,0 +1,127 @@
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	blocktest "github.com/prysmaticlabs/prysm/v5/consensus-types/blocks/testing"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+type testOrigin struct {
+	state     []byte
+	block     []byte
+	blockRoot [32]byte
+}
+
+// newTestOrigin builds a state at the given slot along with the block it last applied.
+func newTestOrigin(t *testing.T, slot primitives.Slot, graffiti byte) *testOrigin {
+	cfg := params.BeaconConfig()
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetFork(&ethpb.Fork{
+		PreviousVersion: cfg.GenesisForkVersion,
+		CurrentVersion:  cfg.GenesisForkVersion,
+	}))
+	require.NoError(t, st.SetSlot(slot))
+
+	pb := util.NewBeaconBlock()
+	pb.Block.Body.Graffiti[0] = graffiti
+	b, err := blocks.NewSignedBeaconBlock(pb)
+	require.NoError(t, err)
+	b, err = blocktest.SetBlockSlot(b, slot)
+	require.NoError(t, err)
+	header, err := b.Header()
+	require.NoError(t, err)
+	require.NoError(t, st.SetLatestBlockHeader(header.Header))
+	sr, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	b, err = blocktest.SetBlockStateRoot(b, sr)
+	require.NoError(t, err)
+
+	o := &testOrigin{}
+	o.blockRoot, err = b.Block().HashTreeRoot()
+	require.NoError(t, err)
+	o.block, err = b.MarshalSSZ()
+	require.NoError(t, err)
+	o.state, err = st.MarshalSSZ()
+	require.NoError(t, err)
+	return o
+}
+
+// newTestProvider serves the finalized checkpoint, along with the state and block of the given origin.
+func newTestProvider(t *testing.T, cp *ethpb.Checkpoint, o *testOrigin) *httptest.Server {
+	slot, err := slots.EpochStart(cp.Epoch)
+	require.NoError(t, err)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		switch r.URL.Path {
+		case "/eth/v1/beacon/states/head/finality_checkpoints":
+			err = json.NewEncoder(w).Encode(&structs.GetFinalityCheckpointsResponse{Data: &structs.FinalityCheckpoints{
+				Finalized: &structs.Checkpoint{Epoch: fmt.Sprintf("%d", cp.Epoch), Root: hexutil.Encode(cp.Root)},
+			}})
+		case path.Join("/eth/v2/debug/beacon/states", fmt.Sprintf("%d", slot)):
+			_, err = w.Write(o.state)
+		case path.Join("/eth/v2/beacon/blocks", fmt.Sprintf("%d", slot)):
+			_, err = w.Write(o.block)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+		require.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestAPIInitializer_Quorum(t *testing.T) {
+	ctx := context.Background()
+	epoch := primitives.Epoch(10)
+	slot, err := slots.EpochStart(epoch)
+	require.NoError(t, err)
+	good := newTestOrigin(t, slot, 1)
+	evil := newTestOrigin(t, slot, 2)
+	goodCp := &ethpb.Checkpoint{Epoch: epoch, Root: good.blockRoot[:]}
+	evilCp := &ethpb.Checkpoint{Epoch: epoch, Root: evil.blockRoot[:]}
+
+	t.Run("majority agrees", func(t *testing.T) {
+		// The first agreeing provider serves a state that does not match the checkpoint it reported.
+		lying := newTestProvider(t, goodCp, evil)
+		honest := newTestProvider(t, goodCp, good)
+		disagreeing := newTestProvider(t, evilCp, evil)
+		dl, err := NewAPIInitializer([]string{lying.URL, honest.URL, disagreeing.URL})
+		require.NoError(t, err)
+		d := dbtest.SetupDB(t)
+		require.NoError(t, dl.Initialize(ctx, d))
+		origin, err := d.OriginCheckpointBlockRoot(ctx)
+		require.NoError(t, err)
+		require.Equal(t, good.blockRoot, origin)
+	})
+	t.Run("no quorum", func(t *testing.T) {
+		honest := newTestProvider(t, goodCp, good)
+		disagreeing := newTestProvider(t, evilCp, evil)
+		dl, err := NewAPIInitializer([]string{honest.URL, honest.URL, disagreeing.URL}, WithQuorum(3))
+		require.NoError(t, err)
+		err = dl.Initialize(ctx, dbtest.SetupDB(t))
+		require.Equal(t, true, errors.Is(err, errNoQuorum))
+		require.ErrorContains(t, "disagreeing providers: "+disagreeing.URL, err)
+	})
+	t.Run("invalid quorum", func(t *testing.T) {
+		_, err := NewAPIInitializer([]string{"http://localhost:3500"}, WithQuorum(2))
+		require.ErrorContains(t, "quorum must be between 1 and the number of providers", err)
+	})
+}
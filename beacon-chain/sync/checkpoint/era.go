@@ -0,0 +1,366 @@
+package checkpoint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/v5/io/era"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	errWeakSubjectivityMismatch = errors.New("archive does not match the weak subjectivity checkpoint")
+	errChainEnd                 = errors.New("end of the chain of archived blocks")
+	errNotFinalized             = errors.New("archived block conflicts with the finalized chain")
+)
+
+// EraInitializer initializes a beacon-node database to use checkpoint sync from an era file, or a directory of era
+// files. The latest state of the archive is used as the origin, and the blocks preceding it are imported so that
+// backfill does not need to fetch them. Everything is checked against a weak subjectivity checkpoint.
+type EraInitializer struct {
+	paths []string
+	ws    *ethpb.Checkpoint
+}
+
+var _ Initializer = &EraInitializer{}
+
+// NewEraInitializer creates an EraInitializer for the era file or directory of era files at path,
+// validated against the given weak subjectivity checkpoint.
+func NewEraInitializer(path string, ws *ethpb.Checkpoint) (*EraInitializer, error) {
+	if ws == nil {
+		return nil, errors.New("a weak subjectivity checkpoint is required to checkpoint sync from era files")
+	}
+	paths, err := era.List(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listing era archive %s for checkpoint sync init", path)
+	}
+	return &EraInitializer{paths: paths, ws: ws}, nil
+}
+
+// Initialize is called in the BeaconNode db startup code if an Initializer is present.
+// Initialize does what is needed to prepare the beacon node database for syncing from the latest state of the archive.
+func (ei *EraInitializer) Initialize(ctx context.Context, d db.Database) error {
+	origin, err := d.OriginCheckpointBlockRoot(ctx)
+	if err == nil && origin != params.BeaconConfig().ZeroHash {
+		log.Warnf("Origin checkpoint root %#x found in db, ignoring checkpoint sync flags", origin)
+		return nil
+	}
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		return errors.Wrap(err, "error while checking database for origin root")
+	}
+
+	files, closeFiles, err := openEraFiles(ei.paths)
+	if err != nil {
+		return err
+	}
+	defer closeFiles()
+
+	serState, err := files[0].State()
+	if err != nil {
+		return errors.Wrap(err, "could not read latest state of the archive")
+	}
+	vu, err := detect.FromState(serState)
+	if err != nil {
+		return errors.Wrap(err, "error detecting chain config for archived state")
+	}
+	st, err := vu.UnmarshalBeaconState(serState)
+	if err != nil {
+		return errors.Wrap(err, "error unmarshaling archived state")
+	}
+	originRoot, err := latestBlockRoot(ctx, st)
+	if err != nil {
+		return err
+	}
+	serBlock, originBlock, err := readBlock(files, st.LatestBlockHeader().Slot)
+	if err != nil {
+		return errors.Wrapf(err, "could not find block %#x of the archived state", originRoot)
+	}
+	if originBlock.Root() != originRoot {
+		return fmt.Errorf("archived block at slot %d has root %#x, but the archived state was built on %#x",
+			originBlock.Block().Slot(), originBlock.Root(), originRoot)
+	}
+	if err := ei.verifyWeakSubjectivity(st, originBlock, files); err != nil {
+		return err
+	}
+
+	if err := d.SaveOrigin(ctx, serState, serBlock); err != nil {
+		return err
+	}
+	return importBlocks(ctx, d, files, originBlock)
+}
+
+// ImportEraBlocks imports the blocks found in the era files at the given paths that precede the lowest block of the
+// database, and moves the low end of the backfill range down to the oldest of them. This is meant for databases that
+// were initialized with checkpoint sync. Blocks of the era files that overlap with the finalized blocks of the database
+// must match them.
+func ImportEraBlocks(ctx context.Context, d db.Database, paths []string) error {
+	status, err := d.BackfillStatus(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not read backfill status, only databases initialized with checkpoint sync can import blocks")
+	}
+	low, err := d.Block(ctx, bytesutil.ToBytes32(status.LowRoot))
+	if err != nil {
+		return errors.Wrapf(err, "could not read lowest block %#x", status.LowRoot)
+	}
+	lowBlock, err := blocks.NewROBlockWithRoot(low, bytesutil.ToBytes32(status.LowRoot))
+	if err != nil {
+		return err
+	}
+	files, closeFiles, err := openEraFiles(paths)
+	if err != nil {
+		return err
+	}
+	defer closeFiles()
+	if err := verifyFinalized(ctx, d, files, lowBlock.Block().Slot()); err != nil {
+		return err
+	}
+	return importBlocks(ctx, d, files, lowBlock)
+}
+
+// verifyFinalized checks that the blocks of the era files from the given slot up to the finalized checkpoint are
+// finalized blocks of the database, for the slots the database has blocks for.
+func verifyFinalized(ctx context.Context, d db.Database, files []*era.File, from primitives.Slot) error {
+	cp, err := d.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not read finalized checkpoint")
+	}
+	finalized, err := slots.EpochStart(cp.Epoch)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		for _, slot := range f.BlockSlots() {
+			if slot < from || slot > finalized {
+				continue
+			}
+			hasBlocks, _, err := d.BlockRootsBySlot(ctx, slot)
+			if err != nil {
+				return errors.Wrapf(err, "could not read block roots at slot %d", slot)
+			}
+			if !hasBlocks {
+				continue
+			}
+			b, err := readBlockFrom(f, slot)
+			if err != nil {
+				return err
+			}
+			if !d.IsFinalizedBlock(ctx, b.Root()) {
+				return errors.Wrapf(errNotFinalized, "archived block %#x at slot %d", b.Root(), slot)
+			}
+		}
+	}
+	return nil
+}
+
+// verifyWeakSubjectivity checks that the weak subjectivity checkpoint is part of the archived chain.
+func (ei *EraInitializer) verifyWeakSubjectivity(st state.BeaconState, originBlock blocks.ROBlock, files []*era.File) error {
+	wsSlot, err := slots.EpochStart(ei.ws.Epoch)
+	if err != nil {
+		return err
+	}
+	wsRoot := bytesutil.ToBytes32(ei.ws.Root)
+	var root [32]byte
+	switch {
+	case wsSlot > st.Slot():
+		return errors.Wrapf(errWeakSubjectivityMismatch, "latest archived state at slot %d is older than the checkpoint at slot %d", st.Slot(), wsSlot)
+	case wsSlot == st.Slot():
+		// A block at the slot of the state is in the era file of the next era, if it was provided.
+		root = originBlock.Root()
+		if _, b, err := readBlock(files, wsSlot); err == nil && b.Block().ParentRoot() == root {
+			root = b.Root()
+		}
+	case originBlock.Block().Slot() <= wsSlot:
+		// No block was archived between the checkpoint and the state.
+		root = originBlock.Root()
+	default:
+		// The block roots of the state are not trusted, only the parent roots of the archived blocks link the
+		// checkpoint to the origin block.
+		found := false
+		err := walkChain(files, originBlock, func(b blocks.ROBlock) error {
+			if b.Block().Slot() > wsSlot {
+				return nil
+			}
+			root, found = b.Root(), true
+			return errChainEnd
+		})
+		if err != nil && !errors.Is(err, errChainEnd) {
+			return err
+		}
+		if !found {
+			return errors.Wrapf(errWeakSubjectivityMismatch, "archived blocks do not reach back to the checkpoint at slot %d", wsSlot)
+		}
+	}
+	if root != wsRoot {
+		return errors.Wrapf(errWeakSubjectivityMismatch, "checkpoint root is %#x, archived chain has %#x at slot %d", wsRoot, root, wsSlot)
+	}
+	log.WithFields(logrus.Fields{
+		"epoch": ei.ws.Epoch,
+		"root":  fmt.Sprintf("%#x", wsRoot),
+	}).Info("Archived chain matches the weak subjectivity checkpoint")
+	return nil
+}
+
+// importBlocks saves the archived blocks preceding the origin block, one era at a time, and moves the low end of
+// the backfill range down to the oldest of them.
+func importBlocks(ctx context.Context, d db.Database, files []*era.File, originBlock blocks.ROBlock) error {
+	child := originBlock.Root()
+	batch := make([]blocks.ROBlock, 0)
+	imported := 0
+	save := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		// Batches are built from the highest slot down.
+		for i, j := 0, len(batch)-1; i < j; i, j = i+1, j-1 {
+			batch[i], batch[j] = batch[j], batch[i]
+		}
+		if err := d.SaveROBlocks(ctx, batch, false); err != nil {
+			return errors.Wrap(err, "could not save archived blocks")
+		}
+		if err := d.BackfillFinalizedIndex(ctx, batch, child); err != nil {
+			return errors.Wrap(err, "could not update finalized index for archived blocks")
+		}
+		status, err := d.BackfillStatus(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not read backfill status")
+		}
+		lowest := batch[0]
+		pr := lowest.Block().ParentRoot()
+		status.LowSlot = uint64(lowest.Block().Slot())
+		status.LowRoot = lowest.RootSlice()
+		status.LowParentRoot = pr[:]
+		if err := d.SaveBackfillStatus(ctx, status); err != nil {
+			return errors.Wrap(err, "could not save backfill status")
+		}
+		imported += len(batch)
+		child = lowest.Root()
+		batch = batch[:0]
+		return nil
+	}
+	err := walkChain(files, originBlock, func(b blocks.ROBlock) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		batch = append(batch, b)
+		if uint64(len(batch)) == uint64(params.BeaconConfig().SlotsPerHistoricalRoot) {
+			return save()
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errChainEnd) {
+		return err
+	}
+	if err := save(); err != nil {
+		return err
+	}
+	log.WithField("blocks", imported).Info("Imported archived blocks preceding the checkpoint sync origin")
+	return nil
+}
+
+// walkChain calls fn with the ancestors of the given block found in the era files, from the highest slot down,
+// until an ancestor is missing from the archive.
+func walkChain(files []*era.File, from blocks.ROBlock, fn func(blocks.ROBlock) error) error {
+	parent := from.Block().ParentRoot()
+	for _, f := range files {
+		slotList := f.BlockSlots()
+		for i := len(slotList) - 1; i >= 0; i-- {
+			if slotList[i] >= from.Block().Slot() {
+				continue
+			}
+			b, err := readBlockFrom(f, slotList[i])
+			if err != nil {
+				return err
+			}
+			if b.Root() != parent {
+				log.WithFields(logrus.Fields{
+					"slot":     slotList[i],
+					"root":     fmt.Sprintf("%#x", b.Root()),
+					"expected": fmt.Sprintf("%#x", parent),
+				}).Warn("Archived block is not the parent of the following block, ignoring older blocks")
+				return errChainEnd
+			}
+			if err := fn(b); err != nil {
+				return err
+			}
+			from, parent = b, b.Block().ParentRoot()
+		}
+	}
+	return nil
+}
+
+// openEraFiles opens the era files at the given paths, sorted from the latest era to the oldest one.
+func openEraFiles(paths []string) ([]*era.File, func(), error) {
+	files := make([]*era.File, 0, len(paths))
+	closeFiles := func() {
+		for _, f := range files {
+			if err := f.Close(); err != nil {
+				log.WithError(err).Error("Could not close era file")
+			}
+		}
+	}
+	for _, p := range paths {
+		f, err := era.Open(p)
+		if err != nil {
+			closeFiles()
+			return nil, nil, err
+		}
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].StateSlot() > files[j].StateSlot() })
+	return files, closeFiles, nil
+}
+
+func readBlock(files []*era.File, slot primitives.Slot) ([]byte, blocks.ROBlock, error) {
+	for _, f := range files {
+		if serBlock, err := f.Block(slot); err == nil {
+			b, err := unmarshalBlock(serBlock)
+			return serBlock, b, err
+		}
+	}
+	return nil, blocks.ROBlock{}, fmt.Errorf("no archived block at slot %d", slot)
+}
+
+func readBlockFrom(f *era.File, slot primitives.Slot) (blocks.ROBlock, error) {
+	serBlock, err := f.Block(slot)
+	if err != nil {
+		return blocks.ROBlock{}, err
+	}
+	return unmarshalBlock(serBlock)
+}
+
+func unmarshalBlock(serBlock []byte) (blocks.ROBlock, error) {
+	vu, err := detect.FromBlock(serBlock)
+	if err != nil {
+		return blocks.ROBlock{}, errors.Wrap(err, "error detecting chain config for archived block")
+	}
+	b, err := vu.UnmarshalBeaconBlock(serBlock)
+	if err != nil {
+		return blocks.ROBlock{}, errors.Wrap(err, "error unmarshaling archived block")
+	}
+	return blocks.NewROBlock(b)
+}
+
+// latestBlockRoot returns the root of the latest block applied to the state.
+func latestBlockRoot(ctx context.Context, st state.BeaconState) ([32]byte, error) {
+	header := st.LatestBlockHeader()
+	// The state root of the latest block header is only filled in by the next slot transition.
+	if bytesutil.ToBytes32(header.StateRoot) == [32]byte{} {
+		sr, err := st.HashTreeRoot(ctx)
+		if err != nil {
+			return [32]byte{}, errors.Wrap(err, "could not compute archived state root")
+		}
+		header.StateRoot = sr[:]
+	}
+	return header.HashTreeRoot()
+}
@@ -0,0 +1,296 @@
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db"
+	dbtest "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/io/era"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+type testArchive struct {
+	dir   string
+	roots map[primitives.Slot][32]byte
+	// origin is the last block of the archive, which the state of the latest era was built on.
+	origin primitives.Slot
+}
+
+// rootAt returns the root of the latest block at or before the slot.
+func (a *testArchive) rootAt(slot primitives.Slot) [32]byte {
+	for ; slot > 0; slot-- {
+		if r, ok := a.roots[slot]; ok {
+			return r
+		}
+	}
+	return [32]byte{}
+}
+
+// lowestFrom returns the slot of the first block at or after the slot.
+func (a *testArchive) lowestFrom(slot primitives.Slot) primitives.Slot {
+	for ; slot <= a.origin; slot++ {
+		if _, ok := a.roots[slot]; ok {
+			return slot
+		}
+	}
+	return 0
+}
+
+// newTestArchive writes eras 2 and 3 of a chain with a block every step slots.
+func newTestArchive(t *testing.T, step primitives.Slot) *testArchive {
+	return newTamperedArchive(t, step, nil)
+}
+
+// newTamperedArchive is like newTestArchive, but lets tamper change the block roots of the archived state.
+func newTamperedArchive(t *testing.T, step primitives.Slot, tamper func(blockRoots [][]byte)) *testArchive {
+	ctx := context.Background()
+	cfg := params.BeaconConfig()
+	perEra := cfg.SlotsPerHistoricalRoot
+	a := &testArchive{dir: t.TempDir(), roots: make(map[primitives.Slot][32]byte)}
+
+	blks := make(map[primitives.Slot]*ethpb.SignedBeaconBlock)
+	parent := [32]byte{0xff}
+	for slot := perEra; slot < 3*perEra; slot += step {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = bytesCopy(parent)
+		var err error
+		parent, err = b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		blks[slot], a.roots[slot], a.origin = b, parent, slot
+	}
+
+	// Build the state the way the state transition does: the origin block is applied, then the state root is
+	// filled in the latest header when advancing to the next slot.
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetFork(&ethpb.Fork{
+		PreviousVersion: cfg.GenesisForkVersion,
+		CurrentVersion:  cfg.GenesisForkVersion,
+	}))
+	require.NoError(t, st.SetSlot(a.origin))
+	origin := blks[a.origin]
+	bodyRoot, err := origin.Block.Body.HashTreeRoot()
+	require.NoError(t, err)
+	header := &ethpb.BeaconBlockHeader{
+		Slot:       origin.Block.Slot,
+		ParentRoot: origin.Block.ParentRoot,
+		StateRoot:  make([]byte, 32),
+		BodyRoot:   bodyRoot[:],
+	}
+	require.NoError(t, st.SetLatestBlockHeader(header))
+	sr, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	origin.Block.StateRoot = sr[:]
+	header.StateRoot = sr[:]
+	require.NoError(t, st.SetLatestBlockHeader(header))
+	a.roots[a.origin], err = origin.Block.HashTreeRoot()
+	require.NoError(t, err)
+	blockRoots := make([][]byte, perEra)
+	for slot := 2 * perEra; slot < 3*perEra; slot++ {
+		blockRoots[slot%perEra] = bytesCopy(a.rootAt(slot))
+	}
+	if tamper != nil {
+		tamper(blockRoots)
+	}
+	require.NoError(t, st.SetBlockRoots(blockRoots))
+	require.NoError(t, st.SetSlot(3*perEra))
+	serState, err := st.MarshalSSZ()
+	require.NoError(t, err)
+
+	for e := uint64(2); e <= 3; e++ {
+		buf := bytes.NewBuffer(nil)
+		w, err := era.NewWriter(buf, e)
+		require.NoError(t, err)
+		for slot := w.StartSlot(); slot < w.StateSlot(); slot++ {
+			if b, ok := blks[slot]; ok {
+				ser, err := b.MarshalSSZ()
+				require.NoError(t, err)
+				require.NoError(t, w.AddBlock(slot, ser))
+			}
+		}
+		// Only the state of the latest era is used.
+		require.NoError(t, w.AddState(serState))
+		require.NoError(t, w.Close())
+		path := filepath.Join(a.dir, era.FileName(cfg.ConfigName, e, [32]byte{byte(e)}))
+		require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+	}
+	return a
+}
+
+func bytesCopy(r [32]byte) []byte {
+	return append([]byte{}, r[:]...)
+}
+
+func TestEraInitializer(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig()
+	perEra := cfg.SlotsPerHistoricalRoot
+	ctx := context.Background()
+	const step = 28
+	a := newTestArchive(t, step)
+
+	epoch := func(s primitives.Slot) primitives.Epoch {
+		return primitives.Epoch(s / cfg.SlotsPerEpoch)
+	}
+	// Checkpoints that are not at the archived state are at the start of epochs without a block, so their root is
+	// the root of the last block before them.
+	recent := epoch(3*perEra) - 2
+	old := epoch(perEra) + 2
+	start := func(e primitives.Epoch) primitives.Slot {
+		return primitives.Slot(e) * cfg.SlotsPerEpoch
+	}
+	for _, e := range []primitives.Epoch{recent, old} {
+		_, ok := a.roots[start(e)]
+		require.Equal(t, false, ok)
+	}
+
+	cases := []struct {
+		name string
+		ws   *ethpb.Checkpoint
+		err  error
+	}{
+		{
+			name: "checkpoint at the archived state",
+			ws:   &ethpb.Checkpoint{Epoch: epoch(3 * perEra), Root: bytesCopy(a.roots[a.origin])},
+		},
+		{
+			name: "checkpoint in the latest era",
+			ws:   &ethpb.Checkpoint{Epoch: recent, Root: bytesCopy(a.rootAt(start(recent)))},
+		},
+		{
+			name: "checkpoint in the archived blocks",
+			ws:   &ethpb.Checkpoint{Epoch: old, Root: bytesCopy(a.rootAt(start(old)))},
+		},
+		{
+			name: "wrong root",
+			ws:   &ethpb.Checkpoint{Epoch: old, Root: bytesCopy(a.rootAt(start(old) + step))},
+			err:  errWeakSubjectivityMismatch,
+		},
+		{
+			name: "checkpoint older than the archive",
+			ws:   &ethpb.Checkpoint{Epoch: 1, Root: bytesCopy(a.roots[perEra])},
+			err:  errWeakSubjectivityMismatch,
+		},
+		{
+			name: "checkpoint newer than the archive",
+			ws:   &ethpb.Checkpoint{Epoch: epoch(4 * perEra), Root: bytesCopy(a.roots[a.origin])},
+			err:  errWeakSubjectivityMismatch,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := dbtest.SetupDB(t)
+			ei, err := NewEraInitializer(a.dir, c.ws)
+			require.NoError(t, err)
+			err = ei.Initialize(ctx, d)
+			if c.err != nil {
+				require.ErrorIs(t, err, c.err)
+				_, err := d.OriginCheckpointBlockRoot(ctx)
+				require.ErrorIs(t, err, db.ErrNotFound)
+				return
+			}
+			require.NoError(t, err)
+
+			originRoot, err := d.OriginCheckpointBlockRoot(ctx)
+			require.NoError(t, err)
+			require.Equal(t, a.roots[a.origin], originRoot)
+			status, err := d.BackfillStatus(ctx)
+			require.NoError(t, err)
+			require.Equal(t, uint64(perEra), status.LowSlot)
+			require.DeepEqual(t, bytesCopy(a.roots[perEra]), status.LowRoot)
+			for slot, root := range a.roots {
+				require.Equal(t, true, d.HasBlock(ctx, root), "missing block at slot %d", slot)
+			}
+			require.Equal(t, true, d.IsFinalizedBlock(ctx, a.roots[perEra+step]))
+		})
+	}
+}
+
+func TestEraInitializer_BrokenChain(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig()
+	perEra := cfg.SlotsPerHistoricalRoot
+	ctx := context.Background()
+	a := newTestArchive(t, 24)
+
+	// Replace era 2 with blocks from another chain, they should not be imported.
+	other := newTestArchive(t, 32)
+	name := era.FileName(cfg.ConfigName, 2, [32]byte{2})
+	require.NoError(t, os.Rename(filepath.Join(other.dir, name), filepath.Join(a.dir, name)))
+
+	d := dbtest.SetupDB(t)
+	ei, err := NewEraInitializer(a.dir, &ethpb.Checkpoint{
+		Epoch: primitives.Epoch(3 * perEra / cfg.SlotsPerEpoch),
+		Root:  bytesCopy(a.roots[a.origin]),
+	})
+	require.NoError(t, err)
+	require.NoError(t, ei.Initialize(ctx, d))
+	status, err := d.BackfillStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(a.lowestFrom(2*perEra)), status.LowSlot)
+	require.Equal(t, false, d.HasBlock(ctx, a.rootAt(2*perEra-1)))
+	require.Equal(t, false, d.HasBlock(ctx, other.rootAt(2*perEra-1)))
+}
+
+func TestEraInitializer_TamperedState(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig()
+	perEra := cfg.SlotsPerHistoricalRoot
+	ctx := context.Background()
+
+	// The block roots of the state claim a checkpoint the archived blocks do not descend from.
+	wsEpoch := primitives.Epoch(3*perEra/cfg.SlotsPerEpoch) - 2
+	wsSlot := primitives.Slot(wsEpoch) * cfg.SlotsPerEpoch
+	forged := [32]byte{0xaa}
+	a := newTamperedArchive(t, 28, func(blockRoots [][]byte) {
+		blockRoots[wsSlot%perEra] = bytesCopy(forged)
+	})
+
+	d := dbtest.SetupDB(t)
+	ei, err := NewEraInitializer(a.dir, &ethpb.Checkpoint{Epoch: wsEpoch, Root: bytesCopy(forged)})
+	require.NoError(t, err)
+	require.ErrorIs(t, ei.Initialize(ctx, d), errWeakSubjectivityMismatch)
+	_, err = d.OriginCheckpointBlockRoot(ctx)
+	require.ErrorIs(t, err, db.ErrNotFound)
+}
+
+func TestImportEraBlocks(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig()
+	perEra := cfg.SlotsPerHistoricalRoot
+	ctx := context.Background()
+	a := newTestArchive(t, 24)
+	eraPath := func(a *testArchive, e uint64) string {
+		return filepath.Join(a.dir, era.FileName(cfg.ConfigName, e, [32]byte{byte(e)}))
+	}
+
+	d := dbtest.SetupDB(t)
+	ei, err := NewEraInitializer(eraPath(a, 3), &ethpb.Checkpoint{
+		Epoch: primitives.Epoch(3 * perEra / cfg.SlotsPerEpoch),
+		Root:  bytesCopy(a.roots[a.origin]),
+	})
+	require.NoError(t, err)
+	require.NoError(t, ei.Initialize(ctx, d))
+	status, err := d.BackfillStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(a.lowestFrom(2*perEra)), status.LowSlot)
+
+	// Blocks of another chain at slots the db has finalized blocks for are rejected.
+	other := newTestArchive(t, 32)
+	require.ErrorIs(t, ImportEraBlocks(ctx, d, []string{eraPath(a, 2), eraPath(other, 3)}), errNotFinalized)
+
+	require.NoError(t, ImportEraBlocks(ctx, d, []string{eraPath(a, 2), eraPath(a, 3)}))
+	status, err = d.BackfillStatus(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(perEra), status.LowSlot)
+	require.Equal(t, true, d.IsFinalizedBlock(ctx, a.roots[perEra]))
+}
@@ -3,6 +3,7 @@ package initialsync
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -77,6 +78,8 @@ type blocksFetcherConfig struct {
 	peerFilterCapacityWeight float64
 	mode                     syncMode
 	bs                       filesystem.BlobStorageSummarizer
+	cs                       *filesystem.DataColumnStorage
+	custody                  map[uint64]bool
 }
 
 // blocksFetcher is a service to fetch chain data from peers.
@@ -93,6 +96,8 @@ type blocksFetcher struct {
 	p2p             p2p.P2P
 	db              db.ReadOnlyDatabase
 	bs              filesystem.BlobStorageSummarizer
+	cs              *filesystem.DataColumnStorage
+	custody         map[uint64]bool
 	blocksPerPeriod uint64
 	rateLimiter     *leakybucket.Collector
 	peerLocks       map[peer.ID]*peerLock
@@ -152,6 +157,8 @@ func newBlocksFetcher(ctx context.Context, cfg *blocksFetcherConfig) *blocksFetc
 		p2p:             cfg.p2p,
 		db:              cfg.db,
 		bs:              cfg.bs,
+		cs:              cfg.cs,
+		custody:         cfg.custody,
 		blocksPerPeriod: uint64(blocksPerPeriod),
 		rateLimiter:     rateLimiter,
 		peerLocks:       make(map[peer.ID]*peerLock),
@@ -321,6 +328,13 @@ func (f *blocksFetcher) handleRequest(ctx context.Context, start primitives.Slot
 		}
 		response.bwb = bwb
 	}
+	if response.err == nil {
+		bwb, err := f.fetchColumnsFromPeer(ctx, response.bwb, response.pid, peers)
+		if err != nil {
+			response.err = err
+		}
+		response.bwb = bwb
+	}
 	return response
 }
 
@@ -399,7 +413,8 @@ func countCommitments(bwb []blocks.BlockWithROBlobs, retentionStart primitives.S
 	for i := range bwb {
 		b := bwb[i]
 		slot := b.Block.Block().Slot()
-		if b.Block.Version() < version.Deneb {
+		// From Fulu, block data is fetched as data columns by fetchColumnsFromPeer.
+		if b.Block.Version() < version.Deneb || b.Block.Version() >= version.Fulu {
 			continue
 		}
 		if slot < retentionStart {
@@ -493,7 +508,7 @@ var errDidntPopulate = errors.New("skipping population of block")
 
 func populateBlock(bw blocks.BlockWithROBlobs, blobs []blocks.ROBlob, req *p2ppb.BlobSidecarsByRangeRequest, bss filesystem.BlobStorageSummarizer) (blocks.BlockWithROBlobs, error) {
 	blk := bw.Block
-	if blk.Version() < version.Deneb || blk.Block().Slot() < req.StartSlot {
+	if blk.Version() < version.Deneb || blk.Version() >= version.Fulu || blk.Block().Slot() < req.StartSlot {
 		return bw, errDidntPopulate
 	}
 	commits, err := blk.Block().Body().BlobKzgCommitments()
@@ -570,6 +585,138 @@ func (f *blocksFetcher) fetchBlobsFromPeer(ctx context.Context, bwb []blocks.Blo
 	return nil, errNoPeersAvailable
 }
 
+var errMissingColumnsForBlockCommitments = errors.New("peer unable to serve the custody columns for blocks with kzg commitments")
+
+// columnRequest builds a DataColumnSidecarsByRange request covering the Fulu blocks with commitments in the data
+// column retention window, for the custody columns which are not already in storage.
+func (f *blocksFetcher) columnRequest(bwb []blocks.BlockWithROBlobs, retentionStart primitives.Slot) *p2ppb.DataColumnSidecarsByRangeRequest {
+	var low, high primitives.Slot
+	found := false
+	missing := make(map[uint64]bool)
+	for i := range bwb {
+		b := bwb[i].Block
+		if b.Version() < version.Fulu || b.Block().Slot() < retentionStart {
+			continue
+		}
+		commits, err := b.Block().Body().BlobKzgCommitments()
+		if err != nil || len(commits) == 0 {
+			continue
+		}
+		needed := false
+		for idx := range f.custody {
+			if f.cs != nil && f.cs.Summary(b.Root()).HasIndex(idx) {
+				continue
+			}
+			missing[idx] = true
+			needed = true
+		}
+		if !needed {
+			continue
+		}
+		// bwb is sorted by slot, so the first block in need of columns gives the lower bound.
+		if !found {
+			low = b.Block().Slot()
+			found = true
+		}
+		high = b.Block().Slot()
+	}
+	if !found {
+		return nil
+	}
+	columns := make([]uint64, 0, len(missing))
+	for idx := range missing {
+		columns = append(columns, idx)
+	}
+	slices.Sort(columns)
+	return &p2ppb.DataColumnSidecarsByRangeRequest{
+		StartSlot: low,
+		Count:     uint64(high.FlooredSubSlot(low)) + 1,
+		Columns:   columns,
+	}
+}
+
+// populateColumns assigns the fetched columns to their blocks, ensuring every Fulu block in the request range
+// with commitments has all of the custody columns that are not already in storage.
+func (f *blocksFetcher) populateColumns(bwb []blocks.BlockWithROBlobs, dcs []blocks.RODataColumn, req *p2ppb.DataColumnSidecarsByRangeRequest) ([]blocks.BlockWithROBlobs, error) {
+	byRoot := make(map[[32]byte][]blocks.RODataColumn)
+	for i := range dcs {
+		r := dcs[i].BlockRoot()
+		byRoot[r] = append(byRoot[r], dcs[i])
+	}
+	for i := range bwb {
+		b := bwb[i].Block
+		if b.Version() < version.Fulu || b.Block().Slot() < req.StartSlot {
+			continue
+		}
+		commits, err := b.Block().Body().BlobKzgCommitments()
+		if err != nil || len(commits) == 0 {
+			continue
+		}
+		got := make(map[uint64]blocks.RODataColumn)
+		for _, dc := range byRoot[b.Root()] {
+			if len(dc.KzgCommitments) != len(commits) {
+				return bwb, errors.Wrapf(errMissingColumnsForBlockCommitments, "root=%#x column=%d commitments=%d, expected=%d", b.Root(), dc.ColumnIndex, len(dc.KzgCommitments), len(commits))
+			}
+			got[dc.ColumnIndex] = dc
+		}
+		columns := make([]blocks.RODataColumn, 0, len(f.custody))
+		for idx := range f.custody {
+			if f.cs != nil && f.cs.Summary(b.Root()).HasIndex(idx) {
+				continue
+			}
+			dc, ok := got[idx]
+			if !ok {
+				return bwb, errors.Wrapf(errMissingColumnsForBlockCommitments, "root=%#x slot=%d column=%d", b.Root(), b.Block().Slot(), idx)
+			}
+			columns = append(columns, dc)
+		}
+		bwb[i].Columns = columns
+	}
+	return bwb, nil
+}
+
+// fetchColumnsFromPeer fetches the custody columns of the Fulu blocks in the batch, starting with the peer which served the blocks.
+func (f *blocksFetcher) fetchColumnsFromPeer(ctx context.Context, bwb []blocks.BlockWithROBlobs, pid peer.ID, peers []peer.ID) ([]blocks.BlockWithROBlobs, error) {
+	ctx, span := trace.StartSpan(ctx, "initialsync.fetchColumnsFromPeer")
+	defer span.End()
+	currentEpoch := slots.ToEpoch(f.clock.CurrentSlot())
+	if len(f.custody) == 0 || currentEpoch < params.BeaconConfig().FuluForkEpoch {
+		return bwb, nil
+	}
+	var retentionEpoch primitives.Epoch
+	if minEpochs := params.BeaconConfig().MinEpochsForDataColumnSidecarsRequest; currentEpoch > minEpochs {
+		retentionEpoch = currentEpoch - minEpochs
+	}
+	retentionStart, err := slots.EpochStart(retentionEpoch)
+	if err != nil {
+		return nil, err
+	}
+	req := f.columnRequest(bwb, retentionStart)
+	if req == nil {
+		return bwb, nil
+	}
+	peers = f.filterPeers(ctx, peers, peersPercentagePerRequest)
+	wantedPeers := append([]peer.ID{pid}, peers...)
+	bestPeers := f.hasSufficientBandwidth(wantedPeers, req.Count)
+	peers = append(bestPeers, pid)
+	for i := 0; i < len(peers); i++ {
+		p := peers[i]
+		dcs, err := f.requestColumns(ctx, req, p)
+		if err != nil {
+			log.WithField("peer", p).WithError(err).Debug("Could not request data columns by range from peer")
+			continue
+		}
+		f.p2p.Peers().Scorers().BlockProviderScorer().Touch(p)
+		robs, err := f.populateColumns(bwb, dcs, req)
+		if err != nil {
+			log.WithField("peer", p).WithError(err).Debug("Invalid DataColumnSidecarsByRange response")
+			continue
+		}
+		return robs, nil
+	}
+	return nil, errNoPeersAvailable
+}
+
 // requestBlocks is a wrapper for handling BeaconBlocksByRangeRequest requests/streams.
 func (f *blocksFetcher) requestBlocks(
 	ctx context.Context,
@@ -627,6 +774,32 @@ func (f *blocksFetcher) requestBlobs(ctx context.Context, req *p2ppb.BlobSidecar
 	return prysmsync.SendBlobsByRangeRequest(ctx, f.clock, f.p2p, pid, f.ctxMap, req)
 }
 
+func (f *blocksFetcher) requestColumns(ctx context.Context, req *p2ppb.DataColumnSidecarsByRangeRequest, pid peer.ID) ([]blocks.RODataColumn, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	l := f.peerLock(pid)
+	l.Lock()
+	log.WithFields(logrus.Fields{
+		"peer":     pid,
+		"start":    req.StartSlot,
+		"count":    req.Count,
+		"columns":  len(req.Columns),
+		"capacity": f.rateLimiter.Remaining(pid.String()),
+		"score":    f.p2p.Peers().Scorers().BlockProviderScorer().FormatScorePretty(pid),
+	}).Debug("Requesting data columns")
+	// As with blobs, column requests are accounted for against the block rate limit.
+	if f.rateLimiter.Remaining(pid.String()) < int64(req.Count) {
+		if err := f.waitForBandwidth(pid, req.Count); err != nil {
+			l.Unlock()
+			return nil, err
+		}
+	}
+	f.rateLimiter.Add(pid.String(), int64(req.Count))
+	l.Unlock()
+	return prysmsync.SendDataColumnsByRangeRequest(ctx, f.clock, f.p2p, pid, f.ctxMap, req)
+}
+
 // requestBlocksByRoot is a wrapper for handling BeaconBlockByRootsReq requests/streams.
 func (f *blocksFetcher) requestBlocksByRoot(
 	ctx context.Context,
@@ -19,6 +19,7 @@ import (
 	p2pt "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
 	beaconsync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/verification"
 	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
@@ -1261,6 +1262,39 @@ func TestVerifyAndPopulateBlobs(t *testing.T) {
 	})
 }
 
+func TestColumnRequestAndPopulate(t *testing.T) {
+	b10, c10 := util.GenerateTestFuluBlockWithColumns(t, [32]byte{}, 10, 1)
+	b11, _ := util.GenerateTestFuluBlockWithColumns(t, b10.Root(), 11, 0)
+	b12, c12 := util.GenerateTestFuluBlockWithColumns(t, b11.Root(), 12, 2)
+	newBwb := func() []blocks.BlockWithROBlobs {
+		return []blocks.BlockWithROBlobs{{Block: b10}, {Block: b11}, {Block: b12}}
+	}
+	cs := filesystem.NewEphemeralDataColumnStorage(t)
+	// Column 1 of the first block is already on disk, so only column 5 is needed for it.
+	require.NoError(t, cs.Save(verification.FakeVerifyDataColumnSliceForTest(t, c10[1:2])...))
+	f := &blocksFetcher{cs: cs, custody: map[uint64]bool{1: true, 5: true}}
+
+	req := f.columnRequest(newBwb(), 0)
+	require.NotNil(t, req)
+	require.Equal(t, primitives.Slot(10), req.StartSlot)
+	require.Equal(t, uint64(3), req.Count)
+	require.DeepEqual(t, []uint64{1, 5}, req.Columns)
+	require.IsNil(t, f.columnRequest(newBwb(), 13))
+
+	t.Run("all custody columns", func(t *testing.T) {
+		bwb, err := f.populateColumns(newBwb(), append(append([]blocks.RODataColumn{}, c10...), c12...), req)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(bwb[0].Columns))
+		require.Equal(t, uint64(5), bwb[0].Columns[0].ColumnIndex)
+		require.Equal(t, 0, len(bwb[1].Columns))
+		require.Equal(t, 2, len(bwb[2].Columns))
+	})
+	t.Run("missing custody column", func(t *testing.T) {
+		_, err := f.populateColumns(newBwb(), []blocks.RODataColumn{c10[5], c12[1]}, req)
+		require.ErrorIs(t, err, errMissingColumnsForBlockCommitments)
+	})
+}
+
 func TestBatchLimit(t *testing.T) {
 	params.SetupTestConfigCleanup(t)
 	testCfg := params.BeaconConfig().Copy()
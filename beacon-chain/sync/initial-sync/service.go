@@ -53,21 +53,24 @@ type Config struct {
 	ClockWaiter         startup.ClockWaiter
 	InitialSyncComplete chan struct{}
 	BlobStorage         *filesystem.BlobStorage
+	DataColumnStorage   *filesystem.DataColumnStorage
 }
 
 // Service service.
 type Service struct {
-	cfg             *Config
-	ctx             context.Context
-	cancel          context.CancelFunc
-	synced          *abool.AtomicBool
-	chainStarted    *abool.AtomicBool
-	counter         *ratecounter.RateCounter
-	genesisChan     chan time.Time
-	clock           *startup.Clock
-	verifierWaiter  *verification.InitializerWaiter
-	newBlobVerifier verification.NewBlobVerifier
-	ctxMap          sync.ContextByteVersions
+	cfg                   *Config
+	ctx                   context.Context
+	cancel                context.CancelFunc
+	synced                *abool.AtomicBool
+	chainStarted          *abool.AtomicBool
+	counter               *ratecounter.RateCounter
+	genesisChan           chan time.Time
+	clock                 *startup.Clock
+	verifierWaiter        *verification.InitializerWaiter
+	newBlobVerifier       verification.NewBlobVerifier
+	newDataColumnVerifier verification.NewDataColumnVerifier
+	custody               map[uint64]bool
+	ctxMap                sync.ContextByteVersions
 }
 
 // Option is a functional option for the initial-sync Service.
@@ -148,6 +151,7 @@ func (s *Service) Start() {
 		return
 	}
 	s.newBlobVerifier = newBlobVerifierFromInitializer(v)
+	s.newDataColumnVerifier = newDataColumnVerifierFromInitializer(v)
 
 	gt := clock.GenesisTime()
 	if gt.IsZero() {
@@ -368,3 +372,20 @@ func newBlobVerifierFromInitializer(ini *verification.Initializer) verification.
 		return ini.NewBlobVerifier(b, reqs)
 	}
 }
+
+func newDataColumnVerifierFromInitializer(ini *verification.Initializer) verification.NewDataColumnVerifier {
+	return func(dc blocks.RODataColumn, reqs []verification.Requirement) verification.DataColumnVerifier {
+		return ini.NewDataColumnVerifier(dc, reqs)
+	}
+}
+
+// availabilityStore returns the AvailabilityStore used to check the data of blocks fetched by initial sync. Blocks
+// before Fulu are checked against their blobs, and later blocks against the columns the node custodies.
+func (s *Service) availabilityStore() *das.ForkAvailabilityStore {
+	bv := verification.NewBlobBatchVerifier(s.newBlobVerifier, verification.InitsyncBlobSidecarRequirements)
+	cv := verification.NewDataColumnBatchVerifier(s.newDataColumnVerifier, verification.ByRangeRequestDataColumnSidecarRequirements)
+	return das.NewForkAvailabilityStore(
+		das.NewLazilyPersistentStore(s.cfg.BlobStorage, bv),
+		das.NewLazilyPersistentStoreColumn(s.cfg.DataColumnStorage, cv, s.custody),
+	)
+}
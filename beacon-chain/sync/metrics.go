@@ -82,6 +82,13 @@ var (
 			Buckets: []float64{5, 10, 50, 100, 150, 250, 500, 1000, 2000},
 		},
 	)
+	rpcDataColumnsByRangeResponseLatency = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "rpc_data_columns_by_range_response_latency_milliseconds",
+			Help:    "Captures total time to respond to rpc DataColumnsByRange requests in a milliseconds distribution",
+			Buckets: []float64{5, 10, 50, 100, 150, 250, 500, 1000, 2000},
+		},
+	)
 	rpcBlobsByRangeResponseLatency = promauto.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "rpc_blobs_by_range_response_latency_milliseconds",
@@ -150,6 +157,18 @@ var (
 			Help: "Time to verify gossiped blob sidecars",
 		},
 	)
+	dataColumnSidecarArrivalGossipSummary = promauto.NewSummary(
+		prometheus.SummaryOpts{
+			Name: "gossip_data_column_sidecar_arrival_milliseconds",
+			Help: "Time for gossiped data column sidecars to arrive",
+		},
+	)
+	dataColumnSidecarVerificationGossipSummary = promauto.NewSummary(
+		prometheus.SummaryOpts{
+			Name: "gossip_data_column_sidecar_verification_milliseconds",
+			Help: "Time to verify gossiped data column sidecars",
+		},
+	)
 	pendingAttCount = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "gossip_pending_attestations_total",
 		Help: "increased when receiving a new pending attestation",
@@ -171,6 +190,14 @@ var (
 		},
 	)
 
+	// Dropped data column sidecars due to missing parent block.
+	missingParentDataColumnSidecarCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "gossip_missing_parent_data_column_sidecar_total",
+			Help: "The number of data column sidecars that were dropped due to missing parent block",
+		},
+	)
+
 	blobRecoveredFromELTotal = promauto.NewCounter(
 		prometheus.CounterOpts{
 			Name: "blob_recovered_from_el_total",
@@ -0,0 +1,103 @@
+package sync
+
+import (
+	"testing"
+
+	p2ptypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestValidateDataColumnsByRange(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.FuluForkEpoch = 1
+	params.OverrideBeaconConfig(cfg)
+	resetFlags := flags.Get()
+	flags.Init(&flags.GlobalFlags{DataColumnBatchLimit: 256})
+	defer flags.Init(resetFlags)
+
+	fuluStart, err := slots.EpochStart(params.BeaconConfig().FuluForkEpoch)
+	require.NoError(t, err)
+	current := fuluStart + 100
+
+	t.Run("zero count", func(t *testing.T) {
+		_, _, err := validateDataColumnsByRange(&ethpb.DataColumnSidecarsByRangeRequest{StartSlot: fuluStart, Columns: []uint64{1}}, current)
+		require.ErrorIs(t, err, p2ptypes.ErrInvalidRequest)
+	})
+	t.Run("column out of range", func(t *testing.T) {
+		req := &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: fuluStart, Count: 10, Columns: []uint64{params.BeaconConfig().NumberOfColumns}}
+		_, _, err := validateDataColumnsByRange(req, current)
+		require.ErrorIs(t, err, p2ptypes.ErrInvalidDataColumnIndexInReq)
+	})
+	t.Run("columns deduplicated and batch limited", func(t *testing.T) {
+		req := &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: fuluStart, Count: 128, Columns: []uint64{9, 1, 9, 5}}
+		rp, columns, err := validateDataColumnsByRange(req, current)
+		require.NoError(t, err)
+		require.DeepEqual(t, []uint64{1, 5, 9}, columns)
+		require.Equal(t, fuluStart, rp.start)
+		require.Equal(t, current, rp.end)
+		// 256 columns per batch / 3 requested columns.
+		require.Equal(t, uint64(85), rp.size)
+	})
+	t.Run("start before fulu clamped", func(t *testing.T) {
+		req := &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: 0, Count: 10, Columns: []uint64{1}}
+		rp, _, err := validateDataColumnsByRange(req, current)
+		require.NoError(t, err)
+		require.Equal(t, fuluStart, rp.start)
+	})
+	t.Run("start after current is a noop", func(t *testing.T) {
+		req := &ethpb.DataColumnSidecarsByRangeRequest{StartSlot: current + 1, Count: 10, Columns: []uint64{1}}
+		rp, _, err := validateDataColumnsByRange(req, current)
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), rp.size)
+	})
+}
+
+func TestDataColumnRPCMinValidSlot(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.FuluForkEpoch = 10
+	params.OverrideBeaconConfig(cfg)
+
+	minReq := params.BeaconConfig().MinEpochsForDataColumnSidecarsRequest
+	s, err := DataColumnRPCMinValidSlot(0)
+	require.NoError(t, err)
+	expected, err := slots.EpochStart(10)
+	require.NoError(t, err)
+	require.Equal(t, expected, s)
+
+	current, err := slots.EpochStart(minReq + 100)
+	require.NoError(t, err)
+	s, err = DataColumnRPCMinValidSlot(current)
+	require.NoError(t, err)
+	expected, err = slots.EpochStart(primitives.Epoch(100))
+	require.NoError(t, err)
+	require.Equal(t, expected, s)
+}
+
+func TestValidateDataColumnByRootRequest(t *testing.T) {
+	ids := p2ptypes.DataColumnSidecarsByRootReq{{BlockRoot: make([]byte, 32), ColumnIndex: 3}}
+	require.NoError(t, validateDataColumnByRootRequest(ids))
+
+	ids = append(ids, &ethpb.DataColumnIdentifier{BlockRoot: make([]byte, 32), ColumnIndex: params.BeaconConfig().NumberOfColumns})
+	require.ErrorIs(t, validateDataColumnByRootRequest(ids), p2ptypes.ErrInvalidDataColumnIndexInReq)
+
+	tooMany := make(p2ptypes.DataColumnSidecarsByRootReq, params.BeaconConfig().MaxRequestDataColumnSidecars+1)
+	require.ErrorIs(t, validateDataColumnByRootRequest(tooMany), p2ptypes.ErrMaxDataColumnReqExceeded)
+}
+
+func TestDataColumnSubnetFromTopic(t *testing.T) {
+	subnet, err := dataColumnSubnetFromTopic("/eth2/01020304/data_column_sidecar_17/ssz_snappy")
+	require.NoError(t, err)
+	require.Equal(t, uint64(17), subnet)
+
+	_, err = dataColumnSubnetFromTopic("/eth2/01020304/blob_sidecar_1/ssz_snappy")
+	require.ErrorIs(t, err, errInvalidTopic)
+	_, err = dataColumnSubnetFromTopic("/eth2/01020304/data_column_sidecar_x/ssz_snappy")
+	require.ErrorIs(t, err, errInvalidTopic)
+}
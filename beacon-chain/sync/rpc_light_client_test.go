@@ -0,0 +1,224 @@
+package sync
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	db "github.com/prysmaticlabs/prysm/v5/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/testing"
+	p2ptypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	lightclient "github.com/prysmaticlabs/prysm/v5/consensus-types/light-client"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	leakybucket "github.com/prysmaticlabs/prysm/v5/container/leaky-bucket"
+	"github.com/prysmaticlabs/prysm/v5/network/forks"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+func TestLightClientStore_SetFinalityUpdate(t *testing.T) {
+	var store lightClientStore
+	require.Equal(t, true, store.setFinalityUpdate(testFinalityUpdate(t, 20, 8, 10)))
+	// Older finalized header.
+	require.Equal(t, false, store.setFinalityUpdate(testFinalityUpdate(t, 21, 7, 500)))
+	// Same finalized header, and neither has a supermajority.
+	require.Equal(t, false, store.setFinalityUpdate(testFinalityUpdate(t, 21, 8, 300)))
+	// Same finalized header, and the first one with a supermajority.
+	require.Equal(t, true, store.setFinalityUpdate(testFinalityUpdate(t, 21, 8, 400)))
+	require.Equal(t, primitives.Slot(21), store.latestFinalityUpdate().AttestedHeader().Beacon().Slot)
+	require.Equal(t, false, store.setFinalityUpdate(testFinalityUpdate(t, 22, 8, 500)))
+	// Newer finalized header.
+	require.Equal(t, true, store.setFinalityUpdate(testFinalityUpdate(t, 23, 9, 1)))
+	require.Equal(t, primitives.Slot(9), store.latestFinalityUpdate().FinalizedHeader().Beacon().Slot)
+}
+
+func TestLightClientStore_SetOptimisticUpdate(t *testing.T) {
+	var store lightClientStore
+	require.Equal(t, true, store.setOptimisticUpdate(testOptimisticUpdate(t, 20, 10)))
+	require.Equal(t, false, store.setOptimisticUpdate(testOptimisticUpdate(t, 20, 500)))
+	require.Equal(t, false, store.setOptimisticUpdate(testOptimisticUpdate(t, 19, 500)))
+	require.Equal(t, true, store.setOptimisticUpdate(testOptimisticUpdate(t, 21, 1)))
+	require.Equal(t, primitives.Slot(21), store.latestOptimisticUpdate().AttestedHeader().Beacon().Slot)
+}
+
+func TestLightClientOptimisticUpdateRPCHandler(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	assert.Equal(t, 1, len(p1.BHost.Network().Peers()), "Expected peers to be connected")
+
+	chain := &mock.ChainService{ValidatorsRoot: [32]byte{'A'}, Genesis: time.Now()}
+	r := &Service{
+		cfg: &config{
+			p2p:   p1,
+			chain: chain,
+			clock: startup.NewClock(chain.Genesis, chain.ValidatorsRoot),
+		},
+		rateLimiter: newRateLimiter(p1),
+	}
+	pcl := protocol.ID(p2p.RPCLightClientOptimisticUpdateTopicV1 + p1.Encoding().ProtocolSuffix())
+	r.rateLimiter.limiterMap[string(pcl)] = leakybucket.NewCollector(2, 2, time.Second, false)
+
+	// No update was computed yet.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectFailure(t, responseCodeResourceUnavailable, p2ptypes.ErrResourceUnavailable.Error(), stream)
+	})
+	stream, err := p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	require.ErrorIs(t, r.lightClientOptimisticUpdateRPCHandler(context.Background(), nil, stream), p2ptypes.ErrResourceUnavailable)
+	if util.WaitTimeout(&wg, time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+
+	update := testOptimisticUpdate(t, 20, 10)
+	require.Equal(t, true, r.lcStore.setOptimisticUpdate(update))
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectSuccess(t, stream)
+		ctxBytes, err := readContextFromStream(stream)
+		require.NoError(t, err)
+		digest, err := forks.ForkDigestFromEpoch(slots.ToEpoch(20), chain.ValidatorsRoot[:])
+		require.NoError(t, err)
+		assert.DeepEqual(t, digest[:], ctxBytes)
+		got := &ethpb.LightClientOptimisticUpdateAltair{}
+		require.NoError(t, p1.Encoding().DecodeWithMaxLength(stream, got))
+		assert.DeepSSZEqual(t, update.Proto(), got)
+	})
+	stream, err = p1.BHost.NewStream(context.Background(), p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	require.NoError(t, r.lightClientOptimisticUpdateRPCHandler(context.Background(), nil, stream))
+	if util.WaitTimeout(&wg, time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestLightClientUpdatesByRangeRPCHandler(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	assert.Equal(t, 1, len(p1.BHost.Network().Peers()), "Expected peers to be connected")
+
+	d := db.SetupDB(t)
+	ctx := context.Background()
+	// Periods 3 and 4 are contiguous, period 6 is after a gap and is not served.
+	for _, period := range []uint64{3, 4, 6} {
+		update := testLightClientUpdate(t, primitives.Slot(period*100), primitives.Slot(period), 10)
+		require.NoError(t, d.SaveLightClientUpdate(ctx, period, update))
+	}
+	chain := &mock.ChainService{ValidatorsRoot: [32]byte{'A'}, Genesis: time.Now()}
+	r := &Service{
+		cfg: &config{
+			beaconDB: d,
+			p2p:      p1,
+			chain:    chain,
+			clock:    startup.NewClock(chain.Genesis, chain.ValidatorsRoot),
+		},
+		rateLimiter: newRateLimiter(p1),
+	}
+	pcl := protocol.ID(p2p.RPCLightClientUpdatesByRangeTopicV1 + p1.Encoding().ProtocolSuffix())
+	r.rateLimiter.limiterMap[string(pcl)] = leakybucket.NewCollector(10, 10, time.Second, false)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		for _, period := range []uint64{3, 4} {
+			expectSuccess(t, stream)
+			_, err := readContextFromStream(stream)
+			require.NoError(t, err)
+			got := &ethpb.LightClientUpdateAltair{}
+			require.NoError(t, p1.Encoding().DecodeWithMaxLength(stream, got))
+			assert.Equal(t, primitives.Slot(period*100), got.AttestedHeader.Beacon.Slot)
+		}
+	})
+	stream, err := p1.BHost.NewStream(ctx, p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	require.NoError(t, r.lightClientUpdatesByRangeRPCHandler(ctx, &p2ptypes.LightClientUpdatesByRangeReq{StartPeriod: 3, Count: 4}, stream))
+	if util.WaitTimeout(&wg, time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectFailure(t, responseCodeInvalidRequest, p2ptypes.ErrInvalidRequest.Error(), stream)
+	})
+	stream, err = p1.BHost.NewStream(ctx, p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	require.ErrorIs(t, r.lightClientUpdatesByRangeRPCHandler(ctx, &p2ptypes.LightClientUpdatesByRangeReq{StartPeriod: 3, Count: 0}, stream), p2ptypes.ErrInvalidRequest)
+	if util.WaitTimeout(&wg, time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestMatchesLocalLightClientUpdate(t *testing.T) {
+	local := testOptimisticUpdate(t, 20, 10)
+	res, err := matchesLocalLightClientUpdate(testOptimisticUpdate(t, 20, 10), local)
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationAccept, res)
+	res, err = matchesLocalLightClientUpdate(testOptimisticUpdate(t, 20, 11), local)
+	require.NoError(t, err)
+	assert.Equal(t, pubsub.ValidationIgnore, res)
+}
+
+// testLightClientUpdate returns an Altair update with the given attested and finalized header slots, signed by the
+// given number of sync committee members.
+func testLightClientUpdate(t *testing.T, attestedSlot, finalizedSlot primitives.Slot, participants uint64) interfaces.LightClientUpdate {
+	st, err := util.NewBeaconStateAltair()
+	require.NoError(t, err)
+	update, err := lightClient.CreateDefaultLightClientUpdate(attestedSlot+1, st)
+	require.NoError(t, err)
+	header := func(slot primitives.Slot) interfaces.LightClientHeader {
+		h, err := lightclient.NewWrappedHeader(&ethpb.LightClientHeaderAltair{
+			Beacon: &ethpb.BeaconBlockHeader{
+				Slot:       slot,
+				ParentRoot: make([]byte, 32),
+				StateRoot:  make([]byte, 32),
+				BodyRoot:   make([]byte, 32),
+			},
+		})
+		require.NoError(t, err)
+		return h
+	}
+	require.NoError(t, update.SetAttestedHeader(header(attestedSlot)))
+	require.NoError(t, update.SetFinalizedHeader(header(finalizedSlot)))
+	bits := bitfield.NewBitvector512()
+	for i := uint64(0); i < participants; i++ {
+		bits.SetBitAt(i, true)
+	}
+	update.SetSyncAggregate(&ethpb.SyncAggregate{
+		SyncCommitteeBits:      bits,
+		SyncCommitteeSignature: make([]byte, 96),
+	})
+	update.SetSignatureSlot(attestedSlot + 1)
+	return update
+}
+
+func testFinalityUpdate(t *testing.T, attestedSlot, finalizedSlot primitives.Slot, participants uint64) interfaces.LightClientFinalityUpdate {
+	update, err := lightclient.NewFinalityUpdateFromUpdate(testLightClientUpdate(t, attestedSlot, finalizedSlot, participants))
+	require.NoError(t, err)
+	return update
+}
+
+func testOptimisticUpdate(t *testing.T, attestedSlot primitives.Slot, participants uint64) interfaces.LightClientOptimisticUpdate {
+	update, err := lightclient.NewOptimisticUpdateFromUpdate(testLightClientUpdate(t, attestedSlot, 0, participants))
+	require.NoError(t, err)
+	return update
+}
@@ -25,6 +25,7 @@ import (
 
 var errBlobChunkedReadFailure = errors.New("failed to read stream of chunk-encoded blobs")
 var errBlobUnmarshal = errors.New("Could not unmarshal chunk-encoded blob")
+var errDataColumnChunkedReadFailure = errors.New("failed to read stream of chunk-encoded data columns")
 
 // Any error from the following declaration block should result in peer downscoring.
 var (
@@ -38,6 +39,8 @@ var (
 	errBlobResponseOutOfBounds        = errors.Wrap(ErrInvalidFetchedData, "received BlobSidecar with slot outside BlobSidecarsByRangeRequest bounds")
 	errChunkResponseBlockMismatch     = errors.Wrap(ErrInvalidFetchedData, "blob block details do not match")
 	errChunkResponseParentMismatch    = errors.Wrap(ErrInvalidFetchedData, "parent root for response element doesn't match previous element root")
+	errMaxRequestDataColumnsExceeded  = errors.Wrap(ErrInvalidFetchedData, "peer exceeded req data column chunk tx limit")
+	errDataColumnResponseOutOfBounds  = errors.Wrap(ErrInvalidFetchedData, "received DataColumnSidecar outside DataColumnSidecarsByRangeRequest bounds")
 )
 
 // BeaconBlockProcessor defines a block processing function, which allows to start utilizing
@@ -184,6 +187,99 @@ func SendBlobsByRangeRequest(ctx context.Context, tor blockchain.TemporalOracle,
 	return readChunkEncodedBlobs(stream, p2pApi.Encoding(), ctxMap, composeBlobValidations(vfuncs...), max)
 }
 
+// SendDataColumnsByRangeRequest requests the given columns for a range of slots from a peer. Sidecars outside the
+// requested slots or columns are rejected, as is a response with more sidecars than could have been requested.
+func SendDataColumnsByRangeRequest(ctx context.Context, tor blockchain.TemporalOracle, p2pApi p2p.SenderEncoder, pid peer.ID, ctxMap ContextByteVersions, req *ethpb.DataColumnSidecarsByRangeRequest) ([]blocks.RODataColumn, error) {
+	topic, err := p2p.TopicFromMessage(p2p.DataColumnSidecarsByRangeName, slots.ToEpoch(tor.CurrentSlot()))
+	if err != nil {
+		return nil, err
+	}
+	log.WithFields(logrus.Fields{
+		"topic":     topic,
+		"startSlot": req.StartSlot,
+		"count":     req.Count,
+		"columns":   len(req.Columns),
+	}).Debug("Sending data column by range request")
+	stream, err := p2pApi.Send(ctx, req, topic, pid)
+	if err != nil {
+		return nil, err
+	}
+	defer closeStream(stream, log)
+
+	max := params.BeaconConfig().MaxRequestDataColumnSidecars
+	if requested := req.Count * uint64(len(req.Columns)); max > requested {
+		max = requested
+	}
+	vf := dataColumnValidatorFromRangeReq(req)
+	sidecars := make([]blocks.RODataColumn, 0)
+	// Attempt an extra read beyond max to check if the peer is sending more sidecars than requested.
+	for i := uint64(0); i < max+1; i++ {
+		dc, err := readChunkedDataColumnSidecar(stream, p2pApi.Encoding(), ctxMap, vf)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if i == max {
+			return nil, errMaxRequestDataColumnsExceeded
+		}
+		sidecars = append(sidecars, dc)
+	}
+	return sidecars, nil
+}
+
+func dataColumnValidatorFromRangeReq(req *ethpb.DataColumnSidecarsByRangeRequest) func(blocks.RODataColumn) error {
+	end := req.StartSlot + primitives.Slot(req.Count)
+	requested := make(map[uint64]bool, len(req.Columns))
+	for _, c := range req.Columns {
+		requested[c] = true
+	}
+	return func(dc blocks.RODataColumn) error {
+		if dc.Slot() < req.StartSlot || dc.Slot() >= end {
+			return errors.Wrapf(errDataColumnResponseOutOfBounds, "req start,end:%d,%d, resp:%d", req.StartSlot, end, dc.Slot())
+		}
+		if !requested[dc.ColumnIndex] {
+			return errors.Wrapf(errUnrequested, "root=%#x column=%d", dc.BlockRoot(), dc.ColumnIndex)
+		}
+		return nil
+	}
+}
+
+func readChunkedDataColumnSidecar(stream network.Stream, encoding encoder.NetworkEncoding, ctxMap ContextByteVersions, vf func(blocks.RODataColumn) error) (blocks.RODataColumn, error) {
+	var dc blocks.RODataColumn
+	code, msg, err := ReadStatusCode(stream, encoding)
+	if err != nil {
+		return dc, err
+	}
+	if code != 0 {
+		return dc, errors.Wrap(errDataColumnChunkedReadFailure, msg)
+	}
+	ctxb, err := readContextFromStream(stream)
+	if err != nil {
+		return dc, errors.Wrap(err, "error reading chunk context bytes from stream")
+	}
+	v, found := ctxMap[bytesutil.ToBytes4(ctxb)]
+	if !found {
+		return dc, errors.Wrapf(errDataColumnChunkedReadFailure, "unrecognized fork digest %#x", ctxb)
+	}
+	if v < version.Fulu {
+		return dc, fmt.Errorf("unexpected context bytes for DataColumnSidecar, ctx=%#x, v=%s", ctxb, version.String(v))
+	}
+	pb := &ethpb.DataColumnSidecar{}
+	if err := encoding.DecodeWithMaxLength(stream, pb); err != nil {
+		return dc, errors.Wrap(err, "failed to decode the protobuf-encoded DataColumnSidecar message from RPC chunk stream")
+	}
+	dc, err = blocks.NewRODataColumn(pb)
+	if err != nil {
+		return dc, errors.Wrap(err, "unexpected error initializing RODataColumn")
+	}
+	if err := vf(dc); err != nil {
+		return dc, errors.Wrap(err, "validation failure decoding data column RPC response")
+	}
+	return dc, nil
+}
+
 func SendBlobSidecarByRoot(
 	ctx context.Context, tor blockchain.TemporalOracle, p2pApi p2p.P2P, pid peer.ID,
 	ctxMap ContextByteVersions, req *p2ptypes.BlobSidecarsByRootReq, slot primitives.Slot,
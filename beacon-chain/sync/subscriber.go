@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"runtime/debug"
+	"slices"
 	"strings"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
@@ -135,6 +137,22 @@ func (s *Service) registerSubscribers(epoch primitives.Epoch, digest [4]byte) {
 		)
 	}
 
+	// Light client gossip topics, new in Altair.
+	if features.Get().EnableLightClient && params.BeaconConfig().AltairForkEpoch <= epoch {
+		s.subscribe(
+			p2p.LightClientFinalityUpdateTopicFormat,
+			s.validateLightClientFinalityUpdate,
+			s.lightClientUpdateSubscriber,
+			digest,
+		)
+		s.subscribe(
+			p2p.LightClientOptimisticUpdateTopicFormat,
+			s.validateLightClientOptimisticUpdate,
+			s.lightClientUpdateSubscriber,
+			digest,
+		)
+	}
+
 	// New gossip topic in Capella
 	if params.BeaconConfig().CapellaForkEpoch <= epoch {
 		s.subscribe(
@@ -172,6 +190,44 @@ func (s *Service) registerSubscribers(epoch primitives.Epoch, digest [4]byte) {
 			func(currentSlot primitives.Slot) []uint64 { return []uint64{} },
 		)
 	}
+
+	// New gossip topic in Fulu
+	if params.BeaconConfig().FuluForkEpoch <= epoch {
+		s.subscribeWithParameters(
+			p2p.DataColumnSubnetTopicFormat,
+			s.validateDataColumn,
+			s.dataColumnSubscriber,
+			digest,
+			s.dataColumnSubnetIndices,
+			func(currentSlot primitives.Slot) []uint64 { return []uint64{} },
+		)
+	}
+}
+
+// dataColumnSubnetIndices returns the data column subnets covering the columns this node custodies.
+func (s *Service) dataColumnSubnetIndices(_ primitives.Slot) []uint64 {
+	subnetCount := params.BeaconConfig().DataColumnSidecarSubnetCount
+	if flags.Get().SubscribeToAllSubnets {
+		return sliceFromCount(subnetCount)
+	}
+	columns, err := s.custodyColumns()
+	if err != nil {
+		// Subscribing to every subnet is always a safe superset of our custody requirement.
+		log.WithError(err).Error("Could not compute custody columns, subscribing to all data column subnets")
+		return sliceFromCount(subnetCount)
+	}
+	subnets := peerdas.DataColumnSubnets(columns)
+	result := make([]uint64, 0, len(subnets))
+	for subnet := range subnets {
+		result = append(result, subnet)
+	}
+	slices.Sort(result)
+	return result
+}
+
+// custodyColumns computes the set of columns this node custodies, based on its node ID.
+func (s *Service) custodyColumns() (map[uint64]bool, error) {
+	return peerdas.NodeCustodyColumns(s.cfg.p2p.NodeID())
 }
 
 // subscribe to a given topic with a given validator and subscription handler.
@@ -0,0 +1,13 @@
+package sync
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// lightClientUpdateSubscriber is a no-op, as the light client updates which pass validation are the ones this node
+// computed itself.
+func (*Service) lightClientUpdateSubscriber(_ context.Context, _ proto.Message) error {
+	return nil
+}
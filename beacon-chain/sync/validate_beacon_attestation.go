@@ -163,6 +163,10 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 		return validationRes, err
 	}
 
+	if att.Version() >= version.Electra {
+		s.notifySingleAttestation(ctx, att, preState, committeeIndex)
+	}
+
 	if features.Get().EnableSlasher {
 		// Feed the indexed attestation to slasher if enabled. This action
 		// is done in the background to avoid adding more load to this critical code path.
@@ -199,6 +203,27 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 	return pubsub.ValidationAccept, nil
 }
 
+// notifySingleAttestation broadcasts a valid unaggregated attestation since Electra on the attestation feed,
+// as a single attestation identifying its attester.
+func (s *Service) notifySingleAttestation(ctx context.Context, att eth.Att, bs state.ReadOnlyBeaconState, committeeIndex primitives.CommitteeIndex) {
+	committee, err := helpers.BeaconCommitteeFromState(ctx, bs, att.GetData().Slot, committeeIndex)
+	if err != nil {
+		log.WithError(err).Debug("Could not get attestation committee")
+		return
+	}
+	s.cfg.attestationNotifier.OperationFeed().Send(&feed.Event{
+		Type: operation.SingleAttReceived,
+		Data: &operation.SingleAttReceivedData{
+			Attestation: &eth.SingleAttestation{
+				CommitteeId:   committeeIndex,
+				AttesterIndex: committee[att.GetAggregationBits().BitIndices()[0]],
+				Data:          att.GetData(),
+				Signature:     att.GetSignature(),
+			},
+		},
+	})
+}
+
 // This validates beacon unaggregated attestation has correct topic string.
 func (s *Service) validateUnaggregatedAttTopic(ctx context.Context, a eth.Att, bs state.ReadOnlyBeaconState, t string) (pubsub.ValidationResult, error) {
 	ctx, span := trace.StartSpan(ctx, "sync.validateUnaggregatedAttTopic")
@@ -5,10 +5,16 @@ import (
 	"testing"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/go-bitfield"
+	mockChain "github.com/prysmaticlabs/prysm/v5/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/feed/operation"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v5/testing/assert"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
 )
 
 func Test_validateCommitteeIndexElectra(t *testing.T) {
@@ -44,3 +50,31 @@ func Test_validateCommitteeIndexElectra(t *testing.T) {
 		assert.Equal(t, pubsub.ValidationReject, res)
 	})
 }
+
+func TestService_notifySingleAttestation(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisState(t, 64)
+	s := &Service{cfg: &config{attestationNotifier: (&mockChain.ChainService{}).OperationNotifier()}}
+	events := make(chan *feed.Event, 1)
+	sub := s.cfg.attestationNotifier.OperationFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+
+	committee, err := helpers.BeaconCommitteeFromState(ctx, st, 1, 0)
+	require.NoError(t, err)
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	bits.SetBitAt(1, true)
+	cb := primitives.NewAttestationCommitteeBits()
+	cb.SetBitAt(0, true)
+	att := util.HydrateAttestationElectra(&ethpb.AttestationElectra{AggregationBits: bits, CommitteeBits: cb})
+	att.Data.Slot = 1
+
+	s.notifySingleAttestation(ctx, att, st, 0)
+	ev := <-events
+	assert.Equal(t, feed.EventType(operation.SingleAttReceived), ev.Type)
+	data, ok := ev.Data.(*operation.SingleAttReceivedData)
+	require.Equal(t, true, ok)
+	assert.Equal(t, committee[1], data.Attestation.AttesterIndex)
+	assert.Equal(t, primitives.CommitteeIndex(0), data.Attestation.CommitteeId)
+	assert.DeepEqual(t, att.Data, data.Attestation.Data)
+	assert.DeepEqual(t, att.Signature, data.Attestation.Signature)
+}
@@ -0,0 +1,135 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	lightclient "github.com/prysmaticlabs/prysm/v5/consensus-types/light-client"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing"
+	"github.com/prysmaticlabs/prysm/v5/monitoring/tracing/trace"
+	"google.golang.org/protobuf/proto"
+)
+
+// validateLightClientFinalityUpdate validates a light client finality update received over gossip.
+// Only the update matching the one computed locally is forwarded, which also implies that its finalized header
+// is at least as new as the one of the previously forwarded updates.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/p2p-interface.md#light_client_finality_update
+func (s *Service) validateLightClientFinalityUpdate(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid == s.cfg.p2p.PeerID() {
+		return pubsub.ValidationAccept, nil
+	}
+
+	// The updates computed locally are not up to date while syncing.
+	if s.cfg.initialSync.Syncing() {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	_, span := trace.StartSpan(ctx, "sync.validateLightClientFinalityUpdate")
+	defer span.End()
+
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+	pm, ok := m.(proto.Message)
+	if !ok {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+	update, err := lightclient.NewWrappedFinalityUpdate(pm)
+	if err != nil {
+		return pubsub.ValidationReject, errors.Wrap(err, "could not wrap light client finality update")
+	}
+
+	if !s.lightClientUpdateTimely(update.SignatureSlot()) {
+		return pubsub.ValidationIgnore, nil
+	}
+	local := s.lcStore.latestFinalityUpdate()
+	if local == nil {
+		return pubsub.ValidationIgnore, nil
+	}
+	if res, err := matchesLocalLightClientUpdate(update, local); res != pubsub.ValidationAccept {
+		return res, err
+	}
+	msg.ValidatorData = pm // Used in downstream subscriber
+	return pubsub.ValidationAccept, nil
+}
+
+// validateLightClientOptimisticUpdate validates a light client optimistic update received over gossip.
+// Only the update matching the one computed locally is forwarded, which also implies that its attested header
+// is at least as new as the one of the previously forwarded updates.
+// spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/altair/light-client/p2p-interface.md#light_client_optimistic_update
+func (s *Service) validateLightClientOptimisticUpdate(ctx context.Context, pid peer.ID, msg *pubsub.Message) (pubsub.ValidationResult, error) {
+	// Validation runs on publish (not just subscriptions), so we should approve any message from
+	// ourselves.
+	if pid == s.cfg.p2p.PeerID() {
+		return pubsub.ValidationAccept, nil
+	}
+
+	// The updates computed locally are not up to date while syncing.
+	if s.cfg.initialSync.Syncing() {
+		return pubsub.ValidationIgnore, nil
+	}
+
+	_, span := trace.StartSpan(ctx, "sync.validateLightClientOptimisticUpdate")
+	defer span.End()
+
+	m, err := s.decodePubsubMessage(msg)
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return pubsub.ValidationReject, err
+	}
+	pm, ok := m.(proto.Message)
+	if !ok {
+		return pubsub.ValidationReject, errWrongMessage
+	}
+	update, err := lightclient.NewWrappedOptimisticUpdate(pm)
+	if err != nil {
+		return pubsub.ValidationReject, errors.Wrap(err, "could not wrap light client optimistic update")
+	}
+
+	if !s.lightClientUpdateTimely(update.SignatureSlot()) {
+		return pubsub.ValidationIgnore, nil
+	}
+	local := s.lcStore.latestOptimisticUpdate()
+	if local == nil {
+		return pubsub.ValidationIgnore, nil
+	}
+	if res, err := matchesLocalLightClientUpdate(update, local); res != pubsub.ValidationAccept {
+		return res, err
+	}
+	msg.ValidatorData = pm // Used in downstream subscriber
+	return pubsub.ValidationAccept, nil
+}
+
+// lightClientUpdateTimely checks that the block at the signature slot of an update had time to propagate, that is
+// that a third of the signature slot has passed, allowing for clock disparity.
+func (s *Service) lightClientUpdateTimely(signatureSlot primitives.Slot) bool {
+	forwardTime := lightClientUpdateForwardTime(s.cfg.clock.SlotStart(signatureSlot))
+	return !s.cfg.clock.Now().Add(params.BeaconConfig().MaximumGossipClockDisparityDuration()).Before(forwardTime)
+}
+
+// matchesLocalLightClientUpdate accepts an update that is exactly the same as the one computed locally, and
+// ignores any other one.
+func matchesLocalLightClientUpdate(update, local ssz.Marshaler) (pubsub.ValidationResult, error) {
+	received, err := update.MarshalSSZ()
+	if err != nil {
+		return pubsub.ValidationReject, errors.Wrap(err, "could not marshal received light client update")
+	}
+	expected, err := local.MarshalSSZ()
+	if err != nil {
+		return pubsub.ValidationIgnore, errors.Wrap(err, "could not marshal local light client update")
+	}
+	if !bytes.Equal(received, expected) {
+		return pubsub.ValidationIgnore, nil
+	}
+	return pubsub.ValidationAccept, nil
+}
@@ -92,3 +92,67 @@ func (batch *BlobBatchVerifier) verifyOneBlob(sc blocks.ROBlob) (blocks.Verified
 
 	return bv.VerifiedROBlob()
 }
+
+// NewDataColumnBatchVerifier initializes a data column batch verifier. It requires the caller to correctly specify
+// verification Requirements and to also pass in a NewDataColumnVerifier, which is a callback function that
+// returns a new DataColumnVerifier for handling a single column in the batch.
+func NewDataColumnBatchVerifier(newVerifier NewDataColumnVerifier, reqs []Requirement) *DataColumnBatchVerifier {
+	return &DataColumnBatchVerifier{
+		newVerifier: newVerifier,
+		reqs:        reqs,
+	}
+}
+
+// DataColumnBatchVerifier is the data column counterpart of BlobBatchVerifier, used to verify the columns
+// for a block received over RPC, where the block itself has already been checked against its parent.
+type DataColumnBatchVerifier struct {
+	newVerifier NewDataColumnVerifier
+	reqs        []Requirement
+}
+
+// VerifiedRODataColumns satisfies the das.DataColumnBatchVerifier interface, used by das.LazilyPersistentStoreColumn.
+func (batch *DataColumnBatchVerifier) VerifiedRODataColumns(_ context.Context, blk blocks.ROBlock, dcs []blocks.RODataColumn) ([]blocks.VerifiedRODataColumn, error) {
+	if len(dcs) == 0 {
+		return nil, nil
+	}
+	blkSig := blk.Signature()
+	// As with blobs, the proposer signature has already been validated for the block, so we only need to
+	// ensure the sidecars carry the same signature and root.
+	for i := range dcs {
+		colSig := bytesutil.ToBytes96(dcs[i].SignedBlockHeader.Signature)
+		if blkSig != colSig {
+			return nil, ErrBatchSignatureMismatch
+		}
+		if blk.Root() != dcs[i].BlockRoot() {
+			return nil, ErrBatchBlockRootMismatch
+		}
+	}
+	vs := make([]blocks.VerifiedRODataColumn, len(dcs))
+	for i := range dcs {
+		vc, err := batch.verifyOneColumn(dcs[i])
+		if err != nil {
+			return nil, err
+		}
+		vs[i] = vc
+	}
+	return vs, nil
+}
+
+func (batch *DataColumnBatchVerifier) verifyOneColumn(dc blocks.RODataColumn) (blocks.VerifiedRODataColumn, error) {
+	vc := blocks.VerifiedRODataColumn{}
+	dv := batch.newVerifier(dc, batch.reqs)
+	// VerifiedRODataColumns checks the block signature for all columns in the batch before calling verifyOneColumn.
+	dv.SatisfyRequirement(RequireValidProposerSignature)
+
+	if err := dv.ValidFields(); err != nil {
+		return vc, err
+	}
+	if err := dv.SidecarInclusionProven(); err != nil {
+		return vc, err
+	}
+	if err := dv.SidecarKzgProofVerified(); err != nil {
+		return vc, err
+	}
+
+	return dv.VerifiedRODataColumn()
+}
@@ -26,6 +26,10 @@ const (
 	RequireSidecarInclusionProven
 	RequireSidecarKzgProofVerified
 	RequireSidecarProposerExpected
+
+	// Data column sidecar specific requirements.
+	RequireDataColumnValidFields
+	RequireDataColumnCorrectSubnet
 )
 
 var allBlobSidecarRequirements = []Requirement{
@@ -0,0 +1,314 @@
+package verification
+
+import (
+	"context"
+	goError "errors"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v5/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/runtime/logging"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+var allDataColumnSidecarRequirements = []Requirement{
+	RequireDataColumnValidFields,
+	RequireDataColumnCorrectSubnet,
+	RequireNotFromFutureSlot,
+	RequireSlotAboveFinalized,
+	RequireValidProposerSignature,
+	RequireSidecarParentSeen,
+	RequireSidecarParentValid,
+	RequireSidecarParentSlotLower,
+	RequireSidecarDescendsFromFinalized,
+	RequireSidecarInclusionProven,
+	RequireSidecarKzgProofVerified,
+	RequireSidecarProposerExpected,
+}
+
+// GossipDataColumnSidecarRequirements defines the set of requirements that DataColumnSidecars received on gossip
+// must satisfy in order to upgrade an RODataColumn to a VerifiedRODataColumn.
+var GossipDataColumnSidecarRequirements = requirementList(allDataColumnSidecarRequirements).excluding()
+
+// ByRangeRequestDataColumnSidecarRequirements is the list of verification requirements for data column sidecars
+// received in response to a DataColumnSidecarsByRange or DataColumnSidecarsByRoot request. Like the equivalent
+// blob requirements, the checks that depend on the block (and forkchoice) are done once the block is known.
+var ByRangeRequestDataColumnSidecarRequirements = requirementList(GossipDataColumnSidecarRequirements).excluding(
+	RequireDataColumnCorrectSubnet,
+	RequireNotFromFutureSlot,
+	RequireSlotAboveFinalized,
+	RequireSidecarParentSeen,
+	RequireSidecarParentValid,
+	RequireSidecarParentSlotLower,
+	RequireSidecarDescendsFromFinalized,
+	RequireSidecarProposerExpected,
+)
+
+var (
+	ErrDataColumnInvalid = errors.New("data column failed verification")
+	// ErrDataColumnFieldsInvalid means RequireDataColumnValidFields failed.
+	ErrDataColumnFieldsInvalid = errors.New("data column sidecar fields are invalid")
+	// ErrDataColumnIncorrectSubnet means RequireDataColumnCorrectSubnet failed.
+	ErrDataColumnIncorrectSubnet = errors.New("data column sidecar received on the wrong subnet")
+)
+
+type RODataColumnVerifier struct {
+	*sharedResources
+	results         *results
+	column          blocks.RODataColumn
+	parent          state.BeaconState
+	verifyCellProof rodatacolumnCellProofVerifier
+}
+
+type rodatacolumnCellProofVerifier func([]blocks.RODataColumn) error
+
+var _ DataColumnVerifier = &RODataColumnVerifier{}
+
+// VerifiedRODataColumn "upgrades" the wrapped RODataColumn to a VerifiedRODataColumn.
+// If any of the verifications ran against the column failed, or some required verifications
+// were not run, an error will be returned.
+func (dv *RODataColumnVerifier) VerifiedRODataColumn() (blocks.VerifiedRODataColumn, error) {
+	if dv.results.allSatisfied() {
+		return blocks.NewVerifiedRODataColumn(dv.column), nil
+	}
+	return blocks.VerifiedRODataColumn{}, dv.results.errors(ErrDataColumnInvalid)
+}
+
+// SatisfyRequirement allows the caller to assert that a requirement has been satisfied.
+// See ROBlobVerifier.SatisfyRequirement for details.
+func (dv *RODataColumnVerifier) SatisfyRequirement(req Requirement) {
+	dv.recordResult(req, nil)
+}
+
+func (dv *RODataColumnVerifier) recordResult(req Requirement, err *error) {
+	if err == nil || *err == nil {
+		dv.results.record(req, nil)
+		return
+	}
+	dv.results.record(req, *err)
+}
+
+// ValidFields represents the spec verification:
+// [REJECT] The sidecar is valid as verified by verify_data_column_sidecar(sidecar).
+func (dv *RODataColumnVerifier) ValidFields() (err error) {
+	defer dv.recordResult(RequireDataColumnValidFields, &err)
+	if err := peerdas.VerifyDataColumnSidecar(dv.column); err != nil {
+		log.WithError(err).WithFields(logging.DataColumnFields(dv.column)).Debug("invalid data column sidecar fields")
+		return columnErrBuilder(ErrDataColumnFieldsInvalid)
+	}
+	return nil
+}
+
+// CorrectSubnet represents the spec verification:
+// [REJECT] The sidecar is for the correct subnet -- i.e. compute_subnet_for_data_column_sidecar(sidecar.index) == subnet_id.
+func (dv *RODataColumnVerifier) CorrectSubnet(subnet uint64) (err error) {
+	defer dv.recordResult(RequireDataColumnCorrectSubnet, &err)
+	if expected := peerdas.ComputeSubnetForDataColumnSidecar(dv.column.ColumnIndex); expected != subnet {
+		log.WithFields(logging.DataColumnFields(dv.column)).WithField("subnet", subnet).WithField("expectedSubnet", expected).
+			Debug("data column sidecar received on the wrong subnet")
+		return columnErrBuilder(ErrDataColumnIncorrectSubnet)
+	}
+	return nil
+}
+
+// NotFromFutureSlot represents the spec verification:
+// [IGNORE] The sidecar is not from a future slot (with a MAXIMUM_GOSSIP_CLOCK_DISPARITY allowance)
+// -- i.e. validate that block_header.slot <= current_slot
+func (dv *RODataColumnVerifier) NotFromFutureSlot() (err error) {
+	defer dv.recordResult(RequireNotFromFutureSlot, &err)
+	if dv.clock.CurrentSlot() == dv.column.Slot() {
+		return nil
+	}
+	earliestStart := dv.clock.SlotStart(dv.column.Slot()).Add(-1 * params.BeaconConfig().MaximumGossipClockDisparityDuration())
+	if dv.clock.Now().Before(earliestStart) {
+		log.WithFields(logging.DataColumnFields(dv.column)).Debug("sidecar slot is too far in the future")
+		return columnErrBuilder(ErrFromFutureSlot)
+	}
+	return nil
+}
+
+// SlotAboveFinalized represents the spec verification:
+// [IGNORE] The sidecar is from a slot greater than the latest finalized slot
+// -- i.e. validate that block_header.slot > compute_start_slot_at_epoch(state.finalized_checkpoint.epoch)
+func (dv *RODataColumnVerifier) SlotAboveFinalized() (err error) {
+	defer dv.recordResult(RequireSlotAboveFinalized, &err)
+	fcp := dv.fc.FinalizedCheckpoint()
+	fSlot, err := slots.EpochStart(fcp.Epoch)
+	if err != nil {
+		return errors.Wrapf(columnErrBuilder(ErrSlotNotAfterFinalized), "error computing epoch start slot for finalized checkpoint (%d) %s", fcp.Epoch, err.Error())
+	}
+	if dv.column.Slot() <= fSlot {
+		log.WithFields(logging.DataColumnFields(dv.column)).Debug("sidecar slot is not after finalized checkpoint")
+		return columnErrBuilder(ErrSlotNotAfterFinalized)
+	}
+	return nil
+}
+
+// ValidProposerSignature represents the spec verification:
+// [REJECT] The proposer signature of sidecar.signed_block_header, is valid with respect to the block_header.proposer_index pubkey.
+func (dv *RODataColumnVerifier) ValidProposerSignature(ctx context.Context) (err error) {
+	defer dv.recordResult(RequireValidProposerSignature, &err)
+	sd := columnToSignatureData(dv.column)
+	// First check if there is a cached verification that can be reused.
+	seen, err := dv.sc.SignatureVerified(sd)
+	if seen {
+		columnVerificationProposerSignatureCache.WithLabelValues("hit-valid").Inc()
+		if err != nil {
+			log.WithFields(logging.DataColumnFields(dv.column)).WithError(err).Debug("reusing failed proposer signature validation from cache")
+			columnVerificationProposerSignatureCache.WithLabelValues("hit-invalid").Inc()
+			return columnErrBuilder(ErrInvalidProposerSignature)
+		}
+		return nil
+	}
+	columnVerificationProposerSignatureCache.WithLabelValues("miss").Inc()
+
+	parent, err := dv.parentState(ctx)
+	if err != nil {
+		log.WithFields(logging.DataColumnFields(dv.column)).WithError(err).Debug("could not replay parent state for data column signature verification")
+		return columnErrBuilder(ErrInvalidProposerSignature)
+	}
+	if err = dv.sc.VerifySignature(sd, parent); err != nil {
+		log.WithFields(logging.DataColumnFields(dv.column)).WithError(err).Debug("signature verification failed")
+		return columnErrBuilder(ErrInvalidProposerSignature)
+	}
+	return nil
+}
+
+// SidecarParentSeen represents the spec verification:
+// [IGNORE] The sidecar's block's parent (defined by block_header.parent_root) has been seen
+// (via both gossip and non-gossip sources) (a client MAY queue sidecars for processing once the parent block is retrieved).
+func (dv *RODataColumnVerifier) SidecarParentSeen(parentSeen func([32]byte) bool) (err error) {
+	defer dv.recordResult(RequireSidecarParentSeen, &err)
+	if parentSeen != nil && parentSeen(dv.column.ParentRoot()) {
+		return nil
+	}
+	if dv.fc.HasNode(dv.column.ParentRoot()) {
+		return nil
+	}
+	log.WithFields(logging.DataColumnFields(dv.column)).Debug("parent root has not been seen")
+	return columnErrBuilder(ErrSidecarParentNotSeen)
+}
+
+// SidecarParentValid represents the spec verification:
+// [REJECT] The sidecar's block's parent (defined by block_header.parent_root) passes validation.
+func (dv *RODataColumnVerifier) SidecarParentValid(badParent func([32]byte) bool) (err error) {
+	defer dv.recordResult(RequireSidecarParentValid, &err)
+	if badParent != nil && badParent(dv.column.ParentRoot()) {
+		log.WithFields(logging.DataColumnFields(dv.column)).Debug("parent root is invalid")
+		return columnErrBuilder(ErrSidecarParentInvalid)
+	}
+	return nil
+}
+
+// SidecarParentSlotLower represents the spec verification:
+// [REJECT] The sidecar is from a higher slot than the sidecar's block's parent (defined by block_header.parent_root).
+func (dv *RODataColumnVerifier) SidecarParentSlotLower() (err error) {
+	defer dv.recordResult(RequireSidecarParentSlotLower, &err)
+	parentSlot, err := dv.fc.Slot(dv.column.ParentRoot())
+	if err != nil {
+		return errors.Wrap(columnErrBuilder(ErrSlotNotAfterParent), "parent root not in forkchoice")
+	}
+	if parentSlot >= dv.column.Slot() {
+		return columnErrBuilder(ErrSlotNotAfterParent)
+	}
+	return nil
+}
+
+// SidecarDescendsFromFinalized represents the spec verification:
+// [REJECT] The current finalized_checkpoint is an ancestor of the sidecar's block
+// -- i.e. get_checkpoint_block(store, block_header.parent_root, store.finalized_checkpoint.epoch) == store.finalized_checkpoint.root.
+func (dv *RODataColumnVerifier) SidecarDescendsFromFinalized() (err error) {
+	defer dv.recordResult(RequireSidecarDescendsFromFinalized, &err)
+	if !dv.fc.HasNode(dv.column.ParentRoot()) {
+		log.WithFields(logging.DataColumnFields(dv.column)).Debug("parent root not in forkchoice")
+		return columnErrBuilder(ErrSidecarNotFinalizedDescendent)
+	}
+	return nil
+}
+
+// SidecarInclusionProven represents the spec verification:
+// [REJECT] The sidecar's kzg_commitments field inclusion proof is valid as verified by verify_data_column_sidecar_inclusion_proof(sidecar).
+func (dv *RODataColumnVerifier) SidecarInclusionProven() (err error) {
+	defer dv.recordResult(RequireSidecarInclusionProven, &err)
+	if err = peerdas.VerifyDataColumnSidecarInclusionProof(dv.column); err != nil {
+		log.WithError(err).WithFields(logging.DataColumnFields(dv.column)).Debug("sidecar inclusion proof verification failed")
+		return columnErrBuilder(ErrSidecarInclusionProofInvalid)
+	}
+	return nil
+}
+
+// SidecarKzgProofVerified represents the spec verification:
+// [REJECT] The sidecar's column data is valid as verified by verify_data_column_sidecar_kzg_proofs(sidecar).
+func (dv *RODataColumnVerifier) SidecarKzgProofVerified() (err error) {
+	defer dv.recordResult(RequireSidecarKzgProofVerified, &err)
+	if err = dv.verifyCellProof([]blocks.RODataColumn{dv.column}); err != nil {
+		log.WithError(err).WithFields(logging.DataColumnFields(dv.column)).Debug("cell kzg proof verification failed")
+		return columnErrBuilder(ErrSidecarKzgProofInvalid)
+	}
+	return nil
+}
+
+// SidecarProposerExpected represents the spec verification:
+// [REJECT] The sidecar is proposed by the expected proposer_index for the block's slot
+// in the context of the current shuffling (defined by block_header.parent_root/block_header.slot).
+func (dv *RODataColumnVerifier) SidecarProposerExpected(ctx context.Context) (err error) {
+	defer dv.recordResult(RequireSidecarProposerExpected, &err)
+	e := slots.ToEpoch(dv.column.Slot())
+	if e > 0 {
+		e = e - 1
+	}
+	r, err := dv.fc.TargetRootForEpoch(dv.column.ParentRoot(), e)
+	if err != nil {
+		return columnErrBuilder(ErrSidecarUnexpectedProposer)
+	}
+	c := &forkchoicetypes.Checkpoint{Root: r, Epoch: e}
+	idx, cached := dv.pc.Proposer(c, dv.column.Slot())
+	if !cached {
+		pst, err := dv.parentState(ctx)
+		if err != nil {
+			log.WithError(err).WithFields(logging.DataColumnFields(dv.column)).Debug("state replay to parent_root failed")
+			return columnErrBuilder(ErrSidecarUnexpectedProposer)
+		}
+		idx, err = dv.pc.ComputeProposer(ctx, dv.column.ParentRoot(), dv.column.Slot(), pst)
+		if err != nil {
+			log.WithError(err).WithFields(logging.DataColumnFields(dv.column)).Debug("error computing proposer index from parent state")
+			return columnErrBuilder(ErrSidecarUnexpectedProposer)
+		}
+	}
+	if idx != dv.column.ProposerIndex() {
+		log.WithFields(logging.DataColumnFields(dv.column)).WithField("expectedProposer", idx).
+			Debug("unexpected data column proposer")
+		return columnErrBuilder(ErrSidecarUnexpectedProposer)
+	}
+	return nil
+}
+
+func (dv *RODataColumnVerifier) parentState(ctx context.Context) (state.BeaconState, error) {
+	if dv.parent != nil {
+		return dv.parent, nil
+	}
+	st, err := dv.sr.StateByRoot(ctx, dv.column.ParentRoot())
+	if err != nil {
+		return nil, err
+	}
+	dv.parent = st
+	return dv.parent, nil
+}
+
+func columnToSignatureData(c blocks.RODataColumn) SignatureData {
+	return SignatureData{
+		Root:      c.BlockRoot(),
+		Parent:    c.ParentRoot(),
+		Signature: bytesutil.ToBytes96(c.SignedBlockHeader.Signature),
+		Proposer:  c.ProposerIndex(),
+		Slot:      c.Slot(),
+	}
+}
+
+func columnErrBuilder(baseErr error) error {
+	return goError.Join(ErrDataColumnInvalid, baseErr)
+}
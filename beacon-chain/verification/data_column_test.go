@@ -0,0 +1,131 @@
+package verification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/peerdas"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestDataColumnValidFields(t *testing.T) {
+	ini := &Initializer{}
+	_, columns := util.GenerateTestFuluBlockWithColumns(t, [32]byte{}, 1, 2)
+	v := ini.NewDataColumnVerifier(columns[0], GossipDataColumnSidecarRequirements)
+	require.NoError(t, v.ValidFields())
+	require.Equal(t, true, v.results.executed(RequireDataColumnValidFields))
+	require.NoError(t, v.results.result(RequireDataColumnValidFields))
+
+	bad := columns[1]
+	bad.ColumnIndex = params.BeaconConfig().NumberOfColumns
+	v = ini.NewDataColumnVerifier(bad, GossipDataColumnSidecarRequirements)
+	require.ErrorIs(t, v.ValidFields(), ErrDataColumnFieldsInvalid)
+	require.NotNil(t, v.results.result(RequireDataColumnValidFields))
+}
+
+func TestDataColumnCorrectSubnet(t *testing.T) {
+	ini := &Initializer{}
+	_, columns := util.GenerateTestFuluBlockWithColumns(t, [32]byte{}, 1, 1)
+	c := columns[3]
+	subnet := peerdas.ComputeSubnetForDataColumnSidecar(c.ColumnIndex)
+	v := ini.NewDataColumnVerifier(c, GossipDataColumnSidecarRequirements)
+	require.NoError(t, v.CorrectSubnet(subnet))
+	v = ini.NewDataColumnVerifier(c, GossipDataColumnSidecarRequirements)
+	require.ErrorIs(t, v.CorrectSubnet(subnet+1), ErrDataColumnIncorrectSubnet)
+	require.NotNil(t, v.results.result(RequireDataColumnCorrectSubnet))
+}
+
+func TestDataColumnSidecarInclusionProven(t *testing.T) {
+	ini := &Initializer{}
+	_, columns := util.GenerateTestFuluBlockWithColumns(t, [32]byte{}, 1, 2)
+	v := ini.NewDataColumnVerifier(columns[0], GossipDataColumnSidecarRequirements)
+	require.NoError(t, v.SidecarInclusionProven())
+	require.NoError(t, v.results.result(RequireSidecarInclusionProven))
+
+	bad := columns[1]
+	bad.KzgCommitmentsInclusionProof = [][]byte{make([]byte, 32), make([]byte, 32), make([]byte, 32), make([]byte, 32)}
+	v = ini.NewDataColumnVerifier(bad, GossipDataColumnSidecarRequirements)
+	require.ErrorIs(t, v.SidecarInclusionProven(), ErrSidecarInclusionProofInvalid)
+	require.NotNil(t, v.results.result(RequireSidecarInclusionProven))
+}
+
+func TestDataColumnSidecarKzgProofVerified(t *testing.T) {
+	_, columns := util.GenerateTestFuluBlockWithColumns(t, [32]byte{}, 1, 1)
+	c := columns[0]
+	passes := func(dcs []blocks.RODataColumn) error {
+		require.Equal(t, c.ColumnIndex, dcs[0].ColumnIndex)
+		return nil
+	}
+	v := &RODataColumnVerifier{verifyCellProof: passes, results: newResults(), column: c}
+	require.NoError(t, v.SidecarKzgProofVerified())
+	require.NoError(t, v.results.result(RequireSidecarKzgProofVerified))
+
+	fails := func([]blocks.RODataColumn) error {
+		return errors.New("bad cells")
+	}
+	v = &RODataColumnVerifier{verifyCellProof: fails, results: newResults(), column: c}
+	require.ErrorIs(t, v.SidecarKzgProofVerified(), ErrSidecarKzgProofInvalid)
+	require.NotNil(t, v.results.result(RequireSidecarKzgProofVerified))
+}
+
+func TestDataColumnSidecarProposerExpected(t *testing.T) {
+	ctx := context.Background()
+	_, columns := util.GenerateTestFuluBlockWithColumns(t, [32]byte{}, 1, 1)
+	c := columns[0]
+	ini := Initializer{shared: &sharedResources{pc: &mockProposerCache{ProposerCB: pcReturnsIdx(c.ProposerIndex())}, fc: &mockForkchoicer{TargetRootForEpochCB: fcReturnsTargetRoot([32]byte{})}}}
+	v := ini.NewDataColumnVerifier(c, GossipDataColumnSidecarRequirements)
+	require.NoError(t, v.SidecarProposerExpected(ctx))
+
+	ini = Initializer{shared: &sharedResources{pc: &mockProposerCache{ProposerCB: pcReturnsIdx(c.ProposerIndex() + 1)}, fc: &mockForkchoicer{TargetRootForEpochCB: fcReturnsTargetRoot([32]byte{})}}}
+	v = ini.NewDataColumnVerifier(c, GossipDataColumnSidecarRequirements)
+	require.ErrorIs(t, v.SidecarProposerExpected(ctx), ErrSidecarUnexpectedProposer)
+}
+
+func TestDataColumnRequirementSatisfaction(t *testing.T) {
+	_, columns := util.GenerateTestFuluBlockWithColumns(t, [32]byte{}, 1, 1)
+	ini := Initializer{}
+	v := ini.NewDataColumnVerifier(columns[0], ByRangeRequestDataColumnSidecarRequirements)
+	_, err := v.VerifiedRODataColumn()
+	require.ErrorIs(t, err, ErrDataColumnInvalid)
+
+	for _, r := range ByRangeRequestDataColumnSidecarRequirements {
+		v.SatisfyRequirement(r)
+	}
+	_, err = v.VerifiedRODataColumn()
+	require.NoError(t, err)
+}
+
+func TestAllDataColumnRequirementsHaveStrings(t *testing.T) {
+	for i := range allDataColumnSidecarRequirements {
+		require.NotEqual(t, unknownRequirementName, allDataColumnSidecarRequirements[i].String())
+	}
+}
+
+func TestDataColumnBatchVerifier(t *testing.T) {
+	blk, columns := util.GenerateTestFuluBlockWithColumns(t, [32]byte{}, 1, 1)
+	newVerifier := func(cellProofErr error) NewDataColumnVerifier {
+		return func(dc blocks.RODataColumn, reqs []Requirement) DataColumnVerifier {
+			verifyCells := func([]blocks.RODataColumn) error { return cellProofErr }
+			return &RODataColumnVerifier{verifyCellProof: verifyCells, results: newResults(reqs...), column: dc}
+		}
+	}
+	ctx := context.Background()
+
+	bv := NewDataColumnBatchVerifier(newVerifier(nil), ByRangeRequestDataColumnSidecarRequirements)
+	vcs, err := bv.VerifiedRODataColumns(ctx, blk, columns[:4])
+	require.NoError(t, err)
+	require.Equal(t, 4, len(vcs))
+
+	bv = NewDataColumnBatchVerifier(newVerifier(errors.New("bad cells")), ByRangeRequestDataColumnSidecarRequirements)
+	_, err = bv.VerifiedRODataColumns(ctx, blk, columns[:4])
+	require.ErrorIs(t, err, ErrSidecarKzgProofInvalid)
+
+	_, other := util.GenerateTestFuluBlockWithColumns(t, [32]byte{1}, 1, 1)
+	bv = NewDataColumnBatchVerifier(newVerifier(nil), ByRangeRequestDataColumnSidecarRequirements)
+	_, err = bv.VerifiedRODataColumns(ctx, blk, other[:1])
+	require.NotNil(t, err)
+}
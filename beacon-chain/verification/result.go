@@ -29,6 +29,10 @@ func (r Requirement) String() string {
 		return "RequireSidecarKzgProofVerified"
 	case RequireSidecarProposerExpected:
 		return "RequireSidecarProposerExpected"
+	case RequireDataColumnValidFields:
+		return "RequireDataColumnValidFields"
+	case RequireDataColumnCorrectSubnet:
+		return "RequireDataColumnCorrectSubnet"
 	default:
 		return unknownRequirementName
 	}
@@ -1,10 +1,13 @@
 package storage
 
 import (
+	"fmt"
 	"path"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/filesystem"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/node"
 	"github.com/prysmaticlabs/prysm/v5/cmd"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
@@ -24,6 +27,33 @@ var (
 		Value:   uint64(params.BeaconConfig().MinEpochsForBlobsSidecarsRequest),
 		Aliases: []string{"extend-blob-retention-epoch"},
 	}
+	BlobStorageLayoutFlag = &cli.StringFlag{
+		Name:  "blob-storage-layout",
+		Usage: fmt.Sprintf("Dictates how to organize the blob directory structure. Valid values are: %s. If the by-epoch layout is chosen, blobs stored in the flat layout are migrated on startup.", strings.Join(filesystem.LayoutNames, ", ")),
+		Value: filesystem.LayoutNameFlat,
+	}
+	// DataColumnStoragePathFlag defines the location of data column sidecar storage.
+	DataColumnStoragePathFlag = &cli.PathFlag{
+		Name:  "data-column-path",
+		Usage: "Location for data column storage. Default location will be a 'data-columns' directory next to the beacon db.",
+	}
+	PruneHistoryFlag = &cli.BoolFlag{
+		Name:  "prune-history",
+		Usage: "Delete finalized blocks and states that are older than the history retention period (see --history-retention-epochs).",
+	}
+	HistoryRetentionEpochsFlag = &cli.Uint64Flag{
+		Name:  "history-retention-epochs",
+		Usage: "Number of epochs behind the finalized checkpoint for which blocks and states are kept when --prune-history is enabled. Defaults to the MIN_EPOCHS_FOR_BLOCK_REQUESTS of the network configuration, and the node will exit with an error at startup if the value is less than it.",
+		Value: params.BeaconConfig().MinEpochsForBlockRequests,
+	}
+	StateDiffExponentsFlag = &cli.IntSliceFlag{
+		Name: "state-diff-exponents",
+		Usage: "Store finalized states as hierarchical state diffs, at the multiples of 2^e slots for each exponent e. " +
+			"The states at the multiples of the largest interval are stored in full, the others as a diff against the state " +
+			"at the previous multiple of the next larger interval. Each interval must be a multiple of an epoch, e.g. 21,18,16,13,11,9,5. " +
+			"The exponents are recorded in the db with the first state diff, and the node refuses to start with other exponents or without the flag afterwards. " +
+			"Cannot be used with --prune-history.",
+	}
 )
 
 // BeaconNodeOptions sets configuration values on the node.BeaconNode value at node startup.
@@ -37,7 +67,25 @@ func BeaconNodeOptions(c *cli.Context) ([]node.Option, error) {
 	}
 	opts := []node.Option{node.WithBlobStorageOptions(
 		filesystem.WithBlobRetentionEpochs(e), filesystem.WithBasePath(blobStoragePath(c)),
-	)}
+		filesystem.WithLayout(c.String(BlobStorageLayoutFlag.Name)),
+	), node.WithDataColumnStorageOptions(filesystem.WithDataColumnBasePath(dataColumnStoragePath(c)))}
+	if c.Bool(PruneHistoryFlag.Name) {
+		he, err := historyRetentionEpoch(c)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, node.WithDBOptions(kv.WithHistoryRetention(he)))
+	}
+	if c.IsSet(StateDiffExponentsFlag.Name) {
+		if c.Bool(PruneHistoryFlag.Name) {
+			return nil, fmt.Errorf("--%s cannot be used with --%s", StateDiffExponentsFlag.Name, PruneHistoryFlag.Name)
+		}
+		exponents, err := stateDiffExponents(c)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, node.WithDBOptions(kv.WithStateDiffExponents(exponents)))
+	}
 	return opts, nil
 }
 
@@ -50,6 +98,15 @@ func blobStoragePath(c *cli.Context) string {
 	return blobsPath
 }
 
+func dataColumnStoragePath(c *cli.Context) string {
+	columnsPath := c.Path(DataColumnStoragePathFlag.Name)
+	if columnsPath == "" {
+		// append a "data-columns" subdir to the end of the data dir path
+		columnsPath = path.Join(c.String(cmd.DataDirFlag.Name), "data-columns")
+	}
+	return columnsPath
+}
+
 var errInvalidBlobRetentionEpochs = errors.New("value is smaller than spec minimum")
 
 // blobRetentionEpoch returns the spec default MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUEST
@@ -69,3 +126,53 @@ func blobRetentionEpoch(cliCtx *cli.Context) (primitives.Epoch, error) {
 
 	return re, nil
 }
+
+var errInvalidHistoryRetentionEpochs = errors.New("value is smaller than spec minimum")
+
+// historyRetentionEpoch returns the spec default MIN_EPOCHS_FOR_BLOCK_REQUESTS
+// or a user-specified flag overriding this value. If a user-specified override is
+// smaller than the spec default, an error will be returned.
+func historyRetentionEpoch(cliCtx *cli.Context) (primitives.Epoch, error) {
+	spec := primitives.Epoch(params.BeaconConfig().MinEpochsForBlockRequests)
+	if !cliCtx.IsSet(HistoryRetentionEpochsFlag.Name) {
+		return spec, nil
+	}
+
+	re := primitives.Epoch(cliCtx.Uint64(HistoryRetentionEpochsFlag.Name))
+	if re < spec {
+		return spec, errors.Wrapf(errInvalidHistoryRetentionEpochs, "%s=%d, spec=%d", HistoryRetentionEpochsFlag.Name, re, spec)
+	}
+
+	return re, nil
+}
+
+// maxStateDiffExponent bounds the largest interval between full states to 2^40 slots, far more than needed.
+const maxStateDiffExponent = 40
+
+var errInvalidStateDiffExponents = errors.New("invalid state diff exponents")
+
+// stateDiffExponents returns the user-specified state diff exponents, checking that they are unique and that the
+// intervals they define are multiples of an epoch, so that every hierarchical state is on an epoch boundary.
+func stateDiffExponents(cliCtx *cli.Context) ([]uint64, error) {
+	values := cliCtx.IntSlice(StateDiffExponentsFlag.Name)
+	if len(values) == 0 {
+		return nil, errors.Wrapf(errInvalidStateDiffExponents, "%s must not be empty", StateDiffExponentsFlag.Name)
+	}
+	spe := uint64(params.BeaconConfig().SlotsPerEpoch)
+	exponents := make([]uint64, 0, len(values))
+	seen := make(map[int]bool, len(values))
+	for _, e := range values {
+		if e < 0 || e > maxStateDiffExponent {
+			return nil, errors.Wrapf(errInvalidStateDiffExponents, "%s=%d, must be between 0 and %d", StateDiffExponentsFlag.Name, e, maxStateDiffExponent)
+		}
+		if seen[e] {
+			return nil, errors.Wrapf(errInvalidStateDiffExponents, "%s=%d is repeated", StateDiffExponentsFlag.Name, e)
+		}
+		seen[e] = true
+		if (uint64(1)<<e)%spe != 0 {
+			return nil, errors.Wrapf(errInvalidStateDiffExponents, "%s=%d, 2^%d is not a multiple of %d slots per epoch", StateDiffExponentsFlag.Name, e, e, spe)
+		}
+		exponents = append(exponents, uint64(e))
+	}
+	return exponents, nil
+}
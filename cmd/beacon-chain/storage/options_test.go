@@ -61,3 +61,60 @@ func TestConfigureBlobRetentionEpoch(t *testing.T) {
 	_, err = blobRetentionEpoch(cliCtx)
 	require.ErrorIs(t, err, errInvalidBlobRetentionEpochs)
 }
+
+func TestConfigureHistoryRetentionEpoch(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	specMinEpochs := primitives.Epoch(params.BeaconConfig().MinEpochsForBlockRequests)
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	// Test case: Spec default.
+	epochs, err := historyRetentionEpoch(cliCtx)
+	require.NoError(t, err)
+	require.Equal(t, specMinEpochs, epochs)
+
+	set.Uint64(HistoryRetentionEpochsFlag.Name, 0, "")
+
+	// Test case: Input epoch is greater than or equal to spec value.
+	expectedChange := specMinEpochs + 1
+	require.NoError(t, set.Set(HistoryRetentionEpochsFlag.Name, fmt.Sprintf("%d", expectedChange)))
+	epochs, err = historyRetentionEpoch(cliCtx)
+	require.NoError(t, err)
+	require.Equal(t, expectedChange, epochs)
+
+	// Test case: Input epoch is less than spec value.
+	expectedChange = specMinEpochs - 1
+	require.NoError(t, set.Set(HistoryRetentionEpochsFlag.Name, fmt.Sprintf("%d", expectedChange)))
+	_, err = historyRetentionEpoch(cliCtx)
+	require.ErrorIs(t, err, errInvalidHistoryRetentionEpochs)
+}
+
+func TestStateDiffExponents(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	newContext := func(t *testing.T, values ...string) *cli.Context {
+		set := flag.NewFlagSet("test", 0)
+		set.Var(cli.NewIntSlice(), StateDiffExponentsFlag.Name, "")
+		for _, v := range values {
+			require.NoError(t, set.Set(StateDiffExponentsFlag.Name, v))
+		}
+		return cli.NewContext(&cli.App{}, set, nil)
+	}
+
+	exponents, err := stateDiffExponents(newContext(t, "21", "13", "5"))
+	require.NoError(t, err)
+	require.DeepEqual(t, []uint64{21, 13, 5}, exponents)
+
+	_, err = stateDiffExponents(newContext(t))
+	require.ErrorIs(t, err, errInvalidStateDiffExponents)
+
+	// 2^4 slots is not a multiple of an epoch.
+	_, err = stateDiffExponents(newContext(t, "13", "4"))
+	require.ErrorIs(t, err, errInvalidStateDiffExponents)
+
+	_, err = stateDiffExponents(newContext(t, "13", "13"))
+	require.ErrorIs(t, err, errInvalidStateDiffExponents)
+
+	_, err = stateDiffExponents(newContext(t, "41"))
+	require.ErrorIs(t, err, errInvalidStateDiffExponents)
+}
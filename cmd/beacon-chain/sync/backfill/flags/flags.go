@@ -40,4 +40,29 @@ var (
 		Usage: "Specifies the oldest slot that backfill should download. " +
 			"If this value is greater than current_slot - MIN_EPOCHS_FOR_BLOCK_REQUESTS, it will be ignored with a warning log.",
 	}
+	// BackfillToGenesis makes backfill download the full block history, and enables the reconstruction of the
+	// archived states needed to serve historical states, so that a checkpoint synced node can become an archive node.
+	BackfillToGenesis = &cli.BoolFlag{
+		Name: "backfill-to-genesis",
+		Usage: "Backfill blocks all the way back to genesis instead of stopping at current_slot - MIN_EPOCHS_FOR_BLOCK_REQUESTS. " +
+			"Once the block history reaches genesis, the archived states (see --slots-per-archive-point) are regenerated " +
+			"up to the checkpoint sync origin. Cannot be used with --backfill-oldest-slot or --prune-history.",
+	}
+	// ReconstructHistoricalStates enables the background service that regenerates the archived states before the
+	// checkpoint sync origin, without requiring backfill to download the block history.
+	ReconstructHistoricalStates = &cli.BoolFlag{
+		Name: "reconstruct-historical-states",
+		Usage: "Once the block history in the db reaches genesis, replay it to regenerate the archived states " +
+			"(see --slots-per-archive-point) up to the checkpoint sync origin, so that historical states can be served " +
+			"for slots before it. Always enabled by --backfill-to-genesis. Without it, the node does not start unless the " +
+			"block history already reaches genesis, for instance when it was imported from era files. Reconstruction " +
+			"resumes where it stopped after a restart. Cannot be used with --prune-history.",
+	}
+	// HistoricalStateReconstructionRate limits the speed of historical state reconstruction, so that it doesn't
+	// compete with the processing of new blocks.
+	HistoricalStateReconstructionRate = &cli.Uint64Flag{
+		Name:  "historical-state-reconstruction-rate",
+		Usage: "Maximum number of blocks per second replayed by historical state reconstruction. 0 means no limit.",
+		Value: 32,
+	}
 )
@@ -1,8 +1,12 @@
 package backfill
 
 import (
+	"fmt"
+
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/node"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/reconstruction"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/backfill"
+	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/storage"
 	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/sync/backfill/flags"
 	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
 	"github.com/urfave/cli/v2"
@@ -11,6 +15,17 @@ import (
 // BeaconNodeOptions sets the appropriate functional opts on the *node.BeaconNode value, to decouple options
 // from flag parsing.
 func BeaconNodeOptions(c *cli.Context) ([]node.Option, error) {
+	if c.Bool(flags.BackfillToGenesis.Name) {
+		for _, f := range []string{flags.BackfillOldestSlot.Name, storage.PruneHistoryFlag.Name} {
+			if c.IsSet(f) {
+				return nil, fmt.Errorf("--%s cannot be used with --%s", flags.BackfillToGenesis.Name, f)
+			}
+		}
+	}
+	// History pruning deletes the blocks that reconstruction replays.
+	if c.Bool(flags.ReconstructHistoricalStates.Name) && c.IsSet(storage.PruneHistoryFlag.Name) {
+		return nil, fmt.Errorf("--%s cannot be used with --%s", flags.ReconstructHistoricalStates.Name, storage.PruneHistoryFlag.Name)
+	}
 	opt := func(node *node.BeaconNode) (err error) {
 		bno := []backfill.ServiceOption{
 			backfill.WithBatchSize(c.Uint64(flags.BackfillBatchSize.Name)),
@@ -22,7 +37,15 @@ func BeaconNodeOptions(c *cli.Context) ([]node.Option, error) {
 			uv := c.Uint64(flags.BackfillOldestSlot.Name)
 			bno = append(bno, backfill.WithMinimumSlot(primitives.Slot(uv)))
 		}
+		if c.Bool(flags.BackfillToGenesis.Name) {
+			bno = append(bno, backfill.WithBackfillToGenesis())
+		}
 		node.BackfillOpts = bno
+		node.ReconstructionOpts = []reconstruction.Option{
+			reconstruction.WithEnabled(c.Bool(flags.ReconstructHistoricalStates.Name) || c.Bool(flags.BackfillToGenesis.Name)),
+			reconstruction.WithBackfillToGenesis(c.Bool(flags.BackfillToGenesis.Name)),
+			reconstruction.WithBlocksPerSecond(c.Uint64(flags.HistoricalStateReconstructionRate.Name)),
+		}
 		return nil
 	}
 	return []node.Option{opt}, nil
@@ -4,8 +4,10 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/node"
 	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/checkpoint"
+	"github.com/prysmaticlabs/prysm/v5/cmd/beacon-chain/flags"
 	"github.com/urfave/cli/v2"
 )
 
@@ -22,12 +24,25 @@ var (
 		Usage: "Rather than syncing from genesis, you can start processing from a ssz-serialized BeaconState+Block." +
 			" This flag allows you to specify a local file containing the checkpoint Block to load.",
 	}
-	RemoteURL = &cli.StringFlag{
+	RemoteURL = &cli.StringSliceFlag{
 		Name: "checkpoint-sync-url",
 		Usage: "URL of a synced beacon node to trust in obtaining checkpoint sync data. " +
+			"The flag can be repeated to use several beacon nodes, which must agree on the finalized checkpoint (see --checkpoint-sync-quorum). " +
 			"As an additional safety measure, it is strongly recommended to only use this option in conjunction with " +
 			"--weak-subjectivity-checkpoint flag",
 	}
+	// Quorum sets how many of the beacon nodes given with RemoteURL must agree on the finalized checkpoint.
+	Quorum = &cli.IntFlag{
+		Name:  "checkpoint-sync-quorum",
+		Usage: "Number of --checkpoint-sync-url beacon nodes that must agree on the finalized checkpoint. Defaults to a majority of them.",
+	}
+	// EraPath defines a flag to start the beacon chain from the latest state of local era files.
+	EraPath = &cli.PathFlag{
+		Name: "checkpoint-era-path",
+		Usage: "Rather than syncing from genesis, you can start processing from the latest state found in era files. " +
+			"This flag allows you to specify an era file, or a directory of era files. The blocks they contain are imported so that " +
+			"backfill has less to download. Requires the --weak-subjectivity-checkpoint flag, which the archive is validated against.",
+	}
 )
 
 // BeaconNodeOptions is responsible for determining if the checkpoint sync options have been used, and if so,
@@ -36,11 +51,35 @@ var (
 func BeaconNodeOptions(c *cli.Context) ([]node.Option, error) {
 	blockPath := c.Path(BlockPath.Name)
 	statePath := c.Path(StatePath.Name)
-	remoteURL := c.String(RemoteURL.Name)
-	if remoteURL != "" {
+	remoteURLs := c.StringSlice(RemoteURL.Name)
+	if eraPath := c.Path(EraPath.Name); eraPath != "" {
+		if len(remoteURLs) > 0 || blockPath != "" || statePath != "" {
+			return nil, fmt.Errorf("--%s can not be used with other checkpoint sync flags", EraPath.Name)
+		}
+		ws, err := helpers.ParseWeakSubjectivityInputString(c.String(flags.WeakSubjectivityCheckpoint.Name))
+		if err != nil {
+			return nil, err
+		}
+		if ws == nil {
+			return nil, fmt.Errorf("--%s requires --%s", EraPath.Name, flags.WeakSubjectivityCheckpoint.Name)
+		}
+		opt := func(node *node.BeaconNode) (err error) {
+			node.CheckpointInitializer, err = checkpoint.NewEraInitializer(eraPath, ws)
+			if err != nil {
+				return errors.Wrap(err, "error preparing to initialize checkpoint from era files")
+			}
+			return nil
+		}
+		return []node.Option{opt}, nil
+	}
+	if len(remoteURLs) > 0 {
+		var opts []checkpoint.APIInitializerOpt
+		if c.IsSet(Quorum.Name) {
+			opts = append(opts, checkpoint.WithQuorum(c.Int(Quorum.Name)))
+		}
 		opt := func(node *node.BeaconNode) error {
 			var err error
-			node.CheckpointInitializer, err = checkpoint.NewAPIInitializer(remoteURL)
+			node.CheckpointInitializer, err = checkpoint.NewAPIInitializer(remoteURLs, opts...)
 			if err != nil {
 				return errors.Wrap(err, "error while constructing beacon node api client for checkpoint sync")
 			}
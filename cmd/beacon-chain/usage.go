@@ -115,7 +115,10 @@ var appHelpFlagGroups = []flagGroup{
 			flags.BlockBatchLimitBurstFactor,
 			flags.BlobBatchLimit,
 			flags.BlobBatchLimitBurstFactor,
+			flags.DataColumnBatchLimit,
+			flags.DataColumnBatchLimitBurstFactor,
 			flags.DisableDebugRPCEndpoints,
+			flags.DisableOperationPoolPersistence,
 			flags.SubscribeToAllSubnets,
 			flags.HistoricalSlasherNode,
 			flags.ChainID,
@@ -136,14 +139,24 @@ var appHelpFlagGroups = []flagGroup{
 			checkpoint.BlockPath,
 			checkpoint.StatePath,
 			checkpoint.RemoteURL,
+			checkpoint.Quorum,
+			checkpoint.EraPath,
 			genesis.StatePath,
 			genesis.BeaconAPIURL,
 			storage.BlobStoragePathFlag,
 			storage.BlobRetentionEpochFlag,
+			storage.BlobStorageLayoutFlag,
+			storage.DataColumnStoragePathFlag,
+			storage.PruneHistoryFlag,
+			storage.HistoryRetentionEpochsFlag,
+			storage.StateDiffExponentsFlag,
 			backfill.EnableExperimentalBackfill,
 			backfill.BackfillWorkerCount,
 			backfill.BackfillBatchSize,
 			backfill.BackfillOldestSlot,
+			backfill.BackfillToGenesis,
+			backfill.ReconstructHistoricalStates,
+			backfill.HistoricalStateReconstructionRate,
 		},
 	},
 	{
@@ -0,0 +1,355 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/checkpoint"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/io/era"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var eraFlags = struct {
+	Path                       string
+	EraPath                    string
+	StartEra                   uint64
+	EndEra                     uint64
+	WeakSubjectivityCheckpoint string
+}{}
+
+var (
+	dbPathFlag = &cli.StringFlag{
+		Name:        "path",
+		Usage:       "path to directory containing beaconchain.db",
+		Destination: &eraFlags.Path,
+		Required:    true,
+	}
+	eraPathFlag = &cli.StringFlag{
+		Name:        "era-path",
+		Usage:       "directory of era files, or a single era file when importing",
+		Destination: &eraFlags.EraPath,
+		Required:    true,
+	}
+)
+
+var exportEraCmd = &cli.Command{
+	Name:  "export-era",
+	Usage: "write the finalized blocks and states of the beacon db to era files",
+	Action: func(cliCtx *cli.Context) error {
+		if err := exportEraAction(cliCtx); err != nil {
+			return errors.Wrap(err, "could not export era files")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		dbPathFlag,
+		eraPathFlag,
+		&cli.Uint64Flag{
+			Name:        "start-era",
+			Usage:       "first era to export, defaults to the earliest era the db has all the blocks and a state for",
+			Destination: &eraFlags.StartEra,
+		},
+		&cli.Uint64Flag{
+			Name:        "end-era",
+			Usage:       "last era to export, defaults to the last finalized era",
+			Destination: &eraFlags.EndEra,
+		},
+	},
+}
+
+var importEraCmd = &cli.Command{
+	Name:  "import-era",
+	Usage: "import the finalized blocks and states of era files into an empty or checkpoint synced beacon db. " +
+		"Only the blocks are imported into a checkpoint synced db, the states of the era files are not used.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := importEraAction(cliCtx); err != nil {
+			return errors.Wrap(err, "could not import era files")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		dbPathFlag,
+		eraPathFlag,
+		&cli.StringFlag{
+			Name: "weak-subjectivity-checkpoint",
+			Usage: "checkpoint the era files are validated against when importing into an empty db, " +
+				"in the block_root:epoch_number format",
+			Destination: &eraFlags.WeakSubjectivityCheckpoint,
+		},
+	},
+}
+
+func exportEraAction(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	d, err := kv.NewKVStore(ctx, eraFlags.Path)
+	if err != nil {
+		return err
+	}
+	defer closeDB(d)
+	var start, end *uint64
+	if cliCtx.IsSet("start-era") {
+		start = &eraFlags.StartEra
+	}
+	if cliCtx.IsSet("end-era") {
+		end = &eraFlags.EndEra
+	}
+	return exportEras(ctx, d, eraFlags.EraPath, start, end)
+}
+
+func importEraAction(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	ws, err := helpers.ParseWeakSubjectivityInputString(eraFlags.WeakSubjectivityCheckpoint)
+	if err != nil {
+		return err
+	}
+	d, err := kv.NewKVStore(ctx, eraFlags.Path)
+	if err != nil {
+		return err
+	}
+	defer closeDB(d)
+	return importEras(ctx, d, eraFlags.EraPath, ws)
+}
+
+func closeDB(d *kv.Store) {
+	if err := d.Close(); err != nil {
+		log.WithError(err).Error("Could not close db")
+	}
+}
+
+// exportEras writes an era file for each era from start to end in dir. When start is nil, eras are exported from the
+// earliest one the db can export, and when end is nil, up to the last one that is finalized.
+func exportEras(ctx context.Context, d *kv.Store, dir string, start, end *uint64) error {
+	cp, err := d.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not read finalized checkpoint")
+	}
+	finalized, err := slots.EpochStart(cp.Epoch)
+	if err != nil {
+		return err
+	}
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+	last := uint64(finalized / perEra)
+	if end == nil {
+		end = &last
+	}
+	if *end > last {
+		return fmt.Errorf("era %d is not finalized, the last finalized era is %d", *end, last)
+	}
+	earliest, err := earliestExportableEra(ctx, d)
+	if err != nil {
+		return err
+	}
+	if start == nil {
+		start = &earliest
+	}
+	if *start < earliest {
+		return fmt.Errorf("era %d can not be exported, the earliest era the db has all the blocks and a state for is %d", *start, earliest)
+	}
+	if *start > *end {
+		return fmt.Errorf("start era %d is after end era %d", *start, *end)
+	}
+	if err := os.MkdirAll(dir, params.BeaconIoConfig().ReadWriteExecutePermissions); err != nil {
+		return err
+	}
+	history := stategen.NewFinalizedHistory(d, finalized)
+	for e := *start; e <= *end; e++ {
+		path, err := exportEra(ctx, d, history, dir, e)
+		if err != nil {
+			return errors.Wrapf(err, "could not export era %d", e)
+		}
+		log.WithField("path", path).Infof("Exported era %d", e)
+	}
+	return nil
+}
+
+// earliestExportableEra returns the lowest era for which the db has every block, along with a state at or before
+// them to replay the state of the era from. Blocks and states below the history pruning boundary are gone, and a
+// checkpoint synced db only has the blocks backfill saved below its origin state.
+func earliestExportableEra(ctx context.Context, d *kv.Store) (uint64, error) {
+	lowBlock, lowState := d.HistoryPrunedBefore(), d.HistoryPrunedBefore()
+	_, err := d.OriginCheckpointBlockRoot(ctx)
+	switch {
+	case err == nil:
+		status, err := d.BackfillStatus(ctx)
+		if err != nil {
+			return 0, errors.Wrap(err, "could not read backfill status")
+		}
+		// Backfill is complete once it reaches the genesis block.
+		complete, hasGenesisState := status.LowSlot == 0, false
+		if gr, err := d.GenesisBlockRoot(ctx); err == nil {
+			complete = complete || bytesutil.ToBytes32(status.LowParentRoot) == gr
+			hasGenesisState = d.HasState(ctx, gr)
+		} else if !errors.Is(err, kv.ErrNotFound) {
+			return 0, errors.Wrap(err, "could not read genesis block root")
+		}
+		if !complete {
+			lowBlock = max(lowBlock, primitives.Slot(status.LowSlot))
+		}
+		if !complete || !hasGenesisState {
+			lowState = max(lowState, primitives.Slot(status.OriginSlot))
+		}
+	case !errors.Is(err, kv.ErrNotFound):
+		return 0, errors.Wrap(err, "could not read checkpoint sync origin")
+	}
+	if lowBlock == 0 && lowState == 0 {
+		return 0, nil
+	}
+	// The blocks of an era start at the state slot of the previous era, and its state is replayed from a state
+	// before its state slot.
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+	return max(uint64((lowBlock+perEra-1)/perEra)+1, uint64(lowState/perEra)+1), nil
+}
+
+func exportEra(ctx context.Context, d *kv.Store, history *stategen.CanonicalHistory, dir string, e uint64) (string, error) {
+	// The state of an era is the state at the first slot of the next era, before the block at that slot is applied,
+	// so that the block the state was built on is part of the era.
+	stateSlot := primitives.Slot(e) * params.BeaconConfig().SlotsPerHistoricalRoot
+	blockSlot := stateSlot
+	if blockSlot > 0 {
+		blockSlot--
+	}
+	st, err := history.ReplayerForSlot(blockSlot).ReplayToSlot(ctx, stateSlot)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not generate state at slot %d", stateSlot)
+	}
+	root, err := historicalRoot(st, e)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, era.FileName(params.BeaconConfig().ConfigName, e, root))
+	// Write to a temporary file, so that an interrupted export does not leave a partial era file behind.
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp) // #nosec G304 -- path is provided by the node operator.
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := f.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+			log.WithError(err).Error("Could not close era file")
+		}
+		if err := os.Remove(tmp); err != nil && !os.IsNotExist(err) {
+			log.WithError(err).Error("Could not remove temporary era file")
+		}
+	}()
+
+	w, err := era.NewWriter(f, e)
+	if err != nil {
+		return "", err
+	}
+	for slot := w.StartSlot(); slot < w.StateSlot(); slot++ {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		b, err := finalizedBlock(ctx, d, slot)
+		if err != nil {
+			return "", err
+		}
+		if b == nil {
+			continue
+		}
+		if err := w.AddBlock(slot, b); err != nil {
+			return "", err
+		}
+	}
+	serState, err := st.MarshalSSZ()
+	if err != nil {
+		return "", err
+	}
+	if err := w.AddState(serState); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	return path, os.Rename(tmp, path)
+}
+
+// finalizedBlock returns the ssz encoded finalized block at the given slot, or nil if the slot is empty.
+func finalizedBlock(ctx context.Context, d *kv.Store, slot primitives.Slot) ([]byte, error) {
+	_, roots, err := d.BlockRootsBySlot(ctx, slot)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read block roots at slot %d", slot)
+	}
+	for _, r := range roots {
+		if !d.IsFinalizedBlock(ctx, r) {
+			continue
+		}
+		b, err := d.Block(ctx, r)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read block %#x", r)
+		}
+		return b.MarshalSSZ()
+	}
+	return nil, nil
+}
+
+// historicalRoot returns the root identifying an era in the name of its era file: the genesis validators root for
+// era 0, and the historical root or historical summary root of the era after that.
+func historicalRoot(st state.BeaconState, e uint64) ([32]byte, error) {
+	if e == 0 {
+		return bytesutil.ToBytes32(st.GenesisValidatorsRoot()), nil
+	}
+	roots, err := st.HistoricalRoots()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if e <= uint64(len(roots)) {
+		return bytesutil.ToBytes32(roots[e-1]), nil
+	}
+	if st.Version() >= version.Capella {
+		summaries, err := st.HistoricalSummaries()
+		if err != nil {
+			return [32]byte{}, err
+		}
+		if i := e - 1 - uint64(len(roots)); i < uint64(len(summaries)) {
+			return summaries[i].HashTreeRoot()
+		}
+	}
+	return [32]byte{}, fmt.Errorf("state at slot %d has no historical root for era %d", st.Slot(), e)
+}
+
+// importEras imports the era files at path. An empty db is initialized from the latest state of the era files, like
+// checkpoint sync does, after validating them against the weak subjectivity checkpoint. A checkpoint synced db gets
+// the blocks preceding its oldest block.
+func importEras(ctx context.Context, d *kv.Store, path string, ws *ethpb.Checkpoint) error {
+	paths, err := era.List(path)
+	if err != nil {
+		return err
+	}
+	_, err = d.OriginCheckpointBlockRoot(ctx)
+	if err == nil {
+		log.Info("Importing the blocks of the era files into a checkpoint synced db, the states of the era files are not used")
+		return checkpoint.ImportEraBlocks(ctx, d, paths)
+	}
+	if !errors.Is(err, kv.ErrNotFound) {
+		return err
+	}
+	if _, err := d.GenesisBlockRoot(ctx); err == nil {
+		return errors.New("db was synced from genesis and already has the full history")
+	}
+	if ws == nil {
+		return errors.New("a weak subjectivity checkpoint is required to import era files into an empty db")
+	}
+	ei, err := checkpoint.NewEraInitializer(path, ws)
+	if err != nil {
+		return err
+	}
+	return ei.Initialize(ctx, d)
+}
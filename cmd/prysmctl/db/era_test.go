@@ -0,0 +1,165 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/sync/checkpoint"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/io/era"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+const testEraBlockStep = 4
+
+// newTestChainDB saves a chain with a block every testEraBlockStep slots up to the given slot in a new db at dir,
+// and finalizes the checkpoint at the given epoch.
+func newTestChainDB(t *testing.T, dir string, last primitives.Slot, finalized primitives.Epoch) (*kv.Store, map[primitives.Slot][32]byte) {
+	ctx := context.Background()
+	d, err := kv.NewKVStore(ctx, dir)
+	require.NoError(t, err)
+
+	st, keys := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, d.SaveGenesisData(ctx, st))
+	genesisRoot, err := d.GenesisBlockRoot(ctx)
+	require.NoError(t, err)
+	roots := map[primitives.Slot][32]byte{0: genesisRoot}
+
+	finalizedSlot := primitives.Slot(finalized) * params.BeaconConfig().SlotsPerEpoch
+	conf := util.DefaultBlockGenConfig()
+	conf.NumAttestations = 0
+	for slot := primitives.Slot(testEraBlockStep); slot <= last; slot += testEraBlockStep {
+		pb, err := util.GenerateFullBlock(st, keys, conf, slot)
+		require.NoError(t, err)
+		b, err := blocks.NewSignedBeaconBlock(pb)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, b)
+		require.NoError(t, err)
+		require.NoError(t, d.SaveBlock(ctx, b))
+		roots[slot], err = b.Block().HashTreeRoot()
+		require.NoError(t, err)
+		if slot == finalizedSlot {
+			require.NoError(t, d.SaveState(ctx, st, roots[slot]))
+		}
+	}
+	require.NoError(t, d.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: finalized, Root: bytes32(roots[finalizedSlot])}))
+	return d, roots
+}
+
+func TestExportImportEras(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.SlotsPerHistoricalRoot = 64
+	params.OverrideBeaconConfig(cfg)
+	ctx := context.Background()
+	perEra := cfg.SlotsPerHistoricalRoot
+	perEpoch := cfg.SlotsPerEpoch
+
+	// Eras 0 to 2 are finalized.
+	srcDir := t.TempDir()
+	src, roots := newTestChainDB(t, srcDir, 3*perEra, primitives.Epoch(5*perEra/2/perEpoch))
+	dir := t.TempDir()
+	end := uint64(3)
+	require.ErrorContains(t, "era 3 is not finalized", exportEras(ctx, src, dir, nil, &end))
+	earliest, err := earliestExportableEra(ctx, src)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), earliest)
+	require.NoError(t, exportEras(ctx, src, dir, nil, nil))
+	eraPaths := make([]string, 3)
+	for e := range eraPaths {
+		eraPaths[e] = filepath.Join(dir, era.FileName(cfg.ConfigName, uint64(e), rootOfEra(t, src, uint64(e))))
+	}
+	// Only one db can be open at a time.
+	require.NoError(t, src.Close())
+	paths, err := era.List(dir)
+	require.NoError(t, err)
+	require.DeepEqual(t, eraPaths, paths)
+
+	f, err := era.Open(eraPaths[1])
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+	require.Equal(t, perEra/testEraBlockStep, primitives.Slot(len(f.BlockSlots())))
+	require.Equal(t, primitives.Slot(0), f.BlockSlots()[0])
+	require.Equal(t, perEra-testEraBlockStep, f.BlockSlots()[len(f.BlockSlots())-1])
+
+	wsEpoch := primitives.Epoch(3 * perEra / 2 / perEpoch)
+	ws := &ethpb.Checkpoint{Epoch: wsEpoch, Root: bytes32(roots[primitives.Slot(wsEpoch)*perEpoch])}
+
+	t.Run("empty db", func(t *testing.T) {
+		d, err := kv.NewKVStore(ctx, t.TempDir())
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, d.Close())
+		}()
+		require.ErrorContains(t, "weak subjectivity checkpoint is required", importEras(ctx, d, dir, nil))
+		require.NoError(t, importEras(ctx, d, dir, ws))
+
+		origin, err := d.OriginCheckpointBlockRoot(ctx)
+		require.NoError(t, err)
+		require.Equal(t, roots[2*perEra-testEraBlockStep], origin)
+		status, err := d.BackfillStatus(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), status.LowSlot)
+		for slot := primitives.Slot(0); slot < 2*perEra; slot += testEraBlockStep {
+			require.Equal(t, true, d.IsFinalizedBlock(ctx, roots[slot]), "block at slot %d is not finalized", slot)
+		}
+
+		// The db has all the blocks, but no state to replay the eras preceding its origin state from.
+		earliest, err := earliestExportableEra(ctx, d)
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), earliest)
+		start := uint64(1)
+		require.ErrorContains(t, "the earliest era the db has all the blocks and a state for is 2", exportEras(ctx, d, t.TempDir(), &start, nil))
+	})
+	t.Run("checkpoint synced db", func(t *testing.T) {
+		d, err := kv.NewKVStore(ctx, t.TempDir())
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, d.Close())
+		}()
+		// Checkpoint sync from the latest era only, then import the older eras.
+		ei, err := checkpoint.NewEraInitializer(eraPaths[2], ws)
+		require.NoError(t, err)
+		require.NoError(t, ei.Initialize(ctx, d))
+		status, err := d.BackfillStatus(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(perEra), status.LowSlot)
+
+		require.NoError(t, importEras(ctx, d, dir, nil))
+		status, err = d.BackfillStatus(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), status.LowSlot)
+		require.Equal(t, true, d.HasBlock(ctx, roots[testEraBlockStep]))
+	})
+	t.Run("genesis synced db", func(t *testing.T) {
+		d, err := kv.NewKVStore(ctx, srcDir)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, d.Close())
+		}()
+		require.ErrorContains(t, "already has the full history", importEras(ctx, d, dir, nil))
+	})
+}
+
+func rootOfEra(t *testing.T, d *kv.Store, e uint64) [32]byte {
+	cp, err := d.FinalizedCheckpoint(context.Background())
+	require.NoError(t, err)
+	st, err := d.State(context.Background(), [32]byte(cp.Root))
+	require.NoError(t, err)
+	r, err := historicalRoot(st, e)
+	require.NoError(t, err)
+	return r
+}
+
+func bytes32(r [32]byte) []byte {
+	return append([]byte{}, r[:]...)
+}
@@ -0,0 +1,198 @@
+package lightclient
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+// follower keeps a light client store up to date with the updates served by a beacon node.
+type follower struct {
+	client                *beacon.Client
+	genesisTime           time.Time
+	genesisValidatorsRoot []byte
+
+	lock  sync.RWMutex
+	store *lightClient.Store
+}
+
+// newFollower initializes the light client store from the bootstrap of the trusted block served by the beacon node.
+func newFollower(ctx context.Context, c *beacon.Client, trustedBlockRoot [32]byte) (*follower, error) {
+	genesis, err := c.GetGenesis(ctx)
+	if err != nil {
+		return nil, err
+	}
+	genesisTime, err := strconv.ParseUint(genesis.GenesisTime, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse genesis time")
+	}
+	genesisValidatorsRoot, err := hexutil.Decode(genesis.GenesisValidatorsRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse genesis validators root")
+	}
+	if len(genesisValidatorsRoot) != fieldparams.RootLength {
+		return nil, errors.Errorf("genesis validators root has length %d, expected %d", len(genesisValidatorsRoot), fieldparams.RootLength)
+	}
+	if want := hexutil.Encode(params.BeaconConfig().GenesisForkVersion); genesis.GenesisForkVersion != want {
+		return nil, errors.Errorf("beacon node genesis fork version %s does not match the chain config genesis fork version %s", genesis.GenesisForkVersion, want)
+	}
+
+	bootstrap, err := c.GetLightClientBootstrap(ctx, trustedBlockRoot)
+	if err != nil {
+		return nil, err
+	}
+	store, err := lightClient.NewStore(trustedBlockRoot, bootstrap)
+	if err != nil {
+		return nil, err
+	}
+	log.WithField("slot", store.FinalizedHeader.Beacon().Slot).Info("Initialized light client from trusted block")
+
+	return &follower{
+		client:                c,
+		genesisTime:           time.Unix(int64(genesisTime), 0),
+		genesisValidatorsRoot: genesisValidatorsRoot,
+		store:                 store,
+	}, nil
+}
+
+// run follows the chain until the context is canceled, catching up with the sync committee periods first and then
+// processing the latest finality and optimistic updates once per slot.
+func (f *follower) run(ctx context.Context) error {
+	if err := f.syncPeriods(ctx); err != nil {
+		return err
+	}
+	// Updates are produced once the block of the slot is imported, so the beacon node is polled a third into the slot.
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	ticker := slots.NewSlotTickerWithOffset(f.genesisTime, time.Duration(secondsPerSlot)*time.Second/3, secondsPerSlot)
+	defer ticker.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C():
+			f.poll(ctx)
+		}
+	}
+}
+
+// syncPeriods applies the best update of every sync committee period between the store and the current slot, so
+// that the store knows the sync committees signing the latest updates.
+func (f *follower) syncPeriods(ctx context.Context) error {
+	for {
+		f.lock.RLock()
+		storePeriod := syncCommitteePeriodAtSlot(f.store.FinalizedHeader.Beacon().Slot)
+		hasNextSyncCommittee := f.store.NextSyncCommittee != nil
+		f.lock.RUnlock()
+
+		startPeriod := storePeriod
+		if hasNextSyncCommittee {
+			startPeriod++
+		}
+		currentPeriod := syncCommitteePeriodAtSlot(f.currentSlot())
+		if startPeriod > currentPeriod {
+			return nil
+		}
+		count := min(currentPeriod-startPeriod+1, params.BeaconConfig().MaxRequestLightClientUpdates)
+		updates, err := f.client.GetLightClientUpdatesByRange(ctx, startPeriod, count)
+		if err != nil {
+			return err
+		}
+		for _, update := range updates {
+			err := f.processUpdate(update)
+			if errors.Is(err, lightClient.ErrInvalidUpdate) {
+				// The update may not be relevant to the store anymore, the following ones may still be.
+				log.WithError(err).WithField("period", syncCommitteePeriodAtSlot(update.AttestedHeader().Beacon().Slot)).Warn("Skipping light client update")
+				continue
+			}
+			if err != nil {
+				return errors.Wrapf(err, "could not process update of period %d", syncCommitteePeriodAtSlot(update.AttestedHeader().Beacon().Slot))
+			}
+		}
+
+		f.lock.RLock()
+		progressed := syncCommitteePeriodAtSlot(f.store.FinalizedHeader.Beacon().Slot) != storePeriod || (f.store.NextSyncCommittee != nil) != hasNextSyncCommittee
+		f.lock.RUnlock()
+		// The beacon node has no better update yet, the remaining periods are caught up with while polling.
+		if !progressed {
+			return nil
+		}
+		log.WithField("period", startPeriod+uint64(len(updates))-1).Info("Synced light client sync committee periods")
+	}
+}
+
+// poll processes the latest updates known to the beacon node.
+func (f *follower) poll(ctx context.Context) {
+	currentSlot := f.currentSlot()
+	f.lock.Lock()
+	err := f.store.ProcessForceUpdate(currentSlot)
+	f.lock.Unlock()
+	if err != nil {
+		log.WithError(err).Error("Could not force update light client store")
+	}
+	if err := f.syncPeriods(ctx); err != nil {
+		log.WithError(err).Warn("Could not sync light client sync committee periods")
+	}
+
+	finalityUpdate, err := f.client.GetLightClientFinalityUpdate(ctx)
+	if err != nil {
+		log.WithError(err).Warn("Could not get light client finality update")
+	} else {
+		f.lock.Lock()
+		err = f.store.ProcessFinalityUpdate(finalityUpdate, currentSlot, f.genesisValidatorsRoot)
+		f.lock.Unlock()
+		if err != nil {
+			log.WithError(err).Warn("Could not process light client finality update")
+		}
+	}
+
+	optimisticUpdate, err := f.client.GetLightClientOptimisticUpdate(ctx)
+	if err != nil {
+		log.WithError(err).Warn("Could not get light client optimistic update")
+	} else {
+		f.lock.Lock()
+		err = f.store.ProcessOptimisticUpdate(optimisticUpdate, currentSlot, f.genesisValidatorsRoot)
+		f.lock.Unlock()
+		if err != nil {
+			log.WithError(err).Warn("Could not process light client optimistic update")
+		}
+	}
+
+	finalized, optimistic := f.headers()
+	log.WithFields(logrus.Fields{
+		"finalizedSlot":  finalized.Beacon().Slot,
+		"optimisticSlot": optimistic.Beacon().Slot,
+	}).Info("Processed light client updates")
+}
+
+func (f *follower) processUpdate(update interfaces.LightClientUpdate) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.store.ProcessUpdate(update, f.currentSlot(), f.genesisValidatorsRoot)
+}
+
+// headers returns the finalized and optimistic headers of the store.
+func (f *follower) headers() (finalized, optimistic interfaces.LightClientHeader) {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+	return f.store.FinalizedHeader, f.store.OptimisticHeader
+}
+
+func (f *follower) currentSlot() primitives.Slot {
+	return slots.Since(f.genesisTime)
+}
+
+func syncCommitteePeriodAtSlot(slot primitives.Slot) uint64 {
+	return slots.SyncCommitteePeriod(slots.ToEpoch(slot))
+}
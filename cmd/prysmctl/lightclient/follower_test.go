@@ -0,0 +1,82 @@
+package lightclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	lightClient "github.com/prysmaticlabs/prysm/v5/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+func TestFollower_ServesTrustedHeaders(t *testing.T) {
+	l := util.NewTestLightClient(t).SetupTestAltair()
+	bootstrap, err := lightClient.NewLightClientBootstrapFromBeaconState(l.Ctx, l.State.Slot(), l.State, l.Block)
+	require.NoError(t, err)
+	data, err := structs.LightClientBootstrapFromConsensus(bootstrap)
+	require.NoError(t, err)
+	blockRoot, err := l.Block.Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	genesisForkVersion := hexutil.Encode(params.BeaconConfig().GenesisForkVersion)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/beacon/genesis", func(w http.ResponseWriter, _ *http.Request) {
+		httputil.WriteJson(w, &structs.GetGenesisResponse{Data: &structs.Genesis{
+			GenesisTime:           "1606824023",
+			GenesisValidatorsRoot: hexutil.Encode(make([]byte, 32)),
+			GenesisForkVersion:    genesisForkVersion,
+		}})
+	})
+	mux.HandleFunc(fmt.Sprintf("/eth/v1/beacon/light_client/bootstrap/%#x", blockRoot), func(w http.ResponseWriter, _ *http.Request) {
+		httputil.WriteJson(w, &structs.LightClientBootstrapResponse{Version: version.String(bootstrap.Version()), Data: data})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	c, err := beacon.NewClient(srv.URL)
+	require.NoError(t, err)
+
+	_, err = newFollower(context.Background(), c, [32]byte{'a'})
+	require.ErrorContains(t, "404", err)
+
+	f, err := newFollower(context.Background(), c, blockRoot)
+	require.NoError(t, err)
+
+	for _, path := range []string{finalizedHeaderPath, optimisticHeaderPath} {
+		rec := httptest.NewRecorder()
+		newHandler(f).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		resp := &headerResponse{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), resp))
+		require.Equal(t, "altair", resp.Version)
+		require.Equal(t, fmt.Sprintf("%#x", blockRoot), resp.Data.Root)
+		require.Equal(t, fmt.Sprintf("%d", l.Block.Block().Slot()), resp.Data.Beacon.Slot)
+	}
+}
+
+func TestNewFollower_GenesisForkVersionMismatch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/beacon/genesis", func(w http.ResponseWriter, _ *http.Request) {
+		httputil.WriteJson(w, &structs.GetGenesisResponse{Data: &structs.Genesis{
+			GenesisTime:           "1606824023",
+			GenesisValidatorsRoot: hexutil.Encode(make([]byte, 32)),
+			GenesisForkVersion:    "0x12345678",
+		}})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	c, err := beacon.NewClient(srv.URL)
+	require.NoError(t, err)
+
+	_, err = newFollower(context.Background(), c, [32]byte{'a'})
+	require.ErrorContains(t, "does not match the chain config genesis fork version", err)
+}
@@ -0,0 +1,113 @@
+package lightclient
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/urfave/cli/v2"
+)
+
+var runFlags = struct {
+	BeaconNodeHost   string
+	Timeout          time.Duration
+	TrustedBlockRoot string
+	ChainConfigFile  string
+	HTTPHost         string
+}{}
+
+var runCmd = &cli.Command{
+	Name:  "run",
+	Usage: "Follow the chain from a trusted block root by verifying the light client updates served by a beacon node, and serve the finalized and optimistic headers over a REST API.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := cliActionRun(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not run light client")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port for beacon node connection",
+			Destination: &runFlags.BeaconNodeHost,
+			Value:       "localhost:3500",
+		},
+		&cli.DurationFlag{
+			Name:        "http-timeout",
+			Usage:       "timeout for http requests made to beacon-node-url (uses duration format, ex: 2m31s). default: 1m",
+			Destination: &runFlags.Timeout,
+			Value:       time.Minute,
+		},
+		&cli.StringFlag{
+			Name:        "trusted-block-root",
+			Usage:       "Hex encoded root of a recent finalized block to start following the chain from, such as a weak subjectivity checkpoint root",
+			Destination: &runFlags.TrustedBlockRoot,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "chain-config-file",
+			Usage:       "The path to a YAML file with chain config values, defaults to the mainnet config",
+			Destination: &runFlags.ChainConfigFile,
+		},
+		&cli.StringFlag{
+			Name:        "http-host",
+			Usage:       "host:port on which the finalized and optimistic headers are served",
+			Destination: &runFlags.HTTPHost,
+			Value:       "127.0.0.1:5053",
+		},
+	},
+}
+
+func cliActionRun(_ *cli.Context) error {
+	f := runFlags
+	if f.ChainConfigFile != "" {
+		if err := params.LoadChainConfigFile(f.ChainConfigFile, nil); err != nil {
+			return errors.Wrap(err, "could not load chain config file")
+		}
+	}
+	root, err := hexutil.Decode(f.TrustedBlockRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not decode trusted block root")
+	}
+	if len(root) != fieldparams.RootLength {
+		return errors.Errorf("trusted block root has length %d, expected %d", len(root), fieldparams.RootLength)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	c, err := beacon.NewClient(f.BeaconNodeHost, client.WithTimeout(f.Timeout))
+	if err != nil {
+		return err
+	}
+	lc, err := newFollower(ctx, c, bytesutil.ToBytes32(root))
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{Addr: f.HTTPHost, Handler: newHandler(lc), ReadHeaderTimeout: time.Second}
+	go func() {
+		log.WithField("address", f.HTTPHost).Info("Serving light client headers")
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.WithError(err).Error("Light client HTTP server failed")
+			cancel()
+		}
+	}()
+	defer func() {
+		if err := srv.Close(); err != nil {
+			log.WithError(err).Error("Could not close light client HTTP server")
+		}
+	}()
+
+	return lc.run(ctx)
+}
@@ -0,0 +1,64 @@
+package lightclient
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+)
+
+const (
+	finalizedHeaderPath  = "/prysm/v1/light_client/finalized_header"
+	optimisticHeaderPath = "/prysm/v1/light_client/optimistic_header"
+)
+
+type headerResponse struct {
+	Version string  `json:"version"`
+	Data    *header `json:"data"`
+}
+
+type header struct {
+	Root                 string                     `json:"root"`
+	Beacon               *structs.BeaconBlockHeader `json:"beacon"`
+	ExecutionBlockHash   string                     `json:"execution_block_hash,omitempty"`
+	ExecutionBlockNumber string                     `json:"execution_block_number,omitempty"`
+}
+
+// newHandler returns the handler of the REST API serving the headers tracked by the follower.
+func newHandler(f *follower) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+finalizedHeaderPath, func(w http.ResponseWriter, _ *http.Request) {
+		finalized, _ := f.headers()
+		writeHeader(w, finalized)
+	})
+	mux.HandleFunc("GET "+optimisticHeaderPath, func(w http.ResponseWriter, _ *http.Request) {
+		_, optimistic := f.headers()
+		writeHeader(w, optimistic)
+	})
+	return mux
+}
+
+func writeHeader(w http.ResponseWriter, h interfaces.LightClientHeader) {
+	root, err := h.Beacon().HashTreeRoot()
+	if err != nil {
+		httputil.HandleError(w, "Could not compute header root: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data := &header{
+		Root:   fmt.Sprintf("%#x", root),
+		Beacon: structs.BeaconBlockHeaderFromConsensus(h.Beacon()),
+	}
+	if h.Version() >= version.Capella {
+		execution, err := h.Execution()
+		if err != nil {
+			httputil.HandleError(w, "Could not get execution payload header: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		data.ExecutionBlockHash = fmt.Sprintf("%#x", execution.BlockHash())
+		data.ExecutionBlockNumber = fmt.Sprintf("%d", execution.BlockNumber())
+	}
+	httputil.WriteJson(w, &headerResponse{Version: version.String(h.Version()), Data: data})
+}
@@ -0,0 +1,129 @@
+package slasher
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	beaconslasher "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
+	beaconsync "github.com/prysmaticlabs/prysm/v5/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+var (
+	_ = beaconslasher.RemoteChain(&remoteChain{})
+	_ = beaconsync.Checker(&remoteChain{})
+)
+
+// remoteChain is the chain of the beacon nodes followed by the slasher. It tracks the highest head slot
+// reported by the beacon nodes, and submits the slashings found by the slasher to all of them.
+type remoteChain struct {
+	clients     []*beacon.Client
+	genesisTime time.Time
+	lock        sync.RWMutex
+	head        primitives.Slot
+}
+
+func newRemoteChain(clients []*beacon.Client, genesisTime time.Time) *remoteChain {
+	return &remoteChain{clients: clients, genesisTime: genesisTime}
+}
+
+// HeadSlot returns the highest head slot reported by the beacon nodes.
+func (c *remoteChain) HeadSlot() primitives.Slot {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.head
+}
+
+// updateHead records a head slot reported by a beacon node.
+func (c *remoteChain) updateHead(slot primitives.Slot) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if slot > c.head {
+		c.head = slot
+	}
+}
+
+// NumValidators returns the number of validators in the head state of the first beacon node able to serve it.
+func (c *remoteChain) NumValidators(ctx context.Context) (int, error) {
+	var err error
+	for _, cl := range c.clients {
+		var n int
+		if n, err = numValidators(ctx, cl); err == nil {
+			return n, nil
+		}
+		log.WithError(err).WithField("beaconNode", cl.NodeURL()).Warn("Could not get number of validators")
+	}
+	return 0, errors.Wrap(err, "no beacon node could serve the number of validators")
+}
+
+func numValidators(ctx context.Context, cl *beacon.Client) (int, error) {
+	// Without any id, the validators endpoint serves the whole registry.
+	vals, err := cl.GetValidators(ctx, beacon.IdHead, nil)
+	if err != nil {
+		return 0, err
+	}
+	return len(vals), nil
+}
+
+// SubmitAttesterSlashing submits the slashing to all the beacon nodes, and fails if none of them accepted it.
+func (c *remoteChain) SubmitAttesterSlashing(ctx context.Context, slashing ethpb.AttSlashing) error {
+	return c.submit(func(cl *beacon.Client) error {
+		return cl.SubmitAttesterSlashing(ctx, slashing)
+	})
+}
+
+// SubmitProposerSlashing submits the slashing to all the beacon nodes, and fails if none of them accepted it.
+func (c *remoteChain) SubmitProposerSlashing(ctx context.Context, slashing *ethpb.ProposerSlashing) error {
+	return c.submit(func(cl *beacon.Client) error {
+		return cl.SubmitProposerSlashing(ctx, slashing)
+	})
+}
+
+func (c *remoteChain) submit(fn func(cl *beacon.Client) error) error {
+	var err error
+	submitted := false
+	for _, cl := range c.clients {
+		if e := fn(cl); e != nil {
+			log.WithError(e).WithField("beaconNode", cl.NodeURL()).Warn("Beacon node did not accept slashing")
+			err = e
+			continue
+		}
+		submitted = true
+	}
+	if !submitted {
+		return err
+	}
+	return nil
+}
+
+// Initialized is part of the sync.Checker interface, the slasher is initialized once it knows the head of the chain.
+func (c *remoteChain) Initialized() bool {
+	return c.HeadSlot() > 0
+}
+
+// Syncing is part of the sync.Checker interface. The beacon nodes are considered to be syncing
+// while their head is more than an epoch behind the current slot.
+func (c *remoteChain) Syncing() bool {
+	return c.HeadSlot()+params.BeaconConfig().SlotsPerEpoch < slots.SinceGenesis(c.genesisTime)
+}
+
+// Synced is part of the sync.Checker interface.
+func (c *remoteChain) Synced() bool {
+	return !c.Syncing()
+}
+
+// Status is part of the sync.Checker interface.
+func (c *remoteChain) Status() error {
+	return nil
+}
+
+// Resync is part of the sync.Checker interface, the beacon nodes followed by the slasher sync on their own.
+func (c *remoteChain) Resync() error {
+	return nil
+}
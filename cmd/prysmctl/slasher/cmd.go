@@ -0,0 +1,13 @@
+package slasher
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "slasher",
+		Usage: "commands for running a slasher outside of the beacon node",
+		Subcommands: []*cli.Command{
+			runCmd,
+		},
+	},
+}
@@ -0,0 +1,351 @@
+package slasher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/api/client/event"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	asyncevent "github.com/prysmaticlabs/prysm/v5/async/event"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	lruwrpr "github.com/prysmaticlabs/prysm/v5/cache/lru"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/network/forks"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/attestation"
+	"github.com/prysmaticlabs/prysm/v5/runtime/version"
+	"github.com/prysmaticlabs/prysm/v5/time/slots"
+)
+
+const (
+	// seenSize is the number of recent attestations and blocks remembered to skip those received from
+	// several beacon nodes.
+	seenSize = 1 << 16
+	// reconnectDelay is the delay before subscribing again to the events of a beacon node after a disconnection.
+	reconnectDelay = 5 * time.Second
+)
+
+// followedTopics are the event topics followed on each beacon node. Blocks are only fed to the slasher once
+// imported by a beacon node, which verified their signature. Attestation events are emitted before the attestations
+// are validated, so their signature is verified before they are fed to the slasher.
+//
+// A beacon node ignores the gossip blocks of a proposer after the first one of the slot, before emitting any event
+// for them, so the API gives no way to see the blocks of an equivocation that a node did not import. A proposer
+// equivocation is only detected when each of its blocks is imported by one of the followed beacon nodes, typically
+// by nodes which received different blocks first. Following several beacon nodes makes this more likely, but
+// doesn't guarantee it.
+var followedTopics = []string{event.EventHead, event.EventBlock, event.EventAttestation}
+
+type committeeKey struct {
+	slot  primitives.Slot
+	index primitives.CommitteeIndex
+}
+
+// attVerifier converts attestations received from the beacon nodes to indexed attestations and verifies their
+// signature, caching the committees and public keys fetched from the beacon nodes. The lock only guards the caches,
+// so that the requests to the beacon nodes and the signature verifications run concurrently.
+type attVerifier struct {
+	genesisValidatorsRoot [32]byte
+	lock                  sync.RWMutex
+	committees            map[primitives.Epoch]map[committeeKey][]primitives.ValidatorIndex
+	pubkeys               map[primitives.ValidatorIndex]bls.PublicKey
+}
+
+func newAttVerifier(genesisValidatorsRoot [32]byte) *attVerifier {
+	return &attVerifier{
+		genesisValidatorsRoot: genesisValidatorsRoot,
+		committees:            make(map[primitives.Epoch]map[committeeKey][]primitives.ValidatorIndex),
+		pubkeys:               make(map[primitives.ValidatorIndex]bls.PublicKey),
+	}
+}
+
+// indexed returns the indexed form of the attestation, after verifying its signature.
+func (v *attVerifier) indexed(ctx context.Context, c *beacon.Client, att ethpb.Att) (ethpb.IndexedAtt, error) {
+	data := att.GetData()
+	committees, err := v.attCommittees(ctx, c, att)
+	if err != nil {
+		return nil, err
+	}
+	indexed, err := attestation.ConvertToIndexed(ctx, att, committees...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not convert to indexed attestation")
+	}
+	if err := attestation.IsValidAttestationIndices(ctx, indexed); err != nil {
+		return nil, err
+	}
+	pubkeys, err := v.attPubkeys(ctx, c, indexed.GetAttestingIndices())
+	if err != nil {
+		return nil, err
+	}
+	fork, err := forks.Fork(data.Target.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	domain, err := signing.Domain(fork, data.Target.Epoch, params.BeaconConfig().DomainBeaconAttester, v.genesisValidatorsRoot[:])
+	if err != nil {
+		return nil, err
+	}
+	if err := attestation.VerifyIndexedAttestationSig(ctx, indexed, pubkeys, domain); err != nil {
+		return nil, err
+	}
+	return indexed, nil
+}
+
+// attCommittees returns the committees of the attestation, in the order of their committee index.
+func (v *attVerifier) attCommittees(ctx context.Context, c *beacon.Client, att ethpb.Att) ([][]primitives.ValidatorIndex, error) {
+	data := att.GetData()
+	epoch := slots.ToEpoch(data.Slot)
+	byKey, err := v.epochCommittees(ctx, c, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	indices := []primitives.CommitteeIndex{data.CommitteeIndex}
+	if att.Version() >= version.Electra {
+		indices = indices[:0]
+		for _, i := range att.CommitteeBitsVal().BitIndices() {
+			indices = append(indices, primitives.CommitteeIndex(i))
+		}
+	}
+	committees := make([][]primitives.ValidatorIndex, len(indices))
+	for i, idx := range indices {
+		cm, ok := byKey[committeeKey{slot: data.Slot, index: idx}]
+		if !ok {
+			return nil, errors.Errorf("no committee %d at slot %d", idx, data.Slot)
+		}
+		committees[i] = cm
+	}
+	return committees, nil
+}
+
+// epochCommittees returns the committees of the epoch, fetching them from the beacon node if not yet known.
+func (v *attVerifier) epochCommittees(ctx context.Context, c *beacon.Client, epoch primitives.Epoch) (map[committeeKey][]primitives.ValidatorIndex, error) {
+	v.lock.RLock()
+	byKey, ok := v.committees[epoch]
+	v.lock.RUnlock()
+	if ok {
+		return byKey, nil
+	}
+
+	committees, err := c.GetCommittees(ctx, beacon.IdHead, epoch)
+	if err != nil {
+		return nil, err
+	}
+	byKey = make(map[committeeKey][]primitives.ValidatorIndex, len(committees))
+	for _, cm := range committees {
+		byKey[committeeKey{slot: cm.Slot, index: cm.Index}] = cm.Validators
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	// Attestations older than a couple of epochs are not propagated, so neither are their committees kept.
+	for e := range v.committees {
+		if e+2 < epoch {
+			delete(v.committees, e)
+		}
+	}
+	v.committees[epoch] = byKey
+	return byKey, nil
+}
+
+// attPubkeys returns the public keys of the given validators, fetching those not yet known from the beacon node.
+func (v *attVerifier) attPubkeys(ctx context.Context, c *beacon.Client, indices []uint64) ([]bls.PublicKey, error) {
+	pubkeys := make([]bls.PublicKey, len(indices))
+	var missing []string
+	v.lock.RLock()
+	for i, idx := range indices {
+		if pk, ok := v.pubkeys[primitives.ValidatorIndex(idx)]; ok {
+			pubkeys[i] = pk
+			continue
+		}
+		missing = append(missing, strconv.FormatUint(idx, 10))
+	}
+	v.lock.RUnlock()
+	if len(missing) == 0 {
+		return pubkeys, nil
+	}
+
+	vals, err := c.GetValidators(ctx, beacon.IdHead, missing)
+	if err != nil {
+		return nil, err
+	}
+	fetched := make(map[primitives.ValidatorIndex]bls.PublicKey, len(vals))
+	for _, val := range vals {
+		if val.Validator == nil {
+			continue
+		}
+		idx, err := strconv.ParseUint(val.Index, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid validator index")
+		}
+		pk, err := hexutil.Decode(val.Validator.Pubkey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid public key of validator %d", idx)
+		}
+		pubkey, err := bls.PublicKeyFromBytes(pk)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid public key of validator %d", idx)
+		}
+		fetched[primitives.ValidatorIndex(idx)] = pubkey
+	}
+
+	v.lock.Lock()
+	for idx, pk := range fetched {
+		v.pubkeys[idx] = pk
+	}
+	v.lock.Unlock()
+
+	for i, idx := range indices {
+		if pubkeys[i] != nil {
+			continue
+		}
+		pk, ok := fetched[primitives.ValidatorIndex(idx)]
+		if !ok {
+			return nil, errors.Errorf("unknown validator %d", idx)
+		}
+		pubkeys[i] = pk
+	}
+	return pubkeys, nil
+}
+
+// follower feeds the slasher with the blocks and attestations of the beacon nodes, received over their event stream.
+type follower struct {
+	chain        *remoteChain
+	verifier     *attVerifier
+	attsFeed     *asyncevent.Feed
+	headersFeed  *asyncevent.Feed
+	seenAtts     *lru.Cache
+	seenHeaders  *lru.Cache
+	streamClient *http.Client
+}
+
+func newFollower(chain *remoteChain, verifier *attVerifier, attsFeed, headersFeed *asyncevent.Feed) *follower {
+	return &follower{
+		chain:        chain,
+		verifier:     verifier,
+		attsFeed:     attsFeed,
+		headersFeed:  headersFeed,
+		seenAtts:     lruwrpr.New(seenSize),
+		seenHeaders:  lruwrpr.New(seenSize),
+		streamClient: &http.Client{},
+	}
+}
+
+// follow subscribes to the events of the beacon node until the context is canceled, subscribing again
+// after each disconnection. The beacon node replays the events missed while disconnected.
+func (f *follower) follow(ctx context.Context, c *beacon.Client) {
+	logger := log.WithField("beaconNode", c.NodeURL())
+	stream, err := event.NewEventStream(ctx, f.streamClient, c.NodeURL(), followedTopics)
+	if err != nil {
+		logger.WithError(err).Error("Could not create event stream")
+		return
+	}
+	events := make(chan *event.Event, 1)
+	go func() {
+		for {
+			stream.Subscribe(events)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(reconnectDelay):
+			}
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := f.handle(ctx, c, ev); err != nil {
+				logger.WithError(err).WithField("eventType", ev.EventType).Debug("Could not handle event")
+			}
+		}
+	}
+}
+
+func (f *follower) handle(ctx context.Context, c *beacon.Client, ev *event.Event) error {
+	switch ev.EventType {
+	case event.EventConnectionError, event.EventError:
+		log.WithField("beaconNode", c.NodeURL()).WithField("error", string(ev.Data)).Warn("Beacon node event stream failed")
+		return nil
+	case event.EventHead:
+		head := &structs.HeadEvent{}
+		if err := json.Unmarshal(ev.Data, head); err != nil {
+			return err
+		}
+		slot, err := strconv.ParseUint(head.Slot, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "invalid head slot")
+		}
+		f.chain.updateHead(primitives.Slot(slot))
+		return nil
+	case event.EventBlock:
+		blk := &structs.BlockEvent{}
+		if err := json.Unmarshal(ev.Data, blk); err != nil {
+			return err
+		}
+		root, err := hexutil.Decode(blk.Block)
+		if err != nil {
+			return errors.Wrap(err, "invalid block root")
+		}
+		if ok, _ := f.seenHeaders.ContainsOrAdd(bytesutil.ToBytes32(root), true); ok {
+			return nil
+		}
+		header, err := c.GetBlockHeader(ctx, beacon.IdFromRoot(bytesutil.ToBytes32(root)))
+		if err != nil {
+			f.seenHeaders.Remove(bytesutil.ToBytes32(root))
+			return err
+		}
+		f.headersFeed.Send(header)
+		return nil
+	case event.EventAttestation:
+		att, err := attFromEvent(ev.Data)
+		if err != nil {
+			return err
+		}
+		key := string(att.GetSignature())
+		if ok, _ := f.seenAtts.ContainsOrAdd(key, true); ok {
+			return nil
+		}
+		indexed, err := f.verifier.indexed(ctx, c, att)
+		if err != nil {
+			f.seenAtts.Remove(key)
+			return err
+		}
+		f.attsFeed.Send(&types.WrappedIndexedAtt{IndexedAtt: indexed})
+		return nil
+	default:
+		return nil
+	}
+}
+
+// attFromEvent decodes the attestation of an attestation event, which has committee bits since Electra.
+func attFromEvent(data []byte) (ethpb.Att, error) {
+	att := &structs.AttestationElectra{}
+	if err := json.Unmarshal(data, att); err != nil {
+		return nil, err
+	}
+	if att.CommitteeBits != "" {
+		return att.ToConsensus()
+	}
+	return (&structs.Attestation{
+		AggregationBits: att.AggregationBits,
+		Data:            att.Data,
+		Signature:       att.Signature,
+	}).ToConsensus()
+}
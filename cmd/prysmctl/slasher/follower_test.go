@@ -0,0 +1,280 @@
+package slasher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v5/api/client/event"
+	"github.com/prysmaticlabs/prysm/v5/api/server/structs"
+	asyncevent "github.com/prysmaticlabs/prysm/v5/async/event"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v5/network/forks"
+	"github.com/prysmaticlabs/prysm/v5/network/httputil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	"github.com/prysmaticlabs/prysm/v5/testing/util"
+)
+
+// testNode serves the committees and validators of a beacon node, with validators 2 and 5 in the only
+// committee of slot 1.
+func testNode(t *testing.T, keys map[string]bls.SecretKey) (*beacon.Client, *http.ServeMux) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/beacon/states/head/committees", func(w http.ResponseWriter, _ *http.Request) {
+		httputil.WriteJson(w, &structs.GetCommitteesResponse{Data: []*structs.Committee{
+			{Index: "0", Slot: "1", Validators: []string{"2", "5"}},
+		}})
+	})
+	mux.HandleFunc("/eth/v1/beacon/states/head/validators", func(w http.ResponseWriter, r *http.Request) {
+		req := &structs.GetValidatorsRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		resp := &structs.GetValidatorsResponse{}
+		for _, id := range req.Ids {
+			resp.Data = append(resp.Data, &structs.ValidatorContainer{
+				Index:     id,
+				Validator: &structs.Validator{Pubkey: hexutil.Encode(keys[id].PublicKey().Marshal())},
+			})
+		}
+		httputil.WriteJson(w, resp)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	c, err := beacon.NewClient(srv.URL)
+	require.NoError(t, err)
+	return c, mux
+}
+
+func signedTestAtt(t *testing.T, keys map[string]bls.SecretKey, gvr [32]byte) *ethpb.Attestation {
+	att := util.HydrateAttestation(&ethpb.Attestation{
+		AggregationBits: bitfield.Bitlist{0b111},
+		Data:            &ethpb.AttestationData{Slot: 1},
+	})
+	fork, err := forks.Fork(0)
+	require.NoError(t, err)
+	domain, err := signing.Domain(fork, 0, params.BeaconConfig().DomainBeaconAttester, gvr[:])
+	require.NoError(t, err)
+	root, err := signing.ComputeSigningRoot(att.Data, domain)
+	require.NoError(t, err)
+	att.Signature = bls.AggregateSignatures([]bls.Signature{keys["2"].Sign(root[:]), keys["5"].Sign(root[:])}).Marshal()
+	return att
+}
+
+func TestAttVerifier_Indexed(t *testing.T) {
+	keys := make(map[string]bls.SecretKey)
+	for _, id := range []string{"2", "5"} {
+		k, err := bls.RandKey()
+		require.NoError(t, err)
+		keys[id] = k
+	}
+	c, _ := testNode(t, keys)
+	gvr := [32]byte{'g'}
+	att := signedTestAtt(t, keys, gvr)
+
+	indexed, err := newAttVerifier(gvr).indexed(context.Background(), c, att)
+	require.NoError(t, err)
+	require.DeepEqual(t, []uint64{2, 5}, indexed.GetAttestingIndices())
+
+	_, err = newAttVerifier([32]byte{'o'}).indexed(context.Background(), c, att)
+	require.ErrorIs(t, err, signing.ErrSigFailedToVerify)
+
+	att.Data.CommitteeIndex = 1
+	_, err = newAttVerifier(gvr).indexed(context.Background(), c, att)
+	require.ErrorContains(t, "no committee 1 at slot 1", err)
+}
+
+func TestAttVerifier_IndexedConcurrently(t *testing.T) {
+	keys := make(map[string]bls.SecretKey)
+	for _, id := range []string{"2", "5"} {
+		k, err := bls.RandKey()
+		require.NoError(t, err)
+		keys[id] = k
+	}
+	c, _ := testNode(t, keys)
+	gvr := [32]byte{'g'}
+	att := signedTestAtt(t, keys, gvr)
+
+	v := newAttVerifier(gvr)
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = v.indexed(context.Background(), c, att)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, len(v.committees))
+	require.Equal(t, 2, len(v.pubkeys))
+}
+
+func TestFollower_Handle(t *testing.T) {
+	keys := make(map[string]bls.SecretKey)
+	for _, id := range []string{"2", "5"} {
+		k, err := bls.RandKey()
+		require.NoError(t, err)
+		keys[id] = k
+	}
+	c, mux := testNode(t, keys)
+	header := util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{})
+	header.Header.Slot = 3
+	root := [32]byte{'r'}
+	headerRequests := 0
+	mux.HandleFunc(fmt.Sprintf("/eth/v1/beacon/headers/%#x", root), func(w http.ResponseWriter, _ *http.Request) {
+		headerRequests++
+		httputil.WriteJson(w, &structs.GetBlockHeaderResponse{Data: &structs.SignedBeaconBlockHeaderContainer{
+			Header: structs.SignedBeaconBlockHeaderFromConsensus(header),
+			Root:   hexutil.Encode(root[:]),
+		}})
+	})
+
+	gvr := [32]byte{'g'}
+	chain := newRemoteChain([]*beacon.Client{c}, time.Now())
+	attsFeed, headersFeed := new(asyncevent.Feed), new(asyncevent.Feed)
+	atts := make(chan *types.WrappedIndexedAtt, 2)
+	headers := make(chan *ethpb.SignedBeaconBlockHeader, 2)
+	attsSub := attsFeed.Subscribe(atts)
+	defer attsSub.Unsubscribe()
+	headersSub := headersFeed.Subscribe(headers)
+	defer headersSub.Unsubscribe()
+	f := newFollower(chain, newAttVerifier(gvr), attsFeed, headersFeed)
+	ctx := context.Background()
+
+	headData, err := json.Marshal(&structs.HeadEvent{Slot: "7"})
+	require.NoError(t, err)
+	require.NoError(t, f.handle(ctx, c, &event.Event{EventType: event.EventHead, Data: headData}))
+	require.Equal(t, uint64(7), uint64(chain.HeadSlot()))
+
+	blockData, err := json.Marshal(&structs.BlockEvent{Slot: "3", Block: hexutil.Encode(root[:])})
+	require.NoError(t, err)
+	// The same block received from several beacon nodes is fed to the slasher once.
+	for i := 0; i < 2; i++ {
+		require.NoError(t, f.handle(ctx, c, &event.Event{EventType: event.EventBlock, Data: blockData}))
+	}
+	require.Equal(t, 1, headerRequests)
+	require.Equal(t, 1, len(headers))
+	require.DeepEqual(t, header, <-headers)
+
+	attData, err := json.Marshal(structs.AttFromConsensus(signedTestAtt(t, keys, gvr)))
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, f.handle(ctx, c, &event.Event{EventType: event.EventAttestation, Data: attData}))
+	}
+	require.Equal(t, 1, len(atts))
+	require.DeepEqual(t, []uint64{2, 5}, (<-atts).GetAttestingIndices())
+
+	invalid := signedTestAtt(t, keys, [32]byte{'o'})
+	attData, err = json.Marshal(structs.AttFromConsensus(invalid))
+	require.NoError(t, err)
+	require.ErrorIs(t, f.handle(ctx, c, &event.Event{EventType: event.EventAttestation, Data: attData}), signing.ErrSigFailedToVerify)
+	require.Equal(t, 0, len(atts))
+}
+
+func TestFollower_HandleEquivocation(t *testing.T) {
+	// Each beacon node imported one of the two blocks of the proposer for slot 3.
+	newNode := func(root [32]byte, bodyRoot byte) (*beacon.Client, *ethpb.SignedBeaconBlockHeader) {
+		c, mux := testNode(t, nil)
+		header := util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{})
+		header.Header.Slot = 3
+		header.Header.ProposerIndex = 5
+		header.Header.BodyRoot = bytesutil.PadTo([]byte{bodyRoot}, 32)
+		mux.HandleFunc(fmt.Sprintf("/eth/v1/beacon/headers/%#x", root), func(w http.ResponseWriter, _ *http.Request) {
+			httputil.WriteJson(w, &structs.GetBlockHeaderResponse{Data: &structs.SignedBeaconBlockHeaderContainer{
+				Header: structs.SignedBeaconBlockHeaderFromConsensus(header),
+				Root:   hexutil.Encode(root[:]),
+			}})
+		})
+		return c, header
+	}
+	rootA, rootB := [32]byte{'a'}, [32]byte{'b'}
+	a, headerA := newNode(rootA, 'a')
+	b, headerB := newNode(rootB, 'b')
+
+	attsFeed, headersFeed := new(asyncevent.Feed), new(asyncevent.Feed)
+	headers := make(chan *ethpb.SignedBeaconBlockHeader, 2)
+	headersSub := headersFeed.Subscribe(headers)
+	defer headersSub.Unsubscribe()
+	chain := newRemoteChain([]*beacon.Client{a, b}, time.Now())
+	f := newFollower(chain, newAttVerifier([32]byte{}), attsFeed, headersFeed)
+	ctx := context.Background()
+
+	for _, n := range []struct {
+		c    *beacon.Client
+		root [32]byte
+	}{{a, rootA}, {b, rootB}} {
+		data, err := json.Marshal(&structs.BlockEvent{Slot: "3", Block: hexutil.Encode(n.root[:])})
+		require.NoError(t, err)
+		require.NoError(t, f.handle(ctx, n.c, &event.Event{EventType: event.EventBlock, Data: data}))
+	}
+	// Both blocks of the equivocation reach the slasher.
+	require.Equal(t, 2, len(headers))
+	require.DeepEqual(t, headerA, <-headers)
+	require.DeepEqual(t, headerB, <-headers)
+}
+
+func TestRemoteChain(t *testing.T) {
+	var submitted []string
+	newNode := func(name string, fail bool) *beacon.Client {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/eth/v1/beacon/states/head/validators", func(w http.ResponseWriter, r *http.Request) {
+			req := &structs.GetValidatorsRequest{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(req))
+			require.Equal(t, 0, len(req.Ids))
+			resp := &structs.GetValidatorsResponse{}
+			for i := 0; i < 13; i++ {
+				resp.Data = append(resp.Data, &structs.ValidatorContainer{Index: fmt.Sprint(i)})
+			}
+			httputil.WriteJson(w, resp)
+		})
+		mux.HandleFunc("/eth/v1/beacon/pool/proposer_slashings", func(w http.ResponseWriter, _ *http.Request) {
+			if fail {
+				httputil.HandleError(w, "invalid slashing", http.StatusBadRequest)
+				return
+			}
+			submitted = append(submitted, name)
+		})
+		srv := httptest.NewServer(mux)
+		t.Cleanup(srv.Close)
+		c, err := beacon.NewClient(srv.URL)
+		require.NoError(t, err)
+		return c
+	}
+	ctx := context.Background()
+	slashing := &ethpb.ProposerSlashing{
+		Header_1: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+		Header_2: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{}),
+	}
+
+	chain := newRemoteChain([]*beacon.Client{newNode("a", false), newNode("b", true), newNode("c", false)}, time.Now())
+	n, err := chain.NumValidators(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 13, n)
+	require.NoError(t, chain.SubmitProposerSlashing(ctx, slashing))
+	require.DeepEqual(t, []string{"a", "c"}, submitted)
+
+	chain = newRemoteChain([]*beacon.Client{newNode("b", true)}, time.Now())
+	require.ErrorContains(t, "invalid slashing", chain.SubmitProposerSlashing(ctx, slashing))
+
+	chain.updateHead(params.BeaconConfig().SlotsPerEpoch)
+	// The wall clock is three epochs after genesis.
+	cfg := params.BeaconConfig()
+	chain.genesisTime = time.Now().Add(-time.Duration(uint64(3*cfg.SlotsPerEpoch)*cfg.SecondsPerSlot) * time.Second)
+	require.Equal(t, true, chain.Syncing())
+	chain.updateHead(3 * params.BeaconConfig().SlotsPerEpoch)
+	require.Equal(t, false, chain.Syncing())
+}
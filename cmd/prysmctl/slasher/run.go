@@ -0,0 +1,168 @@
+package slasher
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/api/client"
+	"github.com/prysmaticlabs/prysm/v5/api/client/beacon"
+	asyncevent "github.com/prysmaticlabs/prysm/v5/async/event"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/db/slasherkv"
+	beaconslasher "github.com/prysmaticlabs/prysm/v5/beacon-chain/slasher"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v5/cmd"
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	"github.com/urfave/cli/v2"
+)
+
+var runFlags = struct {
+	BeaconNodeHosts cli.StringSlice
+	Timeout         time.Duration
+	DataDir         string
+	ChainConfigFile string
+}{}
+
+var runCmd = &cli.Command{
+	Name:  "run",
+	Usage: "Detect slashable offenses in the blocks and attestations of one or more beacon nodes, followed over their event stream, and submit the slashings found to their operations pool.",
+	Action: func(cliCtx *cli.Context) error {
+		if err := cliActionRun(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not run slasher")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:        "beacon-node-host",
+			Usage:       "host:port for beacon node connection, can be given several times to follow several beacon nodes",
+			Destination: &runFlags.BeaconNodeHosts,
+			Value:       cli.NewStringSlice("localhost:3500"),
+		},
+		&cli.DurationFlag{
+			Name:        "http-timeout",
+			Usage:       "timeout for http requests made to the beacon nodes, other than the event streams (uses duration format, ex: 2m31s). default: 1m",
+			Destination: &runFlags.Timeout,
+			Value:       time.Minute,
+		},
+		&cli.StringFlag{
+			Name:        "datadir",
+			Usage:       "Directory of the slasher database",
+			Destination: &runFlags.DataDir,
+			Value:       filepath.Join(cmd.DefaultDataDir(), "slasher"),
+		},
+		&cli.StringFlag{
+			Name:        "chain-config-file",
+			Usage:       "The path to a YAML file with chain config values, defaults to the mainnet config",
+			Destination: &runFlags.ChainConfigFile,
+		},
+	},
+}
+
+func cliActionRun(_ *cli.Context) error {
+	f := runFlags
+	if f.ChainConfigFile != "" {
+		if err := params.LoadChainConfigFile(f.ChainConfigFile, nil); err != nil {
+			return errors.Wrap(err, "could not load chain config file")
+		}
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	hosts := f.BeaconNodeHosts.Value()
+	if len(hosts) == 0 {
+		return errors.New("no beacon node host provided")
+	}
+	clients := make([]*beacon.Client, len(hosts))
+	for i, host := range hosts {
+		c, err := beacon.NewClient(host, client.WithTimeout(f.Timeout))
+		if err != nil {
+			return err
+		}
+		clients[i] = c
+	}
+	genesisTime, genesisValidatorsRoot, err := fetchGenesis(ctx, clients[0])
+	if err != nil {
+		return err
+	}
+	chain := newRemoteChain(clients, genesisTime)
+	head, err := clients[0].GetBlockHeader(ctx, beacon.IdHead)
+	if err != nil {
+		return err
+	}
+	chain.updateHead(head.Header.Slot)
+
+	db, err := slasherkv.NewKVStore(ctx, f.DataDir)
+	if err != nil {
+		return errors.Wrap(err, "could not open slasher database")
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Could not close slasher database")
+		}
+	}()
+
+	clock := startup.NewClockSynchronizer()
+	if err := clock.SetClock(startup.NewClock(genesisTime, genesisValidatorsRoot)); err != nil {
+		return err
+	}
+	attsFeed, headersFeed := new(asyncevent.Feed), new(asyncevent.Feed)
+	srv, err := beaconslasher.New(ctx, &beaconslasher.ServiceConfig{
+		IndexedAttestationsFeed: attsFeed,
+		BeaconBlockHeadersFeed:  headersFeed,
+		Database:                db,
+		SyncChecker:             chain,
+		ClockWaiter:             clock,
+		RemoteChain:             chain,
+	})
+	if err != nil {
+		return err
+	}
+	srv.Start()
+
+	fl := newFollower(chain, newAttVerifier(genesisValidatorsRoot), attsFeed, headersFeed)
+	var wg sync.WaitGroup
+	for _, c := range clients {
+		wg.Add(1)
+		go func(c *beacon.Client) {
+			defer wg.Done()
+			fl.follow(ctx, c)
+		}(c)
+	}
+	log.WithField("beaconNodes", hosts).Info("Following beacon nodes")
+	wg.Wait()
+	return srv.Stop()
+}
+
+// fetchGenesis returns the genesis time and genesis validators root of the chain of the beacon node.
+func fetchGenesis(ctx context.Context, c *beacon.Client) (time.Time, [32]byte, error) {
+	genesis, err := c.GetGenesis(ctx)
+	if err != nil {
+		return time.Time{}, [32]byte{}, err
+	}
+	genesisTime, err := strconv.ParseInt(genesis.GenesisTime, 10, 64)
+	if err != nil {
+		return time.Time{}, [32]byte{}, errors.Wrap(err, "could not parse genesis time")
+	}
+	root, err := hexutil.Decode(genesis.GenesisValidatorsRoot)
+	if err != nil {
+		return time.Time{}, [32]byte{}, errors.Wrap(err, "could not parse genesis validators root")
+	}
+	if len(root) != fieldparams.RootLength {
+		return time.Time{}, [32]byte{}, errors.Errorf("genesis validators root has length %d, expected %d", len(root), fieldparams.RootLength)
+	}
+	if want := hexutil.Encode(params.BeaconConfig().GenesisForkVersion); genesis.GenesisForkVersion != want {
+		return time.Time{}, [32]byte{}, errors.Errorf("beacon node genesis fork version %s does not match the chain config genesis fork version %s", genesis.GenesisForkVersion, want)
+	}
+	return time.Unix(genesisTime, 0), bytesutil.ToBytes32(root), nil
+}
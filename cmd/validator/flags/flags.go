@@ -309,6 +309,13 @@ var (
 		Aliases: []string{"remote-signer-keys-file"},
 	}
 
+	// Web3SignerBatchSigningFlag enables batching of sign requests sent to web3signer.
+	// example:--validators-external-signer-batch-signing
+	Web3SignerBatchSigningFlag = &cli.BoolFlag{
+		Name:  "validators-external-signer-batch-signing",
+		Usage: "Coalesces concurrent sign requests into batches signed by web3signer in a single call. Falls back to signing one key at a time if web3signer does not support batched signing.",
+	}
+
 	// KeymanagerKindFlag defines the kind of keymanager desired by a user during wallet creation.
 	KeymanagerKindFlag = &cli.StringFlag{
 		Name:  "keymanager-kind",
@@ -389,6 +396,12 @@ var (
 		Usage: "To enable the use of prysm validator client in Distributed Validator Cluster",
 		Value: false,
 	}
+	// BroadcastToAllBeaconNodesFlag publishes signed objects to all beacon nodes set with --beacon-rpc-provider or --beacon-rest-api-provider.
+	BroadcastToAllBeaconNodesFlag = &cli.BoolFlag{
+		Name: "broadcast-to-all-beacon-nodes",
+		Usage: "Publishes signed attestations, aggregates, sync committee messages and blocks to all configured beacon nodes in parallel, " +
+			"instead of only the current one. Duties are still fetched from the current beacon node.",
+	}
 )
 
 // DefaultValidatorDir returns OS-specific default validator directory.
@@ -127,6 +127,7 @@ var appHelpFlagGroups = []flagGroup{
 			flags.Web3SignerURLFlag,
 			flags.Web3SignerPublicValidatorKeysFlag,
 			flags.Web3SignerKeyFileFlag,
+			flags.Web3SignerBatchSigningFlag,
 		},
 	},
 	{
@@ -143,6 +144,7 @@ var appHelpFlagGroups = []flagGroup{
 			flags.DisablePenaltyRewardLogFlag,
 			flags.DisableAccountMetricsFlag,
 			flags.EnableDistributed,
+			flags.BroadcastToAllBeaconNodesFlag,
 			flags.AuthTokenPathFlag,
 		},
 	},
@@ -302,6 +302,7 @@ var mainnetBeaconConfig = &BeaconChainConfig{
 
 	// PeerDAS
 	NumberOfColumns:                       128,
+	NumberOfCustodyGroups:                 128,
 	MaxCellsInExtendedMatrix:              768,
 	SamplesPerSlot:                        8,
 	CustodyRequirement:                    4,
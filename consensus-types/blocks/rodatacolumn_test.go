@@ -0,0 +1,87 @@
+package blocks
+
+import (
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestRODataColumnNilChecks(t *testing.T) {
+	cases := []struct {
+		name string
+		dc   *ethpb.DataColumnSidecar
+		err  error
+	}{
+		{
+			name: "nil sidecar",
+			err:  errNilDataColumn,
+		},
+		{
+			name: "nil signed block header",
+			dc:   &ethpb.DataColumnSidecar{},
+			err:  errNilBlockHeader,
+		},
+		{
+			name: "nil inner header",
+			dc:   &ethpb.DataColumnSidecar{SignedBlockHeader: &ethpb.SignedBeaconBlockHeader{}},
+			err:  errNilBlockHeader,
+		},
+		{
+			name: "nil signature",
+			dc: &ethpb.DataColumnSidecar{
+				SignedBlockHeader: &ethpb.SignedBeaconBlockHeader{
+					Header: &ethpb.BeaconBlockHeader{
+						ParentRoot: make([]byte, fieldparams.RootLength),
+						StateRoot:  make([]byte, fieldparams.RootLength),
+						BodyRoot:   make([]byte, fieldparams.RootLength),
+					},
+				},
+			},
+			err: errMissingBlockSignature,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := NewRODataColumn(c.dc)
+			require.ErrorIs(t, err, c.err)
+			_, err = NewRODataColumnWithRoot(c.dc, [32]byte{1})
+			require.ErrorIs(t, err, c.err)
+		})
+	}
+}
+
+func TestRODataColumnAccessors(t *testing.T) {
+	parent := bytesutil.PadTo([]byte("parent"), fieldparams.RootLength)
+	body := bytesutil.PadTo([]byte("body"), fieldparams.RootLength)
+	header := &ethpb.BeaconBlockHeader{
+		Slot:          primitives.Slot(7),
+		ProposerIndex: primitives.ValidatorIndex(3),
+		ParentRoot:    parent,
+		StateRoot:     make([]byte, fieldparams.RootLength),
+		BodyRoot:      body,
+	}
+	dc, err := NewRODataColumn(&ethpb.DataColumnSidecar{
+		SignedBlockHeader: &ethpb.SignedBeaconBlockHeader{
+			Header:    header,
+			Signature: make([]byte, fieldparams.BLSSignatureLength),
+		},
+	})
+	require.NoError(t, err)
+	root, err := header.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, root, dc.BlockRoot())
+	assert.DeepEqual(t, root[:], dc.BlockRootSlice())
+	assert.Equal(t, primitives.Slot(7), dc.Slot())
+	assert.Equal(t, primitives.ValidatorIndex(3), dc.ProposerIndex())
+	assert.Equal(t, bytesutil.ToBytes32(parent), dc.ParentRoot())
+	assert.DeepEqual(t, parent, dc.ParentRootSlice())
+	assert.Equal(t, bytesutil.ToBytes32(body), dc.BodyRoot())
+
+	verified := NewVerifiedRODataColumn(dc)
+	assert.Equal(t, 1, len(RODataColumnSlice{verified.RODataColumn}.Protos()))
+}
@@ -0,0 +1,161 @@
+package era
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+)
+
+// Entry types of the e2store records used by era files.
+var (
+	typeVersion                     = [2]byte{0x65, 0x32}
+	typeCompressedSignedBeaconBlock = [2]byte{0x01, 0x00}
+	typeCompressedBeaconState       = [2]byte{0x02, 0x00}
+	typeSlotIndex                   = [2]byte{0x69, 0x32}
+)
+
+const (
+	// headerSize is the size of an e2store record header: a 2 byte type, a 4 byte length and 2 reserved bytes.
+	headerSize = 8
+	// maxRecordSize bounds the size of a single record, well above the size of any state or block.
+	maxRecordSize = 1 << 30
+)
+
+var (
+	errUnexpectedType = errors.New("unexpected e2store record type")
+	errInvalidRecord  = errors.New("invalid e2store record")
+)
+
+type header struct {
+	typ    [2]byte
+	length uint32
+}
+
+func (h header) marshal() []byte {
+	b := make([]byte, headerSize)
+	copy(b, h.typ[:])
+	binary.LittleEndian.PutUint32(b[2:], h.length)
+	return b
+}
+
+func readHeader(r io.ReaderAt, offset int64) (header, error) {
+	b := make([]byte, headerSize)
+	if _, err := r.ReadAt(b, offset); err != nil {
+		return header{}, errors.Wrapf(err, "could not read e2store header at offset %d", offset)
+	}
+	if b[6] != 0 || b[7] != 0 {
+		return header{}, errors.Wrapf(errInvalidRecord, "non-zero reserved bytes at offset %d", offset)
+	}
+	h := header{length: binary.LittleEndian.Uint32(b[2:6])}
+	copy(h.typ[:], b[:2])
+	return h, nil
+}
+
+// readRecord reads the data of the record of the given type at offset.
+func readRecord(r io.ReaderAt, offset int64, typ [2]byte) ([]byte, error) {
+	h, err := readHeader(r, offset)
+	if err != nil {
+		return nil, err
+	}
+	if h.typ != typ {
+		return nil, errors.Wrapf(errUnexpectedType, "expected %#x, got %#x at offset %d", typ, h.typ, offset)
+	}
+	if h.length > maxRecordSize {
+		return nil, errors.Wrapf(errInvalidRecord, "record of %d bytes at offset %d is too large", h.length, offset)
+	}
+	data := make([]byte, h.length)
+	if _, err := r.ReadAt(data, offset+headerSize); err != nil {
+		return nil, errors.Wrapf(err, "could not read e2store record at offset %d", offset)
+	}
+	return data, nil
+}
+
+// writeRecord writes a record with the given type and data, returning the number of bytes written.
+func writeRecord(w io.Writer, typ [2]byte, data []byte) (int64, error) {
+	if len(data) > maxRecordSize {
+		return 0, errors.Wrapf(errInvalidRecord, "record of %d bytes is too large", len(data))
+	}
+	if _, err := w.Write(header{typ: typ, length: uint32(len(data))}.marshal()); err != nil {
+		return 0, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return 0, err
+	}
+	return int64(headerSize + len(data)), nil
+}
+
+func compress(data []byte) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	w := snappy.NewBufferedWriter(buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(data []byte) ([]byte, error) {
+	return io.ReadAll(snappy.NewReader(bytes.NewReader(data)))
+}
+
+// slotIndex maps slots to the offsets of their records, relative to the beginning of the index record.
+// An offset of zero means there is no record for the slot.
+type slotIndex struct {
+	start   uint64
+	offsets []int64
+}
+
+func (s *slotIndex) size() int64 {
+	return headerSize + 8 + 8*int64(len(s.offsets)) + 8
+}
+
+func (s *slotIndex) marshal() []byte {
+	b := make([]byte, 8+8*len(s.offsets)+8)
+	binary.LittleEndian.PutUint64(b, s.start)
+	for i, o := range s.offsets {
+		binary.LittleEndian.PutUint64(b[8+8*i:], uint64(o))
+	}
+	binary.LittleEndian.PutUint64(b[8+8*len(s.offsets):], uint64(len(s.offsets)))
+	return b
+}
+
+// readSlotIndex reads the slot index record ending at end.
+func readSlotIndex(r io.ReaderAt, end int64) (*slotIndex, int64, error) {
+	if end < headerSize+16 {
+		return nil, 0, errors.Wrap(errInvalidRecord, "too small for a slot index")
+	}
+	b := make([]byte, 8)
+	if _, err := r.ReadAt(b, end-8); err != nil {
+		return nil, 0, errors.Wrap(err, "could not read slot index count")
+	}
+	count := binary.LittleEndian.Uint64(b)
+	if count > uint64(end-headerSize-16)/8 {
+		return nil, 0, errors.Wrapf(errInvalidRecord, "slot index count %d does not fit", count)
+	}
+	idx := &slotIndex{offsets: make([]int64, count)}
+	offset := end - idx.size()
+	data, err := readRecord(r, offset, typeSlotIndex)
+	if err != nil {
+		return nil, 0, err
+	}
+	if int64(len(data)) != idx.size()-headerSize {
+		return nil, 0, errors.Wrapf(errInvalidRecord, "slot index of %d bytes, expected %d", len(data), idx.size()-headerSize)
+	}
+	idx.start = binary.LittleEndian.Uint64(data)
+	for i := range idx.offsets {
+		idx.offsets[i] = int64(binary.LittleEndian.Uint64(data[8+8*i:]))
+	}
+	return idx, offset, nil
+}
+
+// FileName returns the conventional name of an era file: the config name, the era number and the first 4 bytes
+// of the historical root of the era.
+func FileName(configName string, era uint64, historicalRoot [32]byte) string {
+	return fmt.Sprintf("%s-%05d-%x.era", configName, era, historicalRoot[:4])
+}
@@ -0,0 +1,96 @@
+package era
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+func TestWriteRead(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MinimalSpecConfig())
+	perEra := params.BeaconConfig().SlotsPerHistoricalRoot
+
+	blocks := map[primitives.Slot][]byte{
+		2 * perEra:   []byte("first block"),
+		2*perEra + 5: []byte("block with skipped slots before it"),
+		3*perEra - 1: bytes.Repeat([]byte("last block"), 1000),
+	}
+	state := bytes.Repeat([]byte("state"), 10000)
+
+	buf := bytes.NewBuffer(nil)
+	w, err := NewWriter(buf, 3)
+	require.NoError(t, err)
+	require.ErrorContains(t, "outside of era", w.AddBlock(perEra, []byte("previous era")))
+	for _, slot := range []primitives.Slot{2 * perEra, 2*perEra + 5, 3*perEra - 1} {
+		require.NoError(t, w.AddBlock(slot, blocks[slot]))
+	}
+	require.NoError(t, w.AddState(state))
+	require.NoError(t, w.Close())
+
+	path := filepath.Join(t.TempDir(), FileName("minimal", 3, [32]byte{0xab, 0xcd}))
+	require.Equal(t, "minimal-00003-abcd0000.era", filepath.Base(path))
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+	f, err := Open(path)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, f.Close())
+	}()
+
+	require.Equal(t, 3*perEra, f.StateSlot())
+	st, err := f.State()
+	require.NoError(t, err)
+	require.DeepEqual(t, state, st)
+	require.DeepEqual(t, []primitives.Slot{2 * perEra, 2*perEra + 5, 3*perEra - 1}, f.BlockSlots())
+	for slot, b := range blocks {
+		got, err := f.Block(slot)
+		require.NoError(t, err)
+		require.DeepEqual(t, b, got)
+	}
+	_, err = f.Block(2*perEra + 1)
+	require.ErrorIs(t, err, errNotFound)
+}
+
+func TestWriteRead_Genesis(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	w, err := NewWriter(buf, 0)
+	require.NoError(t, err)
+	require.NoError(t, w.AddState([]byte("genesis")))
+	require.NoError(t, w.Close())
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(0), r.StateSlot())
+	require.Equal(t, 0, len(r.BlockSlots()))
+	st, err := r.State()
+	require.NoError(t, err)
+	require.DeepEqual(t, []byte("genesis"), st)
+}
+
+func TestNewReader_Invalid(t *testing.T) {
+	_, err := NewReader(bytes.NewReader([]byte("not an era file")), 15)
+	require.ErrorContains(t, "not an era file", err)
+}
+
+func TestList(t *testing.T) {
+	dir := t.TempDir()
+	_, err := List(dir)
+	require.ErrorContains(t, "no era files found", err)
+
+	names := []string{"mainnet-00001-00000000.era", "mainnet-00002-00000000.era"}
+	for _, n := range append(names, "notes.txt") {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, n), nil, 0600))
+	}
+	paths, err := List(dir)
+	require.NoError(t, err)
+	require.DeepEqual(t, []string{filepath.Join(dir, names[0]), filepath.Join(dir, names[1])}, paths)
+
+	paths, err = List(filepath.Join(dir, names[1]))
+	require.NoError(t, err)
+	require.DeepEqual(t, []string{filepath.Join(dir, names[1])}, paths)
+}
@@ -0,0 +1,149 @@
+package era
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+var errNotFound = errors.New("not found in era file")
+
+// Reader reads the blocks and state of an era file.
+type Reader struct {
+	r          io.ReaderAt
+	stateSlot  primitives.Slot
+	state      int64
+	blockStart primitives.Slot
+	blocks     []int64
+}
+
+// NewReader reads the slot indices of the era file in r, of the given size.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	if _, err := readRecord(r, 0, typeVersion); err != nil {
+		return nil, errors.Wrap(err, "not an era file")
+	}
+	stateIdx, offset, err := readSlotIndex(r, size)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read state index")
+	}
+	if len(stateIdx.offsets) != 1 {
+		return nil, errors.Wrapf(errInvalidRecord, "state index with %d entries", len(stateIdx.offsets))
+	}
+	er := &Reader{
+		r:         r,
+		stateSlot: primitives.Slot(stateIdx.start),
+		state:     offset + stateIdx.offsets[0],
+	}
+	// Only the genesis era does not contain blocks.
+	if er.stateSlot == 0 {
+		return er, nil
+	}
+	blockIdx, offset, err := readSlotIndex(r, offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read block index")
+	}
+	if uint64(len(blockIdx.offsets)) != uint64(params.BeaconConfig().SlotsPerHistoricalRoot) {
+		return nil, errors.Wrapf(errInvalidRecord, "block index with %d entries", len(blockIdx.offsets))
+	}
+	er.blockStart = primitives.Slot(blockIdx.start)
+	er.blocks = make([]int64, len(blockIdx.offsets))
+	for i, o := range blockIdx.offsets {
+		if o != 0 {
+			er.blocks[i] = offset + o
+		}
+	}
+	return er, nil
+}
+
+// StateSlot returns the slot of the state of the era file.
+func (r *Reader) StateSlot() primitives.Slot {
+	return r.stateSlot
+}
+
+// State returns the ssz encoded state of the era file.
+func (r *Reader) State() ([]byte, error) {
+	data, err := readRecord(r.r, r.state, typeCompressedBeaconState)
+	if err != nil {
+		return nil, err
+	}
+	return decompress(data)
+}
+
+// BlockSlots returns the slots of the blocks in the era file, in increasing order.
+func (r *Reader) BlockSlots() []primitives.Slot {
+	slots := make([]primitives.Slot, 0)
+	for i, o := range r.blocks {
+		if o != 0 {
+			slots = append(slots, r.blockStart+primitives.Slot(i))
+		}
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+	return slots
+}
+
+// Block returns the ssz encoded signed block at the given slot.
+func (r *Reader) Block(slot primitives.Slot) ([]byte, error) {
+	if slot < r.blockStart || uint64(slot-r.blockStart) >= uint64(len(r.blocks)) || r.blocks[slot-r.blockStart] == 0 {
+		return nil, errors.Wrapf(errNotFound, "no block at slot %d", slot)
+	}
+	data, err := readRecord(r.r, r.blocks[slot-r.blockStart], typeCompressedSignedBeaconBlock)
+	if err != nil {
+		return nil, err
+	}
+	return decompress(data)
+}
+
+// File is an era file opened from disk.
+type File struct {
+	*Reader
+	f *os.File
+}
+
+// Open opens the era file at path.
+func Open(path string) (*File, error) {
+	f, err := os.Open(path) // #nosec G304 -- path is provided by the node operator.
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err == nil {
+		var r *Reader
+		if r, err = NewReader(f, info.Size()); err == nil {
+			return &File{Reader: r, f: f}, nil
+		}
+	}
+	if cerr := f.Close(); cerr != nil {
+		log.WithError(cerr).WithField("path", path).Error("Could not close era file")
+	}
+	return nil, errors.Wrapf(err, "could not read era file %s", path)
+}
+
+// Close closes the era file.
+func (f *File) Close() error {
+	return f.f.Close()
+}
+
+// List returns the path of the era file at path, or of the era files in it if it is a directory.
+func List(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	paths, err := filepath.Glob(filepath.Join(path, "*.era"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not list era files in %s", path)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no era files found in %s", path)
+	}
+	return paths, nil
+}
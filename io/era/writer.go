@@ -0,0 +1,120 @@
+package era
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+)
+
+// Writer writes an era file. The blocks of the era must be added in slot order with AddBlock,
+// followed by the state at the end of the era with AddState, before calling Close.
+type Writer struct {
+	w          io.Writer
+	era        uint64
+	written    int64
+	blocks     []int64
+	lastBlock  primitives.Slot
+	hasBlock   bool
+	state      int64
+	stateAdded bool
+}
+
+// NewWriter starts writing the era file of the given era number to w.
+func NewWriter(w io.Writer, era uint64) (*Writer, error) {
+	ew := &Writer{w: w, era: era}
+	if era > 0 {
+		ew.blocks = make([]int64, params.BeaconConfig().SlotsPerHistoricalRoot)
+	}
+	n, err := writeRecord(w, typeVersion, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not write era version")
+	}
+	ew.written += n
+	return ew, nil
+}
+
+// StartSlot returns the slot of the first block of the era.
+func (w *Writer) StartSlot() primitives.Slot {
+	if w.era == 0 {
+		return 0
+	}
+	return primitives.Slot(w.era-1) * params.BeaconConfig().SlotsPerHistoricalRoot
+}
+
+// StateSlot returns the slot of the state at the end of the era.
+func (w *Writer) StateSlot() primitives.Slot {
+	return primitives.Slot(w.era) * params.BeaconConfig().SlotsPerHistoricalRoot
+}
+
+// AddBlock adds the ssz encoded signed block at the given slot.
+func (w *Writer) AddBlock(slot primitives.Slot, block []byte) error {
+	if w.stateAdded {
+		return errors.New("blocks must be added before the state")
+	}
+	if slot < w.StartSlot() || slot >= w.StateSlot() {
+		return fmt.Errorf("block at slot %d is outside of era %d", slot, w.era)
+	}
+	if w.hasBlock && slot <= w.lastBlock {
+		return fmt.Errorf("block at slot %d added after block at slot %d", slot, w.lastBlock)
+	}
+	data, err := compress(block)
+	if err != nil {
+		return errors.Wrapf(err, "could not compress block at slot %d", slot)
+	}
+	offset := w.written
+	n, err := writeRecord(w.w, typeCompressedSignedBeaconBlock, data)
+	if err != nil {
+		return errors.Wrapf(err, "could not write block at slot %d", slot)
+	}
+	w.written += n
+	w.blocks[slot-w.StartSlot()] = offset
+	w.lastBlock, w.hasBlock = slot, true
+	return nil
+}
+
+// AddState adds the ssz encoded state at the end of the era.
+func (w *Writer) AddState(state []byte) error {
+	if w.stateAdded {
+		return errors.New("state already added")
+	}
+	data, err := compress(state)
+	if err != nil {
+		return errors.Wrap(err, "could not compress state")
+	}
+	w.state = w.written
+	n, err := writeRecord(w.w, typeCompressedBeaconState, data)
+	if err != nil {
+		return errors.Wrap(err, "could not write state")
+	}
+	w.written += n
+	w.stateAdded = true
+	return nil
+}
+
+// Close writes the slot indices of the era file. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if !w.stateAdded {
+		return errors.New("era file must contain a state")
+	}
+	if w.era > 0 {
+		idx := &slotIndex{start: uint64(w.StartSlot()), offsets: make([]int64, len(w.blocks))}
+		for i, o := range w.blocks {
+			if o != 0 {
+				idx.offsets[i] = o - w.written
+			}
+		}
+		n, err := writeRecord(w.w, typeSlotIndex, idx.marshal())
+		if err != nil {
+			return errors.Wrap(err, "could not write block index")
+		}
+		w.written += n
+	}
+	idx := &slotIndex{start: uint64(w.StateSlot()), offsets: []int64{w.state - w.written}}
+	if _, err := writeRecord(w.w, typeSlotIndex, idx.marshal()); err != nil {
+		return errors.Wrap(err, "could not write state index")
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package dbval
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+const blobBackfillStatusSize = 16
+
+var errInvalidBlobBackfillStatus = errors.New("invalid encoded BlobBackfillStatus")
+
+// BlobBackfillStatus is a value used to keep track of the progress of the process of backfilling the BlobSidecars of
+// blocks that are already in the database, independently of the blocks themselves. There is only one
+// BlobBackfillStatus value in the database.
+type BlobBackfillStatus struct {
+	// LowSlot is the lowest slot of the range of blocks whose BlobSidecars are known to be on disk.
+	LowSlot uint64
+	// HighSlot is the end of the range of blocks whose BlobSidecars are known to be on disk, exclusive. The range is
+	// empty when HighSlot equals LowSlot.
+	HighSlot uint64
+}
+
+// Marshal encodes the BlobBackfillStatus as the little-endian LowSlot and HighSlot values.
+func (s *BlobBackfillStatus) Marshal() []byte {
+	b := make([]byte, blobBackfillStatusSize)
+	binary.LittleEndian.PutUint64(b[:8], s.LowSlot)
+	binary.LittleEndian.PutUint64(b[8:], s.HighSlot)
+	return b
+}
+
+// Unmarshal decodes a BlobBackfillStatus encoded by Marshal.
+func (s *BlobBackfillStatus) Unmarshal(b []byte) error {
+	if len(b) != blobBackfillStatusSize {
+		return errors.Wrapf(errInvalidBlobBackfillStatus, "expected %d bytes, got %d", blobBackfillStatusSize, len(b))
+	}
+	s.LowSlot = binary.LittleEndian.Uint64(b[:8])
+	s.HighSlot = binary.LittleEndian.Uint64(b[8:])
+	if s.HighSlot < s.LowSlot {
+		return errors.Wrapf(errInvalidBlobBackfillStatus, "high slot %d is lower than low slot %d", s.HighSlot, s.LowSlot)
+	}
+	return nil
+}
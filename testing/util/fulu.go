@@ -0,0 +1,81 @@
+package util
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/prysmaticlabs/prysm/v5/config/params"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v5/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+)
+
+// bytesPerCell is the size of a serialized cell, FIELD_ELEMENTS_PER_CELL * BYTES_PER_FIELD_ELEMENT.
+const bytesPerCell = 64 * 32
+
+// GenerateTestFuluBlockWithColumns generates a Fulu block with nblobs commitments, along with a data column sidecar
+// for every column. Each blob is a distinct constant polynomial, so its commitment is a multiple of the G1 generator,
+// all of its cells hold the same value and all of its cell KZG proofs are the point at infinity. The sidecars are
+// therefore fully valid, including their inclusion and cell KZG proofs.
+func GenerateTestFuluBlockWithColumns(t *testing.T, parent [32]byte, slot primitives.Slot, nblobs int) (blocks.ROBlock, []blocks.RODataColumn) {
+	block := NewBeaconBlockFulu()
+	block.Block.Slot = slot
+	block.Block.ParentRoot = parent[:]
+	block.Block.Body.BlobKzgCommitments = make([][]byte, nblobs)
+	blobCells := make([][]byte, nblobs)
+	_, _, g1Gen, _ := bls12381.Generators()
+	var infinity bls12381.G1Affine
+	proof := infinity.Bytes()
+	for i := range block.Block.Body.BlobKzgCommitments {
+		var value fr.Element
+		value.SetUint64(uint64(slot)<<16 | uint64(i+1))
+		var commitment bls12381.G1Affine
+		commitment.ScalarMultiplication(&g1Gen, value.BigInt(new(big.Int)))
+		c := commitment.Bytes()
+		block.Block.Body.BlobKzgCommitments[i] = c[:]
+
+		v := value.Bytes()
+		blobCells[i] = bytes.Repeat(v[:], bytesPerCell/len(v))
+	}
+
+	body, err := blocks.NewBeaconBlockBody(block.Block.Body)
+	require.NoError(t, err)
+	inclusion, err := blocks.MerkleProofKZGCommitments(body)
+	require.NoError(t, err)
+	sbb, err := blocks.NewSignedBeaconBlock(block)
+	require.NoError(t, err)
+	header, err := sbb.Header()
+	require.NoError(t, err)
+	root, err := block.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	numberOfColumns := params.BeaconConfig().NumberOfColumns
+	columns := make([]blocks.RODataColumn, 0, numberOfColumns)
+	for i := uint64(0); i < numberOfColumns; i++ {
+		cells := make([][]byte, nblobs)
+		proofs := make([][]byte, nblobs)
+		for j := range cells {
+			cells[j] = bytes.Clone(blobCells[j])
+			proofs[j] = bytes.Clone(proof[:])
+		}
+		pb := &ethpb.DataColumnSidecar{
+			ColumnIndex:                  i,
+			DataColumn:                   cells,
+			KzgCommitments:               block.Block.Body.BlobKzgCommitments,
+			KzgProof:                     proofs,
+			SignedBlockHeader:            header,
+			KzgCommitmentsInclusionProof: inclusion,
+		}
+		dc, err := blocks.NewRODataColumnWithRoot(pb, root)
+		require.NoError(t, err)
+		columns = append(columns, dc)
+	}
+
+	rob, err := blocks.NewROBlock(sbb)
+	require.NoError(t, err)
+	return rob, columns
+}
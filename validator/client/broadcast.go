@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
+	"github.com/sirupsen/logrus"
+)
+
+// broadcastNode is a beacon node that signed objects are published to.
+type broadcastNode struct {
+	host   string
+	client iface.ValidatorClient
+}
+
+// broadcastValidatorClient fetches duties and everything else from the primary beacon node, but publishes
+// signed attestations, aggregates, sync committee messages and blocks to all configured beacon nodes in parallel.
+// A publish succeeds as soon as one beacon node accepts it, so that a single slow or failing beacon node
+// does not cause missed duties.
+type broadcastValidatorClient struct {
+	iface.ValidatorClient
+	nodes []*broadcastNode
+}
+
+var _ iface.ValidatorClient = &broadcastValidatorClient{}
+
+func newBroadcastValidatorClient(primary iface.ValidatorClient, nodes []*broadcastNode) *broadcastValidatorClient {
+	return &broadcastValidatorClient{
+		ValidatorClient: primary,
+		nodes:           nodes,
+	}
+}
+
+// ProposeBeaconBlock publishes the block to all beacon nodes.
+func (c *broadcastValidatorClient) ProposeBeaconBlock(ctx context.Context, in *ethpb.GenericSignedBeaconBlock) (*ethpb.ProposeResponse, error) {
+	return broadcast(ctx, c.nodes, "block", func(ctx context.Context, client iface.ValidatorClient) (*ethpb.ProposeResponse, error) {
+		return client.ProposeBeaconBlock(ctx, in)
+	})
+}
+
+// ProposeAttestation publishes the attestation to all beacon nodes.
+func (c *broadcastValidatorClient) ProposeAttestation(ctx context.Context, in *ethpb.Attestation) (*ethpb.AttestResponse, error) {
+	return broadcast(ctx, c.nodes, "attestation", func(ctx context.Context, client iface.ValidatorClient) (*ethpb.AttestResponse, error) {
+		return client.ProposeAttestation(ctx, in)
+	})
+}
+
+// ProposeAttestationElectra publishes the attestation to all beacon nodes.
+func (c *broadcastValidatorClient) ProposeAttestationElectra(ctx context.Context, in *ethpb.AttestationElectra) (*ethpb.AttestResponse, error) {
+	return broadcast(ctx, c.nodes, "attestation", func(ctx context.Context, client iface.ValidatorClient) (*ethpb.AttestResponse, error) {
+		return client.ProposeAttestationElectra(ctx, in)
+	})
+}
+
+// SubmitSignedAggregateSelectionProof publishes the aggregate to all beacon nodes.
+func (c *broadcastValidatorClient) SubmitSignedAggregateSelectionProof(ctx context.Context, in *ethpb.SignedAggregateSubmitRequest) (*ethpb.SignedAggregateSubmitResponse, error) {
+	return broadcast(ctx, c.nodes, "aggregate", func(ctx context.Context, client iface.ValidatorClient) (*ethpb.SignedAggregateSubmitResponse, error) {
+		return client.SubmitSignedAggregateSelectionProof(ctx, in)
+	})
+}
+
+// SubmitSignedAggregateSelectionProofElectra publishes the aggregate to all beacon nodes.
+func (c *broadcastValidatorClient) SubmitSignedAggregateSelectionProofElectra(ctx context.Context, in *ethpb.SignedAggregateSubmitElectraRequest) (*ethpb.SignedAggregateSubmitResponse, error) {
+	return broadcast(ctx, c.nodes, "aggregate", func(ctx context.Context, client iface.ValidatorClient) (*ethpb.SignedAggregateSubmitResponse, error) {
+		return client.SubmitSignedAggregateSelectionProofElectra(ctx, in)
+	})
+}
+
+// SubmitSyncMessage publishes the sync committee message to all beacon nodes.
+func (c *broadcastValidatorClient) SubmitSyncMessage(ctx context.Context, in *ethpb.SyncCommitteeMessage) (*empty.Empty, error) {
+	return broadcast(ctx, c.nodes, "sync_committee_message", func(ctx context.Context, client iface.ValidatorClient) (*empty.Empty, error) {
+		return client.SubmitSyncMessage(ctx, in)
+	})
+}
+
+// SubmitSignedContributionAndProof publishes the sync committee contribution to all beacon nodes.
+func (c *broadcastValidatorClient) SubmitSignedContributionAndProof(ctx context.Context, in *ethpb.SignedContributionAndProof) (*empty.Empty, error) {
+	return broadcast(ctx, c.nodes, "sync_committee_contribution", func(ctx context.Context, client iface.ValidatorClient) (*empty.Empty, error) {
+		return client.SubmitSignedContributionAndProof(ctx, in)
+	})
+}
+
+type broadcastResult[T any] struct {
+	host string
+	resp T
+	err  error
+}
+
+// broadcast calls publish on all nodes in parallel and returns the first successful response. Publishing to the
+// other nodes carries on in the background. An error is only returned if publishing failed on every node.
+func broadcast[T any](
+	ctx context.Context,
+	nodes []*broadcastNode,
+	objectType string,
+	publish func(context.Context, iface.ValidatorClient) (T, error),
+) (T, error) {
+	results := make(chan broadcastResult[T], len(nodes))
+	for _, n := range nodes {
+		go func(n *broadcastNode) {
+			start := time.Now()
+			resp, err := publish(ctx, n.client)
+			broadcastPublishLatency.WithLabelValues(n.host, objectType).Observe(time.Since(start).Seconds())
+			if err != nil {
+				broadcastPublishFailures.WithLabelValues(n.host, objectType).Inc()
+				log.WithError(err).WithFields(logrus.Fields{
+					"host": n.host,
+					"type": objectType,
+				}).Debug("Could not publish to beacon node")
+			}
+			results <- broadcastResult[T]{host: n.host, resp: resp, err: err}
+		}(n)
+	}
+
+	var zero T
+	failures := make([]string, 0, len(nodes))
+	for range nodes {
+		select {
+		case r := <-results:
+			if r.err == nil {
+				return r.resp, nil
+			}
+			failures = append(failures, fmt.Sprintf("%s: %v", r.host, r.err))
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+	}
+	return zero, errors.Errorf("could not publish %s to any beacon node: %s", objectType, strings.Join(failures, "; "))
+}
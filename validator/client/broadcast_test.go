@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	validatormock "github.com/prysmaticlabs/prysm/v5/testing/validator-mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBroadcastValidatorClient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := validatormock.NewMockValidatorClient(ctrl)
+	healthy := validatormock.NewMockValidatorClient(ctrl)
+	failing := validatormock.NewMockValidatorClient(ctrl)
+	client := newBroadcastValidatorClient(primary, []*broadcastNode{
+		{host: "healthy", client: healthy},
+		{host: "failing", client: failing},
+	})
+
+	t.Run("duties are only fetched from the primary", func(t *testing.T) {
+		primary.EXPECT().Duties(gomock.Any(), gomock.Any()).Return(&ethpb.DutiesResponse{}, nil)
+		_, err := client.Duties(context.Background(), &ethpb.DutiesRequest{})
+		require.NoError(t, err)
+	})
+	t.Run("first success wins", func(t *testing.T) {
+		att := &ethpb.Attestation{}
+		resp := &ethpb.AttestResponse{AttestationDataRoot: []byte{'a'}}
+		healthy.EXPECT().ProposeAttestation(gomock.Any(), att).Return(resp, nil)
+		// The call may still be in flight when the broadcast returns.
+		failing.EXPECT().ProposeAttestation(gomock.Any(), att).Return(nil, errors.New("bad node")).MaxTimes(1)
+		got, err := client.ProposeAttestation(context.Background(), att)
+		require.NoError(t, err)
+		assert.DeepEqual(t, resp, got)
+	})
+	t.Run("fails when all nodes fail", func(t *testing.T) {
+		msg := &ethpb.SyncCommitteeMessage{}
+		healthy.EXPECT().SubmitSyncMessage(gomock.Any(), msg).Return(nil, errors.New("timeout"))
+		failing.EXPECT().SubmitSyncMessage(gomock.Any(), msg).Return(nil, errors.New("bad node"))
+		_, err := client.SubmitSyncMessage(context.Background(), msg)
+		require.ErrorContains(t, "could not publish sync_committee_message to any beacon node", err)
+		assert.ErrorContains(t, "healthy: timeout", err)
+		assert.ErrorContains(t, "failing: bad node", err)
+	})
+	t.Run("does not wait for slow nodes", func(t *testing.T) {
+		blk := &ethpb.GenericSignedBeaconBlock{}
+		release := make(chan struct{})
+		done := make(chan struct{})
+		healthy.EXPECT().ProposeBeaconBlock(gomock.Any(), blk).Return(&ethpb.ProposeResponse{}, nil)
+		failing.EXPECT().ProposeBeaconBlock(gomock.Any(), blk).DoAndReturn(
+			func(context.Context, *ethpb.GenericSignedBeaconBlock) (*ethpb.ProposeResponse, error) {
+				defer close(done)
+				<-release
+				return nil, errors.New("too late")
+			})
+		_, err := client.ProposeBeaconBlock(context.Background(), blk)
+		require.NoError(t, err)
+		close(release)
+		<-done
+	})
+	t.Run("contributions", func(t *testing.T) {
+		c := &ethpb.SignedContributionAndProof{}
+		healthy.EXPECT().SubmitSignedContributionAndProof(gomock.Any(), c).Return(&empty.Empty{}, nil).MaxTimes(1)
+		failing.EXPECT().SubmitSignedContributionAndProof(gomock.Any(), c).Return(&empty.Empty{}, nil).MaxTimes(1)
+		_, err := client.SubmitSignedContributionAndProof(context.Background(), c)
+		require.NoError(t, err)
+	})
+}
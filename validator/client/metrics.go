@@ -203,6 +203,29 @@ var (
 			"pubkey",
 		},
 	)
+	// broadcastPublishLatency tracks how long each beacon node takes to accept a published object when broadcasting.
+	broadcastPublishLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "validator",
+			Name:      "broadcast_publish_latency_seconds",
+			Help:      "Time taken by each beacon node to accept a published object when broadcasting to all beacon nodes.",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2, 4},
+		},
+		[]string{
+			"host", "type",
+		},
+	)
+	// broadcastPublishFailures counts the objects that a beacon node did not accept when broadcasting.
+	broadcastPublishFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "broadcast_publish_failures_total",
+			Help:      "Number of objects a beacon node failed to accept when broadcasting to all beacon nodes.",
+		},
+		[]string{
+			"host", "type",
+		},
+	)
 )
 
 // LogValidatorGainsAndLosses logs important metrics related to this validator client's
@@ -15,6 +15,7 @@ import (
 	grpcutil "github.com/prysmaticlabs/prysm/v5/api/grpc"
 	"github.com/prysmaticlabs/prysm/v5/async/event"
 	lruwrpr "github.com/prysmaticlabs/prysm/v5/cache/lru"
+	"github.com/prysmaticlabs/prysm/v5/config/features"
 	fieldparams "github.com/prysmaticlabs/prysm/v5/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v5/config/params"
 	"github.com/prysmaticlabs/prysm/v5/config/proposer"
@@ -23,6 +24,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v5/validator/accounts/wallet"
 	beaconApi "github.com/prysmaticlabs/prysm/v5/validator/client/beacon-api"
 	beaconChainClientFactory "github.com/prysmaticlabs/prysm/v5/validator/client/beacon-chain-client-factory"
+	grpcApi "github.com/prysmaticlabs/prysm/v5/validator/client/grpc-api"
 	"github.com/prysmaticlabs/prysm/v5/validator/client/iface"
 	nodeclientfactory "github.com/prysmaticlabs/prysm/v5/validator/client/node-client-factory"
 	validatorclientfactory "github.com/prysmaticlabs/prysm/v5/validator/client/validator-client-factory"
@@ -57,6 +59,8 @@ type ValidatorService struct {
 	emitAccountMetrics      bool
 	logValidatorPerformance bool
 	distributed             bool
+	broadcast               bool
+	broadcastConns          []*grpc.ClientConn
 }
 
 // Config for the validator service.
@@ -83,6 +87,8 @@ type Config struct {
 	LogValidatorPerformance bool
 	EmitAccountMetrics      bool
 	Distributed             bool
+	// BroadcastToAllBeaconNodes publishes signed objects to all configured beacon nodes instead of only the current one.
+	BroadcastToAllBeaconNodes bool
 }
 
 // NewValidatorService creates a new validator service for the service
@@ -106,6 +112,7 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		emitAccountMetrics:      cfg.EmitAccountMetrics,
 		logValidatorPerformance: cfg.LogValidatorPerformance,
 		distributed:             cfg.Distributed,
+		broadcast:               cfg.BroadcastToAllBeaconNodes,
 	}
 
 	dialOpts := ConstructDialOptions(
@@ -133,6 +140,18 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		cfg.BeaconApiTimeout,
 	)
 
+	if s.broadcast && !features.Get().EnableBeaconRESTApi {
+		// The main connection only talks to one of the endpoints at a time,
+		// so every endpoint gets a connection of its own to broadcast to.
+		for _, endpoint := range strings.Split(strings.ReplaceAll(cfg.BeaconNodeGRPCEndpoint, " ", ""), ",") {
+			conn, err := grpc.DialContext(ctx, endpoint, dialOpts...)
+			if err != nil {
+				return s, errors.Wrapf(err, "could not dial beacon node %s", endpoint)
+			}
+			s.broadcastConns = append(s.broadcastConns, conn)
+		}
+	}
+
 	return s, nil
 }
 
@@ -178,6 +197,9 @@ func (v *ValidatorService) Start() {
 	)
 
 	validatorClient := validatorclientfactory.NewValidatorClient(v.conn, restHandler)
+	if v.broadcast {
+		validatorClient = v.withBroadcast(validatorClient, hosts)
+	}
 
 	valStruct := &validator{
 		slotFeed:                       new(event.Feed),
@@ -221,10 +243,42 @@ func (v *ValidatorService) Start() {
 	go run(v.ctx, v.validator)
 }
 
+// withBroadcast wraps the validator client so that signed objects are published to all beacon nodes.
+func (v *ValidatorService) withBroadcast(validatorClient iface.ValidatorClient, restHosts []string) iface.ValidatorClient {
+	var nodes []*broadcastNode
+	if features.Get().EnableBeaconRESTApi {
+		for _, host := range restHosts {
+			handler := beaconApi.NewBeaconApiJsonRestHandler(http.Client{Timeout: v.conn.GetBeaconApiTimeout()}, host)
+			nodes = append(nodes, &broadcastNode{
+				host:   host,
+				client: validatorclientfactory.NewValidatorClient(v.conn, handler),
+			})
+		}
+	} else {
+		for _, conn := range v.broadcastConns {
+			nodes = append(nodes, &broadcastNode{
+				host:   conn.Target(),
+				client: grpcApi.NewGrpcValidatorClient(conn),
+			})
+		}
+	}
+	if len(nodes) < 2 {
+		log.Warn("Broadcasting to all beacon nodes was requested, but only one beacon node is configured")
+		return validatorClient
+	}
+	log.WithField("beaconNodes", len(nodes)).Info("Broadcasting signed objects to all beacon nodes")
+	return newBroadcastValidatorClient(validatorClient, nodes)
+}
+
 // Stop the validator service.
 func (v *ValidatorService) Stop() error {
 	v.cancel()
 	log.Info("Stopping service")
+	for _, conn := range v.broadcastConns {
+		if err := conn.Close(); err != nil {
+			log.WithError(err).WithField("host", conn.Target()).Error("Could not close beacon node connection")
+		}
+	}
 	if v.conn != nil {
 		return v.conn.GetGrpcClientConn().Close()
 	}
@@ -0,0 +1,191 @@
+package remote_web3signer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer/internal"
+)
+
+const (
+	// batchWindow is how long the first request of a batch waits for other requests to join it.
+	batchWindow = 10 * time.Millisecond
+	// maxBatchSize caps the size of a batch, regardless of what the remote signer advertises.
+	maxBatchSize     = 512
+	batchSignTimeout = 10 * time.Second
+)
+
+// signBatcher coalesces sign requests made at about the same time, typically by all keys performing the same duty,
+// into batches that are signed by the remote signer in a single call. If the remote signer does not advertise batch
+// support, the batched requests are signed one key at a time instead.
+type signBatcher struct {
+	client       internal.HttpSignerClient
+	window       time.Duration
+	maxBatchSize int
+
+	lock    sync.Mutex
+	pending []*pendingSign
+	timer   *time.Timer
+
+	capabilitiesLock sync.Mutex
+	checked          bool
+	supported        bool
+	supportedSize    int
+}
+
+type pendingSign struct {
+	ctx     context.Context
+	pubKey  string
+	request internal.SignRequestJson
+	result  chan signResult
+}
+
+type signResult struct {
+	sig bls.Signature
+	err error
+}
+
+func newSignBatcher(client internal.HttpSignerClient, window time.Duration, maxBatchSize int) *signBatcher {
+	return &signBatcher{
+		client:       client,
+		window:       window,
+		maxBatchSize: maxBatchSize,
+	}
+}
+
+// sign queues the request for the next batch and waits for its signature.
+func (b *signBatcher) sign(ctx context.Context, pubKey string, request internal.SignRequestJson) (bls.Signature, error) {
+	p := &pendingSign{
+		ctx:     ctx,
+		pubKey:  pubKey,
+		request: request,
+		result:  make(chan signResult, 1),
+	}
+	b.add(p)
+	select {
+	case r := <-p.result:
+		return r.sig, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (b *signBatcher) add(p *pendingSign) {
+	b.lock.Lock()
+	b.pending = append(b.pending, p)
+	if len(b.pending) >= b.maxBatchSize {
+		batch := b.takePending()
+		b.lock.Unlock()
+		go b.send(batch)
+		return
+	}
+	if b.timer == nil {
+		b.timer = time.AfterFunc(b.window, b.flush)
+	}
+	b.lock.Unlock()
+}
+
+func (b *signBatcher) flush() {
+	b.lock.Lock()
+	batch := b.takePending()
+	b.lock.Unlock()
+	if len(batch) > 0 {
+		b.send(batch)
+	}
+}
+
+// takePending must be called with the lock held.
+func (b *signBatcher) takePending() []*pendingSign {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	batch := b.pending
+	b.pending = nil
+	return batch
+}
+
+func (b *signBatcher) send(batch []*pendingSign) {
+	ctx, cancel := context.WithTimeout(context.Background(), batchSignTimeout)
+	defer cancel()
+	client, ok := b.client.(internal.BatchHttpSignerClient)
+	if !ok {
+		b.signEach(batch)
+		return
+	}
+	size, supported := b.batchSupport(ctx, client)
+	if !supported {
+		b.signEach(batch)
+		return
+	}
+	for len(batch) > 0 {
+		n := min(size, len(batch))
+		b.signBatch(ctx, client, batch[:n])
+		batch = batch[n:]
+	}
+}
+
+func (b *signBatcher) signBatch(ctx context.Context, client internal.BatchHttpSignerClient, batch []*pendingSign) {
+	requests := make([]*internal.BatchSignRequest, len(batch))
+	for i, p := range batch {
+		requests[i] = &internal.BatchSignRequest{PublicKey: p.pubKey, Request: []byte(p.request)}
+	}
+	signBatchSize.Observe(float64(len(batch)))
+	sigs, errs, err := client.SignBatch(ctx, requests)
+	if errors.Is(err, internal.ErrBatchSigningUnsupported) {
+		log.WithError(err).Warn("Remote signer stopped accepting batched sign requests, signing one key at a time")
+		b.markBatchUnsupported()
+		b.signEach(batch)
+		return
+	}
+	for i, p := range batch {
+		if err != nil {
+			p.result <- signResult{err: errors.Wrap(err, "batched signing failed")}
+			continue
+		}
+		p.result <- signResult{sig: sigs[i], err: errs[i]}
+	}
+}
+
+// signEach signs every request of the batch with its own call to the remote signer.
+func (b *signBatcher) signEach(batch []*pendingSign) {
+	batchFallbacksTotal.Inc()
+	for _, p := range batch {
+		go func(p *pendingSign) {
+			sig, err := b.client.Sign(p.ctx, p.pubKey, p.request)
+			p.result <- signResult{sig: sig, err: err}
+		}(p)
+	}
+}
+
+// batchSupport returns whether the remote signer supports batched signing and the size to use for each batch.
+// The answer is cached once the remote signer has told us, but not when it could not be reached.
+func (b *signBatcher) batchSupport(ctx context.Context, client internal.BatchHttpSignerClient) (int, bool) {
+	b.capabilitiesLock.Lock()
+	defer b.capabilitiesLock.Unlock()
+	if b.checked {
+		return b.supportedSize, b.supported
+	}
+	capabilities, err := client.BatchCapabilities(ctx)
+	if err != nil {
+		if errors.Is(err, internal.ErrBatchSigningUnsupported) {
+			log.WithError(err).Info("Remote signer does not advertise batched signing, signing one key at a time")
+			b.checked, b.supported = true, false
+		} else {
+			log.WithError(err).Debug("Could not get batched signing capabilities of remote signer")
+		}
+		return 0, false
+	}
+	b.checked, b.supported, b.supportedSize = true, true, min(capabilities.MaxBatchSize, b.maxBatchSize)
+	log.WithField("maxBatchSize", b.supportedSize).Info("Remote signer supports batched signing")
+	return b.supportedSize, true
+}
+
+func (b *signBatcher) markBatchUnsupported() {
+	b.capabilitiesLock.Lock()
+	defer b.capabilitiesLock.Unlock()
+	b.checked, b.supported, b.supportedSize = true, false, 0
+}
@@ -0,0 +1,80 @@
+package remote_web3signer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v5/encoding/bytesutil"
+	validatorpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/v5/testing/assert"
+	"github.com/prysmaticlabs/prysm/v5/testing/require"
+	signertest "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer/internal/testing"
+	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer/v1/mock"
+)
+
+func TestKeymanager_Sign_Batched(t *testing.T) {
+	const keyCount = 20
+	keys := make([]bls.SecretKey, keyCount)
+	pubKeys := make([]string, keyCount)
+	for i := range keys {
+		k, err := bls.RandKey()
+		require.NoError(t, err)
+		keys[i] = k
+		pubKeys[i] = hexutil.Encode(k.PublicKey().Marshal())
+	}
+
+	// signAll signs an attestation with every key at the same time, like validators attesting in the same slot do.
+	signAll := func(t *testing.T, km *Keymanager) {
+		var wg sync.WaitGroup
+		sigs := make([]bls.Signature, keyCount)
+		requests := make([]*validatorpb.SignRequest, keyCount)
+		errs := make([]error, keyCount)
+		for i, k := range keys {
+			requests[i] = mock.GetMockSignRequest("ATTESTATION")
+			requests[i].PublicKey = k.PublicKey().Marshal()
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				sigs[i], errs[i] = km.Sign(context.Background(), requests[i])
+			}(i)
+		}
+		wg.Wait()
+		for i, k := range keys {
+			require.NoError(t, errs[i])
+			assert.DeepEqual(t, k.Sign(requests[i].SigningRoot).Marshal(), sigs[i].Marshal())
+		}
+	}
+	newKeymanager := func(t *testing.T, signer *signertest.Signer) *Keymanager {
+		km, err := NewKeymanager(context.Background(), &SetupConfig{
+			BaseEndpoint:          signer.URL(),
+			GenesisValidatorsRoot: bytesutil.PadTo([]byte{1}, 32),
+			ProvidedPublicKeys:    pubKeys,
+			BatchSigning:          true,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, km.batcher)
+		// Make sure all requests land in the same batch.
+		km.batcher.window = time.Second
+		return km
+	}
+
+	t.Run("batched", func(t *testing.T) {
+		signer := signertest.NewSigner(keys, signertest.WithBatchSupport(8))
+		defer signer.Close()
+		signAll(t, newKeymanager(t, signer))
+		assert.Equal(t, uint64(0), signer.SingleRequests())
+		// 20 requests in batches of at most 8.
+		assert.Equal(t, uint64(3), signer.BatchRequests())
+	})
+	t.Run("falls back to single requests", func(t *testing.T) {
+		signer := signertest.NewSigner(keys)
+		defer signer.Close()
+		signAll(t, newKeymanager(t, signer))
+		assert.Equal(t, uint64(keyCount), signer.SingleRequests())
+		assert.Equal(t, uint64(0), signer.BatchRequests())
+	})
+}
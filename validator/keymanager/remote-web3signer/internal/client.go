@@ -24,8 +24,17 @@ import (
 
 const (
 	ethApiNamespace = "/api/v1/eth2/sign/"
+	// ethBatchSignPath is not part of the web3signer API. Signers that support batched signing
+	// advertise it by answering GET requests on this path with their BatchCapabilities.
+	ethBatchSignPath = "/api/v1/eth2/batch_sign"
+
+	maxIdleConnsPerHost = 64
+	idleConnTimeout     = 90 * time.Second
 )
 
+// ErrBatchSigningUnsupported is returned when the remote signer does not accept batched sign requests.
+var ErrBatchSigningUnsupported = errors.New("remote signer does not support batched signing")
+
 type SignRequestJson []byte
 
 // SignatureResponse is the struct representing the signing request response in json format
@@ -33,18 +42,48 @@ type SignatureResponse struct {
 	Signature hexutil.Bytes `json:"signature"`
 }
 
+// BatchSignRequest is a single entry of a batched signing request.
+type BatchSignRequest struct {
+	PublicKey string          `json:"identifier"`
+	Request   json.RawMessage `json:"request"`
+}
+
+// BatchSignResponse is the result of a single entry of a batched signing request, in the same position as the
+// entry in the request. Either Signature or Error is set.
+type BatchSignResponse struct {
+	Signature hexutil.Bytes `json:"signature,omitempty"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// BatchCapabilities is advertised by remote signers that support batched signing.
+type BatchCapabilities struct {
+	MaxBatchSize int `json:"max_batch_size"`
+}
+
 // HttpSignerClient defines the interface for interacting with a remote web3signer.
 type HttpSignerClient interface {
 	Sign(ctx context.Context, pubKey string, request SignRequestJson) (bls.Signature, error)
 	GetPublicKeys(ctx context.Context, url string) ([]string, error)
 }
 
+// BatchHttpSignerClient is a HttpSignerClient that can also sign many requests in a single call.
+type BatchHttpSignerClient interface {
+	HttpSignerClient
+	// BatchCapabilities returns ErrBatchSigningUnsupported if the remote signer does not advertise batch support.
+	BatchCapabilities(ctx context.Context) (*BatchCapabilities, error)
+	// SignBatch returns one signature or one error per request. The last return value is only set
+	// when the batch as a whole failed.
+	SignBatch(ctx context.Context, requests []*BatchSignRequest) ([]bls.Signature, []error, error)
+}
+
 // ApiClient a wrapper object around web3signer APIs. Please refer to the docs from Consensys' web3signer project.
 type ApiClient struct {
 	BaseURL    *url.URL
 	RestClient *http.Client
 }
 
+var _ BatchHttpSignerClient = &ApiClient{}
+
 // NewApiClient method instantiates a new ApiClient object.
 func NewApiClient(baseEndpoint string) (*ApiClient, error) {
 	u, err := url.ParseRequestURI(baseEndpoint)
@@ -54,9 +93,15 @@ func NewApiClient(baseEndpoint string) (*ApiClient, error) {
 	if u.Scheme == "" || u.Host == "" {
 		return nil, fmt.Errorf("web3signer url must be in the format of http(s)://host:port url used: %v", baseEndpoint)
 	}
+	// The default transport only keeps two idle connections per host, which forces a new connection
+	// for most requests when many keys sign at the same time.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = maxIdleConnsPerHost
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	transport.IdleConnTimeout = idleConnTimeout
 	return &ApiClient{
 		BaseURL:    u,
-		RestClient: &http.Client{},
+		RestClient: &http.Client{Transport: transport},
 	}, nil
 }
 
@@ -85,6 +130,69 @@ func (client *ApiClient) Sign(ctx context.Context, pubKey string, request SignRe
 	}
 }
 
+// BatchCapabilities asks the remote signer whether it supports batched signing.
+func (client *ApiClient) BatchCapabilities(ctx context.Context) (*BatchCapabilities, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.BaseURL.String()+ethBatchSignPath, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid format, failed to create new Get Request Object")
+	}
+	// This request does not go through doRequest, because a signer without batch support
+	// answering with an error status is expected and should not be logged as a failure.
+	resp, err := client.RestClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute json request")
+	}
+	if resp.StatusCode != http.StatusOK {
+		closeBody(resp.Body)
+		return nil, errors.Wrapf(ErrBatchSigningUnsupported, "status %d", resp.StatusCode)
+	}
+	capabilities := &BatchCapabilities{}
+	if err := unmarshalResponse(resp.Body, capabilities); err != nil {
+		return nil, err
+	}
+	if capabilities.MaxBatchSize <= 0 {
+		return nil, errors.Wrapf(ErrBatchSigningUnsupported, "invalid max batch size %d", capabilities.MaxBatchSize)
+	}
+	return capabilities, nil
+}
+
+// SignBatch sends all requests to the remote signer in a single call.
+func (client *ApiClient) SignBatch(ctx context.Context, requests []*BatchSignRequest) ([]bls.Signature, []error, error) {
+	body, err := json.Marshal(requests)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not marshal batch sign request")
+	}
+	resp, err := client.doRequest(ctx, http.MethodPost, client.BaseURL.String()+ethBatchSignPath, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed {
+		closeBody(resp.Body)
+		return nil, nil, errors.Wrapf(ErrBatchSigningUnsupported, "status %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		closeBody(resp.Body)
+		return nil, nil, fmt.Errorf("batch signing failed, Signing Request URL: %v, Status: %v", client.BaseURL.String()+ethBatchSignPath, resp.StatusCode)
+	}
+	var batchResp []BatchSignResponse
+	if err := unmarshalResponse(resp.Body, &batchResp); err != nil {
+		return nil, nil, err
+	}
+	if len(batchResp) != len(requests) {
+		return nil, nil, fmt.Errorf("remote signer returned %d results for %d requests", len(batchResp), len(requests))
+	}
+	sigs := make([]bls.Signature, len(batchResp))
+	errs := make([]error, len(batchResp))
+	for i, r := range batchResp {
+		if r.Error != "" {
+			errs[i] = errors.New(r.Error)
+			continue
+		}
+		sigs[i], errs[i] = bls.SignatureFromBytes(r.Signature)
+	}
+	return sigs, errs, nil
+}
+
 // GetPublicKeys is a wrapper method around the web3signer publickeys api (this may be removed in the future or moved to another location due to its usage).
 func (client *ApiClient) GetPublicKeys(ctx context.Context, url string) ([]string, error) {
 	resp, err := client.doRequest(ctx, http.MethodGet, url, http.NoBody)
@@ -161,6 +269,12 @@ func (client *ApiClient) doRequest(ctx context.Context, httpMethod, fullPath str
 		signRequestDurationSeconds.WithLabelValues(req.Method, strconv.Itoa(resp.StatusCode)).Observe(duration.Seconds())
 	}
 	if resp.StatusCode != http.StatusOK {
+		// The request body was consumed when sending it, rewind it so that it can be dumped.
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
 		requestDump, err = httputil.DumpRequestOut(req, true)
 		if err != nil {
 			return nil, err
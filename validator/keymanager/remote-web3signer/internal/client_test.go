@@ -11,8 +11,10 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v5/testing/require"
 	"github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer/internal"
+	signertest "github.com/prysmaticlabs/prysm/v5/validator/keymanager/remote-web3signer/internal/testing"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -184,3 +186,45 @@ func TestClient_GetServerStatus_HappyPath(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.Nil(t, err)
 }
+
+func TestClient_SignBatch(t *testing.T) {
+	key, err := bls.RandKey()
+	require.NoError(t, err)
+	pubKey := hexutil.Encode(key.PublicKey().Marshal())
+	root := make([]byte, 32)
+	request, err := json.Marshal(map[string]hexutil.Bytes{"signingRoot": root})
+	require.NoError(t, err)
+
+	t.Run("supported", func(t *testing.T) {
+		signer := signertest.NewSigner([]bls.SecretKey{key}, signertest.WithBatchSupport(16))
+		defer signer.Close()
+		cl, err := internal.NewApiClient(signer.URL())
+		require.NoError(t, err)
+
+		capabilities, err := cl.BatchCapabilities(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 16, capabilities.MaxBatchSize)
+
+		sigs, errs, err := cl.SignBatch(context.Background(), []*internal.BatchSignRequest{
+			{PublicKey: pubKey, Request: request},
+			{PublicKey: "0xdeadbeef", Request: request},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(sigs))
+		require.NoError(t, errs[0])
+		require.DeepEqual(t, key.Sign(root).Marshal(), sigs[0].Marshal())
+		require.ErrorContains(t, "public key not found", errs[1])
+		assert.Equal(t, uint64(1), signer.BatchRequests())
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		signer := signertest.NewSigner([]bls.SecretKey{key})
+		defer signer.Close()
+		cl, err := internal.NewApiClient(signer.URL())
+		require.NoError(t, err)
+
+		_, err = cl.BatchCapabilities(context.Background())
+		require.ErrorIs(t, err, internal.ErrBatchSigningUnsupported)
+		_, _, err = cl.SignBatch(context.Background(), []*internal.BatchSignRequest{{PublicKey: pubKey, Request: request}})
+		require.ErrorIs(t, err, internal.ErrBatchSigningUnsupported)
+	})
+}
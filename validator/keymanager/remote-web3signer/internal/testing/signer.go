@@ -0,0 +1,165 @@
+// Package testing provides a local stand-in for a web3signer, to test the remote keymanager against.
+package testing
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+)
+
+const (
+	signPath      = "/api/v1/eth2/sign/"
+	batchSignPath = "/api/v1/eth2/batch_sign"
+)
+
+// Option configures the Signer.
+type Option func(*Signer)
+
+// WithBatchSupport makes the Signer advertise and accept batched sign requests of up to maxBatchSize entries.
+func WithBatchSupport(maxBatchSize int) Option {
+	return func(s *Signer) {
+		s.maxBatchSize = maxBatchSize
+	}
+}
+
+// Signer is a web3signer stand-in serving the sign endpoints over HTTP. Requests are signed by signing
+// their signing root with the Signer's local keys.
+type Signer struct {
+	server       *httptest.Server
+	maxBatchSize int
+
+	lock sync.RWMutex
+	keys map[string]bls.SecretKey
+
+	singleRequests atomic.Uint64
+	batchRequests  atomic.Uint64
+}
+
+// NewSigner starts a Signer holding the given keys. The caller must Close it.
+func NewSigner(keys []bls.SecretKey, opts ...Option) *Signer {
+	s := &Signer{keys: make(map[string]bls.SecretKey, len(keys))}
+	for _, k := range keys {
+		s.keys[hexutil.Encode(k.PublicKey().Marshal())] = k
+	}
+	for _, o := range opts {
+		o(s)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(signPath, s.handleSign)
+	mux.HandleFunc(batchSignPath, s.handleBatch)
+	s.server = httptest.NewServer(mux)
+	return s
+}
+
+// URL returns the base URL of the Signer.
+func (s *Signer) URL() string {
+	return s.server.URL
+}
+
+// Close shuts the Signer down.
+func (s *Signer) Close() {
+	s.server.Close()
+}
+
+// SingleRequests returns how many single sign requests the Signer has served.
+func (s *Signer) SingleRequests() uint64 {
+	return s.singleRequests.Load()
+}
+
+// BatchRequests returns how many batched sign requests the Signer has served.
+func (s *Signer) BatchRequests() uint64 {
+	return s.batchRequests.Load()
+}
+
+type signingRequest struct {
+	SigningRoot hexutil.Bytes `json:"signingRoot"`
+}
+
+type batchEntry struct {
+	Identifier string          `json:"identifier"`
+	Request    json.RawMessage `json:"request"`
+}
+
+type batchResult struct {
+	Signature hexutil.Bytes `json:"signature,omitempty"`
+	Error     string        `json:"error,omitempty"`
+}
+
+func (s *Signer) handleSign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	s.singleRequests.Add(1)
+	req := &signingRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	sig, status, msg := s.sign(strings.TrimPrefix(r.URL.Path, signPath), req)
+	if status != http.StatusOK {
+		http.Error(w, msg, status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]hexutil.Bytes{"signature": sig})
+}
+
+func (s *Signer) handleBatch(w http.ResponseWriter, r *http.Request) {
+	if s.maxBatchSize <= 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+		_ = json.NewEncoder(w).Encode(map[string]int{"max_batch_size": s.maxBatchSize})
+	case http.MethodPost:
+		s.batchRequests.Add(1)
+		var entries []batchEntry
+		if err := json.NewDecoder(r.Body).Decode(&entries); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(entries) > s.maxBatchSize {
+			http.Error(w, "batch too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		results := make([]batchResult, len(entries))
+		for i, e := range entries {
+			req := &signingRequest{}
+			if err := json.Unmarshal(e.Request, req); err != nil {
+				results[i].Error = err.Error()
+				continue
+			}
+			sig, status, msg := s.sign(e.Identifier, req)
+			if status != http.StatusOK {
+				results[i].Error = msg
+				continue
+			}
+			results[i].Signature = sig
+		}
+		_ = json.NewEncoder(w).Encode(results)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Signer) sign(pubKey string, req *signingRequest) ([]byte, int, string) {
+	s.lock.RLock()
+	k, ok := s.keys[pubKey]
+	s.lock.RUnlock()
+	if !ok {
+		return nil, http.StatusNotFound, "public key not found"
+	}
+	if len(req.SigningRoot) == 0 {
+		return nil, http.StatusBadRequest, "missing signing root"
+	}
+	return k.Sign(req.SigningRoot).Marshal(), http.StatusOK, ""
+}
@@ -54,6 +54,10 @@ type SetupConfig struct {
 	// a static list of public keys to be passed by the user to determine what accounts should sign.
 	// This will provide a layer of safety against slashing if the web3signer is shared across validators.
 	ProvidedPublicKeys []string
+
+	// BatchSigning coalesces concurrent sign requests into batches signed by the web3signer in a single call.
+	// Requests are signed one key at a time when the web3signer does not advertise batch support.
+	BatchSigning bool
 }
 
 // Keymanager defines the web3signer keymanager.
@@ -66,6 +70,7 @@ type Keymanager struct {
 	validator             *validator.Validate
 	retriesRemaining      int
 	keyFilePath           string
+	batcher               *signBatcher // nil unless batched signing is enabled
 	lock                  sync.RWMutex
 }
 
@@ -89,6 +94,9 @@ func NewKeymanager(ctx context.Context, cfg *SetupConfig) (*Keymanager, error) {
 		retriesRemaining:      maxRetries,
 		keyFilePath:           cfg.KeyFilePath,
 	}
+	if cfg.BatchSigning {
+		km.batcher = newSignBatcher(km.client, batchWindow, maxBatchSize)
+	}
 
 	keyFileExists := false
 	if km.keyFilePath != "" {
@@ -381,7 +389,14 @@ func (km *Keymanager) Sign(ctx context.Context, request *validatorpb.SignRequest
 		erroredResponsesTotal.Inc()
 		return nil, err
 	}
-	signature, err := km.client.Sign(ctx, hexutil.Encode(request.PublicKey), signRequest)
+	start := time.Now()
+	var signature bls.Signature
+	if km.batcher != nil {
+		signature, err = km.batcher.sign(ctx, hexutil.Encode(request.PublicKey), signRequest)
+	} else {
+		signature, err = km.client.Sign(ctx, hexutil.Encode(request.PublicKey), signRequest)
+	}
+	signRequestDurationSeconds.WithLabelValues(signRequestType(request)).Observe(time.Since(start).Seconds())
 	if err != nil {
 		erroredResponsesTotal.Inc()
 		return nil, errors.Wrap(err, "failed to sign the request")
@@ -446,6 +461,38 @@ func getSignRequestJson(ctx context.Context, validator *validator.Validate, requ
 	}
 }
 
+// signRequestType returns the web3signer type of the sign request, used to label metrics.
+func signRequestType(request *validatorpb.SignRequest) string {
+	switch request.Object.(type) {
+	case *validatorpb.SignRequest_Block:
+		return "BLOCK"
+	case *validatorpb.SignRequest_BlockAltair, *validatorpb.SignRequest_BlockBellatrix, *validatorpb.SignRequest_BlindedBlockBellatrix,
+		*validatorpb.SignRequest_BlockCapella, *validatorpb.SignRequest_BlindedBlockCapella,
+		*validatorpb.SignRequest_BlockDeneb, *validatorpb.SignRequest_BlindedBlockDeneb:
+		return "BLOCK_V2"
+	case *validatorpb.SignRequest_AttestationData:
+		return "ATTESTATION"
+	case *validatorpb.SignRequest_AggregateAttestationAndProof:
+		return "AGGREGATE_AND_PROOF"
+	case *validatorpb.SignRequest_Slot:
+		return "AGGREGATION_SLOT"
+	case *validatorpb.SignRequest_Epoch:
+		return "RANDAO_REVEAL"
+	case *validatorpb.SignRequest_Exit:
+		return "VOLUNTARY_EXIT"
+	case *validatorpb.SignRequest_SyncMessageBlockRoot:
+		return "SYNC_COMMITTEE_MESSAGE"
+	case *validatorpb.SignRequest_SyncAggregatorSelectionData:
+		return "SYNC_COMMITTEE_SELECTION_PROOF"
+	case *validatorpb.SignRequest_ContributionAndProof:
+		return "SYNC_COMMITTEE_CONTRIBUTION_AND_PROOF"
+	case *validatorpb.SignRequest_Registration:
+		return "VALIDATOR_REGISTRATION"
+	default:
+		return "UNKNOWN"
+	}
+}
+
 func handleBlock(ctx context.Context, validator *validator.Validate, request *validatorpb.SignRequest, genesisValidatorsRoot []byte) ([]byte, error) {
 	bockSignRequest, err := web3signerv1.GetBlockSignRequest(request, genesisValidatorsRoot)
 	if err != nil {
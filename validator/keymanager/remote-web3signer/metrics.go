@@ -82,4 +82,21 @@ var (
 		Name: "remote_web3signer_validator_registration_sign_requests_total",
 		Help: "Total number of validator registration sign requests",
 	})
+	signRequestDurationSeconds = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "remote_web3signer_sign_request_duration_seconds",
+			Help:    "Time (in seconds) spent waiting for a signature from web3signer, by sign request type",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"type"},
+	)
+	signBatchSize = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "remote_web3signer_sign_batch_size",
+		Help:    "Number of sign requests sent to web3signer in a single batch",
+		Buckets: []float64{1, 2, 4, 8, 16, 32, 64, 128, 256, 512},
+	})
+	batchFallbacksTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "remote_web3signer_batch_fallbacks_total",
+		Help: "Total number of batches signed one key at a time because batched signing was unavailable",
+	})
 )
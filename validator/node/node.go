@@ -488,27 +488,28 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 	}
 
 	validatorService, err := client.NewValidatorService(c.cliCtx.Context, &client.Config{
-		DB:                      c.db,
-		Wallet:                  c.wallet,
-		WalletInitializedFeed:   c.walletInitializedFeed,
-		GRPCMaxCallRecvMsgSize:  c.cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name),
-		GRPCRetries:             c.cliCtx.Uint(flags.GRPCRetriesFlag.Name),
-		GRPCRetryDelay:          c.cliCtx.Duration(flags.GRPCRetryDelayFlag.Name),
-		GRPCHeaders:             strings.Split(c.cliCtx.String(flags.GRPCHeadersFlag.Name), ","),
-		BeaconNodeGRPCEndpoint:  c.cliCtx.String(flags.BeaconRPCProviderFlag.Name),
-		BeaconNodeCert:          c.cliCtx.String(flags.CertFlag.Name),
-		BeaconApiEndpoint:       c.cliCtx.String(flags.BeaconRESTApiProviderFlag.Name),
-		BeaconApiTimeout:        time.Second * 30,
-		Graffiti:                g.ParseHexGraffiti(c.cliCtx.String(flags.GraffitiFlag.Name)),
-		GraffitiStruct:          graffitiStruct,
-		InteropKmConfig:         interopKmConfig,
-		Web3SignerConfig:        web3signerConfig,
-		ProposerSettings:        ps,
-		ValidatorsRegBatchSize:  c.cliCtx.Int(flags.ValidatorsRegistrationBatchSizeFlag.Name),
-		UseWeb:                  c.cliCtx.Bool(flags.EnableWebFlag.Name),
-		LogValidatorPerformance: !c.cliCtx.Bool(flags.DisablePenaltyRewardLogFlag.Name),
-		EmitAccountMetrics:      !c.cliCtx.Bool(flags.DisableAccountMetricsFlag.Name),
-		Distributed:             c.cliCtx.Bool(flags.EnableDistributed.Name),
+		DB:                        c.db,
+		Wallet:                    c.wallet,
+		WalletInitializedFeed:     c.walletInitializedFeed,
+		GRPCMaxCallRecvMsgSize:    c.cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name),
+		GRPCRetries:               c.cliCtx.Uint(flags.GRPCRetriesFlag.Name),
+		GRPCRetryDelay:            c.cliCtx.Duration(flags.GRPCRetryDelayFlag.Name),
+		GRPCHeaders:               strings.Split(c.cliCtx.String(flags.GRPCHeadersFlag.Name), ","),
+		BeaconNodeGRPCEndpoint:    c.cliCtx.String(flags.BeaconRPCProviderFlag.Name),
+		BeaconNodeCert:            c.cliCtx.String(flags.CertFlag.Name),
+		BeaconApiEndpoint:         c.cliCtx.String(flags.BeaconRESTApiProviderFlag.Name),
+		BeaconApiTimeout:          time.Second * 30,
+		Graffiti:                  g.ParseHexGraffiti(c.cliCtx.String(flags.GraffitiFlag.Name)),
+		GraffitiStruct:            graffitiStruct,
+		InteropKmConfig:           interopKmConfig,
+		Web3SignerConfig:          web3signerConfig,
+		ProposerSettings:          ps,
+		ValidatorsRegBatchSize:    c.cliCtx.Int(flags.ValidatorsRegistrationBatchSizeFlag.Name),
+		UseWeb:                    c.cliCtx.Bool(flags.EnableWebFlag.Name),
+		LogValidatorPerformance:   !c.cliCtx.Bool(flags.DisablePenaltyRewardLogFlag.Name),
+		EmitAccountMetrics:        !c.cliCtx.Bool(flags.DisableAccountMetricsFlag.Name),
+		Distributed:               c.cliCtx.Bool(flags.EnableDistributed.Name),
+		BroadcastToAllBeaconNodes: c.cliCtx.Bool(flags.BroadcastToAllBeaconNodesFlag.Name),
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize validator service")
@@ -551,6 +552,7 @@ func Web3SignerConfig(cliCtx *cli.Context) (*remoteweb3signer.SetupConfig, error
 		if cliCtx.IsSet(flags.Web3SignerKeyFileFlag.Name) {
 			web3signerConfig.KeyFilePath = cliCtx.String(flags.Web3SignerKeyFileFlag.Name)
 		}
+		web3signerConfig.BatchSigning = cliCtx.Bool(flags.Web3SignerBatchSigningFlag.Name)
 	}
 	return web3signerConfig, nil
 }